@@ -1,5 +1,5 @@
 //
-// Copyright (C) 2021 IOTech Ltd
+// Copyright (C) 2021-2024 IOTech Ltd
 //
 // SPDX-License-Identifier: Apache-2.0
 
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
@@ -19,10 +20,12 @@ import (
 	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
 )
 
 // AllCommands query commands by offset, and limit
-func AllCommands(offset int, limit int, dic *di.Container) (deviceCoreCommands []dtos.DeviceCoreCommand, totalCount uint32, err errors.EdgeX) {
+func AllCommands(offset int, limit int, dic *di.Container) (deviceCoreCommands []pkgDtos.DeviceCoreCommand, totalCount uint32, err errors.EdgeX) {
 	// retrieve device information through Metadata DeviceClient
 	dc := bootstrapContainer.DeviceClientFrom(dic.Get)
 	if dc == nil {
@@ -43,17 +46,17 @@ func AllCommands(offset int, limit int, dic *di.Container) (deviceCoreCommands [
 	configuration := commandContainer.ConfigurationFrom(dic.Get)
 	serviceUrl := configuration.Service.Url()
 
-	deviceCoreCommands = make([]dtos.DeviceCoreCommand, len(multiDevicesResponse.Devices))
+	deviceCoreCommands = make([]pkgDtos.DeviceCoreCommand, len(multiDevicesResponse.Devices))
 	for i, device := range multiDevicesResponse.Devices {
 		deviceProfileResponse, err := dpc.DeviceProfileByName(context.Background(), device.ProfileName)
 		if err != nil {
 			return deviceCoreCommands, totalCount, errors.NewCommonEdgeXWrapper(err)
 		}
-		commands, err := buildCoreCommands(device.Name, serviceUrl, deviceProfileResponse.Profile)
+		commands, err := buildCoreCommands(device, serviceUrl, deviceProfileResponse.Profile)
 		if err != nil {
 			return nil, totalCount, errors.NewCommonEdgeXWrapper(err)
 		}
-		deviceCoreCommands[i] = dtos.DeviceCoreCommand{
+		deviceCoreCommands[i] = pkgDtos.DeviceCoreCommand{
 			DeviceName:   device.Name,
 			ProfileName:  device.ProfileName,
 			CoreCommands: commands,
@@ -63,7 +66,7 @@ func AllCommands(offset int, limit int, dic *di.Container) (deviceCoreCommands [
 }
 
 // CommandsByDeviceName query coreCommands with device name
-func CommandsByDeviceName(name string, dic *di.Container) (deviceCoreCommand dtos.DeviceCoreCommand, err errors.EdgeX) {
+func CommandsByDeviceName(name string, dic *di.Container) (deviceCoreCommand pkgDtos.DeviceCoreCommand, err errors.EdgeX) {
 	if name == "" {
 		return deviceCoreCommand, errors.NewCommonEdgeX(errors.KindContractInvalid, "device name is empty", nil)
 	}
@@ -92,12 +95,12 @@ func CommandsByDeviceName(name string, dic *di.Container) (deviceCoreCommand dto
 	configuration := commandContainer.ConfigurationFrom(dic.Get)
 	serviceUrl := configuration.Service.Url()
 
-	commands, err := buildCoreCommands(deviceResponse.Device.Name, serviceUrl, deviceProfileResponse.Profile)
+	commands, err := buildCoreCommands(deviceResponse.Device, serviceUrl, deviceProfileResponse.Profile)
 	if err != nil {
 		return deviceCoreCommand, errors.NewCommonEdgeXWrapper(err)
 	}
 
-	deviceCoreCommand = dtos.DeviceCoreCommand{
+	deviceCoreCommand = pkgDtos.DeviceCoreCommand{
 		DeviceName:   deviceResponse.Device.Name,
 		ProfileName:  deviceResponse.Device.ProfileName,
 		CoreCommands: commands,
@@ -108,8 +111,8 @@ func CommandsByDeviceName(name string, dic *di.Container) (deviceCoreCommand dto
 func commandPath(deviceName, cmdName string) string {
 	return fmt.Sprintf("%s/%s/%s/%s", common.ApiDeviceRoute, common.Name, deviceName, cmdName)
 }
-func buildCoreCommand(deviceName, serviceUrl, cmdName, readWrite string, parameters []dtos.CoreCommandParameter) dtos.CoreCommand {
-	cmd := dtos.CoreCommand{
+func buildCoreCommand(deviceName, serviceUrl, cmdName, readWrite string, parameters []pkgDtos.CoreCommandParameter) pkgDtos.CoreCommand {
+	cmd := pkgDtos.CoreCommand{
 		Name:       cmdName,
 		Url:        serviceUrl,
 		Path:       commandPath(deviceName, cmdName),
@@ -135,47 +138,72 @@ func deviceResourcesByName(resources []dtos.DeviceResource, name string) (res dt
 	return res, exists
 }
 
-// coreCommandParameters creates command parameters by mapping the resourceOperation to corresponding resourceName and valueType
-func coreCommandParameters(resourceOperations []dtos.ResourceOperation, resources []dtos.DeviceResource) ([]dtos.CoreCommandParameter, errors.EdgeX) {
-	parameters := make([]dtos.CoreCommandParameter, len(resourceOperations))
+// coreCommandParameter creates the command parameter with the effective properties of the device resource
+func coreCommandParameter(r dtos.DeviceResource) pkgDtos.CoreCommandParameter {
+	return pkgDtos.CoreCommandParameter{
+		ResourceName: r.Name,
+		ValueType:    r.Properties.ValueType,
+		Units:        r.Properties.Units,
+		Minimum:      r.Properties.Minimum,
+		Maximum:      r.Properties.Maximum,
+		Scale:        r.Properties.Scale,
+		Offset:       r.Properties.Offset,
+	}
+}
+
+// coreCommandParameters creates command parameters by mapping the resourceOperation to corresponding device resource
+func coreCommandParameters(resourceOperations []dtos.ResourceOperation, resources []dtos.DeviceResource) ([]pkgDtos.CoreCommandParameter, errors.EdgeX) {
+	parameters := make([]pkgDtos.CoreCommandParameter, len(resourceOperations))
 	for i, ro := range resourceOperations {
 		r, exists := deviceResourcesByName(resources, ro.DeviceResource)
 		if !exists {
 			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("device command's resource %s doesn't match any deivce resource", ro.DeviceResource), nil)
 		}
-		parameters[i] = dtos.CoreCommandParameter{
-			ResourceName: r.Name,
-			ValueType:    r.Properties.ValueType,
-		}
+		parameters[i] = coreCommandParameter(r)
 	}
 	return parameters, nil
 }
 
-func buildCoreCommands(deviceName string, serviceUrl string, profile dtos.DeviceProfile) ([]dtos.CoreCommand, errors.EdgeX) {
-	commandMap := make(map[string]dtos.CoreCommand)
+// effectiveDeviceResources returns the device resources of the profile with the resource overrides of the device applied
+func effectiveDeviceResources(device dtos.Device, profile dtos.DeviceProfile) ([]dtos.DeviceResource, errors.EdgeX) {
+	overrides, err := pkgModels.DeviceResourceOverrides(device.Properties)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("device %s resource overrides are invalid", device.Name), err)
+	}
+	if len(overrides) == 0 {
+		return profile.DeviceResources, nil
+	}
+	resources := pkgModels.EffectiveDeviceResources(dtos.ToDeviceResourceModels(profile.DeviceResources), overrides)
+	return dtos.FromDeviceResourceModelsToDTOs(resources), nil
+}
+
+func buildCoreCommands(device dtos.Device, serviceUrl string, profile dtos.DeviceProfile) ([]pkgDtos.CoreCommand, errors.EdgeX) {
+	resources, err := effectiveDeviceResources(device, profile)
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+	commandMap := make(map[string]pkgDtos.CoreCommand)
 	// Build commands from device commands
 	for _, c := range profile.DeviceCommands {
 		if c.IsHidden {
 			continue
 		}
-		parameters, err := coreCommandParameters(c.ResourceOperations, profile.DeviceResources)
+		parameters, err := coreCommandParameters(c.ResourceOperations, resources)
 		if err != nil {
 			return nil, errors.NewCommonEdgeXWrapper(err)
 		}
-		commandMap[c.Name] = buildCoreCommand(deviceName, serviceUrl, c.Name, c.ReadWrite, parameters)
+		commandMap[c.Name] = buildCoreCommand(device.Name, serviceUrl, c.Name, c.ReadWrite, parameters)
 	}
 	// Build commands from device resource
-	for _, r := range profile.DeviceResources {
+	for _, r := range resources {
 		if _, ok := commandMap[r.Name]; ok || r.IsHidden {
 			continue
 		}
-		parameters := []dtos.CoreCommandParameter{
-			{ResourceName: r.Name, ValueType: r.Properties.ValueType},
-		}
-		commandMap[r.Name] = buildCoreCommand(deviceName, serviceUrl, r.Name, r.Properties.ReadWrite, parameters)
+		parameters := []pkgDtos.CoreCommandParameter{coreCommandParameter(r)}
+		commandMap[r.Name] = buildCoreCommand(device.Name, serviceUrl, r.Name, r.Properties.ReadWrite, parameters)
 	}
 	// Convert command map to slice
-	var commands []dtos.CoreCommand
+	var commands []pkgDtos.CoreCommand
 	for _, cmd := range commandMap {
 		commands = append(commands, cmd)
 	}
@@ -202,6 +230,9 @@ func IssueGetCommandByName(deviceName string, commandName string, queryParams st
 	if err != nil {
 		return res, errors.NewCommonEdgeXWrapper(err)
 	}
+	if pkgModels.DeviceLifecycleState(deviceResponse.Device.Properties) == pkgModels.Decommissioned {
+		return res, errors.NewCommonEdgeX(errors.KindStatusConflict, fmt.Sprintf("device '%s' is decommissioned", deviceName), nil)
+	}
 
 	// retrieve device service information through Metadata DeviceClient
 	dsc := bootstrapContainer.DeviceServiceClientFrom(dic.Get)
@@ -218,7 +249,9 @@ func IssueGetCommandByName(deviceName string, commandName string, queryParams st
 	if dscc == nil {
 		return res, errors.NewCommonEdgeX(errors.KindServerError, "nil DeviceServiceCommandClient returned", nil)
 	}
-	res, err = dscc.GetCommand(context.Background(), deviceServiceResponse.Service.BaseAddress, deviceName, commandName, queryParams)
+	res, err = issueCachedGetCommand(deviceName, commandName, queryParams, dic, func(queryParams string) (*responses.EventResponse, errors.EdgeX) {
+		return dscc.GetCommand(context.Background(), deviceServiceResponse.Service.BaseAddress, deviceName, commandName, queryParams)
+	})
 	if err != nil {
 		return res, errors.NewCommonEdgeXWrapper(err)
 	}
@@ -246,6 +279,13 @@ func IssueSetCommandByName(deviceName string, commandName string, queryParams st
 	if err != nil {
 		return response, errors.NewCommonEdgeXWrapper(err)
 	}
+	if pkgModels.DeviceLifecycleState(deviceResponse.Device.Properties) == pkgModels.Decommissioned {
+		return response, errors.NewCommonEdgeX(errors.KindStatusConflict, fmt.Sprintf("device '%s' is decommissioned", deviceName), nil)
+	}
+	// the settings are validated against the device resources before anything reaches the device
+	if err = validateSetCommandSettings(deviceResponse.Device, commandName, settings, dic); err != nil {
+		return response, errors.NewCommonEdgeXWrapper(err)
+	}
 
 	// retrieve device service information through Metadata DeviceClient
 	dsc := bootstrapContainer.DeviceServiceClientFrom(dic.Get)
@@ -262,5 +302,13 @@ func IssueSetCommandByName(deviceName string, commandName string, queryParams st
 	if dscc == nil {
 		return response, errors.NewCommonEdgeX(errors.KindServerError, "nil DeviceServiceCommandClient returned", nil)
 	}
-	return dscc.SetCommandWithObject(context.Background(), deviceServiceResponse.Service.BaseAddress, deviceName, commandName, queryParams, settings)
+	if err = CheckSetCommandSafety(deviceName, commandName, settings, dic); err != nil {
+		return response, errors.NewCommonEdgeXWrapper(err)
+	}
+	response, err = dscc.SetCommandWithObject(context.Background(), deviceServiceResponse.Service.BaseAddress, deviceName, commandName, queryParams, settings)
+	if err != nil {
+		return response, errors.NewCommonEdgeXWrapper(err)
+	}
+	InvalidateCachedGetCommands(deviceName)
+	return response, nil
 }
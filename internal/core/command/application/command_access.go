@@ -0,0 +1,110 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/command/config"
+	"github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
+)
+
+const (
+	CommandPermissionRead  = "read"
+	CommandPermissionWrite = "write"
+)
+
+// WarnAdvisoryCommandAccess logs a warning when CommandAccess is enabled while the JWTs aren't verified, since any caller
+// can then claim the identity a policy grants the permissions to
+func WarnAdvisoryCommandAccess(dic *di.Container) {
+	if !container.ConfigurationFrom(dic.Get).Writable.CommandAccess.Enabled || identity.IsJWTValidationEnabled() {
+		return
+	}
+	bootstrapContainer.LoggingClientFrom(dic.Get).Warn("CommandAccess is enabled while the JWT validation is disabled, " +
+		"the caller identities aren't verified and the command access policies are advisory only")
+}
+
+// AuthorizeCommand checks that a policy of the CommandAccess configuration grants the actor the permission of the get or
+// set command of the device, which is always the case while CommandAccess is disabled. A rejected command is logged and
+// the error wraps utils.ErrForbidden.
+func AuthorizeCommand(actor string, deviceName string, commandName string, method string, dic *di.Container) errors.EdgeX {
+	if !container.ConfigurationFrom(dic.Get).Writable.CommandAccess.Enabled {
+		return nil
+	}
+	dc := bootstrapContainer.DeviceClientFrom(dic.Get)
+	if dc == nil {
+		return errors.NewCommonEdgeX(errors.KindServerError, "nil DeviceClient returned", nil)
+	}
+	deviceResponse, err := dc.DeviceByName(context.Background(), deviceName)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return AuthorizeDeviceCommand(actor, deviceResponse.Device, commandName, method, dic)
+}
+
+// AuthorizeDeviceCommand is AuthorizeCommand of the device already queried from core-metadata
+func AuthorizeDeviceCommand(actor string, device dtos.Device, commandName string, method string, dic *di.Container) errors.EdgeX {
+	access := container.ConfigurationFrom(dic.Get).Writable.CommandAccess
+	if !access.Enabled {
+		return nil
+	}
+
+	permission := CommandPermissionRead
+	if strings.EqualFold(method, pkgModels.CommandMethodSet) {
+		permission = CommandPermissionWrite
+	}
+	roles := commandAccessRoles(actor, access.Roles)
+	for _, policy := range access.Policies {
+		if commandPolicyGrants(policy, actor, roles, device, commandName, permission) {
+			return nil
+		}
+	}
+
+	bootstrapContainer.LoggingClientFrom(dic.Get).Warnf("%s command '%s' of device '%s' rejected: '%s' has no %s permission",
+		strings.ToLower(method), commandName, device.Name, actor, permission)
+	return errors.NewCommonEdgeX(errors.KindContractInvalid,
+		fmt.Sprintf("%s isn't allowed to %s command %s of device %s", actor, strings.ToLower(method), commandName, device.Name), utils.ErrForbidden)
+}
+
+// commandAccessRoles returns the roles the actor is a member of
+func commandAccessRoles(actor string, roles map[string][]string) []string {
+	var memberOf []string
+	for role, users := range roles {
+		if slices.Contains(users, actor) {
+			memberOf = append(memberOf, role)
+		}
+	}
+	return memberOf
+}
+
+// commandPolicyGrants returns whether the policy grants the permission of the command of the device to the actor, who
+// is granted through either the users or the roles of the policy
+func commandPolicyGrants(policy config.CommandPolicy, actor string, roles []string, device dtos.Device, commandName string, permission string) bool {
+	if !slices.Contains(policy.Permissions, permission) {
+		return false
+	}
+	if !slices.Contains(policy.Users, actor) && !slices.ContainsFunc(policy.Roles, func(role string) bool { return slices.Contains(roles, role) }) {
+		return false
+	}
+	if len(policy.DeviceLabels) > 0 && !slices.ContainsFunc(device.Labels, func(label string) bool { return slices.Contains(policy.DeviceLabels, label) }) {
+		return false
+	}
+	if len(policy.ProfileNames) > 0 && !slices.Contains(policy.ProfileNames, device.ProfileName) {
+		return false
+	}
+	return len(policy.CommandNames) == 0 || slices.Contains(policy.CommandNames, commandName)
+}
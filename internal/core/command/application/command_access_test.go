@@ -0,0 +1,176 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	loggerMocks "github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/command/config"
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/command/infrastructure/interfaces/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
+)
+
+const (
+	testLine3Pump   = "pump-3"
+	testLine4Pump   = "pump-4"
+	testPumpProfile = "pump"
+)
+
+func mockCommandAccessDic(access config.CommandAccess) *di.Container {
+	dc := &mocks.DeviceClient{}
+	dc.On("DeviceByName", mock.Anything, testLine3Pump).Return(responses.DeviceResponse{
+		Device: dtos.Device{Name: testLine3Pump, ProfileName: testPumpProfile, Labels: []string{"line-3", "pump"}},
+	}, nil)
+	dc.On("DeviceByName", mock.Anything, testLine4Pump).Return(responses.DeviceResponse{
+		Device: dtos.Device{Name: testLine4Pump, ProfileName: testPumpProfile, Labels: []string{"line-4", "pump"}},
+	}, nil)
+	dc.On("DeviceByName", mock.Anything, mock.Anything).Return(responses.DeviceResponse{},
+		edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, "device doesn't exist", nil))
+
+	return di.NewContainer(di.ServiceConstructorMap{
+		commandContainer.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{Writable: config.WritableInfo{CommandAccess: access}}
+		},
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+			return dc
+		},
+	})
+}
+
+func TestAuthorizeCommand(t *testing.T) {
+	access := config.CommandAccess{
+		Enabled: true,
+		Roles: map[string][]string{
+			"operator": {"alice"},
+			"viewer":   {"bob", "alice"},
+		},
+		Policies: map[string]config.CommandPolicy{
+			"operators-write-line-3": {Roles: []string{"operator"}, DeviceLabels: []string{"line-3"}, Permissions: []string{CommandPermissionRead, CommandPermissionWrite}},
+			"viewers-read-pumps":     {Roles: []string{"viewer"}, ProfileNames: []string{testPumpProfile}, Permissions: []string{CommandPermissionRead}},
+			"hmi-write-speed":        {Users: []string{"hmi"}, CommandNames: []string{"speed"}, Permissions: []string{CommandPermissionWrite}},
+		},
+	}
+	dic := mockCommandAccessDic(access)
+
+	tests := []struct {
+		name         string
+		actor        string
+		deviceName   string
+		commandName  string
+		method       string
+		expectedCode int
+	}{
+		{"allowed - role by device label", "alice", testLine3Pump, "speed", pkgModels.CommandMethodSet, 0},
+		{"allowed - role by profile", "bob", testLine4Pump, "speed", pkgModels.CommandMethodGet, 0},
+		{"allowed - user by command name", "hmi", testLine4Pump, "speed", "SET", 0},
+		{"forbidden - device label not matched", "alice", testLine4Pump, "speed", pkgModels.CommandMethodSet, http.StatusForbidden},
+		{"forbidden - read permission only", "bob", testLine3Pump, "speed", pkgModels.CommandMethodSet, http.StatusForbidden},
+		{"forbidden - command name not matched", "hmi", testLine4Pump, "start", pkgModels.CommandMethodSet, http.StatusForbidden},
+		{"forbidden - write permission only", "hmi", testLine4Pump, "speed", pkgModels.CommandMethodGet, http.StatusForbidden},
+		{"forbidden - no role", identity.Anonymous, testLine3Pump, "speed", pkgModels.CommandMethodGet, http.StatusForbidden},
+		{"not found - device", "alice", "missing", "speed", pkgModels.CommandMethodGet, http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := AuthorizeCommand(testCase.actor, testCase.deviceName, testCase.commandName, testCase.method, dic)
+			if testCase.expectedCode == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, testCase.expectedCode, utils.StatusCode(err))
+		})
+	}
+}
+
+func TestAuthorizeCommand_Disabled(t *testing.T) {
+	dic := mockCommandAccessDic(config.CommandAccess{})
+	err := AuthorizeCommand(identity.Anonymous, "missing", "speed", pkgModels.CommandMethodSet, dic)
+	require.NoError(t, err, "every command should be allowed without querying the device while disabled")
+	bootstrapContainer.DeviceClientFrom(dic.Get).(*mocks.DeviceClient).AssertNotCalled(t, "DeviceByName", mock.Anything, mock.Anything)
+}
+
+func TestWarnAdvisoryCommandAccess(t *testing.T) {
+	tests := []struct {
+		name                 string
+		enabled              bool
+		securityEnabled      string
+		disableJWTValidation string
+		expectedWarning      bool
+	}{
+		{"security disabled", true, "false", "", true},
+		{"JWT validation disabled", true, "true", "true", true},
+		{"JWT validation enabled", true, "true", "", false},
+		{"command access disabled", false, "false", "", false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Setenv("EDGEX_SECURITY_SECRET_STORE", testCase.securityEnabled)
+			t.Setenv("EDGEX_DISABLE_JWT_VALIDATION", testCase.disableJWTValidation)
+			lc := &loggerMocks.LoggingClient{}
+			lc.On("Warn", mock.Anything).Return()
+			dic := mockCommandAccessDic(config.CommandAccess{Enabled: testCase.enabled})
+			dic.Update(di.ServiceConstructorMap{
+				bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+					return lc
+				},
+			})
+
+			WarnAdvisoryCommandAccess(dic)
+			if testCase.expectedWarning {
+				lc.AssertCalled(t, "Warn", mock.Anything)
+			} else {
+				lc.AssertNotCalled(t, "Warn", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestExecuteCommandSequence_Forbidden(t *testing.T) {
+	dic := mockCommandAccessDic(config.CommandAccess{
+		Enabled:  true,
+		Policies: map[string]config.CommandPolicy{"read-only": {Users: []string{testActor}, Permissions: []string{CommandPermissionRead}}},
+	})
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("CommandSequenceByName", testCommandSequence).Return(pkgModels.CommandSequence{
+		Name: testCommandSequence,
+		Steps: []pkgModels.CommandSequenceStep{
+			{Type: pkgModels.CommandSequenceStepGet, DeviceName: testLine3Pump, CommandName: "speed"},
+			{Type: pkgModels.CommandSequenceStepSet, DeviceName: testLine3Pump, CommandName: "speed", Settings: map[string]any{"speed": "10"}},
+		},
+	}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		commandContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	_, err := ExecuteCommandSequence(testCommandSequence, pkgModels.CommandAuditOriginHTTP, identity.NewContext(context.Background(), testActor), dic)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusForbidden, utils.StatusCode(err))
+	assert.Contains(t, err.Error(), "step 1")
+	dbClientMock.AssertNotCalled(t, "AddCommandSequenceExecution", mock.Anything)
+}
@@ -0,0 +1,44 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// RecordCommandAudit appends the audit entry of the command which was received at the start time. The command has
+// already been issued, so a failure to record the entry is logged instead of failing the command.
+func RecordCommandAudit(entry pkgModels.CommandAuditEntry, start time.Time, dic *di.Container) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	entry.Timestamp = start.UnixMilli()
+	entry.Latency = time.Since(start).Milliseconds()
+	if _, err := commandContainer.DBClientFrom(dic.Get).AddCommandAuditEntry(entry); err != nil {
+		lc.Errorf("Failed to record the audit entry of %s command %s on device %s issued by '%s' from %s, %v",
+			entry.Method, entry.CommandName, entry.DeviceName, entry.Actor, entry.Origin, err)
+	}
+}
+
+// CommandAuditEntries queries the command audit entries of the device within the timestamp range, sorted by timestamp
+// descending. The entries of all the devices are queried when the device name is empty.
+func CommandAuditEntries(deviceName string, start int64, end int64, offset int, limit int, dic *di.Container) (entries []pkgDtos.CommandAuditEntry, totalCount uint32, err errors.EdgeX) {
+	models, totalCount, err := commandContainer.DBClientFrom(dic.Get).CommandAuditEntries(deviceName, start, end, offset, limit)
+	if err != nil {
+		return nil, 0, errors.NewCommonEdgeXWrapper(err)
+	}
+	entries = make([]pkgDtos.CommandAuditEntry, len(models))
+	for i, e := range models {
+		entries[i] = pkgDtos.FromCommandAuditEntryModelToDTO(e)
+	}
+	return entries, totalCount, nil
+}
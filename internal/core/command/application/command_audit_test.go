@@ -0,0 +1,85 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/command/infrastructure/interfaces/mocks"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+const testActor = "operator"
+
+func mockCommandAuditDic(dbClient *dbMock.DBClient) *di.Container {
+	return di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		commandContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClient
+		},
+	})
+}
+
+func TestRecordCommandAudit(t *testing.T) {
+	entry := pkgModels.CommandAuditEntry{
+		Origin: pkgModels.CommandAuditOriginHTTP, Actor: testActor, DeviceName: testValveDevice, CommandName: testValveCommand,
+		Method: pkgModels.CommandMethodSet, Parameters: map[string]any{testValveCommand: "true"}, StatusCode: http.StatusOK,
+	}
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AddCommandAuditEntry", mock.Anything).Return(pkgModels.CommandAuditEntry{}, nil).Once()
+	dbClientMock.On("AddCommandAuditEntry", mock.Anything).Return(pkgModels.CommandAuditEntry{},
+		edgexErr.NewCommonEdgeX(edgexErr.KindDatabaseError, "db down", nil))
+	dic := mockCommandAuditDic(dbClientMock)
+
+	start := time.Now().Add(-50 * time.Millisecond)
+	RecordCommandAudit(entry, start, dic)
+	recorded := dbClientMock.Calls[0].Arguments.Get(0).(pkgModels.CommandAuditEntry)
+	assert.Equal(t, start.UnixMilli(), recorded.Timestamp)
+	assert.GreaterOrEqual(t, recorded.Latency, int64(50))
+	assert.Equal(t, testActor, recorded.Actor)
+	assert.Equal(t, entry.Parameters, recorded.Parameters)
+
+	// the command is already issued, so the failure to record the entry is only logged
+	RecordCommandAudit(entry, start, dic)
+	dbClientMock.AssertNumberOfCalls(t, "AddCommandAuditEntry", 2)
+}
+
+func TestCommandAuditEntries(t *testing.T) {
+	entry := pkgModels.CommandAuditEntry{
+		Id: "a5b4c3d2-e1f0-4a9b-8c7d-6e5f4a3b2c1d", Timestamp: 1000, Origin: pkgModels.CommandAuditOriginMQTT, Actor: testActor,
+		DeviceName: testValveDevice, CommandName: testValveCommand, Method: pkgModels.CommandMethodGet, StatusCode: http.StatusOK, Latency: 12,
+	}
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("CommandAuditEntries", testValveDevice, int64(0), int64(2000), 0, 20).Return([]pkgModels.CommandAuditEntry{entry}, uint32(1), nil)
+	dbClientMock.On("CommandAuditEntries", "", int64(0), int64(2000), 0, 20).Return(nil, uint32(0),
+		edgexErr.NewCommonEdgeX(edgexErr.KindDatabaseError, "db down", nil))
+	dic := mockCommandAuditDic(dbClientMock)
+
+	entries, totalCount, err := CommandAuditEntries(testValveDevice, 0, 2000, 0, 20, dic)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), totalCount)
+	require.Len(t, entries, 1)
+	assert.Equal(t, entry.Id, entries[0].Id)
+	assert.Equal(t, testActor, entries[0].Actor)
+	assert.Equal(t, entry.Latency, entries[0].Latency)
+
+	_, _, err = CommandAuditEntries("", 0, 2000, 0, 20, dic)
+	require.Error(t, err)
+	assert.Equal(t, edgexErr.KindDatabaseError, edgexErr.Kind(err))
+}
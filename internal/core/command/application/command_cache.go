@@ -0,0 +1,191 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	gometrics "github.com/rcrowley/go-metrics"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+)
+
+const (
+	commandCacheHitsMetricName   = "CommandCacheHits"
+	commandCacheMissesMetricName = "CommandCacheMisses"
+)
+
+var (
+	// commandCacheHits counts the get commands served without a device round trip of their own, either from the cache
+	// or by joining an identical request in flight
+	commandCacheHits = gometrics.NewCounter()
+	// commandCacheMisses counts the get commands of cached commands issued to the device
+	commandCacheMisses = gometrics.NewCounter()
+)
+
+// getCommandCache holds the cached get command responses and the get commands in flight, both keyed by device name,
+// command name and query parameters, along with the generation of each device, which is bumped by every invalidation
+// so that a get command in flight meanwhile doesn't cache its response
+var getCommandCache = struct {
+	mutex       sync.Mutex
+	entries     map[string]cachedGetCommand
+	inFlight    map[string]*getCommandCall
+	generations map[string]uint64
+}{entries: make(map[string]cachedGetCommand), inFlight: make(map[string]*getCommandCall), generations: make(map[string]uint64)}
+
+type cachedGetCommand struct {
+	deviceName string
+	response   *responses.EventResponse
+	expiresAt  time.Time
+}
+
+// getCommandCall is a get command in flight, the identical requests wait for it to be done and share its outcome
+type getCommandCall struct {
+	deviceName string
+	done       chan struct{}
+	response   *responses.EventResponse
+	err        errors.EdgeX
+}
+
+// RegisterCommandCacheMetrics registers the cache hit and miss counters with the metrics manager
+func RegisterCommandCacheMetrics(dic *di.Container) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	metricsManager := bootstrapContainer.MetricsManagerFrom(dic.Get)
+	if metricsManager == nil {
+		lc.Error("Metric Manager not available. Command cache metrics will not be collected.")
+		return
+	}
+
+	if err := metricsManager.Register(commandCacheHitsMetricName, commandCacheHits, nil); err != nil {
+		lc.Errorf("%s metrics will not be collected: %s", commandCacheHitsMetricName, err.Error())
+	}
+	lc.Infof("Registered metrics counter %s", commandCacheHitsMetricName)
+
+	if err := metricsManager.Register(commandCacheMissesMetricName, commandCacheMisses, nil); err != nil {
+		lc.Errorf("%s metrics will not be collected: %s", commandCacheMissesMetricName, err.Error())
+	}
+	lc.Infof("Registered metrics counter %s", commandCacheMissesMetricName)
+}
+
+// issueCachedGetCommand returns the cached response of the get command when the command is cached and its response
+// hasn't expired, otherwise the command is issued through issue. The concurrent identical requests of a cached command
+// share a single device round trip. A request bypasses the cache with the nocache query parameter, and a request with
+// ds-pushevent=true always reaches the device, since the device service must push the event.
+func issueCachedGetCommand(deviceName string, commandName string, queryParams string, dic *di.Container,
+	issue func(queryParams string) (*responses.EventResponse, errors.EdgeX)) (*responses.EventResponse, errors.EdgeX) {
+	queryParams, noCache := removeNoCacheQueryParam(queryParams)
+	ttl := commandCacheTTL(deviceName, commandName, dic)
+	if noCache || ttl <= 0 || strings.Contains("&"+queryParams+"&", "&"+common.PushEvent+"="+common.ValueTrue+"&") {
+		return issue(queryParams)
+	}
+
+	key := deviceName + "|" + commandName + "|" + queryParams
+	getCommandCache.mutex.Lock()
+	if entry, ok := getCommandCache.entries[key]; ok {
+		if time.Now().Before(entry.expiresAt) {
+			getCommandCache.mutex.Unlock()
+			commandCacheHits.Inc(1)
+			return entry.response, nil
+		}
+		delete(getCommandCache.entries, key)
+	}
+	if call, ok := getCommandCache.inFlight[key]; ok {
+		getCommandCache.mutex.Unlock()
+		<-call.done
+		commandCacheHits.Inc(1)
+		return call.response, call.err
+	}
+	call := &getCommandCall{deviceName: deviceName, done: make(chan struct{})}
+	getCommandCache.inFlight[key] = call
+	generation := getCommandCache.generations[deviceName]
+	getCommandCache.mutex.Unlock()
+
+	commandCacheMisses.Inc(1)
+	call.response, call.err = issue(queryParams)
+
+	getCommandCache.mutex.Lock()
+	if getCommandCache.inFlight[key] == call {
+		delete(getCommandCache.inFlight, key)
+	}
+	// only the successful responses are cached, unless the device is invalidated while the command is in flight since
+	// the response may predate a set command. The expired entries are dropped meanwhile to bound the cache size.
+	if call.err == nil && getCommandCache.generations[deviceName] == generation {
+		now := time.Now()
+		for k, entry := range getCommandCache.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(getCommandCache.entries, k)
+			}
+		}
+		getCommandCache.entries[key] = cachedGetCommand{deviceName: deviceName, response: call.response, expiresAt: now.Add(ttl)}
+	}
+	getCommandCache.mutex.Unlock()
+	close(call.done)
+	return call.response, call.err
+}
+
+// InvalidateCachedGetCommands drops the cached get command responses of the device, since a set command may have
+// changed the values they hold. The get commands of the device in flight are neither cached nor joined afterwards.
+func InvalidateCachedGetCommands(deviceName string) {
+	getCommandCache.mutex.Lock()
+	defer getCommandCache.mutex.Unlock()
+	getCommandCache.generations[deviceName]++
+	for k, entry := range getCommandCache.entries {
+		if entry.deviceName == deviceName {
+			delete(getCommandCache.entries, k)
+		}
+	}
+	for k, call := range getCommandCache.inFlight {
+		if call.deviceName == deviceName {
+			delete(getCommandCache.inFlight, k)
+		}
+	}
+}
+
+// commandCacheTTL returns the TTL of the first cache rule matching the get command in rule name order, zero when the
+// command isn't cached. A rule with an invalid TTL is logged and ignored.
+func commandCacheTTL(deviceName string, commandName string, dic *di.Container) time.Duration {
+	commands := container.ConfigurationFrom(dic.Get).Writable.CommandCache.Commands
+	for _, name := range sortedKeys(commands) {
+		command := commands[name]
+		if (command.DeviceName != "" && command.DeviceName != deviceName) || (command.CommandName != "" && command.CommandName != commandName) {
+			continue
+		}
+		ttl, err := parseOptionalDuration(command.TTL)
+		if err != nil {
+			bootstrapContainer.LoggingClientFrom(dic.Get).Errorf("invalid TTL of command cache rule '%s': %v", name, err)
+			continue
+		}
+		return ttl
+	}
+	return 0
+}
+
+// removeNoCacheQueryParam removes the nocache query parameter, which is meant for core-command only, from the raw
+// query, and returns whether it asks to bypass the cache
+func removeNoCacheQueryParam(rawQuery string) (string, bool) {
+	if !strings.Contains(rawQuery, pkgCommon.NoCache) {
+		return rawQuery, false
+	}
+	var noCache bool
+	var params []string
+	for _, param := range strings.Split(rawQuery, "&") {
+		key, value, _ := strings.Cut(param, "=")
+		if key != pkgCommon.NoCache {
+			params = append(params, param)
+			continue
+		}
+		noCache = value == common.ValueTrue
+	}
+	return strings.Join(params, "&"), noCache
+}
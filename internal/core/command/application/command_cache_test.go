@@ -0,0 +1,198 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/command/config"
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+)
+
+const (
+	testMeterDevice  = "meter-1"
+	testMeterService = "device-modbus"
+	testMeterCommand = "energy"
+)
+
+func mockCommandCacheDic(commands map[string]config.CachedCommand, dscc *mocks.DeviceServiceCommandClient) *di.Container {
+	dc := &mocks.DeviceClient{}
+	dc.On("DeviceByName", mock.Anything, testMeterDevice).Return(responses.DeviceResponse{
+		Device: dtos.Device{Name: testMeterDevice, ServiceName: testMeterService},
+	}, nil)
+	dsc := &mocks.DeviceServiceClient{}
+	dsc.On("DeviceServiceByName", mock.Anything, testMeterService).Return(responses.DeviceServiceResponse{
+		Service: dtos.DeviceService{Name: testMeterService, BaseAddress: "http://localhost:59901"},
+	}, nil)
+
+	return di.NewContainer(di.ServiceConstructorMap{
+		commandContainer.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{Writable: config.WritableInfo{CommandCache: config.CommandCache{Commands: commands}}}
+		},
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+			return dc
+		},
+		bootstrapContainer.DeviceServiceClientName: func(get di.Get) interface{} {
+			return dsc
+		},
+		bootstrapContainer.DeviceServiceCommandClientName: func(get di.Get) interface{} {
+			return dscc
+		},
+	})
+}
+
+func resetGetCommandCache() {
+	getCommandCache.entries = make(map[string]cachedGetCommand)
+	getCommandCache.inFlight = make(map[string]*getCommandCall)
+	getCommandCache.generations = make(map[string]uint64)
+	commandCacheHits.Clear()
+	commandCacheMisses.Clear()
+}
+
+func TestIssueCachedGetCommand(t *testing.T) {
+	cached := map[string]config.CachedCommand{"meter": {DeviceName: testMeterDevice, CommandName: testMeterCommand, TTL: "1m"}}
+	expired := map[string]config.CachedCommand{"meter": {DeviceName: testMeterDevice, TTL: "1ns"}}
+	otherDevice := map[string]config.CachedCommand{"other": {DeviceName: "meter-2", TTL: "1m"}}
+	invalid := map[string]config.CachedCommand{"invalid": {TTL: "soon"}}
+
+	tests := []struct {
+		name               string
+		commands           map[string]config.CachedCommand
+		queryParams        []string
+		deviceQueryParams  string
+		expectedRoundTrips int
+		expectedHits       int64
+	}{
+		{"not cached", nil, []string{"", ""}, "", 2, 0},
+		{"cached", cached, []string{"", ""}, "", 1, 1},
+		{"cached by query parameters", cached, []string{"ds-returnevent=true", "ds-returnevent=true"}, "ds-returnevent=true", 1, 1},
+		{"bypassed", cached, []string{"", "nocache=true"}, "", 2, 0},
+		{"bypass parameter isn't forwarded", cached, []string{"nocache=true&ds-returnevent=true"}, "ds-returnevent=true", 1, 0},
+		{"not bypassed", cached, []string{"", "nocache=false"}, "", 1, 1},
+		{"push event isn't cached", cached, []string{"ds-pushevent=true", "ds-pushevent=true"}, "ds-pushevent=true", 2, 0},
+		{"expired", expired, []string{"", ""}, "", 2, 0},
+		{"other device is cached", otherDevice, []string{"", ""}, "", 2, 0},
+		{"invalid TTL isn't cached", invalid, []string{"", ""}, "", 2, 0},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			resetGetCommandCache()
+			dscc := &mocks.DeviceServiceCommandClient{}
+			dscc.On("GetCommand", mock.Anything, "http://localhost:59901", testMeterDevice, testMeterCommand, testCase.deviceQueryParams).
+				Return(&responses.EventResponse{Event: dtos.Event{DeviceName: testMeterDevice}}, nil)
+			dic := mockCommandCacheDic(testCase.commands, dscc)
+
+			for _, queryParams := range testCase.queryParams {
+				res, err := IssueGetCommandByName(testMeterDevice, testMeterCommand, queryParams, dic)
+				require.NoError(t, err)
+				assert.Equal(t, testMeterDevice, res.Event.DeviceName)
+			}
+			dscc.AssertNumberOfCalls(t, "GetCommand", testCase.expectedRoundTrips)
+			assert.Equal(t, testCase.expectedHits, commandCacheHits.Count())
+		})
+	}
+}
+
+func TestIssueCachedGetCommandCoalescing(t *testing.T) {
+	resetGetCommandCache()
+	release := make(chan time.Time)
+	dscc := &mocks.DeviceServiceCommandClient{}
+	dscc.On("GetCommand", mock.Anything, "http://localhost:59901", testMeterDevice, testMeterCommand, "").
+		WaitUntil(release).Return(&responses.EventResponse{Event: dtos.Event{DeviceName: testMeterDevice}}, nil)
+	dic := mockCommandCacheDic(map[string]config.CachedCommand{"meter": {CommandName: testMeterCommand, TTL: "1m"}}, dscc)
+
+	const requests = 5
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := IssueGetCommandByName(testMeterDevice, testMeterCommand, "", dic)
+			assert.NoError(t, err)
+			assert.Equal(t, testMeterDevice, res.Event.DeviceName)
+		}()
+	}
+	// the device round trip is held until every request has either issued it or joined it
+	require.Eventually(t, func() bool {
+		getCommandCache.mutex.Lock()
+		defer getCommandCache.mutex.Unlock()
+		return len(getCommandCache.inFlight) == 1
+	}, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	dscc.AssertNumberOfCalls(t, "GetCommand", 1)
+	assert.Equal(t, int64(1), commandCacheMisses.Count())
+	assert.Equal(t, int64(requests-1), commandCacheHits.Count())
+}
+
+func TestInvalidateCachedGetCommands(t *testing.T) {
+	resetGetCommandCache()
+	dscc := &mocks.DeviceServiceCommandClient{}
+	dscc.On("GetCommand", mock.Anything, "http://localhost:59901", testMeterDevice, testMeterCommand, "").
+		Return(&responses.EventResponse{Event: dtos.Event{DeviceName: testMeterDevice}}, nil)
+	dic := mockCommandCacheDic(map[string]config.CachedCommand{"meter": {TTL: "1m"}}, dscc)
+
+	_, err := IssueGetCommandByName(testMeterDevice, testMeterCommand, "", dic)
+	require.NoError(t, err)
+	InvalidateCachedGetCommands("meter-2")
+	_, err = IssueGetCommandByName(testMeterDevice, testMeterCommand, "", dic)
+	require.NoError(t, err)
+	dscc.AssertNumberOfCalls(t, "GetCommand", 1)
+
+	InvalidateCachedGetCommands(testMeterDevice)
+	_, err = IssueGetCommandByName(testMeterDevice, testMeterCommand, "", dic)
+	require.NoError(t, err)
+	dscc.AssertNumberOfCalls(t, "GetCommand", 2)
+}
+
+func TestInvalidateCachedGetCommandsInFlight(t *testing.T) {
+	resetGetCommandCache()
+	started, release := make(chan struct{}), make(chan struct{})
+	dscc := &mocks.DeviceServiceCommandClient{}
+	dscc.On("GetCommand", mock.Anything, "http://localhost:59901", testMeterDevice, testMeterCommand, "").
+		Run(func(mock.Arguments) {
+			close(started)
+			<-release
+		}).Return(&responses.EventResponse{Event: dtos.Event{Id: "before-set"}}, nil).Once()
+	dscc.On("GetCommand", mock.Anything, "http://localhost:59901", testMeterDevice, testMeterCommand, "").
+		Return(&responses.EventResponse{Event: dtos.Event{Id: "after-set"}}, nil)
+	dic := mockCommandCacheDic(map[string]config.CachedCommand{"meter": {TTL: "1m"}}, dscc)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		res, err := IssueGetCommandByName(testMeterDevice, testMeterCommand, "", dic)
+		assert.NoError(t, err)
+		assert.Equal(t, "before-set", res.Event.Id)
+	}()
+	<-started
+	// the set command completes while the get command is in flight
+	InvalidateCachedGetCommands(testMeterDevice)
+	close(release)
+	<-done
+
+	res, err := IssueGetCommandByName(testMeterDevice, testMeterCommand, "", dic)
+	require.NoError(t, err)
+	assert.Equal(t, "after-set", res.Event.Id, "the response received before the set command shouldn't be cached")
+	dscc.AssertNumberOfCalls(t, "GetCommand", 2)
+}
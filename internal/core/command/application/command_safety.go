@@ -0,0 +1,252 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/command/config"
+	"github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
+)
+
+const (
+	interlockSourceCommand = "command"
+	interlockSourceData    = "data"
+)
+
+// commandRateLimitHistory holds the times the set commands were issued at by rate limit key, the key is the rule name
+// and the device name
+var commandRateLimitHistory = struct {
+	mutex  sync.Mutex
+	issued map[string][]time.Time
+}{issued: make(map[string][]time.Time)}
+
+// CheckSetCommandSafety checks the set command against the interlock rules and the rate limits of the CommandSafety
+// configuration, and must be the last check before the command is issued since an accepted command counts against the
+// rate limits. The rejections are logged, an interlock violation is a StatusConflict error, a rate limit rejection
+// wraps utils.ErrTooManyRequests, and a rule whose condition can't be evaluated rejects the command as well.
+func CheckSetCommandSafety(deviceName string, commandName string, settings map[string]any, dic *di.Container) errors.EdgeX {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	safety := container.ConfigurationFrom(dic.Get).Writable.CommandSafety
+
+	err := checkInterlocks(deviceName, commandName, settings, safety.Interlocks, dic)
+	if err == nil {
+		err = checkRateLimits(deviceName, commandName, safety.RateLimits, time.Now())
+	}
+	if err != nil {
+		lc.Warnf("set command '%s' of device '%s' rejected: %s", commandName, deviceName, err.Error())
+		return err
+	}
+	return nil
+}
+
+// checkInterlocks rejects the set command when the condition of any interlock rule guarding the command holds
+func checkInterlocks(deviceName string, commandName string, settings map[string]any, rules map[string]config.InterlockRule, dic *di.Container) errors.EdgeX {
+	for _, name := range sortedKeys(rules) {
+		rule := rules[name]
+		if !interlockGuards(rule, deviceName, commandName, settings) {
+			continue
+		}
+		current, err := interlockCurrentValue(rule.While, dic)
+		if err != nil {
+			return errors.NewCommonEdgeX(errors.KindServiceUnavailable,
+				fmt.Sprintf("interlock '%s' can't read the current value of resource '%s' of device '%s'", name, rule.While.ResourceName, rule.While.DeviceName), err)
+		}
+		holds, compareErr := compareValues(current, rule.While.Operator, rule.While.Value)
+		if compareErr != nil {
+			return errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("interlock '%s' can't be evaluated", name), compareErr)
+		}
+		if holds {
+			return errors.NewCommonEdgeX(errors.KindStatusConflict,
+				fmt.Sprintf("interlock '%s' forbids the set command while %s.%s %s %s, the current value is %s",
+					name, rule.While.DeviceName, rule.While.ResourceName, rule.While.Operator, rule.While.Value, current), nil)
+		}
+	}
+	return nil
+}
+
+// interlockGuards returns whether the interlock rule guards the set command with the settings
+func interlockGuards(rule config.InterlockRule, deviceName string, commandName string, settings map[string]any) bool {
+	if rule.DeviceName != deviceName || (rule.CommandName != "" && rule.CommandName != commandName) {
+		return false
+	}
+	if rule.ResourceName == "" {
+		return true
+	}
+	value, ok := settings[rule.ResourceName]
+	if !ok {
+		return false
+	}
+	return rule.Value == "" || fmt.Sprint(value) == rule.Value
+}
+
+// interlockCurrentValue returns the current value of the resource of the condition, read either through the get
+// command, bypassing the get command cache, or from the latest reading in core-data
+func interlockCurrentValue(condition config.InterlockCondition, dic *di.Container) (string, errors.EdgeX) {
+	switch condition.Source {
+	case "", interlockSourceCommand:
+		commandName := condition.CommandName
+		if commandName == "" {
+			commandName = condition.ResourceName
+		}
+		res, err := IssueGetCommandByName(condition.DeviceName, commandName, pkgCommon.NoCache+"="+common.ValueTrue, dic)
+		if err != nil {
+			return "", errors.NewCommonEdgeXWrapper(err)
+		}
+		if res != nil {
+			for _, r := range res.Event.Readings {
+				if r.ResourceName == condition.ResourceName {
+					return r.Value, nil
+				}
+			}
+		}
+		return "", errors.NewCommonEdgeX(errors.KindEntityDoesNotExist,
+			fmt.Sprintf("get command '%s' returned no reading of resource '%s'", commandName, condition.ResourceName), nil)
+	case interlockSourceData:
+		rc := bootstrapContainer.ReadingClientFrom(dic.Get)
+		if rc == nil {
+			return "", errors.NewCommonEdgeX(errors.KindServerError, "nil ReadingClient returned, core-data isn't configured in Clients", nil)
+		}
+		res, err := rc.ReadingsByDeviceNameAndResourceName(context.Background(), condition.DeviceName, condition.ResourceName, 0, 1)
+		if err != nil {
+			return "", errors.NewCommonEdgeXWrapper(err)
+		}
+		if len(res.Readings) == 0 {
+			return "", errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "core-data has no reading of the resource", nil)
+		}
+		return res.Readings[0].Value, nil
+	default:
+		return "", errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("unknown interlock source '%s'", condition.Source), nil)
+	}
+}
+
+// compareValues compares the current value with the value of the condition, numerically when both are numbers
+func compareValues(current string, operator string, value string) (bool, error) {
+	a, currentErr := strconv.ParseFloat(current, 64)
+	b, valueErr := strconv.ParseFloat(value, 64)
+	if currentErr == nil && valueErr == nil {
+		switch operator {
+		case "==":
+			return a == b, nil
+		case "!=":
+			return a != b, nil
+		case "<":
+			return a < b, nil
+		case "<=":
+			return a <= b, nil
+		case ">":
+			return a > b, nil
+		case ">=":
+			return a >= b, nil
+		}
+		return false, fmt.Errorf("unknown operator '%s'", operator)
+	}
+
+	switch operator {
+	case "==":
+		return current == value, nil
+	case "!=":
+		return current != value, nil
+	case "<", "<=", ">", ">=":
+		return false, fmt.Errorf("operator '%s' needs numeric values, but got '%s' and '%s'", operator, current, value)
+	}
+	return false, fmt.Errorf("unknown operator '%s'", operator)
+}
+
+// checkRateLimits rejects the set command when it exceeds any rate limit or cool-down of the device, otherwise the
+// command is counted against every matching rate limit
+func checkRateLimits(deviceName string, commandName string, limits map[string]config.CommandRateLimit, now time.Time) errors.EdgeX {
+	commandRateLimitHistory.mutex.Lock()
+	defer commandRateLimitHistory.mutex.Unlock()
+
+	var keys []string
+	for _, name := range sortedKeys(limits) {
+		limit := limits[name]
+		if (limit.DeviceName != "" && limit.DeviceName != deviceName) || (limit.CommandName != "" && limit.CommandName != commandName) {
+			continue
+		}
+		interval, err := parseOptionalDuration(limit.Interval)
+		if err != nil {
+			return errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("invalid Interval of rate limit '%s'", name), err)
+		}
+		coolDown, err := parseOptionalDuration(limit.CoolDown)
+		if err != nil {
+			return errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("invalid CoolDown of rate limit '%s'", name), err)
+		}
+
+		// the times older than both the interval and the cool-down no longer matter
+		key := name + "|" + deviceName
+		retention := max(interval, coolDown)
+		var issued []time.Time
+		for _, t := range commandRateLimitHistory.issued[key] {
+			if now.Sub(t) < retention {
+				issued = append(issued, t)
+			}
+		}
+		if len(issued) == 0 {
+			delete(commandRateLimitHistory.issued, key)
+		} else {
+			commandRateLimitHistory.issued[key] = issued
+		}
+
+		if n := len(issued); n > 0 && now.Sub(issued[n-1]) < coolDown {
+			return errors.NewCommonEdgeX(errors.KindLimitExceeded,
+				fmt.Sprintf("cool-down '%s' of %s between the set commands of device '%s', retry after %s",
+					name, coolDown, deviceName, issued[n-1].Add(coolDown).Sub(now).Round(time.Millisecond)), utils.ErrTooManyRequests)
+		}
+		if limit.MaxCount > 0 && interval > 0 {
+			var count int
+			for _, t := range issued {
+				if now.Sub(t) < interval {
+					count++
+				}
+			}
+			if count >= limit.MaxCount {
+				return errors.NewCommonEdgeX(errors.KindLimitExceeded,
+					fmt.Sprintf("rate limit '%s' of %d set commands per %s of device '%s' exceeded", name, limit.MaxCount, interval, deviceName),
+					utils.ErrTooManyRequests)
+			}
+		}
+		if retention > 0 {
+			keys = append(keys, key)
+		}
+	}
+
+	for _, key := range keys {
+		commandRateLimitHistory.issued[key] = append(commandRateLimitHistory.issued[key], now)
+	}
+	return nil
+}
+
+// parseOptionalDuration parses the duration, an empty duration is zero
+func parseOptionalDuration(duration string) (time.Duration, error) {
+	if duration == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(duration)
+}
+
+// sortedKeys returns the keys of the rules in order, so that the rules are always checked in the same order
+func sortedKeys[T any](rules map[string]T) []string {
+	keys := make([]string, 0, len(rules))
+	for k := range rules {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
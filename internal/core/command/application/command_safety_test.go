@@ -0,0 +1,209 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/command/config"
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
+)
+
+const (
+	testPumpDevice    = "pump-1"
+	testTankDevice    = "tank-1"
+	testTankService   = "device-modbus"
+	testLevelResource = "level"
+)
+
+func mockCommandSafetyDic(safety config.CommandSafety, level string, getErr edgexErr.EdgeX) *di.Container {
+	dc := &mocks.DeviceClient{}
+	dc.On("DeviceByName", mock.Anything, testTankDevice).Return(responses.DeviceResponse{
+		Device: dtos.Device{Name: testTankDevice, ServiceName: testTankService},
+	}, nil)
+	dsc := &mocks.DeviceServiceClient{}
+	dsc.On("DeviceServiceByName", mock.Anything, testTankService).Return(responses.DeviceServiceResponse{
+		Service: dtos.DeviceService{Name: testTankService, BaseAddress: "http://localhost:59901"},
+	}, nil)
+	reading := dtos.BaseReading{DeviceName: testTankDevice, ResourceName: testLevelResource, SimpleReading: dtos.SimpleReading{Value: level}}
+	dscc := &mocks.DeviceServiceCommandClient{}
+	dscc.On("GetCommand", mock.Anything, "http://localhost:59901", testTankDevice, testLevelResource, "").
+		Return(&responses.EventResponse{Event: dtos.Event{Readings: []dtos.BaseReading{reading}}}, getErr)
+	rc := &mocks.ReadingClient{}
+	rc.On("ReadingsByDeviceNameAndResourceName", mock.Anything, testTankDevice, testLevelResource, 0, 1).
+		Return(responses.MultiReadingsResponse{Readings: []dtos.BaseReading{reading}}, nil)
+
+	return di.NewContainer(di.ServiceConstructorMap{
+		commandContainer.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{Writable: config.WritableInfo{CommandSafety: safety}}
+		},
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+			return dc
+		},
+		bootstrapContainer.DeviceServiceClientName: func(get di.Get) interface{} {
+			return dsc
+		},
+		bootstrapContainer.DeviceServiceCommandClientName: func(get di.Get) interface{} {
+			return dscc
+		},
+		bootstrapContainer.ReadingClientName: func(get di.Get) interface{} {
+			return rc
+		},
+	})
+}
+
+func TestCheckSetCommandSafetyInterlocks(t *testing.T) {
+	interlock := func(source string, operator string) config.CommandSafety {
+		return config.CommandSafety{Interlocks: map[string]config.InterlockRule{
+			"pump-dry-run": {DeviceName: testPumpDevice, ResourceName: "on", Value: "true", While: config.InterlockCondition{
+				DeviceName: testTankDevice, ResourceName: testLevelResource, Operator: operator, Value: "10", Source: source,
+			}},
+		}}
+	}
+	getErr := edgexErr.NewCommonEdgeX(edgexErr.KindServiceUnavailable, "device service is down", nil)
+
+	tests := []struct {
+		name               string
+		safety             config.CommandSafety
+		level              string
+		getErr             edgexErr.EdgeX
+		settings           map[string]any
+		expectedStatusCode int
+	}{
+		{"no rules", config.CommandSafety{}, "5", nil, map[string]any{"on": true}, 0},
+		{"condition holds, read through the get command", interlock("", "<"), "5", nil, map[string]any{"on": true}, http.StatusConflict},
+		{"condition holds, read from core-data", interlock("data", "<"), "5", nil, map[string]any{"on": true}, http.StatusConflict},
+		{"condition doesn't hold", interlock("command", "<"), "20", nil, map[string]any{"on": true}, 0},
+		{"other value isn't guarded", interlock("command", "<"), "5", nil, map[string]any{"on": false}, 0},
+		{"other resource isn't guarded", interlock("command", "<"), "5", nil, map[string]any{"speed": 10}, 0},
+		{"current value can't be read", interlock("command", "<"), "5", getErr, map[string]any{"on": "true"}, http.StatusServiceUnavailable},
+		{"condition can't be evaluated", interlock("command", "<"), "full", nil, map[string]any{"on": true}, http.StatusInternalServerError},
+		{"unknown source", interlock("cache", "<"), "5", nil, map[string]any{"on": true}, http.StatusServiceUnavailable},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			dic := mockCommandSafetyDic(testCase.safety, testCase.level, testCase.getErr)
+			err := CheckSetCommandSafety(testPumpDevice, "on", testCase.settings, dic)
+			if testCase.expectedStatusCode == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, testCase.expectedStatusCode, utils.StatusCode(err))
+		})
+	}
+}
+
+func TestCheckSetCommandSafetyBypassesCache(t *testing.T) {
+	safety := config.CommandSafety{Interlocks: map[string]config.InterlockRule{
+		"pump-dry-run": {DeviceName: testPumpDevice, ResourceName: "on", While: config.InterlockCondition{
+			DeviceName: testTankDevice, ResourceName: testLevelResource, Operator: "<", Value: "10",
+		}},
+	}}
+	dic := mockCommandSafetyDic(safety, "20", nil)
+	dic.Update(di.ServiceConstructorMap{
+		commandContainer.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{Writable: config.WritableInfo{
+				CommandSafety: safety,
+				CommandCache:  config.CommandCache{Commands: map[string]config.CachedCommand{"tank-level": {DeviceName: testTankDevice, TTL: "1m"}}},
+			}}
+		},
+	})
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, CheckSetCommandSafety(testPumpDevice, "on", map[string]any{"on": true}, dic))
+	}
+	bootstrapContainer.DeviceServiceCommandClientFrom(dic.Get).(*mocks.DeviceServiceCommandClient).
+		AssertNumberOfCalls(t, "GetCommand", 2)
+}
+
+func TestCheckRateLimits(t *testing.T) {
+	limits := map[string]config.CommandRateLimit{
+		"pump-starts":   {DeviceName: testPumpDevice, CommandName: "on", MaxCount: 2, Interval: "1m"},
+		"pump-cooldown": {DeviceName: testPumpDevice, CoolDown: "10s"},
+	}
+	now := time.Now()
+	commandRateLimitHistory.issued = make(map[string][]time.Time)
+
+	tests := []struct {
+		name               string
+		deviceName         string
+		commandName        string
+		at                 time.Duration
+		expectedStatusCode int
+	}{
+		{"first command", testPumpDevice, "on", 0, 0},
+		{"within the cool-down", testPumpDevice, "speed", 5 * time.Second, http.StatusTooManyRequests},
+		{"after the cool-down", testPumpDevice, "on", 10 * time.Second, 0},
+		{"other device isn't limited", testTankDevice, "on", 11 * time.Second, 0},
+		{"rate limit exceeded", testPumpDevice, "on", 30 * time.Second, http.StatusTooManyRequests},
+		{"other command only has the cool-down", testPumpDevice, "speed", 30 * time.Second, 0},
+		{"after the interval", testPumpDevice, "on", 61 * time.Second, 0},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := checkRateLimits(testCase.deviceName, testCase.commandName, limits, now.Add(testCase.at))
+			if testCase.expectedStatusCode == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, testCase.expectedStatusCode, utils.StatusCode(err))
+		})
+	}
+
+	err := checkRateLimits(testPumpDevice, "on", map[string]config.CommandRateLimit{"invalid": {CoolDown: "soon"}}, now)
+	require.Error(t, err)
+	assert.Equal(t, edgexErr.KindServerError, edgexErr.Kind(err))
+}
+
+func TestCompareValues(t *testing.T) {
+	tests := []struct {
+		current  string
+		operator string
+		value    string
+		expected bool
+		errorExp bool
+	}{
+		{"5", "<", "10", true, false},
+		{"10", "<=", "10.0", true, false},
+		{"10.5", ">", "10", true, false},
+		{"9", ">=", "10", false, false},
+		{"1e1", "==", "10", true, false},
+		{"on", "==", "on", true, false},
+		{"on", "!=", "off", true, false},
+		{"on", "<", "off", false, true},
+		{"5", "=~", "10", false, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.current+testCase.operator+testCase.value, func(t *testing.T) {
+			result, err := compareValues(testCase.current, testCase.operator, testCase.value)
+			if testCase.errorExp {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, result)
+		})
+	}
+}
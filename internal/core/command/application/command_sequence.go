@@ -0,0 +1,474 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
+)
+
+var commandSequenceExecutionStatuses = []string{
+	pkgModels.CommandSequenceStatusRunning,
+	pkgModels.CommandSequenceStatusSucceeded,
+	pkgModels.CommandSequenceStatusFailed,
+	pkgModels.CommandSequenceStatusRolledBack,
+	pkgModels.CommandSequenceStatusRollbackFailed,
+}
+
+// runningCommandSequences holds the ids of the command sequence executions in progress, whose number is bounded by
+// CommandSequence.MaxRunning
+var runningCommandSequences = struct {
+	mutex      sync.Mutex
+	executions map[string]struct{}
+}{executions: make(map[string]struct{})}
+
+// AddCommandSequence persists the command sequence once its steps are validated
+func AddCommandSequence(dto pkgDtos.CommandSequence, ctx context.Context, dic *di.Container) (string, errors.EdgeX) {
+	dbClient := commandContainer.DBClientFrom(dic.Get)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	cs := pkgDtos.ToCommandSequenceModel(dto)
+	err := validateCommandSequence(cs, dic)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	cs, err = dbClient.AddCommandSequence(cs)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	lc.Debugf("Command sequence %s is added with %d steps. Correlation-ID: %s", cs.Name, len(cs.Steps), correlation.FromContext(ctx))
+	return cs.Id, nil
+}
+
+// UpdateCommandSequence replaces the command sequence of the same name once its steps are validated, the executions
+// in progress keep running the steps they started with
+func UpdateCommandSequence(dto pkgDtos.CommandSequence, ctx context.Context, dic *di.Container) errors.EdgeX {
+	dbClient := commandContainer.DBClientFrom(dic.Get)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	cs := pkgDtos.ToCommandSequenceModel(dto)
+	if err := validateCommandSequence(cs, dic); err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	if err := dbClient.UpdateCommandSequence(cs); err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
+	lc.Debugf("Command sequence %s is updated with %d steps. Correlation-ID: %s", cs.Name, len(cs.Steps), correlation.FromContext(ctx))
+	return nil
+}
+
+// CommandSequenceByName queries the command sequence by name
+func CommandSequenceByName(name string, dic *di.Container) (pkgDtos.CommandSequence, errors.EdgeX) {
+	if name == "" {
+		return pkgDtos.CommandSequence{}, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	cs, err := commandContainer.DBClientFrom(dic.Get).CommandSequenceByName(name)
+	if err != nil {
+		return pkgDtos.CommandSequence{}, errors.NewCommonEdgeXWrapper(err)
+	}
+	return pkgDtos.FromCommandSequenceModelToDTO(cs), nil
+}
+
+// AllCommandSequences queries the command sequences with offset and limit, sorted by the modified timestamp descending
+func AllCommandSequences(offset int, limit int, dic *di.Container) (commandSequences []pkgDtos.CommandSequence, totalCount uint32, err errors.EdgeX) {
+	sequences, totalCount, err := commandContainer.DBClientFrom(dic.Get).AllCommandSequences(offset, limit)
+	if err != nil {
+		return nil, 0, errors.NewCommonEdgeXWrapper(err)
+	}
+	commandSequences = make([]pkgDtos.CommandSequence, len(sequences))
+	for i, cs := range sequences {
+		commandSequences[i] = pkgDtos.FromCommandSequenceModelToDTO(cs)
+	}
+	return commandSequences, totalCount, nil
+}
+
+// DeleteCommandSequenceByName deletes the command sequence by name, its executions are kept
+func DeleteCommandSequenceByName(name string, ctx context.Context, dic *di.Container) errors.EdgeX {
+	if name == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	if err := commandContainer.DBClientFrom(dic.Get).DeleteCommandSequenceByName(name); err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
+	bootstrapContainer.LoggingClientFrom(dic.Get).Debugf("Command sequence %s is deleted. Correlation-ID: %s", name, correlation.FromContext(ctx))
+	return nil
+}
+
+// ExecuteCommandSequence starts an execution of the command sequence on behalf of the caller and returns its id right
+// away, the execution records the result of every step as it proceeds
+func ExecuteCommandSequence(name string, origin string, ctx context.Context, dic *di.Container) (string, errors.EdgeX) {
+	if name == "" {
+		return "", errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := commandContainer.DBClientFrom(dic.Get)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	config := commandContainer.ConfigurationFrom(dic.Get)
+
+	cs, err := dbClient.CommandSequenceByName(name)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	// the commands are issued on behalf of the caller, who must be allowed to issue every command of the sequence
+	actor := identity.FromContext(ctx)
+	for i, step := range append(slices.Clip(cs.Steps), cs.RollbackSteps...) {
+		if step.Type != pkgModels.CommandSequenceStepSet && step.Type != pkgModels.CommandSequenceStepGet {
+			continue
+		}
+		if err = AuthorizeCommand(actor, step.DeviceName, step.CommandName, strings.ToLower(step.Type), dic); err != nil {
+			return "", errors.NewCommonEdgeX(errors.Kind(err), fmt.Sprintf("step %d", i), err)
+		}
+	}
+
+	runningCommandSequences.mutex.Lock()
+	defer runningCommandSequences.mutex.Unlock()
+	if maxRunning := config.CommandSequence.MaxRunning; maxRunning > 0 && len(runningCommandSequences.executions) >= maxRunning {
+		return "", errors.NewCommonEdgeX(errors.KindLimitExceeded,
+			fmt.Sprintf("the maximum of %d command sequence executions are running, retry later", maxRunning), utils.ErrTooManyRequests)
+	}
+	e, err := dbClient.AddCommandSequenceExecution(pkgModels.CommandSequenceExecution{
+		SequenceName:  cs.Name,
+		Origin:        origin,
+		Actor:         actor,
+		Status:        pkgModels.CommandSequenceStatusRunning,
+		StartedAt:     time.Now().UnixMilli(),
+		Steps:         cs.Steps,
+		RollbackSteps: cs.RollbackSteps,
+	})
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	runningCommandSequences.executions[e.Id] = struct{}{}
+
+	lc.Debugf("Command sequence %s execution %s is started by %s from %s. Correlation-ID: %s",
+		e.SequenceName, e.Id, e.Actor, e.Origin, correlation.FromContext(ctx))
+	go func() {
+		runCommandSequenceExecution(e, dic)
+
+		runningCommandSequences.mutex.Lock()
+		delete(runningCommandSequences.executions, e.Id)
+		runningCommandSequences.mutex.Unlock()
+	}()
+	return e.Id, nil
+}
+
+// CommandSequenceExecutionById queries the command sequence execution by id
+func CommandSequenceExecutionById(id string, dic *di.Container) (pkgDtos.CommandSequenceExecution, errors.EdgeX) {
+	if id == "" {
+		return pkgDtos.CommandSequenceExecution{}, errors.NewCommonEdgeX(errors.KindContractInvalid, "id is empty", nil)
+	}
+	e, err := commandContainer.DBClientFrom(dic.Get).CommandSequenceExecutionById(id)
+	if err != nil {
+		return pkgDtos.CommandSequenceExecution{}, errors.NewCommonEdgeXWrapper(err)
+	}
+	return pkgDtos.FromCommandSequenceExecutionModelToDTO(e), nil
+}
+
+// CommandSequenceExecutions queries the command sequence executions with the status, sorted by the started-at time
+// descending. All the executions are queried when the status is empty.
+func CommandSequenceExecutions(status string, offset int, limit int, dic *di.Container) (executions []pkgDtos.CommandSequenceExecution, totalCount uint32, err errors.EdgeX) {
+	if status != "" && !slices.Contains(commandSequenceExecutionStatuses, status) {
+		return nil, 0, errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("status %s is invalid, must be one of %v", status, commandSequenceExecutionStatuses), nil)
+	}
+	models, totalCount, err := commandContainer.DBClientFrom(dic.Get).CommandSequenceExecutions(status, offset, limit)
+	if err != nil {
+		return nil, 0, errors.NewCommonEdgeXWrapper(err)
+	}
+	return fromCommandSequenceExecutionModelsToDTOs(models), totalCount, nil
+}
+
+// CommandSequenceExecutionsBySequenceName queries the executions of the command sequence, sorted by the started-at
+// time descending
+func CommandSequenceExecutionsBySequenceName(name string, offset int, limit int, dic *di.Container) (executions []pkgDtos.CommandSequenceExecution, totalCount uint32, err errors.EdgeX) {
+	if name == "" {
+		return nil, 0, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	models, totalCount, err := commandContainer.DBClientFrom(dic.Get).CommandSequenceExecutionsBySequenceName(name, offset, limit)
+	if err != nil {
+		return nil, 0, errors.NewCommonEdgeXWrapper(err)
+	}
+	return fromCommandSequenceExecutionModelsToDTOs(models), totalCount, nil
+}
+
+// FailInterruptedCommandSequenceExecutions records the command sequence executions left RUNNING by a previous run of
+// the service as FAILED, since their remaining steps weren't executed. The executions already started by this run,
+// e.g. requested over the ExternalMQTT meanwhile, are left running.
+func FailInterruptedCommandSequenceExecutions(dic *di.Container) errors.EdgeX {
+	dbClient := commandContainer.DBClientFrom(dic.Get)
+
+	runningCommandSequences.mutex.Lock()
+	defer runningCommandSequences.mutex.Unlock()
+
+	executions, _, err := dbClient.CommandSequenceExecutions(pkgModels.CommandSequenceStatusRunning, 0, -1)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	for _, e := range executions {
+		if _, ok := runningCommandSequences.executions[e.Id]; ok {
+			continue
+		}
+		e.Status = pkgModels.CommandSequenceStatusFailed
+		e.EndedAt = time.Now().UnixMilli()
+		e.Message = "core-command stopped while the command sequence was being executed, the remaining steps weren't executed"
+		if err = dbClient.UpdateCommandSequenceExecution(e); err != nil {
+			return errors.NewCommonEdgeXWrapper(err)
+		}
+	}
+	return nil
+}
+
+// validateCommandSequence validates the steps of the command sequence against the CommandSequence configuration, and
+// the set commands against the device resources like the scheduled commands
+func validateCommandSequence(cs pkgModels.CommandSequence, dic *di.Container) errors.EdgeX {
+	config := commandContainer.ConfigurationFrom(dic.Get).CommandSequence
+
+	if steps := len(cs.Steps) + len(cs.RollbackSteps); config.MaxSteps > 0 && steps > config.MaxSteps {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("command sequence %s has %d steps, which exceeds the maximum of %d", cs.Name, steps, config.MaxSteps), nil)
+	}
+	maxWait, parseErr := parseOptionalDuration(config.MaxWait)
+	if parseErr != nil {
+		return errors.NewCommonEdgeX(errors.KindServerError, "invalid CommandSequence MaxWait", parseErr)
+	}
+	for i, step := range append(slices.Clip(cs.Steps), cs.RollbackSteps...) {
+		if step.Type != pkgModels.CommandSequenceStepGet && len(step.Assertions) > 0 {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("step %d: only a GET step can assert the readings", i), nil)
+		}
+		switch step.Type {
+		case pkgModels.CommandSequenceStepWait:
+			wait, err := time.ParseDuration(step.Duration)
+			if err != nil || wait <= 0 {
+				return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("step %d: invalid duration %s", i, step.Duration), err)
+			}
+			if maxWait > 0 && wait > maxWait {
+				return errors.NewCommonEdgeX(errors.KindContractInvalid,
+					fmt.Sprintf("step %d: duration %s exceeds the maximum wait of %s", i, step.Duration, maxWait), nil)
+			}
+		case pkgModels.CommandSequenceStepGet:
+			// the readings of a get step are recorded and asserted, so the device service must return the event
+			if returnEvent, ok := step.QueryParams[common.ReturnEvent]; ok && !strings.EqualFold(returnEvent, common.ValueTrue) {
+				return errors.NewCommonEdgeX(errors.KindContractInvalid,
+					fmt.Sprintf("step %d: a GET step can't set %s to %s", i, common.ReturnEvent, returnEvent), nil)
+			}
+		case pkgModels.CommandSequenceStepSet:
+			if err := ValidateSetCommand(step.DeviceName, step.CommandName, step.Settings, dic); err != nil {
+				return errors.NewCommonEdgeX(errors.Kind(err), fmt.Sprintf("step %d", i), err)
+			}
+		}
+	}
+	return nil
+}
+
+// runCommandSequenceExecution executes the steps in order and stops at the first failed step, in which case the
+// rollback steps are all executed regardless of their outcome. The execution is recorded after every step.
+func runCommandSequenceExecution(e pkgModels.CommandSequenceExecution, dic *di.Container) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	dbClient := commandContainer.DBClientFrom(dic.Get)
+	update := func() {
+		if err := dbClient.UpdateCommandSequenceExecution(e); err != nil {
+			lc.Errorf("Failed to record command sequence %s execution %s, %v", e.SequenceName, e.Id, err)
+		}
+	}
+
+	failed := -1
+	for i, step := range e.Steps {
+		result := executeCommandSequenceStep(i, step, e, dic)
+		e.StepResults = append(e.StepResults, result)
+		if result.Status == pkgModels.CommandSequenceStatusFailed {
+			failed = i
+			break
+		}
+		update()
+	}
+
+	switch {
+	case failed < 0:
+		e.Status = pkgModels.CommandSequenceStatusSucceeded
+	case len(e.RollbackSteps) == 0:
+		e.Status = pkgModels.CommandSequenceStatusFailed
+		e.Message = fmt.Sprintf("step %d failed, %s", failed, e.StepResults[failed].Message)
+	default:
+		e.Status = pkgModels.CommandSequenceStatusRunning
+		e.Message = fmt.Sprintf("step %d failed, %s", failed, e.StepResults[failed].Message)
+		update()
+		rolledBack := true
+		for i, step := range e.RollbackSteps {
+			result := executeCommandSequenceStep(i, step, e, dic)
+			e.RollbackResults = append(e.RollbackResults, result)
+			rolledBack = rolledBack && result.Status == pkgModels.CommandSequenceStatusSucceeded
+			update()
+		}
+		e.Status = pkgModels.CommandSequenceStatusRolledBack
+		if !rolledBack {
+			e.Status = pkgModels.CommandSequenceStatusRollbackFailed
+		}
+	}
+	e.EndedAt = time.Now().UnixMilli()
+	update()
+
+	if e.Status == pkgModels.CommandSequenceStatusSucceeded {
+		lc.Debugf("Command sequence %s execution %s succeeded", e.SequenceName, e.Id)
+	} else {
+		lc.Warnf("Command sequence %s execution %s is %s, %s", e.SequenceName, e.Id, e.Status, e.Message)
+	}
+}
+
+// executeCommandSequenceStep executes the step of the execution and returns its result. The set and get commands are
+// audited with the actor of the execution.
+func executeCommandSequenceStep(index int, step pkgModels.CommandSequenceStep, e pkgModels.CommandSequenceExecution, dic *di.Container) pkgModels.CommandSequenceStepResult {
+	start := time.Now()
+	result := pkgModels.CommandSequenceStepResult{
+		Index:       index,
+		Type:        step.Type,
+		DeviceName:  step.DeviceName,
+		CommandName: step.CommandName,
+		Status:      pkgModels.CommandSequenceStatusSucceeded,
+		StartedAt:   start.UnixMilli(),
+	}
+	fail := func(statusCode int, message string) {
+		result.Status = pkgModels.CommandSequenceStatusFailed
+		result.StatusCode = statusCode
+		result.Message = message
+	}
+	queryParams := url.Values{}
+	for k, v := range step.QueryParams {
+		queryParams.Set(k, v)
+	}
+	audit := pkgModels.CommandAuditEntry{
+		Origin:      pkgModels.CommandAuditOriginSequence,
+		Actor:       e.Actor,
+		DeviceName:  step.DeviceName,
+		CommandName: step.CommandName,
+		QueryParams: step.QueryParams,
+	}
+
+	switch step.Type {
+	case pkgModels.CommandSequenceStepWait:
+		wait, err := time.ParseDuration(step.Duration)
+		if err != nil {
+			fail(http.StatusBadRequest, fmt.Sprintf("invalid duration %s", step.Duration))
+			break
+		}
+		time.Sleep(wait)
+	case pkgModels.CommandSequenceStepSet:
+		audit.Method = pkgModels.CommandMethodSet
+		audit.Parameters = step.Settings
+		// a critical set command can't await approval in the middle of a sequence
+		critical, err := isCriticalSetCommandByName(step.DeviceName, step.CommandName, dic)
+		if err == nil && critical {
+			err = errors.NewCommonEdgeX(errors.KindStatusConflict,
+				fmt.Sprintf("set command %s of device %s requires approval and can't be issued in a command sequence", step.CommandName, step.DeviceName), nil)
+		}
+		if err != nil {
+			fail(utils.StatusCode(err), err.Error())
+			break
+		}
+		response, err := IssueSetCommandByName(step.DeviceName, step.CommandName, queryParams.Encode(), step.Settings, dic)
+		if err != nil {
+			fail(utils.StatusCode(err), err.Error())
+			break
+		}
+		result.StatusCode = response.StatusCode
+		result.Message = response.Message
+	case pkgModels.CommandSequenceStepGet:
+		audit.Method = pkgModels.CommandMethodGet
+		// the assertions are evaluated against the current values, never against a cached response
+		queryParams.Set(pkgCommon.NoCache, common.ValueTrue)
+		response, err := IssueGetCommandByName(step.DeviceName, step.CommandName, queryParams.Encode(), dic)
+		if err != nil {
+			fail(utils.StatusCode(err), err.Error())
+			break
+		}
+		if response == nil {
+			fail(http.StatusInternalServerError, "the get command returned no event")
+			break
+		}
+		result.StatusCode = response.StatusCode
+		result.Readings = make(map[string]string)
+		for _, r := range response.Event.Readings {
+			result.Readings[r.ResourceName] = r.Value
+		}
+		if message := checkCommandSequenceAssertions(step.Assertions, result.Readings); message != "" {
+			result.Status = pkgModels.CommandSequenceStatusFailed
+			result.Message = message
+		}
+	default:
+		fail(http.StatusBadRequest, fmt.Sprintf("unknown step type %s", step.Type))
+	}
+	if result.StatusCode == 0 && step.Type != pkgModels.CommandSequenceStepWait {
+		result.StatusCode = http.StatusOK
+	}
+	result.EndedAt = time.Now().UnixMilli()
+
+	if audit.Method != "" {
+		audit.StatusCode = result.StatusCode
+		audit.Message = result.Message
+		RecordCommandAudit(audit, start, dic)
+	}
+	return result
+}
+
+// checkCommandSequenceAssertions returns why the first failed assertion doesn't hold on the reading values, empty when
+// all the assertions hold
+func checkCommandSequenceAssertions(assertions []pkgModels.CommandSequenceAssertion, readings map[string]string) string {
+	for _, a := range assertions {
+		value, ok := readings[a.ResourceName]
+		if !ok {
+			return fmt.Sprintf("assertion %s %s %s failed, the get command returned no reading of the resource", a.ResourceName, a.Operator, a.Value)
+		}
+		holds, err := compareValues(value, a.Operator, a.Value)
+		if err != nil {
+			return fmt.Sprintf("assertion %s %s %s failed, %v", a.ResourceName, a.Operator, a.Value, err)
+		}
+		if !holds {
+			return fmt.Sprintf("assertion %s %s %s failed, the reading value is '%s'", a.ResourceName, a.Operator, a.Value, value)
+		}
+	}
+	return ""
+}
+
+// isCriticalSetCommandByName returns whether the set command of the device requires approval
+func isCriticalSetCommandByName(deviceName string, commandName string, dic *di.Container) (bool, errors.EdgeX) {
+	dc := bootstrapContainer.DeviceClientFrom(dic.Get)
+	if dc == nil {
+		return false, errors.NewCommonEdgeX(errors.KindServerError, "nil DeviceClient returned", nil)
+	}
+	deviceResponse, err := dc.DeviceByName(context.Background(), deviceName)
+	if err != nil {
+		return false, errors.NewCommonEdgeXWrapper(err)
+	}
+	return isCriticalSetCommand(deviceResponse.Device, commandName, dic)
+}
+
+func fromCommandSequenceExecutionModelsToDTOs(models []pkgModels.CommandSequenceExecution) []pkgDtos.CommandSequenceExecution {
+	executions := make([]pkgDtos.CommandSequenceExecution, len(models))
+	for i, e := range models {
+		executions[i] = pkgDtos.FromCommandSequenceExecutionModelToDTO(e)
+	}
+	return executions
+}
@@ -0,0 +1,321 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/command/config"
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/command/infrastructure/interfaces/mocks"
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
+)
+
+const (
+	testCommandSequence   = "startup-line-3"
+	testExecutionId       = "0f7b2c1e-6a3d-4e58-9b21-7c4d5e6f7a8b"
+	testValvePosition     = "position"
+	testValvePositionRead = "50"
+)
+
+// mockCommandSequenceDic returns the DIC of the valve device whose close command succeeds when set to true and fails
+// when set to false, and whose position reads 50
+func mockCommandSequenceDic(dbClient *dbMock.DBClient, criticalCommands map[string]config.CriticalCommand) *di.Container {
+	dscc := &mocks.DeviceServiceCommandClient{}
+	dscc.On("SetCommandWithObject", mock.Anything, "http://localhost:59901", testValveDevice, testValveCommand, "", map[string]any{testValveCommand: "true"}).
+		Return(commonDTO.NewBaseResponse("", "", http.StatusOK), nil)
+	dscc.On("SetCommandWithObject", mock.Anything, "http://localhost:59901", testValveDevice, testValveCommand, "", map[string]any{testValveCommand: "false"}).
+		Return(commonDTO.BaseResponse{}, edgexErr.NewCommonEdgeX(edgexErr.KindServiceUnavailable, "device unreachable", nil))
+	dscc.On("GetCommand", mock.Anything, "http://localhost:59901", testValveDevice, testValvePosition, common.ReturnEvent+"="+common.ValueFalse).
+		Return(nil, nil)
+	dscc.On("GetCommand", mock.Anything, "http://localhost:59901", testValveDevice, testValvePosition, "").
+		Return(&responses.EventResponse{Event: dtos.Event{Readings: []dtos.BaseReading{
+			{DeviceName: testValveDevice, ResourceName: testValvePosition, SimpleReading: dtos.SimpleReading{Value: testValvePositionRead}},
+		}}}, nil)
+
+	dic := mockScheduledCommandDic(dbClient)
+	dic.Update(di.ServiceConstructorMap{
+		commandContainer.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Service:         bootstrapConfig.ServiceInfo{Host: "localhost", Port: 59882},
+				CommandSequence: config.CommandSequence{MaxSteps: 4, MaxWait: "1m", MaxRunning: 1},
+				CommandApproval: config.CommandApproval{CriticalCommands: criticalCommands},
+			}
+		},
+		bootstrapContainer.DeviceServiceCommandClientName: func(get di.Get) interface{} {
+			return dscc
+		},
+	})
+	return dic
+}
+
+func closeValveStep(value string) pkgModels.CommandSequenceStep {
+	return pkgModels.CommandSequenceStep{Type: pkgModels.CommandSequenceStepSet, DeviceName: testValveDevice, CommandName: testValveCommand,
+		Settings: map[string]any{testValveCommand: value}}
+}
+
+func readPositionStep(operator string, value string) pkgModels.CommandSequenceStep {
+	return pkgModels.CommandSequenceStep{Type: pkgModels.CommandSequenceStepGet, DeviceName: testValveDevice, CommandName: testValvePosition,
+		Assertions: []pkgModels.CommandSequenceAssertion{{ResourceName: testValvePosition, Operator: operator, Value: value}}}
+}
+
+func TestAddCommandSequence(t *testing.T) {
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AddCommandSequence", mock.Anything).Return(func(cs pkgModels.CommandSequence) pkgModels.CommandSequence {
+		cs.Id = "5d0f6f2a-2c4e-4a8b-9e3f-1b2c3d4e5f6a"
+		return cs
+	}, nil)
+	dic := mockCommandSequenceDic(dbClientMock, nil)
+
+	valid := pkgDtos.CommandSequence{
+		Name: testCommandSequence,
+		Steps: []pkgDtos.CommandSequenceStep{
+			{Type: pkgModels.CommandSequenceStepSet, DeviceName: testValveDevice, CommandName: testValveCommand, Settings: map[string]any{testValveCommand: "true"}},
+			{Type: pkgModels.CommandSequenceStepWait, Duration: "2s"},
+			{Type: pkgModels.CommandSequenceStepGet, DeviceName: testValveDevice, CommandName: testValvePosition,
+				Assertions: []pkgDtos.CommandSequenceAssertion{{ResourceName: testValvePosition, Operator: "==", Value: testValvePositionRead}}},
+		},
+		RollbackSteps: []pkgDtos.CommandSequenceStep{
+			{Type: pkgModels.CommandSequenceStepSet, DeviceName: testValveDevice, CommandName: testValveCommand, Settings: map[string]any{testValveCommand: "false"}},
+		},
+	}
+	tooManySteps := valid
+	tooManySteps.RollbackSteps = append(tooManySteps.RollbackSteps, valid.Steps[1])
+	waitTooLong := valid
+	waitTooLong.Steps = []pkgDtos.CommandSequenceStep{{Type: pkgModels.CommandSequenceStepWait, Duration: "1h"}}
+	setAssertion := valid
+	setAssertion.Steps = []pkgDtos.CommandSequenceStep{valid.Steps[0]}
+	setAssertion.Steps[0].Assertions = valid.Steps[2].Assertions
+	notSettable := valid
+	notSettable.Steps = []pkgDtos.CommandSequenceStep{{Type: pkgModels.CommandSequenceStepSet, DeviceName: testValveDevice, CommandName: testValvePosition,
+		Settings: map[string]any{testValvePosition: "10"}}}
+	noEvent := valid
+	noEvent.Steps = []pkgDtos.CommandSequenceStep{valid.Steps[2]}
+	noEvent.Steps[0].QueryParams = map[string]string{common.ReturnEvent: common.ValueFalse}
+	deviceNotFound := valid
+	deviceNotFound.Steps = []pkgDtos.CommandSequenceStep{valid.Steps[0]}
+	deviceNotFound.Steps[0].DeviceName = "missing"
+
+	tests := []struct {
+		name         string
+		dto          pkgDtos.CommandSequence
+		expectedKind edgexErr.ErrKind
+	}{
+		{"valid", valid, ""},
+		{"invalid - too many steps", tooManySteps, edgexErr.KindContractInvalid},
+		{"invalid - wait exceeds the maximum", waitTooLong, edgexErr.KindContractInvalid},
+		{"invalid - assertion of set step", setAssertion, edgexErr.KindContractInvalid},
+		{"invalid - command can't be set", notSettable, edgexErr.KindContractInvalid},
+		{"invalid - get step returns no event", noEvent, edgexErr.KindContractInvalid},
+		{"invalid - device not found", deviceNotFound, edgexErr.KindEntityDoesNotExist},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			id, err := AddCommandSequence(testCase.dto, context.Background(), dic)
+			if testCase.expectedKind != "" {
+				require.Error(t, err)
+				assert.Equal(t, testCase.expectedKind, edgexErr.Kind(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, id)
+		})
+	}
+	dbClientMock.AssertNumberOfCalls(t, "AddCommandSequence", 1)
+}
+
+func TestRunCommandSequenceExecution(t *testing.T) {
+	tests := []struct {
+		name                  string
+		steps                 []pkgModels.CommandSequenceStep
+		rollbackSteps         []pkgModels.CommandSequenceStep
+		criticalCommands      map[string]config.CriticalCommand
+		expectedStatus        string
+		expectedStepResults   []string
+		expectedRollbackCount int
+	}{
+		{"succeeded",
+			[]pkgModels.CommandSequenceStep{closeValveStep("true"), {Type: pkgModels.CommandSequenceStepWait, Duration: "1ms"}, readPositionStep(">=", "10")},
+			[]pkgModels.CommandSequenceStep{closeValveStep("true")}, nil,
+			pkgModels.CommandSequenceStatusSucceeded,
+			[]string{pkgModels.CommandSequenceStatusSucceeded, pkgModels.CommandSequenceStatusSucceeded, pkgModels.CommandSequenceStatusSucceeded}, 0},
+		{"rolled back - assertion failed",
+			[]pkgModels.CommandSequenceStep{readPositionStep("==", "ready"), closeValveStep("true")},
+			[]pkgModels.CommandSequenceStep{closeValveStep("true")}, nil,
+			pkgModels.CommandSequenceStatusRolledBack,
+			[]string{pkgModels.CommandSequenceStatusFailed}, 1},
+		{"failed - no rollback steps",
+			[]pkgModels.CommandSequenceStep{closeValveStep("false"), closeValveStep("true")},
+			nil, nil,
+			pkgModels.CommandSequenceStatusFailed,
+			[]string{pkgModels.CommandSequenceStatusFailed}, 0},
+		{"rollback failed",
+			[]pkgModels.CommandSequenceStep{closeValveStep("false")},
+			[]pkgModels.CommandSequenceStep{closeValveStep("false"), closeValveStep("true")}, nil,
+			pkgModels.CommandSequenceStatusRollbackFailed,
+			[]string{pkgModels.CommandSequenceStatusFailed}, 2},
+		{"failed - get command returned no event",
+			[]pkgModels.CommandSequenceStep{{Type: pkgModels.CommandSequenceStepGet, DeviceName: testValveDevice, CommandName: testValvePosition,
+				QueryParams: map[string]string{common.ReturnEvent: common.ValueFalse}}},
+			nil, nil,
+			pkgModels.CommandSequenceStatusFailed,
+			[]string{pkgModels.CommandSequenceStatusFailed}, 0},
+		{"failed - critical set command",
+			[]pkgModels.CommandSequenceStep{closeValveStep("true")},
+			nil, map[string]config.CriticalCommand{"valve-close": {DeviceName: testValveDevice, CommandName: testValveCommand}},
+			pkgModels.CommandSequenceStatusFailed,
+			[]string{pkgModels.CommandSequenceStatusFailed}, 0},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			dbClientMock := &dbMock.DBClient{}
+			dbClientMock.On("UpdateCommandSequenceExecution", mock.Anything).Return(nil)
+			dbClientMock.On("AddCommandAuditEntry", mock.Anything).Return(pkgModels.CommandAuditEntry{}, nil)
+			dic := mockCommandSequenceDic(dbClientMock, testCase.criticalCommands)
+
+			runCommandSequenceExecution(pkgModels.CommandSequenceExecution{
+				Id:            testExecutionId,
+				SequenceName:  testCommandSequence,
+				Actor:         testActor,
+				Status:        pkgModels.CommandSequenceStatusRunning,
+				Steps:         testCase.steps,
+				RollbackSteps: testCase.rollbackSteps,
+			}, dic)
+
+			calls := dbClientMock.Calls
+			recorded := calls[len(calls)-1].Arguments.Get(0).(pkgModels.CommandSequenceExecution)
+			assert.Equal(t, testCase.expectedStatus, recorded.Status)
+			assert.NotZero(t, recorded.EndedAt)
+			require.Len(t, recorded.StepResults, len(testCase.expectedStepResults))
+			for i, status := range testCase.expectedStepResults {
+				assert.Equal(t, status, recorded.StepResults[i].Status, "step %d", i)
+			}
+			assert.Len(t, recorded.RollbackResults, testCase.expectedRollbackCount)
+			if testCase.expectedStatus != pkgModels.CommandSequenceStatusSucceeded {
+				assert.Contains(t, recorded.Message, "step 0 failed")
+			}
+		})
+	}
+}
+
+func TestRunCommandSequenceExecution_Audit(t *testing.T) {
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("UpdateCommandSequenceExecution", mock.Anything).Return(nil)
+	dbClientMock.On("AddCommandAuditEntry", mock.Anything).Return(pkgModels.CommandAuditEntry{}, nil)
+	dic := mockCommandSequenceDic(dbClientMock, nil)
+
+	runCommandSequenceExecution(pkgModels.CommandSequenceExecution{
+		Id:           testExecutionId,
+		SequenceName: testCommandSequence,
+		Actor:        testActor,
+		Steps:        []pkgModels.CommandSequenceStep{closeValveStep("true"), {Type: pkgModels.CommandSequenceStepWait, Duration: "1ms"}, readPositionStep("==", "50")},
+	}, dic)
+
+	dbClientMock.AssertNumberOfCalls(t, "AddCommandAuditEntry", 2)
+	for _, call := range dbClientMock.Calls {
+		if call.Method != "AddCommandAuditEntry" {
+			continue
+		}
+		entry := call.Arguments.Get(0).(pkgModels.CommandAuditEntry)
+		assert.Equal(t, pkgModels.CommandAuditOriginSequence, entry.Origin)
+		assert.Equal(t, testActor, entry.Actor, "the commands should be audited with the actor of the execution")
+		assert.Equal(t, http.StatusOK, entry.StatusCode)
+	}
+}
+
+func TestExecuteCommandSequence(t *testing.T) {
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("CommandSequenceByName", testCommandSequence).Return(pkgModels.CommandSequence{
+		Name:  testCommandSequence,
+		Steps: []pkgModels.CommandSequenceStep{{Type: pkgModels.CommandSequenceStepWait, Duration: "1ms"}},
+	}, nil)
+	dbClientMock.On("CommandSequenceByName", mock.Anything).Return(pkgModels.CommandSequence{},
+		edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, "command sequence doesn't exist", nil))
+	var added pkgModels.CommandSequenceExecution
+	dbClientMock.On("AddCommandSequenceExecution", mock.Anything).Return(func(e pkgModels.CommandSequenceExecution) pkgModels.CommandSequenceExecution {
+		e.Id = testExecutionId
+		added = e
+		return e
+	}, nil)
+	dbClientMock.On("UpdateCommandSequenceExecution", mock.Anything).Return(nil)
+	dic := mockCommandSequenceDic(dbClientMock, nil)
+	ctx := identity.NewContext(context.Background(), testActor)
+
+	_, err := ExecuteCommandSequence("missing", pkgModels.CommandAuditOriginHTTP, ctx, dic)
+	require.Error(t, err)
+	assert.Equal(t, edgexErr.KindEntityDoesNotExist, edgexErr.Kind(err))
+
+	// MaxRunning is 1, so no other execution can start while one is running
+	runningCommandSequences.mutex.Lock()
+	runningCommandSequences.executions["running"] = struct{}{}
+	runningCommandSequences.mutex.Unlock()
+	_, err = ExecuteCommandSequence(testCommandSequence, pkgModels.CommandAuditOriginHTTP, ctx, dic)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, utils.StatusCode(err))
+	runningCommandSequences.mutex.Lock()
+	delete(runningCommandSequences.executions, "running")
+	runningCommandSequences.mutex.Unlock()
+
+	id, err := ExecuteCommandSequence(testCommandSequence, pkgModels.CommandAuditOriginHTTP, ctx, dic)
+	require.NoError(t, err)
+	assert.Equal(t, testExecutionId, id)
+	assert.Equal(t, testActor, added.Actor)
+	assert.Equal(t, pkgModels.CommandAuditOriginHTTP, added.Origin)
+	assert.Equal(t, pkgModels.CommandSequenceStatusRunning, added.Status)
+
+	require.Eventually(t, func() bool {
+		runningCommandSequences.mutex.Lock()
+		defer runningCommandSequences.mutex.Unlock()
+		_, running := runningCommandSequences.executions[testExecutionId]
+		return !running
+	}, time.Second, 10*time.Millisecond, "the execution should be released once it ends")
+}
+
+func TestFailInterruptedCommandSequenceExecutions(t *testing.T) {
+	interrupted := pkgModels.CommandSequenceExecution{Id: "interrupted", Status: pkgModels.CommandSequenceStatusRunning}
+	running := pkgModels.CommandSequenceExecution{Id: "running", Status: pkgModels.CommandSequenceStatusRunning}
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("CommandSequenceExecutions", pkgModels.CommandSequenceStatusRunning, 0, -1).
+		Return([]pkgModels.CommandSequenceExecution{interrupted, running}, uint32(2), nil)
+	dbClientMock.On("UpdateCommandSequenceExecution", mock.Anything).Return(nil)
+	dic := mockCommandSequenceDic(dbClientMock, nil)
+
+	runningCommandSequences.mutex.Lock()
+	runningCommandSequences.executions[running.Id] = struct{}{}
+	runningCommandSequences.mutex.Unlock()
+	defer func() {
+		runningCommandSequences.mutex.Lock()
+		delete(runningCommandSequences.executions, running.Id)
+		runningCommandSequences.mutex.Unlock()
+	}()
+
+	err := FailInterruptedCommandSequenceExecutions(dic)
+	require.NoError(t, err)
+	dbClientMock.AssertNumberOfCalls(t, "UpdateCommandSequenceExecution", 1)
+	updated := dbClientMock.Calls[1].Arguments.Get(0).(pkgModels.CommandSequenceExecution)
+	assert.Equal(t, interrupted.Id, updated.Id)
+	assert.Equal(t, pkgModels.CommandSequenceStatusFailed, updated.Status)
+	assert.NotZero(t, updated.EndedAt)
+}
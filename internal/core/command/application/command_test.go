@@ -14,6 +14,9 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
 
 	"github.com/stretchr/testify/assert"
+
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
 )
 
 const (
@@ -61,10 +64,10 @@ func TestBuildCoreCommands(t *testing.T) {
 			},
 		},
 	}
-	expectedCoreCommand := []dtos.CoreCommand{
+	expectedCoreCommand := []pkgDtos.CoreCommand{
 		{
 			Name: command1, Get: true, Path: commandPath(testDeviceName, command1), Url: testServiceUrl,
-			Parameters: []dtos.CoreCommandParameter{
+			Parameters: []pkgDtos.CoreCommandParameter{
 				{ResourceName: resource1, ValueType: common.ValueTypeString},
 				{ResourceName: resource2, ValueType: common.ValueTypeInt16},
 				{ResourceName: resource3, ValueType: common.ValueTypeBool},
@@ -72,28 +75,81 @@ func TestBuildCoreCommands(t *testing.T) {
 		},
 		{
 			Name: resource6, Get: true, Set: true, Path: commandPath(testDeviceName, resource6), Url: testServiceUrl,
-			Parameters: []dtos.CoreCommandParameter{{ResourceName: resource6, ValueType: common.ValueTypeBool}},
+			Parameters: []pkgDtos.CoreCommandParameter{{ResourceName: resource6, ValueType: common.ValueTypeBool}},
 		},
 		{
 			Name: resource1, Get: true, Path: commandPath(testDeviceName, resource1), Url: testServiceUrl,
-			Parameters: []dtos.CoreCommandParameter{{ResourceName: resource1, ValueType: common.ValueTypeString}},
+			Parameters: []pkgDtos.CoreCommandParameter{{ResourceName: resource1, ValueType: common.ValueTypeString}},
 		},
 		{
 			Name: resource2, Set: true, Path: commandPath(testDeviceName, resource2), Url: testServiceUrl,
-			Parameters: []dtos.CoreCommandParameter{{ResourceName: resource2, ValueType: common.ValueTypeInt16}},
+			Parameters: []pkgDtos.CoreCommandParameter{{ResourceName: resource2, ValueType: common.ValueTypeInt16}},
 		},
 		{
 			Name: resource3, Get: true, Set: true, Path: commandPath(testDeviceName, resource3), Url: testServiceUrl,
-			Parameters: []dtos.CoreCommandParameter{{ResourceName: resource3, ValueType: common.ValueTypeBool}},
+			Parameters: []pkgDtos.CoreCommandParameter{{ResourceName: resource3, ValueType: common.ValueTypeBool}},
 		},
 		{
 			Name: resource5, Get: true, Set: true, Path: commandPath(testDeviceName, resource5), Url: testServiceUrl,
-			Parameters: []dtos.CoreCommandParameter{{ResourceName: resource5, ValueType: common.ValueTypeInt16}},
+			Parameters: []pkgDtos.CoreCommandParameter{{ResourceName: resource5, ValueType: common.ValueTypeInt16}},
 		},
 	}
 
-	result, err := buildCoreCommands(testDeviceName, testServiceUrl, profile)
+	result, err := buildCoreCommands(dtos.Device{Name: testDeviceName}, testServiceUrl, profile)
 	require.NoError(t, err)
 
 	assert.ElementsMatch(t, expectedCoreCommand, result)
 }
+
+func TestBuildCoreCommandsWithResourceOverrides(t *testing.T) {
+	min, max, scale := float64(0), float64(100), float64(1)
+	profile := dtos.DeviceProfile{
+		DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: "testProfile"},
+		DeviceResources: []dtos.DeviceResource{
+			{Name: resource3, Properties: dtos.ResourceProperties{ValueType: common.ValueTypeFloat32, ReadWrite: common.ReadWrite_RW, Units: "degreeCelsius", Minimum: &min, Maximum: &max, Scale: &scale}},
+			{Name: resource5, Properties: dtos.ResourceProperties{ValueType: common.ValueTypeInt16, ReadWrite: common.ReadWrite_RW}},
+		},
+		DeviceCommands: []dtos.DeviceCommand{
+			{
+				Name: command1, ReadWrite: common.ReadWrite_R,
+				ResourceOperations: []dtos.ResourceOperation{{DeviceResource: resource3}, {DeviceResource: resource5}},
+			},
+		},
+	}
+	device := dtos.Device{
+		Name: testDeviceName,
+		Properties: map[string]any{
+			pkgModels.DeviceResourceOverridesProperty: map[string]any{
+				resource3: map[string]any{"units": "degreeFahrenheit", "maximum": 212, "scale": 1.8},
+			},
+		},
+	}
+	overriddenMax, overriddenScale := float64(212), 1.8
+	overridden := pkgDtos.CoreCommandParameter{
+		ResourceName: resource3, ValueType: common.ValueTypeFloat32, Units: "degreeFahrenheit",
+		Minimum: &min, Maximum: &overriddenMax, Scale: &overriddenScale,
+	}
+	expectedCoreCommand := []pkgDtos.CoreCommand{
+		{
+			Name: command1, Get: true, Path: commandPath(testDeviceName, command1), Url: testServiceUrl,
+			Parameters: []pkgDtos.CoreCommandParameter{overridden, {ResourceName: resource5, ValueType: common.ValueTypeInt16}},
+		},
+		{
+			Name: resource3, Get: true, Set: true, Path: commandPath(testDeviceName, resource3), Url: testServiceUrl,
+			Parameters: []pkgDtos.CoreCommandParameter{overridden},
+		},
+		{
+			Name: resource5, Get: true, Set: true, Path: commandPath(testDeviceName, resource5), Url: testServiceUrl,
+			Parameters: []pkgDtos.CoreCommandParameter{{ResourceName: resource5, ValueType: common.ValueTypeInt16}},
+		},
+	}
+
+	result, err := buildCoreCommands(device, testServiceUrl, profile)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, expectedCoreCommand, result)
+	assert.Equal(t, "degreeCelsius", profile.DeviceResources[0].Properties.Units, "the profile must not be modified")
+
+	device.Properties[pkgModels.DeviceResourceOverridesProperty] = "invalid"
+	_, err = buildCoreCommands(device, testServiceUrl, profile)
+	assert.Error(t, err)
+}
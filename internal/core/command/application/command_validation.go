@@ -0,0 +1,189 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+)
+
+// valueTypeBitSizes holds the bit sizes used to parse the numeric values of the value types
+var valueTypeBitSizes = map[string]int{
+	common.ValueTypeInt8: 8, common.ValueTypeInt16: 16, common.ValueTypeInt32: 32, common.ValueTypeInt64: 64,
+	common.ValueTypeUint8: 8, common.ValueTypeUint16: 16, common.ValueTypeUint32: 32, common.ValueTypeUint64: 64,
+	common.ValueTypeFloat32: 32, common.ValueTypeFloat64: 64,
+}
+
+// ValidateSetCommand validates the settings of the set command against the device resources of the device, so that
+// an invalid value is rejected before it's issued to the device
+func ValidateSetCommand(deviceName string, commandName string, settings map[string]any, dic *di.Container) errors.EdgeX {
+	dc := bootstrapContainer.DeviceClientFrom(dic.Get)
+	if dc == nil {
+		return errors.NewCommonEdgeX(errors.KindServerError, "nil DeviceClient returned", nil)
+	}
+	deviceResponse, err := dc.DeviceByName(context.Background(), deviceName)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return validateSetCommandSettings(deviceResponse.Device, commandName, settings, dic)
+}
+
+// validateSetCommandSettings validates the settings of the set command against the effective device resources of the
+// device. Every setting must be a writable resource of the command, and its value must be of the resource value type
+// and within the resource minimum and maximum.
+func validateSetCommandSettings(device dtos.Device, commandName string, settings map[string]any, dic *di.Container) errors.EdgeX {
+	dpc := bootstrapContainer.DeviceProfileClientFrom(dic.Get)
+	if dpc == nil {
+		return errors.NewCommonEdgeX(errors.KindServerError, "nil DeviceProfileClient returned", nil)
+	}
+	deviceProfileResponse, err := dpc.DeviceProfileByName(context.Background(), device.ProfileName)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	resources, err := effectiveDeviceResources(device, deviceProfileResponse.Profile)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	operations, err := setCommandResourceOperations(device.Name, commandName, deviceProfileResponse.Profile, resources)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
+	for name, value := range settings {
+		ro, ok := operations[name]
+		if !ok {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid,
+				fmt.Sprintf("resource '%s' isn't part of the set command '%s' of device '%s'", name, commandName, device.Name), nil)
+		}
+		r, _ := deviceResourcesByName(resources, name)
+		if !strings.Contains(r.Properties.ReadWrite, common.ReadWrite_W) {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("resource '%s' of device '%s' is read-only", name, device.Name), nil)
+		}
+		if err := validateResourceValue(r.Properties, ro.Mappings, value); err != nil {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid value of resource '%s': %v", name, err), nil)
+		}
+	}
+	return nil
+}
+
+// setCommandResourceOperations returns the resource operations of the set command by resource name, the command is
+// either a device command or a device resource of the profile
+func setCommandResourceOperations(deviceName string, commandName string, profile dtos.DeviceProfile, resources []dtos.DeviceResource) (map[string]dtos.ResourceOperation, errors.EdgeX) {
+	for _, c := range profile.DeviceCommands {
+		if c.Name != commandName {
+			continue
+		}
+		if !strings.Contains(c.ReadWrite, common.ReadWrite_W) {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("command '%s' of device '%s' is read-only", commandName, deviceName), nil)
+		}
+		operations := make(map[string]dtos.ResourceOperation, len(c.ResourceOperations))
+		for _, ro := range c.ResourceOperations {
+			operations[ro.DeviceResource] = ro
+		}
+		return operations, nil
+	}
+	if r, exists := deviceResourcesByName(resources, commandName); exists {
+		if !strings.Contains(r.Properties.ReadWrite, common.ReadWrite_W) {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("command '%s' of device '%s' is read-only", commandName, deviceName), nil)
+		}
+		return map[string]dtos.ResourceOperation{r.Name: {DeviceResource: r.Name}}, nil
+	}
+	return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("device '%s' doesn't have the command '%s'", deviceName, commandName), nil)
+}
+
+// validateResourceValue validates the value against the value type, minimum and maximum of the resource. A mapped value
+// is validated as the raw value it's mapped from, since the device service reverses the mapping before writing it.
+func validateResourceValue(p dtos.ResourceProperties, mappings map[string]string, value any) error {
+	if s, ok := value.(string); ok {
+		for raw, mapped := range mappings {
+			if mapped == s {
+				value = raw
+				break
+			}
+		}
+	}
+
+	switch p.ValueType {
+	case common.ValueTypeString, common.ValueTypeBinary, common.ValueTypeObject:
+		return nil
+	}
+	if !strings.HasSuffix(p.ValueType, "Array") {
+		return validateScalarValue(p.ValueType, p, value)
+	}
+
+	elements, ok := value.([]any)
+	if s, isString := value.(string); isString {
+		ok = json.Unmarshal([]byte(s), &elements) == nil
+	}
+	if !ok {
+		return fmt.Errorf("expected a %s value", p.ValueType)
+	}
+	elementType := strings.TrimSuffix(p.ValueType, "Array")
+	for _, e := range elements {
+		if err := validateScalarValue(elementType, p, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateScalarValue validates the value is a valueType value within the minimum and maximum of the resource
+func validateScalarValue(valueType string, p dtos.ResourceProperties, value any) error {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		s = strconv.FormatBool(v)
+	default:
+		return fmt.Errorf("expected a %s value, but got %T", valueType, value)
+	}
+
+	var number float64
+	var err error
+	switch valueType {
+	case common.ValueTypeString:
+		return nil
+	case common.ValueTypeBool:
+		_, err = strconv.ParseBool(s)
+	case common.ValueTypeInt8, common.ValueTypeInt16, common.ValueTypeInt32, common.ValueTypeInt64:
+		var n int64
+		n, err = strconv.ParseInt(s, 10, valueTypeBitSizes[valueType])
+		number = float64(n)
+	case common.ValueTypeUint8, common.ValueTypeUint16, common.ValueTypeUint32, common.ValueTypeUint64:
+		var n uint64
+		n, err = strconv.ParseUint(s, 10, valueTypeBitSizes[valueType])
+		number = float64(n)
+	case common.ValueTypeFloat32, common.ValueTypeFloat64:
+		number, err = strconv.ParseFloat(s, valueTypeBitSizes[valueType])
+	default:
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("'%s' isn't a valid %s value", s, valueType)
+	}
+	if valueType == common.ValueTypeBool {
+		return nil
+	}
+	if p.Minimum != nil && number < *p.Minimum {
+		return fmt.Errorf("%s is less than the minimum %v", s, *p.Minimum)
+	}
+	if p.Maximum != nil && number > *p.Maximum {
+		return fmt.Errorf("%s is greater than the maximum %v", s, *p.Maximum)
+	}
+	return nil
+}
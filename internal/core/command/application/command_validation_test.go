@@ -0,0 +1,100 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"testing"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+func TestValidateSetCommandSettings(t *testing.T) {
+	minimum, maximum := 10.0, 35.0
+	profile := dtos.DeviceProfile{
+		DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: "ahu"},
+		DeviceResources: []dtos.DeviceResource{
+			{Name: "setpoint", Properties: dtos.ResourceProperties{ValueType: common.ValueTypeFloat32, ReadWrite: common.ReadWrite_RW, Minimum: &minimum, Maximum: &maximum}},
+			{Name: "fanSpeed", Properties: dtos.ResourceProperties{ValueType: common.ValueTypeUint8, ReadWrite: common.ReadWrite_RW}},
+			{Name: "mode", Properties: dtos.ResourceProperties{ValueType: common.ValueTypeInt16, ReadWrite: common.ReadWrite_W}},
+			{Name: "enabled", Properties: dtos.ResourceProperties{ValueType: common.ValueTypeBool, ReadWrite: common.ReadWrite_RW}},
+			{Name: "schedule", Properties: dtos.ResourceProperties{ValueType: common.ValueTypeInt32Array, ReadWrite: common.ReadWrite_RW, Minimum: &minimum}},
+			{Name: "label", Properties: dtos.ResourceProperties{ValueType: common.ValueTypeString, ReadWrite: common.ReadWrite_RW}},
+			{Name: "temperature", Properties: dtos.ResourceProperties{ValueType: common.ValueTypeFloat32, ReadWrite: common.ReadWrite_R}},
+		},
+		DeviceCommands: []dtos.DeviceCommand{
+			{Name: "operate", ReadWrite: common.ReadWrite_W, ResourceOperations: []dtos.ResourceOperation{
+				{DeviceResource: "setpoint"},
+				{DeviceResource: "mode", Mappings: map[string]string{"1": "heat", "2": "cool"}},
+				{DeviceResource: "temperature"},
+			}},
+			{Name: "status", ReadWrite: common.ReadWrite_R, ResourceOperations: []dtos.ResourceOperation{{DeviceResource: "temperature"}}},
+		},
+	}
+	dpc := &mocks.DeviceProfileClient{}
+	dpc.On("DeviceProfileByName", mock.Anything, profile.Name).Return(responses.DeviceProfileResponse{Profile: profile}, nil)
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.DeviceProfileClientName: func(get di.Get) interface{} {
+			return dpc
+		},
+	})
+	device := dtos.Device{Name: "ahu-1", ProfileName: profile.Name}
+	// the device overrides the maximum of the setpoint
+	overridden := device
+	overridden.Properties = map[string]any{pkgModels.DeviceResourceOverridesProperty: map[string]any{"setpoint": map[string]any{"maximum": 25.0}}}
+
+	tests := []struct {
+		name          string
+		device        dtos.Device
+		commandName   string
+		settings      map[string]any
+		errorExpected bool
+	}{
+		{"valid - device command", device, "operate", map[string]any{"setpoint": "21.5", "mode": "heat"}, false},
+		{"valid - device command with raw value of mapping", device, "operate", map[string]any{"mode": "2"}, false},
+		{"valid - resource command with JSON number", device, "fanSpeed", map[string]any{"fanSpeed": float64(3)}, false},
+		{"valid - bool", device, "enabled", map[string]any{"enabled": true}, false},
+		{"valid - array", device, "schedule", map[string]any{"schedule": "[10, 20]"}, false},
+		{"valid - string", device, "label", map[string]any{"label": "lobby"}, false},
+		{"invalid - unknown command", device, "unknown", map[string]any{"setpoint": "21.5"}, true},
+		{"invalid - read-only device command", device, "status", map[string]any{"temperature": "21.5"}, true},
+		{"invalid - read-only resource command", device, "temperature", map[string]any{"temperature": "21.5"}, true},
+		{"invalid - read-only resource of device command", device, "operate", map[string]any{"temperature": "21.5"}, true},
+		{"invalid - resource not part of command", device, "operate", map[string]any{"setpiont": "21.5"}, true},
+		{"invalid - not a number", device, "operate", map[string]any{"setpoint": "warm"}, true},
+		{"invalid - less than minimum", device, "operate", map[string]any{"setpoint": "5"}, true},
+		{"invalid - greater than maximum", device, "operate", map[string]any{"setpoint": float64(40)}, true},
+		{"invalid - greater than overridden maximum", overridden, "operate", map[string]any{"setpoint": "30"}, true},
+		{"invalid - unknown mapping", device, "operate", map[string]any{"mode": "fan"}, true},
+		{"invalid - out of value type range", device, "fanSpeed", map[string]any{"fanSpeed": "256"}, true},
+		{"invalid - negative unsigned", device, "fanSpeed", map[string]any{"fanSpeed": "-1"}, true},
+		{"invalid - not an integer", device, "fanSpeed", map[string]any{"fanSpeed": float64(2.5)}, true},
+		{"invalid - not a bool", device, "enabled", map[string]any{"enabled": "yes"}, true},
+		{"invalid - not an array", device, "schedule", map[string]any{"schedule": "10"}, true},
+		{"invalid - array element less than minimum", device, "schedule", map[string]any{"schedule": []any{float64(10), float64(5)}}, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateSetCommandSettings(testCase.device, testCase.commandName, testCase.settings, dic)
+			if testCase.errorExpected {
+				require.Error(t, err)
+				assert.Equal(t, edgexErr.KindContractInvalid, edgexErr.Kind(err))
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
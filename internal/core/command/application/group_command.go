@@ -0,0 +1,278 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-messaging/v3/pkg/types"
+
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dtos/requests"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
+)
+
+// groupCommandTarget is a device a group command is issued to, the device is queried by name when it's only selected
+// by name
+type groupCommandTarget struct {
+	name   string
+	device *dtos.Device
+}
+
+// IssueGroupCommand issues the get or set command to every device selected by the request over the MessageBus, with
+// at most GroupCommand.MaxParallelism devices at once. The results are in the order of the device names, and the
+// failure of a device doesn't affect the other devices.
+func IssueGroupCommand(req requests.GroupCommandRequest, ctx context.Context, dic *di.Container) (summary pkgDtos.GroupCommandSummary, results []pkgDtos.GroupCommandResult, err errors.EdgeX) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	config := commandContainer.ConfigurationFrom(dic.Get)
+
+	targets, err := selectGroupCommandTargets(req.Selector, ctx, dic)
+	if err != nil {
+		return summary, nil, errors.NewCommonEdgeXWrapper(err)
+	}
+	if config.GroupCommand.MaxDevices > 0 && len(targets) > config.GroupCommand.MaxDevices {
+		return summary, nil, errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("the selector matches %d devices, more than the maximum %d devices of a group command", len(targets), config.GroupCommand.MaxDevices), nil)
+	}
+	requestTimeout, parseErr := time.ParseDuration(config.Service.RequestTimeout)
+	if parseErr != nil {
+		return summary, nil, errors.NewCommonEdgeX(errors.KindServerError, "failed to parse Service.RequestTimeout", parseErr)
+	}
+
+	parallelism := max(config.GroupCommand.MaxParallelism, 1)
+	semaphore := make(chan struct{}, parallelism)
+	results = make([]pkgDtos.GroupCommandResult, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, target groupCommandTarget) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			start := time.Now()
+			results[i] = issueDeviceCommandRequest(target, req, requestTimeout, ctx, dic)
+			RecordCommandAudit(pkgModels.CommandAuditEntry{
+				Origin:        pkgModels.CommandAuditOriginHTTP,
+				Actor:         identity.FromContext(ctx),
+				CorrelationId: correlation.FromContext(ctx),
+				DeviceName:    target.name,
+				CommandName:   req.CommandName,
+				Method:        req.Method,
+				Parameters:    req.Settings,
+				QueryParams:   req.QueryParams,
+				StatusCode:    results[i].StatusCode,
+				Message:       results[i].Message,
+			}, start, dic)
+		}(i, target)
+	}
+	wg.Wait()
+
+	summary = summarizeGroupCommandResults(results)
+	lc.Debugf("Group command %s %s is issued to %d devices, %d failed. Correlation-ID: %s",
+		req.Method, req.CommandName, summary.Total, summary.Failed, correlation.FromContext(ctx))
+	return summary, results, nil
+}
+
+// selectGroupCommandTargets queries the devices matching all the criteria of the selector, sorted by name. The
+// devices only selected by name are queried when the command is issued, so that a missing device fails alone.
+func selectGroupCommandTargets(selector pkgDtos.DeviceSelector, ctx context.Context, dic *di.Container) ([]groupCommandTarget, errors.EdgeX) {
+	if selector.ProfileName == "" && len(selector.Labels) == 0 {
+		names := slices.Clone(selector.DeviceNames)
+		slices.Sort(names)
+		names = slices.Compact(names)
+		targets := make([]groupCommandTarget, len(names))
+		for i, name := range names {
+			targets[i] = groupCommandTarget{name: name}
+		}
+		return targets, nil
+	}
+
+	dc := bootstrapContainer.DeviceClientFrom(dic.Get)
+	if dc == nil {
+		return nil, errors.NewCommonEdgeX(errors.KindServerError, "nil DeviceClient returned", nil)
+	}
+	var devices []dtos.Device
+	for offset := 0; ; {
+		var res responses.MultiDevicesResponse
+		var err errors.EdgeX
+		if selector.ProfileName != "" {
+			res, err = dc.DevicesByProfileName(ctx, selector.ProfileName, offset, -1)
+		} else {
+			res, err = dc.AllDevices(ctx, selector.Labels, offset, -1)
+		}
+		if err != nil {
+			return nil, errors.NewCommonEdgeXWrapper(err)
+		}
+		devices = append(devices, res.Devices...)
+		offset += len(res.Devices)
+		if len(res.Devices) == 0 || offset >= int(res.TotalCount) {
+			break
+		}
+	}
+
+	var targets []groupCommandTarget
+	for _, device := range devices {
+		if !matchDeviceSelector(device, selector) {
+			continue
+		}
+		d := device
+		targets = append(targets, groupCommandTarget{name: d.Name, device: &d})
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].name < targets[j].name
+	})
+	return targets, nil
+}
+
+// matchDeviceSelector returns whether the device matches all the criteria of the selector
+func matchDeviceSelector(device dtos.Device, selector pkgDtos.DeviceSelector) bool {
+	if selector.ProfileName != "" && device.ProfileName != selector.ProfileName {
+		return false
+	}
+	for _, label := range selector.Labels {
+		if !slices.Contains(device.Labels, label) {
+			return false
+		}
+	}
+	return len(selector.DeviceNames) == 0 || slices.Contains(selector.DeviceNames, device.Name)
+}
+
+// issueDeviceCommandRequest issues the command to the device through the device service over the MessageBus, the
+// same way a command request received on the MessageBus is forwarded
+func issueDeviceCommandRequest(target groupCommandTarget, req requests.GroupCommandRequest, requestTimeout time.Duration, ctx context.Context, dic *di.Container) pkgDtos.GroupCommandResult {
+	result := pkgDtos.GroupCommandResult{DeviceName: target.name}
+	fail := func(err errors.EdgeX) pkgDtos.GroupCommandResult {
+		result.StatusCode = utils.StatusCode(err)
+		result.Message = err.Message()
+		return result
+	}
+
+	if target.device == nil {
+		dc := bootstrapContainer.DeviceClientFrom(dic.Get)
+		if dc == nil {
+			return fail(errors.NewCommonEdgeX(errors.KindServerError, "nil DeviceClient returned", nil))
+		}
+		deviceResponse, err := dc.DeviceByName(ctx, target.name)
+		if err != nil {
+			return fail(err)
+		}
+		target.device = &deviceResponse.Device
+	}
+	if err := AuthorizeDeviceCommand(identity.FromContext(ctx), *target.device, req.CommandName, req.Method, dic); err != nil {
+		return fail(err)
+	}
+	if pkgModels.DeviceLifecycleState(target.device.Properties) == pkgModels.Decommissioned {
+		return fail(errors.NewCommonEdgeX(errors.KindStatusConflict, fmt.Sprintf("device '%s' is decommissioned", target.name), nil))
+	}
+
+	var payload []byte
+	if req.Method == requests.GroupCommandMethodSet {
+		if err := validateSetCommandSettings(*target.device, req.CommandName, req.Settings, dic); err != nil {
+			return fail(err)
+		}
+		var err error
+		if payload, err = json.Marshal(req.Settings); err != nil {
+			return fail(errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to encode the settings", err))
+		}
+		// a critical set command awaits approval as a pending command of the device instead of being issued
+		pendingCommandId, approvalErr := requestSetCommandApproval(pkgModels.CommandAuditOriginHTTP, *target.device, req.CommandName, req.QueryParams, req.Settings, ctx, dic)
+		if approvalErr != nil {
+			return fail(approvalErr)
+		}
+		if pendingCommandId != "" {
+			result.StatusCode = http.StatusAccepted
+			result.Message = PendingApprovalMessage(pendingCommandId)
+			result.PendingCommandId = pendingCommandId
+			return result
+		}
+		if err := CheckSetCommandSafety(target.name, req.CommandName, req.Settings, dic); err != nil {
+			return fail(err)
+		}
+	}
+	queryParams := make(map[string]string, len(req.QueryParams))
+	for k, v := range req.QueryParams {
+		queryParams[k] = v
+	}
+	requestEnvelope := types.NewMessageEnvelopeForRequest(payload, queryParams)
+	if correlationId := correlation.FromContext(ctx); correlationId != "" {
+		requestEnvelope.CorrelationID = correlationId
+	}
+
+	config := commandContainer.ConfigurationFrom(dic.Get)
+	baseTopic := config.MessageBus.GetBaseTopicPrefix()
+	// internal command request topic scheme: <DeviceRequestTopicPrefix>/<device-service>/<device>/<command-name>/<method>
+	deviceRequestTopic := common.NewPathBuilder().EnableNameFieldEscape(config.Service.EnableNameFieldEscape).
+		SetPath(common.BuildTopic(baseTopic, common.CoreCommandDeviceRequestPublishTopic)).
+		SetNameFieldPath(target.device.ServiceName).SetNameFieldPath(target.name).SetNameFieldPath(req.CommandName).SetPath(req.Method).BuildPath()
+	deviceResponseTopicPrefix := common.NewPathBuilder().EnableNameFieldEscape(config.Service.EnableNameFieldEscape).
+		SetPath(baseTopic).SetPath(common.ResponseTopic).SetNameFieldPath(target.device.ServiceName).BuildPath()
+
+	messageBus := bootstrapContainer.MessagingClientFrom(dic.Get)
+	if messageBus == nil {
+		return fail(errors.NewCommonEdgeX(errors.KindServerError, "nil MessagingClient returned", nil))
+	}
+	response, err := messageBus.Request(requestEnvelope, deviceRequestTopic, deviceResponseTopicPrefix, requestTimeout)
+	if err != nil {
+		return fail(errors.NewCommonEdgeX(errors.KindServiceUnavailable, fmt.Sprintf("request to topic '%s' failed", deviceRequestTopic), err))
+	}
+	if response.ErrorCode != 0 {
+		return fail(errors.NewCommonEdgeX(errors.KindServerError, string(response.Payload), nil))
+	}
+
+	result.StatusCode = http.StatusOK
+	if req.Method == requests.GroupCommandMethodSet {
+		InvalidateCachedGetCommands(target.name)
+	}
+	if req.Method == requests.GroupCommandMethodGet && len(response.Payload) > 0 {
+		var eventResponse responses.EventResponse
+		if err := json.Unmarshal(response.Payload, &eventResponse); err != nil {
+			return fail(errors.NewCommonEdgeX(errors.KindServerError, "failed to decode the event response", err))
+		}
+		result.Event = &eventResponse.Event
+	}
+	return result
+}
+
+// summarizeGroupCommandResults counts the succeeded and failed devices of a group command
+func summarizeGroupCommandResults(results []pkgDtos.GroupCommandResult) pkgDtos.GroupCommandSummary {
+	summary := pkgDtos.GroupCommandSummary{Total: len(results)}
+	for _, result := range results {
+		if result.StatusCode == http.StatusOK {
+			summary.Succeeded++
+			continue
+		}
+		if result.StatusCode == http.StatusAccepted {
+			summary.PendingApproval++
+			continue
+		}
+		summary.Failed++
+		summary.FailedDevices = append(summary.FailedDevices, result.DeviceName)
+		if summary.FailuresByStatusCode == nil {
+			summary.FailuresByStatusCode = make(map[int]int)
+		}
+		summary.FailuresByStatusCode[result.StatusCode]++
+	}
+	return summary
+}
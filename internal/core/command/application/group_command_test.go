@@ -0,0 +1,269 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	messagingMocks "github.com/edgexfoundry/go-mod-messaging/v3/messaging/mocks"
+	"github.com/edgexfoundry/go-mod-messaging/v3/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/command/config"
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/command/infrastructure/interfaces/mocks"
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dtos/requests"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+const testLightingProfile = "lighting-controller"
+
+func mockGroupCommandDic(maxParallelism int, dc *mocks.DeviceClient, messageBus *messagingMocks.MessageClient) *di.Container {
+	return di.NewContainer(di.ServiceConstructorMap{
+		commandContainer.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Service:      bootstrapConfig.ServiceInfo{RequestTimeout: "5s"},
+				MessageBus:   bootstrapConfig.MessageBusInfo{BaseTopicPrefix: "edgex"},
+				GroupCommand: config.GroupCommand{MaxParallelism: maxParallelism, MaxDevices: 100},
+			}
+		},
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+			return dc
+		},
+		bootstrapContainer.DeviceProfileClientName: func(get di.Get) interface{} {
+			dpc := &mocks.DeviceProfileClient{}
+			dpc.On("DeviceProfileByName", mock.Anything, testLightingProfile).Return(responses.DeviceProfileResponse{Profile: dtos.DeviceProfile{
+				DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: testLightingProfile},
+				DeviceResources: []dtos.DeviceResource{
+					{Name: "switch", Properties: dtos.ResourceProperties{ValueType: common.ValueTypeBool, ReadWrite: common.ReadWrite_RW}},
+				},
+				DeviceCommands: []dtos.DeviceCommand{{
+					Name: "switch", ReadWrite: common.ReadWrite_RW,
+					ResourceOperations: []dtos.ResourceOperation{{DeviceResource: "switch", Mappings: map[string]string{"true": "on", "false": "off"}}},
+				}},
+			}}, nil)
+			return dpc
+		},
+		bootstrapContainer.MessagingClientName: func(get di.Get) interface{} {
+			return messageBus
+		},
+		commandContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			dbClientMock := &dbMock.DBClient{}
+			dbClientMock.On("AddCommandAuditEntry", mock.Anything).Return(pkgModels.CommandAuditEntry{}, nil)
+			return dbClientMock
+		},
+	})
+}
+
+func lightingDevices(count int) []dtos.Device {
+	devices := make([]dtos.Device, count)
+	for i := range devices {
+		devices[i] = dtos.Device{
+			Name:        "light-" + string(rune('a'+i)),
+			ServiceName: "device-bacnet",
+			ProfileName: testLightingProfile,
+			Labels:      []string{"lighting", "floor-1"},
+		}
+	}
+	return devices
+}
+
+func TestIssueGroupCommandSet(t *testing.T) {
+	devices := lightingDevices(6)
+	devices[1].Labels = []string{"lighting"}
+	devices[2].Properties = map[string]any{pkgModels.DeviceLifecycleStateProperty: pkgModels.Decommissioned}
+
+	dc := &mocks.DeviceClient{}
+	dc.On("AllDevices", mock.Anything, []string{"lighting", "floor-1"}, 0, -1).
+		Return(responses.MultiDevicesResponse{BaseWithTotalCountResponse: commonDTO.BaseWithTotalCountResponse{TotalCount: 6}, Devices: devices}, nil)
+
+	var running, maxRunning int32
+	messageBus := &messagingMocks.MessageClient{}
+	messageBus.On("Request", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				observed := atomic.LoadInt32(&maxRunning)
+				if current <= observed || atomic.CompareAndSwapInt32(&maxRunning, observed, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		}).
+		Return(func(envelope types.MessageEnvelope, requestTopic string, responseTopicPrefix string, timeout time.Duration) *types.MessageEnvelope {
+			var settings map[string]any
+			require.NoError(t, json.Unmarshal(envelope.Payload, &settings))
+			assert.Equal(t, "on", settings["switch"])
+			assert.True(t, strings.HasPrefix(requestTopic, "edgex/device/command/request/device-bacnet/light-"))
+			assert.True(t, strings.HasSuffix(requestTopic, "/switch/set"))
+			if strings.Contains(requestTopic, "light-d") {
+				response := types.NewMessageEnvelopeWithError(envelope.RequestID, "device is offline")
+				return &response
+			}
+			response, err := types.NewMessageEnvelopeForResponse(nil, envelope.RequestID, envelope.CorrelationID, common.ContentTypeJSON)
+			require.NoError(t, err)
+			return &response
+		}, func(envelope types.MessageEnvelope, requestTopic string, responseTopicPrefix string, timeout time.Duration) error {
+			if strings.Contains(requestTopic, "light-e") {
+				return errors.New("timed out")
+			}
+			return nil
+		})
+
+	dic := mockGroupCommandDic(2, dc, messageBus)
+	req := requests.GroupCommandRequest{
+		Selector:    pkgDtos.DeviceSelector{Labels: []string{"lighting", "floor-1"}},
+		CommandName: "switch",
+		Method:      requests.GroupCommandMethodSet,
+		Settings:    map[string]any{"switch": "on"},
+	}
+
+	summary, results, err := IssueGroupCommand(req, context.Background(), dic)
+	require.NoError(t, err)
+
+	require.Len(t, results, 5, "the device without all the labels should not be selected")
+	assert.Equal(t, "light-a", results[0].DeviceName)
+	assert.Equal(t, http.StatusOK, results[0].StatusCode)
+	assert.Equal(t, "light-c", results[1].DeviceName)
+	assert.Equal(t, http.StatusConflict, results[1].StatusCode)
+	assert.Equal(t, http.StatusInternalServerError, results[2].StatusCode)
+	assert.Equal(t, "device is offline", results[2].Message)
+	assert.Equal(t, http.StatusServiceUnavailable, results[3].StatusCode)
+	assert.Equal(t, http.StatusOK, results[4].StatusCode)
+
+	assert.Equal(t, 5, summary.Total)
+	assert.Equal(t, 2, summary.Succeeded)
+	assert.Equal(t, 3, summary.Failed)
+	assert.Equal(t, []string{"light-c", "light-d", "light-e"}, summary.FailedDevices)
+	assert.Equal(t, map[int]int{http.StatusConflict: 1, http.StatusInternalServerError: 1, http.StatusServiceUnavailable: 1}, summary.FailuresByStatusCode)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(2), "the parallelism should be bounded")
+	messageBus.AssertNumberOfCalls(t, "Request", 4)
+}
+
+func TestIssueGroupCommandGetByDeviceNames(t *testing.T) {
+	devices := lightingDevices(2)
+	event := dtos.NewEvent(testLightingProfile, devices[0].Name, "switch")
+
+	dc := &mocks.DeviceClient{}
+	dc.On("DeviceByName", mock.Anything, devices[0].Name).Return(responses.DeviceResponse{Device: devices[0]}, nil)
+	dc.On("DeviceByName", mock.Anything, "missing").Return(responses.DeviceResponse{},
+		edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, "device doesn't exist", nil))
+
+	var mutex sync.Mutex
+	var queryParams []map[string]string
+	messageBus := &messagingMocks.MessageClient{}
+	messageBus.On("Request", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(func(envelope types.MessageEnvelope, requestTopic string, responseTopicPrefix string, timeout time.Duration) *types.MessageEnvelope {
+			mutex.Lock()
+			queryParams = append(queryParams, envelope.QueryParams)
+			mutex.Unlock()
+			payload, err := json.Marshal(responses.NewEventResponse("", "", http.StatusOK, event))
+			require.NoError(t, err)
+			response, err := types.NewMessageEnvelopeForResponse(payload, envelope.RequestID, envelope.CorrelationID, common.ContentTypeJSON)
+			require.NoError(t, err)
+			return &response
+		}, nil)
+
+	dic := mockGroupCommandDic(10, dc, messageBus)
+	req := requests.GroupCommandRequest{
+		Selector:    pkgDtos.DeviceSelector{DeviceNames: []string{devices[0].Name, "missing", devices[0].Name}},
+		CommandName: "switch",
+		Method:      requests.GroupCommandMethodGet,
+		QueryParams: map[string]string{common.PushEvent: common.ValueTrue},
+	}
+
+	summary, results, err := IssueGroupCommand(req, context.Background(), dic)
+	require.NoError(t, err)
+
+	require.Len(t, results, 2, "the duplicate device names should be issued once")
+	assert.Equal(t, http.StatusOK, results[0].StatusCode)
+	require.NotNil(t, results[0].Event)
+	assert.Equal(t, event.Id, results[0].Event.Id)
+	assert.Equal(t, "missing", results[1].DeviceName)
+	assert.Equal(t, http.StatusNotFound, results[1].StatusCode)
+	assert.Equal(t, 1, summary.Failed)
+	require.Len(t, queryParams, 1)
+	assert.Equal(t, common.ValueTrue, queryParams[0][common.PushEvent])
+}
+
+func TestIssueGroupCommandTooManyDevices(t *testing.T) {
+	devices := lightingDevices(3)
+	dc := &mocks.DeviceClient{}
+	dc.On("DevicesByProfileName", mock.Anything, testLightingProfile, 0, -1).
+		Return(responses.MultiDevicesResponse{BaseWithTotalCountResponse: commonDTO.BaseWithTotalCountResponse{TotalCount: 3}, Devices: devices}, nil)
+	messageBus := &messagingMocks.MessageClient{}
+
+	dic := mockGroupCommandDic(10, dc, messageBus)
+	dic.Update(di.ServiceConstructorMap{
+		commandContainer.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Service:      bootstrapConfig.ServiceInfo{RequestTimeout: "5s"},
+				GroupCommand: config.GroupCommand{MaxParallelism: 10, MaxDevices: 2},
+			}
+		},
+	})
+	req := requests.GroupCommandRequest{
+		Selector:    pkgDtos.DeviceSelector{ProfileName: testLightingProfile},
+		CommandName: "switch",
+		Method:      requests.GroupCommandMethodGet,
+	}
+
+	_, _, err := IssueGroupCommand(req, context.Background(), dic)
+	require.Error(t, err)
+	assert.Equal(t, edgexErr.KindContractInvalid, edgexErr.Kind(err))
+	messageBus.AssertNotCalled(t, "Request", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIssueGroupCommandSetInvalidSettings(t *testing.T) {
+	devices := lightingDevices(2)
+	dc := &mocks.DeviceClient{}
+	dc.On("DevicesByProfileName", mock.Anything, testLightingProfile, 0, -1).
+		Return(responses.MultiDevicesResponse{BaseWithTotalCountResponse: commonDTO.BaseWithTotalCountResponse{TotalCount: 2}, Devices: devices}, nil)
+	messageBus := &messagingMocks.MessageClient{}
+
+	dic := mockGroupCommandDic(2, dc, messageBus)
+	req := requests.GroupCommandRequest{
+		Selector:    pkgDtos.DeviceSelector{ProfileName: testLightingProfile},
+		CommandName: "switch",
+		Method:      requests.GroupCommandMethodSet,
+		Settings:    map[string]any{"switch": "dim"},
+	}
+
+	summary, results, err := IssueGroupCommand(req, context.Background(), dic)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, result := range results {
+		assert.Equal(t, http.StatusBadRequest, result.StatusCode)
+		assert.Contains(t, result.Message, "switch")
+	}
+	assert.Equal(t, 2, summary.Failed)
+	messageBus.AssertNotCalled(t, "Request", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
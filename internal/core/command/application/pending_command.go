@@ -0,0 +1,427 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
+)
+
+// criticalCommandTag is the tag of the device commands and device resources requiring approval, e.g. critical: true
+const criticalCommandTag = "critical"
+
+var (
+	asyncExpirePendingCommandsOnce sync.Once
+	// pendingCommandMutex serializes the status transitions of the pending commands, so that a command can't be
+	// approved twice or expired once it's approved
+	pendingCommandMutex sync.Mutex
+)
+
+var pendingCommandStatuses = []string{
+	pkgModels.PendingCommandStatusPending,
+	pkgModels.PendingCommandStatusApproved,
+	pkgModels.PendingCommandStatusSucceeded,
+	pkgModels.PendingCommandStatusFailed,
+	pkgModels.PendingCommandStatusRejected,
+	pkgModels.PendingCommandStatusExpired,
+}
+
+// PendingApprovalMessage returns the message responding to a critical set command that it awaits approval
+func PendingApprovalMessage(pendingCommandId string) string {
+	return fmt.Sprintf("the critical set command awaits approval as pending command %s", pendingCommandId)
+}
+
+// RequestSetCommandApproval creates a pending command awaiting approval when the set command is critical, and returns
+// its id. An empty id is returned for the set commands which don't require approval and can be issued right away.
+func RequestSetCommandApproval(origin string, deviceName string, commandName string, queryParams map[string]string,
+	settings map[string]any, ctx context.Context, dic *di.Container) (string, errors.EdgeX) {
+	if deviceName == "" {
+		return "", errors.NewCommonEdgeX(errors.KindContractInvalid, "device name cannot be empty", nil)
+	}
+	if commandName == "" {
+		return "", errors.NewCommonEdgeX(errors.KindContractInvalid, "command name cannot be empty", nil)
+	}
+
+	dc := bootstrapContainer.DeviceClientFrom(dic.Get)
+	if dc == nil {
+		return "", errors.NewCommonEdgeX(errors.KindServerError, "nil DeviceClient returned", nil)
+	}
+	deviceResponse, err := dc.DeviceByName(context.Background(), deviceName)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	return requestSetCommandApproval(origin, deviceResponse.Device, commandName, queryParams, settings, ctx, dic)
+}
+
+// requestSetCommandApproval creates the pending command of the device when the set command is critical, the settings
+// are validated first so that only a valid command awaits approval
+func requestSetCommandApproval(origin string, device dtos.Device, commandName string, queryParams map[string]string,
+	settings map[string]any, ctx context.Context, dic *di.Container) (string, errors.EdgeX) {
+	dbClient := commandContainer.DBClientFrom(dic.Get)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	config := commandContainer.ConfigurationFrom(dic.Get)
+
+	critical, err := isCriticalSetCommand(device, commandName, dic)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	if !critical {
+		return "", nil
+	}
+	if err = validateSetCommandSettings(device, commandName, settings, dic); err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	expireAfter, parseErr := time.ParseDuration(config.CommandApproval.ExpireAfter)
+	if parseErr != nil {
+		return "", errors.NewCommonEdgeX(errors.KindServerError, "invalid CommandApproval ExpireAfter", parseErr)
+	}
+
+	pc, err := dbClient.AddPendingCommand(pkgModels.PendingCommand{
+		DeviceName:  device.Name,
+		CommandName: commandName,
+		Settings:    settings,
+		QueryParams: queryParams,
+		Origin:      origin,
+		RequestedBy: identity.FromContext(ctx),
+		ExpiresAt:   time.Now().Add(expireAfter).UnixMilli(),
+		Status:      pkgModels.PendingCommandStatusPending,
+	})
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	lc.Infof("Critical set command %s on device %s requested by %s awaits approval as pending command %s. Correlation-ID: %s",
+		pc.CommandName, pc.DeviceName, pc.RequestedBy, pc.Id, correlation.FromContext(ctx))
+	notifyApprovers(pc, dic)
+	return pc.Id, nil
+}
+
+// isCriticalSetCommand returns whether the set command requires approval, either configured in the CommandApproval
+// CriticalCommands or tagged critical in the device profile as a device command or a device resource. A device command
+// is critical as well when any device resource it writes is tagged critical.
+func isCriticalSetCommand(device dtos.Device, commandName string, dic *di.Container) (bool, errors.EdgeX) {
+	config := commandContainer.ConfigurationFrom(dic.Get)
+	for _, c := range config.CommandApproval.CriticalCommands {
+		if (c.DeviceName == "" || c.DeviceName == device.Name) && c.CommandName == commandName {
+			return true, nil
+		}
+	}
+
+	dpc := bootstrapContainer.DeviceProfileClientFrom(dic.Get)
+	if dpc == nil {
+		return false, errors.NewCommonEdgeX(errors.KindServerError, "nil DeviceProfileClient returned", nil)
+	}
+	deviceProfileResponse, err := dpc.DeviceProfileByName(context.Background(), device.ProfileName)
+	if err != nil {
+		return false, errors.NewCommonEdgeXWrapper(err)
+	}
+	resources, err := effectiveDeviceResources(device, deviceProfileResponse.Profile)
+	if err != nil {
+		return false, errors.NewCommonEdgeXWrapper(err)
+	}
+	for _, c := range deviceProfileResponse.Profile.DeviceCommands {
+		if c.Name != commandName {
+			continue
+		}
+		if isCriticalTagged(c.Tags) {
+			return true, nil
+		}
+		for _, ro := range c.ResourceOperations {
+			if r, ok := deviceResourcesByName(resources, ro.DeviceResource); ok && isCriticalTagged(r.Tags) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	r, _ := deviceResourcesByName(resources, commandName)
+	return isCriticalTagged(r.Tags), nil
+}
+
+// isCriticalTagged returns whether the tags contain the critical tag of true, either as a bool or a string
+func isCriticalTagged(tags map[string]any) bool {
+	critical, err := strconv.ParseBool(fmt.Sprint(tags[criticalCommandTag]))
+	return err == nil && critical
+}
+
+// notifyApprovers alerts the approvers of the new pending command through support-notifications, a failure to send
+// the notification is only logged since the command can still be approved
+func notifyApprovers(pc pkgModels.PendingCommand, dic *di.Container) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	config := commandContainer.ConfigurationFrom(dic.Get)
+	nc := bootstrapContainer.NotificationClientFrom(dic.Get)
+	if config.CommandApproval.NotificationCategory == "" || nc == nil {
+		return
+	}
+
+	content := fmt.Sprintf("Critical set command %s on device %s requested by %s awaits approval as pending command %s until %s",
+		pc.CommandName, pc.DeviceName, pc.RequestedBy, pc.Id, time.UnixMilli(pc.ExpiresAt).UTC().Format(time.RFC3339))
+	notification := dtos.NewNotification(nil, config.CommandApproval.NotificationCategory, content, common.CoreCommandServiceKey, models.Critical)
+	_, err := nc.SendNotification(context.Background(), []requests.AddNotificationRequest{requests.NewAddNotificationRequest(notification)})
+	if err != nil {
+		lc.Errorf("Failed to notify the approvers of pending command %s, %v", pc.Id, err)
+	}
+}
+
+// WarnUnverifiedCommandApproval logs an error when the JWTs aren't verified, since the pending commands can't be
+// approved then and the critical set commands are never issued
+func WarnUnverifiedCommandApproval(dic *di.Container) {
+	if identity.IsJWTValidationEnabled() {
+		return
+	}
+	bootstrapContainer.LoggingClientFrom(dic.Get).Error("the JWT validation is disabled, the approver and requester " +
+		"identities can't be verified and the pending critical set commands will be refused approval")
+}
+
+// PendingCommandById queries the pending command by id
+func PendingCommandById(id string, dic *di.Container) (pkgDtos.PendingCommand, errors.EdgeX) {
+	if id == "" {
+		return pkgDtos.PendingCommand{}, errors.NewCommonEdgeX(errors.KindContractInvalid, "id is empty", nil)
+	}
+	pc, err := commandContainer.DBClientFrom(dic.Get).PendingCommandById(id)
+	if err != nil {
+		return pkgDtos.PendingCommand{}, errors.NewCommonEdgeXWrapper(err)
+	}
+	return pkgDtos.FromPendingCommandModelToDTO(pc), nil
+}
+
+// PendingCommands queries the pending commands with the status, sorted by the expires-at time descending. All the
+// pending commands are queried when the status is empty.
+func PendingCommands(status string, offset int, limit int, dic *di.Container) (pendingCommands []pkgDtos.PendingCommand, totalCount uint32, err errors.EdgeX) {
+	if status != "" && !slices.Contains(pendingCommandStatuses, status) {
+		return nil, 0, errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("status %s is invalid, must be one of %v", status, pendingCommandStatuses), nil)
+	}
+	pcs, totalCount, err := commandContainer.DBClientFrom(dic.Get).PendingCommands(status, offset, limit)
+	if err != nil {
+		return nil, 0, errors.NewCommonEdgeXWrapper(err)
+	}
+	pendingCommands = make([]pkgDtos.PendingCommand, len(pcs))
+	for i, pc := range pcs {
+		pendingCommands[i] = pkgDtos.FromPendingCommandModelToDTO(pc)
+	}
+	return pendingCommands, totalCount, nil
+}
+
+// ApprovePendingCommand approves the pending command by id and issues the set command, the outcome of the command is
+// recorded in the pending command and returned. The approver must be another user than the requester.
+func ApprovePendingCommand(id string, ctx context.Context, dic *di.Container) (commonDTO.BaseResponse, errors.EdgeX) {
+	dbClient := commandContainer.DBClientFrom(dic.Get)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	pc, err := reviewPendingCommand(id, pkgModels.PendingCommandStatusApproved, false, ctx, dic)
+	if err != nil {
+		return commonDTO.BaseResponse{}, errors.NewCommonEdgeXWrapper(err)
+	}
+
+	queryParams := url.Values{}
+	for k, v := range pc.QueryParams {
+		queryParams.Set(k, v)
+	}
+	start := time.Now()
+	response, err := IssueSetCommandByName(pc.DeviceName, pc.CommandName, queryParams.Encode(), pc.Settings, dic)
+	if err != nil {
+		pc.Status = pkgModels.PendingCommandStatusFailed
+		pc.StatusCode = utils.StatusCode(err)
+		pc.Message = err.Error()
+		lc.Warnf("Pending command %s of set command %s on device %s approved by %s failed, %v", pc.Id, pc.CommandName, pc.DeviceName, pc.ReviewedBy, err)
+	} else {
+		pc.Status = pkgModels.PendingCommandStatusSucceeded
+		pc.StatusCode = response.StatusCode
+		if pc.StatusCode == 0 {
+			pc.StatusCode = http.StatusOK
+		}
+		pc.Message = response.Message
+		lc.Infof("Pending command %s of set command %s on device %s approved by %s succeeded", pc.Id, pc.CommandName, pc.DeviceName, pc.ReviewedBy)
+	}
+	RecordCommandAudit(pkgModels.CommandAuditEntry{
+		Origin:        pkgModels.CommandAuditOriginHTTP,
+		Actor:         pc.ReviewedBy,
+		CorrelationId: correlation.FromContext(ctx),
+		DeviceName:    pc.DeviceName,
+		CommandName:   pc.CommandName,
+		Method:        pkgModels.CommandMethodSet,
+		Parameters:    pc.Settings,
+		QueryParams:   pc.QueryParams,
+		StatusCode:    pc.StatusCode,
+		Message:       pc.Message,
+	}, start, dic)
+	if updateErr := dbClient.UpdatePendingCommand(pc); updateErr != nil {
+		lc.Errorf("Failed to record the outcome of pending command %s, %v", pc.Id, updateErr)
+	}
+	if err != nil {
+		return response, errors.NewCommonEdgeXWrapper(err)
+	}
+	return response, nil
+}
+
+// RejectPendingCommand rejects the pending command by id, the requester may reject their own command as well
+func RejectPendingCommand(id string, ctx context.Context, dic *di.Container) errors.EdgeX {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	pc, err := reviewPendingCommand(id, pkgModels.PendingCommandStatusRejected, true, ctx, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	lc.Infof("Pending command %s of set command %s on device %s is rejected by %s. Correlation-ID: %s",
+		pc.Id, pc.CommandName, pc.DeviceName, pc.ReviewedBy, correlation.FromContext(ctx))
+	return nil
+}
+
+// reviewPendingCommand moves the pending command awaiting approval to the status on behalf of the reviewer in the
+// context. The reviewer must be one of the configured Approvers when there are any, and can't be anonymous or the
+// requester unless allowRequester. An approval also requires the write permission of the command for both the reviewer
+// and the requester, and is refused while the JWTs aren't verified since the identities could be forged.
+func reviewPendingCommand(id string, status string, allowRequester bool, ctx context.Context, dic *di.Container) (pkgModels.PendingCommand, errors.EdgeX) {
+	if id == "" {
+		return pkgModels.PendingCommand{}, errors.NewCommonEdgeX(errors.KindContractInvalid, "id is empty", nil)
+	}
+	dbClient := commandContainer.DBClientFrom(dic.Get)
+	config := commandContainer.ConfigurationFrom(dic.Get)
+	reviewer := identity.FromContext(ctx)
+
+	pendingCommandMutex.Lock()
+	defer pendingCommandMutex.Unlock()
+
+	pc, err := dbClient.PendingCommandById(id)
+	if err != nil {
+		return pc, errors.NewCommonEdgeXWrapper(err)
+	}
+	if pc.Status != pkgModels.PendingCommandStatusPending {
+		return pc, errors.NewCommonEdgeX(errors.KindStatusConflict, fmt.Sprintf("pending command %s is %s and can't be reviewed", id, pc.Status), nil)
+	}
+	now := time.Now()
+	if now.UnixMilli() > pc.ExpiresAt {
+		pc.Status = pkgModels.PendingCommandStatusExpired
+		if err = dbClient.UpdatePendingCommand(pc); err != nil {
+			return pc, errors.NewCommonEdgeXWrapper(err)
+		}
+		return pc, errors.NewCommonEdgeX(errors.KindStatusConflict, fmt.Sprintf("pending command %s is expired", id), nil)
+	}
+
+	isRequester := reviewer == pc.RequestedBy
+	switch {
+	case reviewer == identity.Anonymous:
+		return pc, errors.NewCommonEdgeX(errors.KindContractInvalid, "an anonymous user can't review a pending command", utils.ErrForbidden)
+	case isRequester && !allowRequester:
+		return pc, errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("pending command %s must be approved by another user than the requester %s", id, pc.RequestedBy), utils.ErrForbidden)
+	case !isRequester && len(config.CommandApproval.Approvers) > 0 && !slices.Contains(config.CommandApproval.Approvers, reviewer):
+		return pc, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("%s isn't an approver of the pending commands", reviewer), utils.ErrForbidden)
+	}
+	// the approval issues the set command, so both the approver and the requester must still be allowed to issue it
+	if status == pkgModels.PendingCommandStatusApproved {
+		if !identity.IsJWTValidationEnabled() {
+			return pc, errors.NewCommonEdgeX(errors.KindContractInvalid,
+				"pending commands can't be approved while the JWT validation is disabled, the approver and requester identities aren't verified", utils.ErrForbidden)
+		}
+		for _, actor := range []string{reviewer, pc.RequestedBy} {
+			if err = AuthorizeCommand(actor, pc.DeviceName, pc.CommandName, pkgModels.CommandMethodSet, dic); err != nil {
+				return pc, errors.NewCommonEdgeXWrapper(err)
+			}
+		}
+	}
+
+	pc.Status = status
+	pc.ReviewedBy = reviewer
+	pc.ReviewedAt = now.UnixMilli()
+	if err = dbClient.UpdatePendingCommand(pc); err != nil {
+		return pc, errors.NewCommonEdgeXWrapper(err)
+	}
+	return pc, nil
+}
+
+// AsyncExpirePendingCommands expires the pending commands past their expiration at every interval, and the commands
+// left APPROVED by a previous run of the service are recorded as FAILED since their outcome is unknown
+func AsyncExpirePendingCommands(interval time.Duration, ctx context.Context, dic *di.Container) {
+	asyncExpirePendingCommandsOnce.Do(func() {
+		go func() {
+			lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+			if err := failInterruptedPendingCommands(dic); err != nil {
+				lc.Errorf("Failed to record the interrupted pending commands, %v", err)
+			}
+			timer := time.NewTimer(interval)
+			for {
+				timer.Reset(interval)
+				select {
+				case <-ctx.Done():
+					lc.Info("Exiting pending command expiration")
+					return
+				case <-timer.C:
+					err := expirePendingCommands(dic)
+					if err != nil {
+						lc.Errorf("Failed to expire the pending commands, %v", err)
+					}
+				}
+			}
+		}()
+	})
+}
+
+// expirePendingCommands expires the pending commands awaiting approval past their expiration
+func expirePendingCommands(dic *di.Container) errors.EdgeX {
+	dbClient := commandContainer.DBClientFrom(dic.Get)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	pendingCommandMutex.Lock()
+	defer pendingCommandMutex.Unlock()
+
+	pcs, err := dbClient.ExpiredPendingCommands(time.Now().UnixMilli(), -1)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	for _, pc := range pcs {
+		pc.Status = pkgModels.PendingCommandStatusExpired
+		if err = dbClient.UpdatePendingCommand(pc); err != nil {
+			return errors.NewCommonEdgeXWrapper(err)
+		}
+		lc.Warnf("Pending command %s of set command %s on device %s requested by %s expired without approval", pc.Id, pc.CommandName, pc.DeviceName, pc.RequestedBy)
+	}
+	return nil
+}
+
+// failInterruptedPendingCommands records the pending commands left APPROVED as FAILED
+func failInterruptedPendingCommands(dic *di.Container) errors.EdgeX {
+	dbClient := commandContainer.DBClientFrom(dic.Get)
+
+	pendingCommandMutex.Lock()
+	defer pendingCommandMutex.Unlock()
+
+	pcs, _, err := dbClient.PendingCommands(pkgModels.PendingCommandStatusApproved, 0, -1)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	for _, pc := range pcs {
+		pc.Status = pkgModels.PendingCommandStatusFailed
+		pc.StatusCode = http.StatusServiceUnavailable
+		pc.Message = "core-command stopped while the command was being issued, the outcome is unknown"
+		if err = dbClient.UpdatePendingCommand(pc); err != nil {
+			return errors.NewCommonEdgeXWrapper(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,343 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/interfaces/mocks"
+	loggerMocks "github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/command/config"
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/command/infrastructure/interfaces/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
+)
+
+const (
+	testPendingCommandId = "b7e1c2d3-4f5a-4b6c-8d7e-9f0a1b2c3d4e"
+	testApprover         = "supervisor"
+)
+
+// mockPendingCommandDic returns the DIC of the valve device whose close command is configured critical
+func mockPendingCommandDic(dbClient *dbMock.DBClient, approvers []string, nc *mocks.NotificationClient) *di.Container {
+	dic := mockScheduledCommandDic(dbClient)
+	dic.Update(di.ServiceConstructorMap{
+		commandContainer.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Service: bootstrapConfig.ServiceInfo{Host: "localhost", Port: 59882},
+				CommandApproval: config.CommandApproval{
+					CriticalCommands: map[string]config.CriticalCommand{
+						"valve-close": {DeviceName: testValveDevice, CommandName: testValveCommand},
+					},
+					Approvers:            approvers,
+					ExpireAfter:          "1h",
+					NotificationCategory: "command-approval",
+				},
+			}
+		},
+		bootstrapContainer.NotificationClientName: func(get di.Get) interface{} {
+			return nc
+		},
+	})
+	return dic
+}
+
+func TestRequestSetCommandApproval(t *testing.T) {
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AddPendingCommand", mock.Anything).Return(func(pc pkgModels.PendingCommand) pkgModels.PendingCommand {
+		pc.Id = testPendingCommandId
+		return pc
+	}, nil)
+	nc := &mocks.NotificationClient{}
+	nc.On("SendNotification", mock.Anything, mock.Anything).Return(nil, nil)
+	dic := mockPendingCommandDic(dbClientMock, nil, nc)
+	ctx := identity.NewContext(context.Background(), testActor)
+
+	tests := []struct {
+		name         string
+		deviceName   string
+		commandName  string
+		settings     map[string]any
+		expectedId   string
+		expectedKind edgexErr.ErrKind
+	}{
+		{"critical command", testValveDevice, testValveCommand, map[string]any{testValveCommand: "true"}, testPendingCommandId, ""},
+		{"not a critical command", testValveDevice, "position", map[string]any{"position": "10"}, "", ""},
+		{"invalid settings of critical command", testValveDevice, testValveCommand, map[string]any{testValveCommand: "open"}, "", edgexErr.KindContractInvalid},
+		{"device not found", "missing", testValveCommand, map[string]any{testValveCommand: "true"}, "", edgexErr.KindEntityDoesNotExist},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			id, err := RequestSetCommandApproval(pkgModels.CommandAuditOriginHTTP, testCase.deviceName, testCase.commandName, nil, testCase.settings, ctx, dic)
+			if testCase.expectedKind != "" {
+				require.Error(t, err)
+				assert.Equal(t, testCase.expectedKind, edgexErr.Kind(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedId, id)
+		})
+	}
+
+	dbClientMock.AssertNumberOfCalls(t, "AddPendingCommand", 1)
+	added := dbClientMock.Calls[0].Arguments.Get(0).(pkgModels.PendingCommand)
+	assert.Equal(t, pkgModels.PendingCommandStatusPending, added.Status)
+	assert.Equal(t, testActor, added.RequestedBy)
+	assert.Equal(t, pkgModels.CommandAuditOriginHTTP, added.Origin)
+	assert.InDelta(t, time.Now().Add(time.Hour).UnixMilli(), added.ExpiresAt, float64(time.Minute.Milliseconds()))
+
+	nc.AssertNumberOfCalls(t, "SendNotification", 1)
+	notification := nc.Calls[0].Arguments.Get(1).([]requests.AddNotificationRequest)[0].Notification
+	assert.Equal(t, "command-approval", notification.Category)
+	assert.Contains(t, notification.Content, testPendingCommandId)
+}
+
+func TestIsCriticalSetCommand(t *testing.T) {
+	dpc := &mocks.DeviceProfileClient{}
+	dpc.On("DeviceProfileByName", mock.Anything, "pump").Return(responses.DeviceProfileResponse{Profile: dtos.DeviceProfile{
+		DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: "pump"},
+		DeviceResources: []dtos.DeviceResource{
+			{Name: "on", Properties: dtos.ResourceProperties{ValueType: common.ValueTypeBool, ReadWrite: common.ReadWrite_RW}, Tags: map[string]any{criticalCommandTag: true}},
+			{Name: "speed", Properties: dtos.ResourceProperties{ValueType: common.ValueTypeInt16, ReadWrite: common.ReadWrite_RW}},
+		},
+		DeviceCommands: []dtos.DeviceCommand{
+			{Name: "start", ReadWrite: common.ReadWrite_W, ResourceOperations: []dtos.ResourceOperation{{DeviceResource: "on"}, {DeviceResource: "speed"}},
+				Tags: map[string]any{criticalCommandTag: "true"}},
+			{Name: "tune", ReadWrite: common.ReadWrite_W, ResourceOperations: []dtos.ResourceOperation{{DeviceResource: "speed"}}},
+			{Name: "boost", ReadWrite: common.ReadWrite_W, ResourceOperations: []dtos.ResourceOperation{{DeviceResource: "speed"}, {DeviceResource: "on"}}},
+		},
+	}}, nil)
+	dic := mockPendingCommandDic(&dbMock.DBClient{}, nil, nil)
+	dic.Update(di.ServiceConstructorMap{
+		bootstrapContainer.DeviceProfileClientName: func(get di.Get) interface{} {
+			return dpc
+		},
+	})
+	device := dtos.Device{Name: "pump-1", ProfileName: "pump"}
+
+	tests := []struct {
+		commandName string
+		expected    bool
+	}{
+		{"start", true},
+		{"tune", false},
+		{"boost", true},
+		{"on", true},
+		{"speed", false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.commandName, func(t *testing.T) {
+			critical, err := isCriticalSetCommand(device, testCase.commandName, dic)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, critical)
+		})
+	}
+}
+
+func TestApprovePendingCommand(t *testing.T) {
+	pending := pkgModels.PendingCommand{Id: testPendingCommandId, DeviceName: testValveDevice, CommandName: testValveCommand,
+		Settings: map[string]any{testValveCommand: "true"}, QueryParams: map[string]string{common.PushEvent: common.ValueTrue},
+		Origin: pkgModels.CommandAuditOriginHTTP, RequestedBy: testActor, ExpiresAt: time.Now().Add(time.Hour).UnixMilli(),
+		Status: pkgModels.PendingCommandStatusPending}
+	expired := pending
+	expired.Id = "expired"
+	expired.ExpiresAt = time.Now().Add(-time.Minute).UnixMilli()
+	rejected := pending
+	rejected.Id = "rejected"
+	rejected.Status = pkgModels.PendingCommandStatusRejected
+
+	tests := []struct {
+		name               string
+		id                 string
+		approver           string
+		approvers          []string
+		expectedStatusCode int
+		expectedStatus     string
+	}{
+		{"approved by another user", pending.Id, testApprover, nil, http.StatusOK, pkgModels.PendingCommandStatusSucceeded},
+		{"approved by a configured approver", pending.Id, testApprover, []string{testApprover}, http.StatusOK, pkgModels.PendingCommandStatusSucceeded},
+		{"requester can't approve", pending.Id, testActor, nil, http.StatusForbidden, ""},
+		{"anonymous can't approve", pending.Id, identity.Anonymous, nil, http.StatusForbidden, ""},
+		{"not a configured approver", pending.Id, testApprover, []string{"manager"}, http.StatusForbidden, ""},
+		{"expired", expired.Id, testApprover, nil, http.StatusConflict, pkgModels.PendingCommandStatusExpired},
+		{"already rejected", rejected.Id, testApprover, nil, http.StatusConflict, ""},
+		{"not found", "missing", testApprover, nil, http.StatusNotFound, ""},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			dbClientMock := &dbMock.DBClient{}
+			dbClientMock.On("PendingCommandById", pending.Id).Return(pending, nil)
+			dbClientMock.On("PendingCommandById", expired.Id).Return(expired, nil)
+			dbClientMock.On("PendingCommandById", rejected.Id).Return(rejected, nil)
+			dbClientMock.On("PendingCommandById", "missing").Return(pkgModels.PendingCommand{},
+				edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, "not found", nil))
+			dbClientMock.On("UpdatePendingCommand", mock.Anything).Return(nil)
+			dbClientMock.On("AddCommandAuditEntry", mock.Anything).Return(pkgModels.CommandAuditEntry{}, nil)
+			dic := mockPendingCommandDic(dbClientMock, testCase.approvers, nil)
+
+			response, err := ApprovePendingCommand(testCase.id, identity.NewContext(context.Background(), testCase.approver), dic)
+			if testCase.expectedStatusCode == http.StatusOK {
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusOK, response.StatusCode)
+				audit := dbClientMock.Calls[len(dbClientMock.Calls)-2].Arguments.Get(0).(pkgModels.CommandAuditEntry)
+				assert.Equal(t, testCase.approver, audit.Actor)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, testCase.expectedStatusCode, utils.StatusCode(err))
+			}
+			if testCase.expectedStatus == "" {
+				if testCase.expectedStatusCode != http.StatusOK {
+					dbClientMock.AssertNotCalled(t, "UpdatePendingCommand", mock.Anything)
+				}
+				return
+			}
+			last := dbClientMock.Calls[len(dbClientMock.Calls)-1].Arguments.Get(0).(pkgModels.PendingCommand)
+			assert.Equal(t, testCase.expectedStatus, last.Status)
+			if testCase.expectedStatus == pkgModels.PendingCommandStatusSucceeded {
+				assert.Equal(t, testCase.approver, last.ReviewedBy)
+				assert.Equal(t, http.StatusOK, last.StatusCode)
+			}
+		})
+	}
+}
+
+func TestApprovePendingCommand_Forbidden(t *testing.T) {
+	pending := pkgModels.PendingCommand{Id: testPendingCommandId, DeviceName: testValveDevice, CommandName: testValveCommand,
+		Settings: map[string]any{testValveCommand: "true"}, RequestedBy: testActor, ExpiresAt: time.Now().Add(time.Hour).UnixMilli(),
+		Status: pkgModels.PendingCommandStatusPending}
+
+	tests := []struct {
+		name                 string
+		writers              []string
+		disableJWTValidation string
+	}{
+		{"approver without write permission", []string{testActor}, ""},
+		{"requester without write permission", []string{testApprover}, ""},
+		{"identities not verified", []string{testActor, testApprover}, "true"},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Setenv("EDGEX_DISABLE_JWT_VALIDATION", testCase.disableJWTValidation)
+			dbClientMock := &dbMock.DBClient{}
+			dbClientMock.On("PendingCommandById", pending.Id).Return(pending, nil)
+			dic := mockPendingCommandDic(dbClientMock, nil, nil)
+			configuration := commandContainer.ConfigurationFrom(dic.Get)
+			configuration.Writable.CommandAccess = config.CommandAccess{
+				Enabled: true,
+				Policies: map[string]config.CommandPolicy{
+					"writers": {Users: testCase.writers, Permissions: []string{CommandPermissionWrite}},
+				},
+			}
+
+			_, err := ApprovePendingCommand(pending.Id, identity.NewContext(context.Background(), testApprover), dic)
+			require.Error(t, err)
+			assert.Equal(t, http.StatusForbidden, utils.StatusCode(err))
+			dbClientMock.AssertNotCalled(t, "UpdatePendingCommand", mock.Anything)
+		})
+	}
+}
+
+func TestWarnUnverifiedCommandApproval(t *testing.T) {
+	tests := []struct {
+		name                 string
+		disableJWTValidation string
+		expectedError        bool
+	}{
+		{"JWT validation disabled", "true", true},
+		{"JWT validation enabled", "", false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Setenv("EDGEX_DISABLE_JWT_VALIDATION", testCase.disableJWTValidation)
+			lc := &loggerMocks.LoggingClient{}
+			lc.On("Error", mock.Anything).Return()
+			dic := mockPendingCommandDic(&dbMock.DBClient{}, nil, nil)
+			dic.Update(di.ServiceConstructorMap{
+				bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+					return lc
+				},
+			})
+
+			WarnUnverifiedCommandApproval(dic)
+			if testCase.expectedError {
+				lc.AssertCalled(t, "Error", mock.Anything)
+			} else {
+				lc.AssertNotCalled(t, "Error", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestRejectPendingCommand(t *testing.T) {
+	pending := pkgModels.PendingCommand{Id: testPendingCommandId, DeviceName: testValveDevice, CommandName: testValveCommand,
+		RequestedBy: testActor, ExpiresAt: time.Now().Add(time.Hour).UnixMilli(), Status: pkgModels.PendingCommandStatusPending}
+
+	tests := []struct {
+		name               string
+		reviewer           string
+		expectedStatusCode int
+	}{
+		{"rejected by an approver", testApprover, http.StatusOK},
+		{"withdrawn by the requester", testActor, http.StatusOK},
+		{"not a configured approver", "operator-2", http.StatusForbidden},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			dbClientMock := &dbMock.DBClient{}
+			dbClientMock.On("PendingCommandById", pending.Id).Return(pending, nil)
+			dbClientMock.On("UpdatePendingCommand", mock.Anything).Return(nil)
+			dic := mockPendingCommandDic(dbClientMock, []string{testApprover}, nil)
+
+			err := RejectPendingCommand(pending.Id, identity.NewContext(context.Background(), testCase.reviewer), dic)
+			if testCase.expectedStatusCode != http.StatusOK {
+				require.Error(t, err)
+				assert.Equal(t, testCase.expectedStatusCode, utils.StatusCode(err))
+				return
+			}
+			require.NoError(t, err)
+			updated := dbClientMock.Calls[1].Arguments.Get(0).(pkgModels.PendingCommand)
+			assert.Equal(t, pkgModels.PendingCommandStatusRejected, updated.Status)
+			assert.Equal(t, testCase.reviewer, updated.ReviewedBy)
+		})
+	}
+}
+
+func TestExpirePendingCommands(t *testing.T) {
+	expired := pkgModels.PendingCommand{Id: testPendingCommandId, DeviceName: testValveDevice, CommandName: testValveCommand,
+		RequestedBy: testActor, ExpiresAt: time.Now().Add(-time.Minute).UnixMilli(), Status: pkgModels.PendingCommandStatusPending}
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("ExpiredPendingCommands", mock.Anything, -1).Return([]pkgModels.PendingCommand{expired}, nil)
+	dbClientMock.On("UpdatePendingCommand", mock.Anything).Return(nil)
+	dic := mockPendingCommandDic(dbClientMock, nil, nil)
+
+	err := expirePendingCommands(dic)
+	require.NoError(t, err)
+	updated := dbClientMock.Calls[1].Arguments.Get(0).(pkgModels.PendingCommand)
+	assert.Equal(t, pkgModels.PendingCommandStatusExpired, updated.Status)
+}
+
+func TestPendingApprovalMessage(t *testing.T) {
+	assert.Contains(t, PendingApprovalMessage(testPendingCommandId), testPendingCommandId)
+	assert.Equal(t, http.StatusAccepted, commonDTO.NewBaseWithIdResponse("", PendingApprovalMessage(testPendingCommandId), http.StatusAccepted, testPendingCommandId).StatusCode)
+}
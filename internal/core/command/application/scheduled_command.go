@@ -0,0 +1,291 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
+)
+
+var (
+	asyncExecuteScheduledCommandsOnce sync.Once
+	// scheduledCommandMutex serializes the status transitions of the scheduled commands, so that a command can't be
+	// cancelled once it's taken for execution
+	scheduledCommandMutex sync.Mutex
+)
+
+var scheduledCommandStatuses = []string{
+	pkgModels.ScheduledCommandStatusScheduled,
+	pkgModels.ScheduledCommandStatusRunning,
+	pkgModels.ScheduledCommandStatusSucceeded,
+	pkgModels.ScheduledCommandStatusFailed,
+	pkgModels.ScheduledCommandStatusCancelled,
+	pkgModels.ScheduledCommandStatusExpired,
+}
+
+// AddScheduledCommand persists the set command to be issued at the execute-at time, or after the delay since now. The
+// device must exist and support the set command with the settings when it's submitted.
+func AddScheduledCommand(dto pkgDtos.ScheduledCommand, ctx context.Context, dic *di.Container) (string, errors.EdgeX) {
+	dbClient := commandContainer.DBClientFrom(dic.Get)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	now := time.Now()
+	sc := pkgDtos.ToScheduledCommandModel(dto)
+	if dto.Delay != "" {
+		delay, err := time.ParseDuration(dto.Delay)
+		if err != nil {
+			return "", errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("failed to parse the delay %s", dto.Delay), err)
+		}
+		sc.ExecuteAt = now.Add(delay).UnixMilli()
+	} else if sc.ExecuteAt < now.UnixMilli() {
+		return "", errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("the executeAt %d is in the past", sc.ExecuteAt), nil)
+	}
+
+	// the settings are validated when submitted, rather than failing the command when it's due
+	err := ValidateSetCommand(sc.DeviceName, sc.CommandName, sc.Settings, dic)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	// the command is issued on behalf of the actor, who must be allowed to set it
+	sc.Actor = identity.FromContext(ctx)
+	if err = AuthorizeCommand(sc.Actor, sc.DeviceName, sc.CommandName, pkgModels.CommandMethodSet, dic); err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	sc.Status = pkgModels.ScheduledCommandStatusScheduled
+	sc.ExecutedAt = 0
+	sc.StatusCode = 0
+	sc.Message = ""
+	sc, err = dbClient.AddScheduledCommand(sc)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	lc.Debugf("Scheduled command %s of set command %s on device %s is added to execute at %d. Correlation-ID: %s",
+		sc.Id, sc.CommandName, sc.DeviceName, sc.ExecuteAt, correlation.FromContext(ctx))
+	return sc.Id, nil
+}
+
+// ScheduledCommandById queries the scheduled command by id
+func ScheduledCommandById(id string, dic *di.Container) (pkgDtos.ScheduledCommand, errors.EdgeX) {
+	if id == "" {
+		return pkgDtos.ScheduledCommand{}, errors.NewCommonEdgeX(errors.KindContractInvalid, "id is empty", nil)
+	}
+	sc, err := commandContainer.DBClientFrom(dic.Get).ScheduledCommandById(id)
+	if err != nil {
+		return pkgDtos.ScheduledCommand{}, errors.NewCommonEdgeXWrapper(err)
+	}
+	return pkgDtos.FromScheduledCommandModelToDTO(sc), nil
+}
+
+// ScheduledCommands queries the scheduled commands with the status, sorted by the execute-at time descending. All the
+// scheduled commands are queried when the status is empty.
+func ScheduledCommands(status string, offset int, limit int, dic *di.Container) (scheduledCommands []pkgDtos.ScheduledCommand, totalCount uint32, err errors.EdgeX) {
+	if status != "" && !slices.Contains(scheduledCommandStatuses, status) {
+		return nil, 0, errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("status %s is invalid, must be one of %v", status, scheduledCommandStatuses), nil)
+	}
+	scs, totalCount, err := commandContainer.DBClientFrom(dic.Get).ScheduledCommands(status, offset, limit)
+	if err != nil {
+		return nil, 0, errors.NewCommonEdgeXWrapper(err)
+	}
+	scheduledCommands = make([]pkgDtos.ScheduledCommand, len(scs))
+	for i, sc := range scs {
+		scheduledCommands[i] = pkgDtos.FromScheduledCommandModelToDTO(sc)
+	}
+	return scheduledCommands, totalCount, nil
+}
+
+// CancelScheduledCommand cancels the scheduled command by id, only a command waiting for execution can be cancelled
+func CancelScheduledCommand(id string, ctx context.Context, dic *di.Container) errors.EdgeX {
+	if id == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "id is empty", nil)
+	}
+	dbClient := commandContainer.DBClientFrom(dic.Get)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	scheduledCommandMutex.Lock()
+	defer scheduledCommandMutex.Unlock()
+
+	sc, err := dbClient.ScheduledCommandById(id)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	if sc.Status != pkgModels.ScheduledCommandStatusScheduled {
+		return errors.NewCommonEdgeX(errors.KindStatusConflict,
+			fmt.Sprintf("scheduled command %s is %s and can't be cancelled", id, sc.Status), nil)
+	}
+	sc.Status = pkgModels.ScheduledCommandStatusCancelled
+	if err = dbClient.UpdateScheduledCommand(sc); err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
+	lc.Debugf("Scheduled command %s is cancelled. Correlation-ID: %s", id, correlation.FromContext(ctx))
+	return nil
+}
+
+// AsyncExecuteScheduledCommands looks up the due scheduled commands at every interval and issues them one by one in
+// the order of the execute-at time. A command later than expireAfter is expired instead, and the commands left
+// RUNNING by a previous run of the service are recorded as FAILED since their outcome is unknown.
+func AsyncExecuteScheduledCommands(interval time.Duration, expireAfter time.Duration, ctx context.Context, dic *di.Container) {
+	asyncExecuteScheduledCommandsOnce.Do(func() {
+		go func() {
+			lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+			if err := failInterruptedScheduledCommands(dic); err != nil {
+				lc.Errorf("Failed to record the interrupted scheduled commands, %v", err)
+			}
+			timer := time.NewTimer(interval)
+			for {
+				timer.Reset(interval) // since the execution might take lots of time, restart the timer to recount the time
+				select {
+				case <-ctx.Done():
+					lc.Info("Exiting scheduled command execution")
+					return
+				case <-timer.C:
+					err := executeDueScheduledCommands(expireAfter, dic)
+					if err != nil {
+						lc.Errorf("Failed to execute the due scheduled commands, %v", err)
+					}
+				}
+			}
+		}()
+	})
+}
+
+// executeDueScheduledCommands issues the scheduled commands due by now, at most ScheduledCommand.MaxBatch commands
+func executeDueScheduledCommands(expireAfter time.Duration, dic *di.Container) errors.EdgeX {
+	dbClient := commandContainer.DBClientFrom(dic.Get)
+	config := commandContainer.ConfigurationFrom(dic.Get)
+
+	scs, err := dbClient.DueScheduledCommands(time.Now().UnixMilli(), max(config.ScheduledCommand.MaxBatch, 1))
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	slices.SortStableFunc(scs, func(a, b pkgModels.ScheduledCommand) int {
+		return cmp.Compare(a.ExecuteAt, b.ExecuteAt)
+	})
+	for _, sc := range scs {
+		if err = executeScheduledCommand(sc.Id, expireAfter, dic); err != nil {
+			return errors.NewCommonEdgeXWrapper(err)
+		}
+	}
+	return nil
+}
+
+// executeScheduledCommand takes the scheduled command for execution unless it's cancelled meanwhile, issues the set
+// command and records the outcome
+func executeScheduledCommand(id string, expireAfter time.Duration, dic *di.Container) errors.EdgeX {
+	dbClient := commandContainer.DBClientFrom(dic.Get)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	scheduledCommandMutex.Lock()
+	sc, err := dbClient.ScheduledCommandById(id)
+	if err != nil {
+		scheduledCommandMutex.Unlock()
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	if sc.Status != pkgModels.ScheduledCommandStatusScheduled { // cancelled after it's queried as due
+		scheduledCommandMutex.Unlock()
+		return nil
+	}
+	now := time.Now()
+	if lateness := now.Sub(time.UnixMilli(sc.ExecuteAt)); expireAfter > 0 && lateness > expireAfter {
+		sc.Status = pkgModels.ScheduledCommandStatusExpired
+		sc.Message = fmt.Sprintf("the command is %s late, which exceeds the expiration %s", lateness.Round(time.Second), expireAfter)
+		err = dbClient.UpdateScheduledCommand(sc)
+		scheduledCommandMutex.Unlock()
+		lc.Warnf("Scheduled command %s on device %s is expired, %s", sc.Id, sc.DeviceName, sc.Message)
+		return err
+	}
+	sc.Status = pkgModels.ScheduledCommandStatusRunning
+	sc.ExecutedAt = now.UnixMilli()
+	err = dbClient.UpdateScheduledCommand(sc)
+	scheduledCommandMutex.Unlock()
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
+	queryParams := url.Values{}
+	for k, v := range sc.QueryParams {
+		queryParams.Set(k, v)
+	}
+	start := time.Now()
+	// a critical set command awaits approval as a pending command requested by the submitter once it's due
+	var response commonDTO.BaseResponse
+	pendingCommandId, err := RequestSetCommandApproval(pkgModels.CommandAuditOriginScheduled, sc.DeviceName, sc.CommandName, sc.QueryParams, sc.Settings,
+		identity.NewContext(context.Background(), sc.Actor), dic)
+	if err == nil && pendingCommandId != "" {
+		response = commonDTO.NewBaseResponse("", PendingApprovalMessage(pendingCommandId), http.StatusAccepted)
+	} else if err == nil {
+		response, err = IssueSetCommandByName(sc.DeviceName, sc.CommandName, queryParams.Encode(), sc.Settings, dic)
+	}
+	if err != nil {
+		sc.Status = pkgModels.ScheduledCommandStatusFailed
+		sc.StatusCode = utils.StatusCode(err)
+		sc.Message = err.Error()
+		lc.Warnf("Scheduled command %s of set command %s on device %s failed, %v", sc.Id, sc.CommandName, sc.DeviceName, err)
+	} else {
+		sc.Status = pkgModels.ScheduledCommandStatusSucceeded
+		sc.StatusCode = response.StatusCode
+		if sc.StatusCode == 0 {
+			sc.StatusCode = http.StatusOK
+		}
+		sc.Message = response.Message
+		lc.Debugf("Scheduled command %s of set command %s on device %s succeeded", sc.Id, sc.CommandName, sc.DeviceName)
+	}
+	RecordCommandAudit(pkgModels.CommandAuditEntry{
+		Origin:      pkgModels.CommandAuditOriginScheduled,
+		Actor:       sc.Actor,
+		DeviceName:  sc.DeviceName,
+		CommandName: sc.CommandName,
+		Method:      pkgModels.CommandMethodSet,
+		Parameters:  sc.Settings,
+		QueryParams: sc.QueryParams,
+		StatusCode:  sc.StatusCode,
+		Message:     sc.Message,
+	}, start, dic)
+	return dbClient.UpdateScheduledCommand(sc)
+}
+
+// failInterruptedScheduledCommands records the scheduled commands left RUNNING as FAILED
+func failInterruptedScheduledCommands(dic *di.Container) errors.EdgeX {
+	dbClient := commandContainer.DBClientFrom(dic.Get)
+
+	scheduledCommandMutex.Lock()
+	defer scheduledCommandMutex.Unlock()
+
+	scs, _, err := dbClient.ScheduledCommands(pkgModels.ScheduledCommandStatusRunning, 0, -1)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	for _, sc := range scs {
+		sc.Status = pkgModels.ScheduledCommandStatusFailed
+		sc.StatusCode = http.StatusServiceUnavailable
+		sc.Message = "core-command stopped while the command was being issued, the outcome is unknown"
+		if err = dbClient.UpdateScheduledCommand(sc); err != nil {
+			return errors.NewCommonEdgeXWrapper(err)
+		}
+	}
+	return nil
+}
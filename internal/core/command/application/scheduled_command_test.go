@@ -0,0 +1,231 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/command/config"
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/command/infrastructure/interfaces/mocks"
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+const (
+	testValveDevice  = "valve-1"
+	testValveService = "device-modbus"
+	testValveCommand = "close"
+)
+
+func mockScheduledCommandDic(dbClient *dbMock.DBClient) *di.Container {
+	dc := &mocks.DeviceClient{}
+	dc.On("DeviceByName", mock.Anything, testValveDevice).Return(responses.DeviceResponse{
+		Device: dtos.Device{Name: testValveDevice, ServiceName: testValveService, ProfileName: "valve"},
+	}, nil)
+	dc.On("DeviceByName", mock.Anything, mock.Anything).Return(responses.DeviceResponse{},
+		edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, "device doesn't exist", nil))
+	dpc := &mocks.DeviceProfileClient{}
+	dpc.On("DeviceProfileByName", mock.Anything, "valve").Return(responses.DeviceProfileResponse{Profile: dtos.DeviceProfile{
+		DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: "valve"},
+		DeviceResources: []dtos.DeviceResource{
+			{Name: testValveCommand, Properties: dtos.ResourceProperties{ValueType: common.ValueTypeBool, ReadWrite: common.ReadWrite_W}},
+			{Name: "position", Properties: dtos.ResourceProperties{ValueType: common.ValueTypeInt16, ReadWrite: common.ReadWrite_R}},
+		},
+	}}, nil)
+	dsc := &mocks.DeviceServiceClient{}
+	dsc.On("DeviceServiceByName", mock.Anything, testValveService).Return(responses.DeviceServiceResponse{
+		Service: dtos.DeviceService{Name: testValveService, BaseAddress: "http://localhost:59901"},
+	}, nil)
+	dscc := &mocks.DeviceServiceCommandClient{}
+	dscc.On("SetCommandWithObject", mock.Anything, "http://localhost:59901", testValveDevice, testValveCommand, "ds-pushevent=true", mock.Anything).
+		Return(commonDTO.NewBaseResponse("", "", http.StatusOK), nil)
+
+	return di.NewContainer(di.ServiceConstructorMap{
+		commandContainer.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Service:          bootstrapConfig.ServiceInfo{Host: "localhost", Port: 59882},
+				ScheduledCommand: config.ScheduledCommand{MaxBatch: 10},
+			}
+		},
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		commandContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClient
+		},
+		bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+			return dc
+		},
+		bootstrapContainer.DeviceProfileClientName: func(get di.Get) interface{} {
+			return dpc
+		},
+		bootstrapContainer.DeviceServiceClientName: func(get di.Get) interface{} {
+			return dsc
+		},
+		bootstrapContainer.DeviceServiceCommandClientName: func(get di.Get) interface{} {
+			return dscc
+		},
+	})
+}
+
+func TestAddScheduledCommand(t *testing.T) {
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AddScheduledCommand", mock.Anything).Return(func(sc pkgModels.ScheduledCommand) pkgModels.ScheduledCommand {
+		sc.Id = "d2bd2a8b-b5a4-4f8e-9d4d-9b1d2c3e4f5a"
+		return sc
+	}, nil)
+	dic := mockScheduledCommandDic(dbClientMock)
+
+	delayed := pkgDtos.ScheduledCommand{DeviceName: testValveDevice, CommandName: testValveCommand, Settings: map[string]any{testValveCommand: "true"}, Delay: "1h"}
+	executeAt := delayed
+	executeAt.Delay = ""
+	executeAt.ExecuteAt = time.Now().Add(time.Minute).UnixMilli()
+	past := executeAt
+	past.ExecuteAt = time.Now().Add(-time.Minute).UnixMilli()
+	notSettable := delayed
+	notSettable.CommandName = "position"
+	deviceNotFound := delayed
+	deviceNotFound.DeviceName = "missing"
+
+	tests := []struct {
+		name         string
+		dto          pkgDtos.ScheduledCommand
+		expectedKind edgexErr.ErrKind
+	}{
+		{"valid - delay", delayed, ""},
+		{"valid - execute at", executeAt, ""},
+		{"invalid - execute at in the past", past, edgexErr.KindContractInvalid},
+		{"invalid - command can't be set", notSettable, edgexErr.KindContractInvalid},
+		{"invalid - device not found", deviceNotFound, edgexErr.KindEntityDoesNotExist},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			id, err := AddScheduledCommand(testCase.dto, identity.NewContext(context.Background(), testActor), dic)
+			if testCase.expectedKind != "" {
+				require.Error(t, err)
+				assert.Equal(t, testCase.expectedKind, edgexErr.Kind(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, id)
+		})
+	}
+
+	added := dbClientMock.Calls[0].Arguments.Get(0).(pkgModels.ScheduledCommand)
+	assert.Equal(t, pkgModels.ScheduledCommandStatusScheduled, added.Status)
+	assert.Equal(t, testActor, added.Actor, "the command should be audited with the actor submitting it")
+	assert.InDelta(t, time.Now().Add(time.Hour).UnixMilli(), added.ExecuteAt, float64(time.Minute.Milliseconds()), "the delay should be resolved to the execute-at time")
+	dbClientMock.AssertNumberOfCalls(t, "AddScheduledCommand", 2)
+}
+
+func TestCancelScheduledCommand(t *testing.T) {
+	scheduled := pkgModels.ScheduledCommand{Id: "scheduled", Status: pkgModels.ScheduledCommandStatusScheduled}
+	succeeded := pkgModels.ScheduledCommand{Id: "succeeded", Status: pkgModels.ScheduledCommandStatusSucceeded}
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("ScheduledCommandById", scheduled.Id).Return(scheduled, nil)
+	dbClientMock.On("ScheduledCommandById", succeeded.Id).Return(succeeded, nil)
+	dbClientMock.On("ScheduledCommandById", "missing").Return(pkgModels.ScheduledCommand{},
+		edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, "not found", nil))
+	dbClientMock.On("UpdateScheduledCommand", mock.Anything).Return(nil)
+	dic := mockScheduledCommandDic(dbClientMock)
+
+	err := CancelScheduledCommand(scheduled.Id, context.Background(), dic)
+	require.NoError(t, err)
+	dbClientMock.AssertCalled(t, "UpdateScheduledCommand", mock.MatchedBy(func(sc pkgModels.ScheduledCommand) bool {
+		return sc.Id == scheduled.Id && sc.Status == pkgModels.ScheduledCommandStatusCancelled
+	}))
+
+	err = CancelScheduledCommand(succeeded.Id, context.Background(), dic)
+	require.Error(t, err)
+	assert.Equal(t, edgexErr.KindStatusConflict, edgexErr.Kind(err))
+
+	err = CancelScheduledCommand("missing", context.Background(), dic)
+	require.Error(t, err)
+	assert.Equal(t, edgexErr.KindEntityDoesNotExist, edgexErr.Kind(err))
+	dbClientMock.AssertNumberOfCalls(t, "UpdateScheduledCommand", 1)
+}
+
+func TestExecuteDueScheduledCommands(t *testing.T) {
+	now := time.Now()
+	due := pkgModels.ScheduledCommand{
+		Id: "due", DeviceName: testValveDevice, CommandName: testValveCommand, Settings: map[string]any{testValveCommand: "true"},
+		QueryParams: map[string]string{common.PushEvent: common.ValueTrue}, ExecuteAt: now.Add(-time.Second).UnixMilli(),
+		Actor: testActor, Status: pkgModels.ScheduledCommandStatusScheduled,
+	}
+	late := due
+	late.Id = "late"
+	late.ExecuteAt = now.Add(-time.Hour).UnixMilli()
+	cancelled := due
+	cancelled.Id = "cancelled"
+	deviceNotFound := due
+	deviceNotFound.Id = "deviceNotFound"
+	deviceNotFound.DeviceName = "missing"
+
+	// the cancelled command was due when queried, but it's cancelled before executed
+	dueCancelled := cancelled
+	cancelled.Status = pkgModels.ScheduledCommandStatusCancelled
+
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("DueScheduledCommands", mock.Anything, 10).Return([]pkgModels.ScheduledCommand{due, late, dueCancelled, deviceNotFound}, nil)
+	for _, sc := range []pkgModels.ScheduledCommand{due, late, cancelled, deviceNotFound} {
+		dbClientMock.On("ScheduledCommandById", sc.Id).Return(sc, nil)
+	}
+	var updated []pkgModels.ScheduledCommand
+	dbClientMock.On("UpdateScheduledCommand", mock.Anything).Run(func(args mock.Arguments) {
+		updated = append(updated, args.Get(0).(pkgModels.ScheduledCommand))
+	}).Return(nil)
+	var audited []pkgModels.CommandAuditEntry
+	dbClientMock.On("AddCommandAuditEntry", mock.Anything).Run(func(args mock.Arguments) {
+		audited = append(audited, args.Get(0).(pkgModels.CommandAuditEntry))
+	}).Return(pkgModels.CommandAuditEntry{}, nil)
+	dic := mockScheduledCommandDic(dbClientMock)
+
+	err := executeDueScheduledCommands(5*time.Minute, dic)
+	require.NoError(t, err)
+
+	// the commands are executed in the order of the execute-at time
+	require.Len(t, updated, 5)
+	assert.Equal(t, late.Id, updated[0].Id)
+	assert.Equal(t, pkgModels.ScheduledCommandStatusExpired, updated[0].Status)
+	assert.Equal(t, due.Id, updated[1].Id)
+	assert.Equal(t, pkgModels.ScheduledCommandStatusRunning, updated[1].Status)
+	assert.Equal(t, due.Id, updated[2].Id)
+	assert.Equal(t, pkgModels.ScheduledCommandStatusSucceeded, updated[2].Status)
+	assert.Equal(t, http.StatusOK, updated[2].StatusCode)
+	assert.NotZero(t, updated[2].ExecutedAt)
+	assert.Equal(t, deviceNotFound.Id, updated[4].Id)
+	assert.Equal(t, pkgModels.ScheduledCommandStatusFailed, updated[4].Status)
+	assert.Equal(t, http.StatusNotFound, updated[4].StatusCode)
+
+	// only the issued commands are audited, the expired one is never issued
+	require.Len(t, audited, 2)
+	assert.Equal(t, pkgModels.CommandAuditOriginScheduled, audited[0].Origin)
+	assert.Equal(t, testActor, audited[0].Actor)
+	assert.Equal(t, pkgModels.CommandMethodSet, audited[0].Method)
+	assert.Equal(t, due.Settings, audited[0].Parameters)
+	assert.Equal(t, http.StatusOK, audited[0].StatusCode)
+	assert.Equal(t, deviceNotFound.DeviceName, audited[1].DeviceName)
+	assert.Equal(t, http.StatusNotFound, audited[1].StatusCode)
+}
@@ -24,11 +24,94 @@ import (
 type ConfigurationStruct struct {
 	Writable     WritableInfo
 	Clients      bootstrapConfig.ClientsCollection
-	Databases    map[string]bootstrapConfig.Database
+	Database     bootstrapConfig.Database
 	Registry     bootstrapConfig.RegistryInfo
 	Service      bootstrapConfig.ServiceInfo
 	MessageBus   bootstrapConfig.MessageBusInfo
 	ExternalMQTT bootstrapConfig.ExternalMQTTInfo
+	// GroupCommand contains the configuration of the commands issued to a group of devices at once
+	GroupCommand GroupCommand
+	// ScheduledCommand contains the configuration of the set commands issued at a scheduled time
+	ScheduledCommand ScheduledCommand
+	// CommandApproval contains the configuration of the critical set commands which are only issued once approved
+	CommandApproval CommandApproval
+	// WebSocket contains the configuration of the WebSocket endpoint accepting the external command requests
+	WebSocket WebSocket
+	// CommandSequence contains the configuration of the named command sequences executed by core-command
+	CommandSequence CommandSequence
+}
+
+// CommandSequence contains the configuration properties of the command sequences.
+type CommandSequence struct {
+	// MaxSteps is the maximum number of steps of a command sequence, including the rollback steps, 0 means no limit
+	MaxSteps int
+	// MaxWait is the longest a WAIT step may wait, e.g. 5m. Empty means no limit.
+	MaxWait string
+	// MaxRunning is the maximum number of executions running concurrently, 0 means no limit
+	MaxRunning int
+}
+
+// WebSocket contains the configuration properties of the WebSocket endpoint, which accepts the same command and command
+// query request envelopes as the ExternalMQTT and streams the responses and the subscribed device events back over the
+// same connection. It's served on its own port, since the connections outlive the request timeout of the REST API.
+type WebSocket struct {
+	Enabled bool
+	// Port is the port the WebSocket endpoint listens on, bound to the same address as the REST API, 0 disables the
+	// WebSocket endpoint
+	Port int
+	// AllowedOrigins are the origins of the browser pages allowed to connect, empty means only the same host as
+	// core-command and "*" allows any origin
+	AllowedOrigins []string
+	// MaxConnections is the maximum number of concurrent connections, 0 means no limit
+	MaxConnections int
+	// SendBuffer is the number of messages queued for a connection, the device events are dropped for a connection
+	// whose queue is full
+	SendBuffer int
+	// MaxInFlight is the maximum number of requests of a connection processed concurrently, the requests received
+	// beyond it are rejected with an error reply. Values below 1 process the requests one at a time.
+	MaxInFlight int
+}
+
+// GroupCommand contains the configuration properties of the group commands.
+type GroupCommand struct {
+	// MaxParallelism is the maximum number of devices a group command is issued to concurrently
+	MaxParallelism int
+	// MaxDevices is the maximum number of devices a group command may select, 0 means no limit
+	MaxDevices int
+}
+
+// ScheduledCommand contains the configuration properties of the scheduled set commands.
+type ScheduledCommand struct {
+	// Interval is how often the due scheduled commands are looked up and executed, e.g. 1s
+	Interval string
+	// ExpireAfter is how late a scheduled command may still be executed, e.g. when core-command was down at the
+	// execute-at time. A command later than ExpireAfter is expired instead of executed, empty means never expire.
+	ExpireAfter string
+	// MaxBatch is the maximum number of due scheduled commands executed at each interval
+	MaxBatch int
+}
+
+// CommandApproval contains the configuration properties of the two-person approval of the critical set commands.
+type CommandApproval struct {
+	// CriticalCommands are the set commands requiring approval keyed by rule name, in addition to the device commands
+	// and device resources tagged critical in their device profile
+	CriticalCommands map[string]CriticalCommand
+	// Approvers are the users allowed to approve or reject the pending commands, empty means any user but the
+	// requester. An anonymous user can never approve a command.
+	Approvers []string
+	// ExpireAfter is how long a pending command awaits approval, e.g. 1h
+	ExpireAfter string
+	// Interval is how often the pending commands past their expiration are expired, e.g. 30s
+	Interval string
+	// NotificationCategory is the category of the notifications alerting the approvers of a new pending command, which
+	// are sent when the support-notifications client is in Clients. Empty means no notification is sent.
+	NotificationCategory string
+}
+
+// CriticalCommand is a set command requiring approval, an empty DeviceName matches the command of every device.
+type CriticalCommand struct {
+	DeviceName  string
+	CommandName string
 }
 
 // WritableInfo contains configuration properties that can be updated and applied without restarting the service.
@@ -36,6 +119,97 @@ type WritableInfo struct {
 	LogLevel        string
 	InsecureSecrets bootstrapConfig.InsecureSecrets
 	Telemetry       bootstrapConfig.TelemetryInfo
+	// CommandSafety contains the interlock rules and rate limits the set commands are checked against before they're
+	// issued to the devices
+	CommandSafety CommandSafety
+	// CommandCache contains the get commands whose responses are cached by core-command
+	CommandCache CommandCache
+	// CommandAccess contains the role-based policies authorizing the users to issue the device commands
+	CommandAccess CommandAccess
+}
+
+// CommandAccess contains the roles of the users and the policies granting them the permissions of the device commands,
+// both keyed by name. While enabled, a command is rejected with 403 unless a policy grants its permission to the caller.
+// The policies are advisory only while the JWTs aren't verified, i.e. without security or with the JWT validation disabled.
+type CommandAccess struct {
+	Enabled bool
+	// Roles are the users, i.e. the identities of the command requests, of each role
+	Roles map[string][]string
+	// Policies grant the permissions of the commands of the matching devices to the users and roles
+	Policies map[string]CommandPolicy
+}
+
+// CommandPolicy grants the permissions to the users and the members of the roles. A device matches when it carries any
+// of the DeviceLabels and uses any of the ProfileNames, and an empty list matches every device or command.
+type CommandPolicy struct {
+	Users        []string
+	Roles        []string
+	DeviceLabels []string
+	ProfileNames []string
+	CommandNames []string
+	// Permissions are "read" to issue the get commands and "write" to issue the set commands
+	Permissions []string
+}
+
+// CommandSafety contains the interlock rules and the rate limits of the set commands, both keyed by the rule name.
+type CommandSafety struct {
+	Interlocks map[string]InterlockRule
+	RateLimits map[string]CommandRateLimit
+}
+
+// InterlockRule rejects a set command while the condition on the current value of another resource holds, e.g. do not
+// set pump.on=true while tank.level<10.
+type InterlockRule struct {
+	// DeviceName and CommandName are the set command guarded by the rule, an empty CommandName guards every set command
+	// of the device
+	DeviceName  string
+	CommandName string
+	// ResourceName and Value narrow the rule down to the set commands writing the resource, optionally with the value
+	ResourceName string
+	Value        string
+	// While is the condition the set command is rejected on
+	While InterlockCondition
+}
+
+// InterlockCondition compares the current value of a device resource with a value.
+type InterlockCondition struct {
+	DeviceName   string
+	ResourceName string
+	// Operator is one of ==, !=, <, <=, > and >=, the ordering operators only apply to numeric values
+	Operator string
+	Value    string
+	// Source is where the current value is read from, either "command" to issue the get command CommandName, which
+	// defaults to ResourceName, or "data" to read the latest reading from core-data, which needs the core-data client
+	// in Clients
+	Source      string
+	CommandName string
+}
+
+// CommandCache contains the get commands whose responses are cached for their TTL, keyed by rule name, so that the
+// frequent reads of slow devices don't reach the device every time.
+type CommandCache struct {
+	Commands map[string]CachedCommand
+}
+
+// CachedCommand caches the responses of a get command for the TTL. An empty DeviceName caches the command of every
+// device, and an empty CommandName caches every get command of the device.
+type CachedCommand struct {
+	DeviceName  string
+	CommandName string
+	// TTL is how long a response is served from the cache, e.g. 5s
+	TTL string
+}
+
+// CommandRateLimit limits how often the set commands are issued to a device. An empty DeviceName applies the limit to
+// every device separately, and an empty CommandName counts every set command of the device against the same limit.
+type CommandRateLimit struct {
+	DeviceName  string
+	CommandName string
+	// MaxCount is the maximum number of set commands issued within Interval, e.g. 10 per 1m, 0 means no limit
+	MaxCount int
+	Interval string
+	// CoolDown is the minimum time between two set commands, e.g. 30s, empty means no cool-down
+	CoolDown string
 }
 
 // UpdateFromRaw converts configuration received from the registry to a service-specific configuration struct which is
@@ -78,6 +252,7 @@ func (c *ConfigurationStruct) GetBootstrap() bootstrapConfig.BootstrapConfigurat
 		Clients:      &c.Clients,
 		Service:      &c.Service,
 		Registry:     &c.Registry,
+		Database:     &c.Database,
 		MessageBus:   &c.MessageBus,
 		ExternalMQTT: &c.ExternalMQTT,
 	}
@@ -95,7 +270,7 @@ func (c *ConfigurationStruct) GetRegistryInfo() bootstrapConfig.RegistryInfo {
 
 // GetDatabaseInfo returns a database information map.
 func (c *ConfigurationStruct) GetDatabaseInfo() bootstrapConfig.Database {
-	return bootstrapConfig.Database{}
+	return c.Database
 }
 
 // GetInsecureSecrets returns the service's InsecureSecrets.
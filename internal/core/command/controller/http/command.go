@@ -1,5 +1,5 @@
 //
-// Copyright (C) 2021-2023 IOTech Ltd
+// Copyright (C) 2021-2024 IOTech Ltd
 //
 // SPDX-License-Identifier: Apache-2.0
 
@@ -9,29 +9,38 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"time"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
-	responseDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
 
 	"github.com/edgexfoundry/edgex-go/internal/core/command/application"
 	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	"github.com/edgexfoundry/edgex-go/internal/io"
 	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	requestDTO "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/requests"
+	responseDTO "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
 
 	"github.com/labstack/echo/v4"
 )
 
 type CommandController struct {
-	dic *di.Container
+	reader io.DtoReader
+	dic    *di.Container
 }
 
 // NewCommandController creates and initializes an CommandController
 func NewCommandController(dic *di.Container) *CommandController {
 	return &CommandController{
-		dic: dic,
+		reader: io.NewJsonDtoReader(),
+		dic:    dic,
 	}
 }
 
@@ -81,12 +90,16 @@ func (cc *CommandController) CommandsByDeviceName(c echo.Context) error {
 func validateGetCommandParameters(r *http.Request) (err errors.EdgeX) {
 	dsReturnEvent := utils.ParseQueryStringToString(r, common.ReturnEvent, common.ValueTrue)
 	dsPushEvent := utils.ParseQueryStringToString(r, common.PushEvent, common.ValueFalse)
+	noCache := utils.ParseQueryStringToString(r, pkgCommon.NoCache, common.ValueFalse)
 	if dsReturnEvent != common.ValueTrue && dsReturnEvent != common.ValueFalse {
 		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid query parameter, %s has to be %s or %s", dsReturnEvent, common.ValueTrue, common.ValueFalse), nil)
 	}
 	if dsPushEvent != common.ValueTrue && dsPushEvent != common.ValueFalse {
 		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid query parameter, %s has to be %s or %s", dsPushEvent, common.ValueTrue, common.ValueFalse), nil)
 	}
+	if noCache != common.ValueTrue && noCache != common.ValueFalse {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid query parameter, %s has to be %s or %s", noCache, common.ValueTrue, common.ValueFalse), nil)
+	}
 	return nil
 }
 
@@ -100,23 +113,37 @@ func (cc *CommandController) IssueGetCommandByName(c echo.Context) error {
 	deviceName := c.Param(common.Name)
 	commandName := c.Param(common.Command)
 
+	// every command is audited with its outcome, no matter whether it succeeds
+	start := time.Now()
+	audit := newCommandAuditEntry(r, deviceName, commandName, pkgModels.CommandMethodGet)
+	defer func() { application.RecordCommandAudit(audit, start, cc.dic) }()
+
 	// Query params
 	queryParams := r.URL.RawQuery
 	err := validateGetCommandParameters(r)
 	if err != nil {
+		audit.StatusCode, audit.Message = err.Code(), err.Error()
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	err = application.AuthorizeCommand(audit.Actor, deviceName, commandName, pkgModels.CommandMethodGet, cc.dic)
+	if err != nil {
+		audit.StatusCode, audit.Message = utils.StatusCode(err), err.Error()
 		return utils.WriteErrorResponse(w, ctx, lc, err, "")
 	}
 
 	response, err := application.IssueGetCommandByName(deviceName, commandName, queryParams, cc.dic)
 	if err != nil {
+		audit.StatusCode, audit.Message = err.Code(), err.Error()
 		return utils.WriteErrorResponse(w, ctx, lc, err, "")
 	}
 	// encode and send out the response
 	if response != nil {
+		audit.StatusCode, audit.Message = response.StatusCode, response.Message
 		utils.WriteHttpHeader(w, ctx, response.StatusCode)
 		return pkg.EncodeAndWriteResponse(response, w, lc)
 	}
 	// If dsReturnEvent is no, there will be no content returned in the http response
+	audit.StatusCode = http.StatusOK
 	utils.WriteHttpHeader(w, ctx, http.StatusOK)
 	return nil
 }
@@ -133,17 +160,79 @@ func (cc *CommandController) IssueSetCommandByName(c echo.Context) error {
 	// Query params
 	queryParams := r.URL.RawQuery
 
+	// every command is audited with its outcome, no matter whether it succeeds
+	start := time.Now()
+	audit := newCommandAuditEntry(r, deviceName, commandName, pkgModels.CommandMethodSet)
+	defer func() { application.RecordCommandAudit(audit, start, cc.dic) }()
+
 	// Request body
 	settings, err := utils.ParseBodyToMap(r)
 	if err != nil {
+		audit.StatusCode, audit.Message = err.Code(), err.Error()
 		return utils.WriteErrorResponse(w, ctx, lc, err, "")
 	}
+	audit.Parameters = settings
+
+	err = application.AuthorizeCommand(audit.Actor, deviceName, commandName, pkgModels.CommandMethodSet, cc.dic)
+	if err != nil {
+		audit.StatusCode, audit.Message = utils.StatusCode(err), err.Error()
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	// a critical set command awaits approval as a pending command instead of being issued
+	pendingCommandId, err := application.RequestSetCommandApproval(audit.Origin, deviceName, commandName, audit.QueryParams, settings,
+		identity.NewContext(ctx, audit.Actor), cc.dic)
+	if err != nil {
+		audit.StatusCode, audit.Message = utils.StatusCode(err), err.Error()
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	if pendingCommandId != "" {
+		pendingResponse := commonDTO.NewBaseWithIdResponse("", application.PendingApprovalMessage(pendingCommandId), http.StatusAccepted, pendingCommandId)
+		audit.StatusCode, audit.Message = pendingResponse.StatusCode, pendingResponse.Message
+		utils.WriteHttpHeader(w, ctx, http.StatusAccepted)
+		return pkg.EncodeAndWriteResponse(pendingResponse, w, lc)
+	}
+
 	response, err := application.IssueSetCommandByName(deviceName, commandName, queryParams, settings, cc.dic)
 	if err != nil {
+		audit.StatusCode, audit.Message = utils.StatusCode(err), err.Error()
 		return utils.WriteErrorResponse(w, ctx, lc, err, "")
 	}
 
+	audit.StatusCode, audit.Message = response.StatusCode, response.Message
 	utils.WriteHttpHeader(w, ctx, response.StatusCode)
 	// encode and send out the response
 	return pkg.EncodeAndWriteResponse(response, w, lc)
 }
+
+func (cc *CommandController) IssueGroupCommand(c echo.Context) error {
+	lc := container.LoggingClientFrom(cc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+	// the commands issued to the devices are audited with the identity of the caller
+	ctx := identity.NewContext(r.Context(), identity.FromRequest(r))
+
+	var reqDTO requestDTO.GroupCommandRequest
+	err := cc.reader.Read(r.Body, &reqDTO)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	summary, results, err := application.IssueGroupCommand(reqDTO, ctx, cc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, reqDTO.RequestId)
+	}
+
+	// the results of the devices have their own status codes when any device fails
+	statusCode := http.StatusOK
+	if summary.Failed > 0 {
+		statusCode = http.StatusMultiStatus
+	}
+	response := responseDTO.NewGroupCommandResponse(reqDTO.RequestId, "", statusCode, summary, results)
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	// encode and send out the response
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
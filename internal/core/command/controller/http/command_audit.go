@@ -0,0 +1,100 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/command/application"
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	responseDTO "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CommandAuditController struct {
+	dic *di.Container
+}
+
+// NewCommandAuditController creates and initializes an CommandAuditController
+func NewCommandAuditController(dic *di.Container) *CommandAuditController {
+	return &CommandAuditController{
+		dic: dic,
+	}
+}
+
+func (ac *CommandAuditController) AllCommandAuditEntries(c echo.Context) error {
+	return ac.commandAuditEntries(c, "", false)
+}
+
+func (ac *CommandAuditController) CommandAuditEntriesByDeviceName(c echo.Context) error {
+	return ac.commandAuditEntries(c, c.Param(common.Name), false)
+}
+
+func (ac *CommandAuditController) CommandAuditEntriesByTimeRange(c echo.Context) error {
+	return ac.commandAuditEntries(c, "", true)
+}
+
+func (ac *CommandAuditController) commandAuditEntries(c echo.Context, deviceName string, byTimeRange bool) error {
+	lc := container.LoggingClientFrom(ac.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+	config := commandContainer.ConfigurationFrom(ac.dic.Get)
+
+	var start, end, offset, limit int
+	var err errors.EdgeX
+	if byTimeRange {
+		start, end, offset, limit, err = utils.ParseTimeRangeOffsetLimit(c, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	} else {
+		end = math.MaxInt64
+		offset, limit, _, err = utils.ParseGetAllObjectsRequestQueryString(c, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	}
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	entries, totalCount, err := application.CommandAuditEntries(deviceName, int64(start), int64(end), offset, limit, ac.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := responseDTO.NewMultiCommandAuditEntriesResponse("", "", http.StatusOK, totalCount, entries)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	// encode and send out the response
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+// newCommandAuditEntry creates the audit entry of the command received over HTTP, the outcome of the command is
+// recorded in the entry by the caller
+func newCommandAuditEntry(r *http.Request, deviceName string, commandName string, method string) pkgModels.CommandAuditEntry {
+	var queryParams map[string]string
+	if values := r.URL.Query(); len(values) > 0 {
+		queryParams = make(map[string]string, len(values))
+		for k := range values {
+			queryParams[k] = values.Get(k)
+		}
+	}
+	return pkgModels.CommandAuditEntry{
+		Origin:        pkgModels.CommandAuditOriginHTTP,
+		Actor:         identity.FromRequest(r),
+		CorrelationId: correlation.FromContext(r.Context()),
+		DeviceName:    deviceName,
+		CommandName:   commandName,
+		Method:        method,
+		QueryParams:   queryParams,
+	}
+}
@@ -0,0 +1,123 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/command/infrastructure/interfaces/mocks"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+func TestCommandAuditEntries(t *testing.T) {
+	entry := pkgModels.CommandAuditEntry{Id: "a5b4c3d2-e1f0-4a9b-8c7d-6e5f4a3b2c1d", Timestamp: 1000, Origin: pkgModels.CommandAuditOriginHTTP,
+		Actor: "operator", DeviceName: testDeviceName, CommandName: testCommandName, Method: pkgModels.CommandMethodSet, StatusCode: http.StatusOK}
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("CommandAuditEntries", "", int64(0), int64(math.MaxInt64), 0, 20).Return([]pkgModels.CommandAuditEntry{entry}, uint32(1), nil)
+	dbClientMock.On("CommandAuditEntries", testDeviceName, int64(0), int64(math.MaxInt64), 0, 20).Return([]pkgModels.CommandAuditEntry{entry}, uint32(1), nil)
+	dbClientMock.On("CommandAuditEntries", "", int64(0), int64(2000), 0, 20).Return([]pkgModels.CommandAuditEntry{entry}, uint32(1), nil)
+	dic := NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		commandContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewCommandAuditController(dic)
+
+	tests := []struct {
+		name               string
+		handler            echo.HandlerFunc
+		paramNames         []string
+		paramValues        []string
+		expectedStatusCode int
+	}{
+		{"Valid - all", controller.AllCommandAuditEntries, nil, nil, http.StatusOK},
+		{"Valid - by device name", controller.CommandAuditEntriesByDeviceName, []string{common.Name}, []string{testDeviceName}, http.StatusOK},
+		{"Valid - by time range", controller.CommandAuditEntriesByTimeRange, []string{common.Start, common.End}, []string{"0", "2000"}, http.StatusOK},
+		{"Invalid - end before start", controller.CommandAuditEntriesByTimeRange, []string{common.Start, common.End}, []string{"2000", "0"}, http.StatusBadRequest},
+		{"Invalid - start not a number", controller.CommandAuditEntriesByTimeRange, []string{common.Start, common.End}, []string{"now", "2000"}, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, pkgCommon.ApiAllCommandAuditRoute, http.NoBody)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			c.SetParamNames(testCase.paramNames...)
+			c.SetParamValues(testCase.paramValues...)
+			err := testCase.handler(c)
+			require.NoError(t, err)
+
+			// Assert
+			require.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode != http.StatusOK {
+				return
+			}
+			var res pkgResponses.MultiCommandAuditEntriesResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, uint32(1), res.TotalCount)
+			require.Len(t, res.Entries, 1)
+			assert.Equal(t, entry.Id, res.Entries[0].Id)
+			assert.Equal(t, entry.Actor, res.Entries[0].Actor)
+		})
+	}
+}
+
+func TestNewCommandAuditEntry(t *testing.T) {
+	token := strings.Join([]string{
+		base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)),
+		base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"operator"}`)),
+		"signature",
+	}, ".")
+
+	tests := []struct {
+		name                string
+		authorization       string
+		rawQuery            string
+		expectedActor       string
+		expectedQueryParams map[string]string
+	}{
+		{"JWT subject", internal.BearerLabel + token, "ds-pushevent=true", "operator", map[string]string{common.PushEvent: common.ValueTrue}},
+		{"no JWT", "", "", identity.Anonymous, nil},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/api/v3/device/name/:name/:command", http.NoBody)
+			req.URL.RawQuery = testCase.rawQuery
+			if testCase.authorization != "" {
+				req.Header.Set(internal.AuthHeaderTitle, testCase.authorization)
+			}
+
+			entry := newCommandAuditEntry(req, testDeviceName, testCommandName, pkgModels.CommandMethodSet)
+			assert.Equal(t, pkgModels.CommandAuditOriginHTTP, entry.Origin)
+			assert.Equal(t, testCase.expectedActor, entry.Actor)
+			assert.Equal(t, testDeviceName, entry.DeviceName)
+			assert.Equal(t, testCommandName, entry.CommandName)
+			assert.Equal(t, pkgModels.CommandMethodSet, entry.Method)
+			assert.Equal(t, testCase.expectedQueryParams, entry.QueryParams)
+		})
+	}
+}
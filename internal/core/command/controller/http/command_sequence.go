@@ -0,0 +1,259 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/command/application"
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	"github.com/edgexfoundry/edgex-go/internal/io"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	requestDTO "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/requests"
+	responseDTO "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CommandSequenceController struct {
+	reader io.DtoReader
+	dic    *di.Container
+}
+
+// NewCommandSequenceController creates and initializes an CommandSequenceController
+func NewCommandSequenceController(dic *di.Container) *CommandSequenceController {
+	return &CommandSequenceController{
+		reader: io.NewJsonDtoReader(),
+		dic:    dic,
+	}
+}
+
+func (csc *CommandSequenceController) AddCommandSequence(c echo.Context) error {
+	r := c.Request()
+	w := c.Response()
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := container.LoggingClientFrom(csc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	var reqDTOs []requestDTO.CommandSequenceRequest
+	err := csc.reader.Read(r.Body, &reqDTOs)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	var addResponses []interface{}
+	for _, req := range reqDTOs {
+		var response interface{}
+		newId, err := application.AddCommandSequence(req.CommandSequence, ctx, csc.dic)
+		if err != nil {
+			lc.Error(err.Error(), common.CorrelationHeader, correlationId)
+			lc.Debug(err.DebugMessages(), common.CorrelationHeader, correlationId)
+			response = commonDTO.NewBaseResponse(req.RequestId, err.Error(), err.Code())
+		} else {
+			response = commonDTO.NewBaseWithIdResponse(req.RequestId, "", http.StatusCreated, newId)
+		}
+		addResponses = append(addResponses, response)
+	}
+
+	utils.WriteHttpHeader(w, ctx, http.StatusMultiStatus)
+	return pkg.EncodeAndWriteResponse(addResponses, w, lc)
+}
+
+func (csc *CommandSequenceController) UpdateCommandSequence(c echo.Context) error {
+	r := c.Request()
+	w := c.Response()
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := container.LoggingClientFrom(csc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	var reqDTOs []requestDTO.CommandSequenceRequest
+	err := csc.reader.Read(r.Body, &reqDTOs)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	var responses []interface{}
+	for _, req := range reqDTOs {
+		var response interface{}
+		err := application.UpdateCommandSequence(req.CommandSequence, ctx, csc.dic)
+		if err != nil {
+			lc.Error(err.Error(), common.CorrelationHeader, correlationId)
+			lc.Debug(err.DebugMessages(), common.CorrelationHeader, correlationId)
+			response = commonDTO.NewBaseResponse(req.RequestId, err.Error(), err.Code())
+		} else {
+			response = commonDTO.NewBaseResponse(req.RequestId, "", http.StatusOK)
+		}
+		responses = append(responses, response)
+	}
+
+	utils.WriteHttpHeader(w, ctx, http.StatusMultiStatus)
+	return pkg.EncodeAndWriteResponse(responses, w, lc)
+}
+
+func (csc *CommandSequenceController) AllCommandSequences(c echo.Context) error {
+	lc := container.LoggingClientFrom(csc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+	config := commandContainer.ConfigurationFrom(csc.dic.Get)
+
+	// parse URL query string for offset and limit
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(c, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	commandSequences, totalCount, err := application.AllCommandSequences(offset, limit, csc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := responseDTO.NewMultiCommandSequencesResponse("", "", http.StatusOK, totalCount, commandSequences)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (csc *CommandSequenceController) CommandSequenceByName(c echo.Context) error {
+	lc := container.LoggingClientFrom(csc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+
+	// URL parameters
+	name := c.Param(common.Name)
+
+	commandSequence, err := application.CommandSequenceByName(name, csc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := responseDTO.NewCommandSequenceResponse("", "", http.StatusOK, commandSequence)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (csc *CommandSequenceController) DeleteCommandSequenceByName(c echo.Context) error {
+	lc := container.LoggingClientFrom(csc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+
+	// URL parameters
+	name := c.Param(common.Name)
+
+	err := application.DeleteCommandSequenceByName(name, ctx, csc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := commonDTO.NewBaseResponse("", "", http.StatusOK)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (csc *CommandSequenceController) ExecuteCommandSequence(c echo.Context) error {
+	lc := container.LoggingClientFrom(csc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	// the commands of the sequence are audited with the identity of the caller
+	ctx := identity.NewContext(r.Context(), identity.FromRequest(r))
+
+	// URL parameters
+	name := c.Param(common.Name)
+
+	executionId, err := application.ExecuteCommandSequence(name, pkgModels.CommandAuditOriginHTTP, ctx, csc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := commonDTO.NewBaseWithIdResponse("", "", http.StatusAccepted, executionId)
+	utils.WriteHttpHeader(w, ctx, http.StatusAccepted)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (csc *CommandSequenceController) AllCommandSequenceExecutions(c echo.Context) error {
+	lc := container.LoggingClientFrom(csc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+	config := commandContainer.ConfigurationFrom(csc.dic.Get)
+
+	// parse URL query string for offset, limit and status
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(c, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	status := utils.ParseQueryStringToString(r, common.Status, "")
+	executions, totalCount, err := application.CommandSequenceExecutions(status, offset, limit, csc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := responseDTO.NewMultiCommandSequenceExecutionsResponse("", "", http.StatusOK, totalCount, executions)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (csc *CommandSequenceController) CommandSequenceExecutionsByName(c echo.Context) error {
+	lc := container.LoggingClientFrom(csc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+	config := commandContainer.ConfigurationFrom(csc.dic.Get)
+
+	// URL parameters
+	name := c.Param(common.Name)
+
+	// parse URL query string for offset and limit
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(c, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	executions, totalCount, err := application.CommandSequenceExecutionsBySequenceName(name, offset, limit, csc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := responseDTO.NewMultiCommandSequenceExecutionsResponse("", "", http.StatusOK, totalCount, executions)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (csc *CommandSequenceController) CommandSequenceExecutionById(c echo.Context) error {
+	lc := container.LoggingClientFrom(csc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+
+	// URL parameters
+	id := c.Param(common.Id)
+
+	execution, err := application.CommandSequenceExecutionById(id, csc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := responseDTO.NewCommandSequenceExecutionResponse("", "", http.StatusOK, execution)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
@@ -0,0 +1,221 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/command/infrastructure/interfaces/mocks"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	requestDTO "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/requests"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+const (
+	testCommandSequenceId   = "6c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f"
+	testCommandSequenceName = "startup-line-3"
+	testExecutionId         = "7d2e3f4a-5b6c-4d7e-9f8a-0b1c2d3e4f5a"
+)
+
+func TestAddCommandSequence(t *testing.T) {
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AddCommandSequence", mock.Anything).Return(pkgModels.CommandSequence{Id: testCommandSequenceId}, nil)
+	dic := NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		commandContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewCommandSequenceController(dic)
+	require.NotNil(t, controller)
+
+	valid := requestDTO.CommandSequenceRequest{
+		BaseRequest: commonDTO.NewBaseRequest(),
+		CommandSequence: pkgDtos.CommandSequence{
+			Name:  testCommandSequenceName,
+			Steps: []pkgDtos.CommandSequenceStep{{Type: pkgModels.CommandSequenceStepWait, Duration: "2s"}},
+		},
+	}
+	noSteps := valid
+	noSteps.CommandSequence.Steps = nil
+	noDuration := valid
+	noDuration.CommandSequence.Steps = []pkgDtos.CommandSequenceStep{{Type: pkgModels.CommandSequenceStepWait}}
+	noSettings := valid
+	noSettings.CommandSequence.Steps = []pkgDtos.CommandSequenceStep{{Type: pkgModels.CommandSequenceStepSet, DeviceName: testDeviceName, CommandName: testCommandName}}
+	noDevice := valid
+	noDevice.CommandSequence.Steps = []pkgDtos.CommandSequenceStep{{Type: pkgModels.CommandSequenceStepGet, CommandName: testCommandName}}
+	unknownOperator := valid
+	unknownOperator.CommandSequence.Steps = []pkgDtos.CommandSequenceStep{{Type: pkgModels.CommandSequenceStepGet, DeviceName: testDeviceName, CommandName: testCommandName,
+		Assertions: []pkgDtos.CommandSequenceAssertion{{ResourceName: testCommandName, Operator: "=~", Value: "ready"}}}}
+	unknownType := valid
+	unknownType.CommandSequence.Steps = []pkgDtos.CommandSequenceStep{{Type: "LOOP"}}
+
+	tests := []struct {
+		name               string
+		request            []requestDTO.CommandSequenceRequest
+		expectedStatusCode int
+		expectedItemCode   int
+	}{
+		{"Valid", []requestDTO.CommandSequenceRequest{valid}, http.StatusMultiStatus, http.StatusCreated},
+		{"Invalid - no steps", []requestDTO.CommandSequenceRequest{noSteps}, http.StatusBadRequest, 0},
+		{"Invalid - wait without duration", []requestDTO.CommandSequenceRequest{noDuration}, http.StatusBadRequest, 0},
+		{"Invalid - set without settings", []requestDTO.CommandSequenceRequest{noSettings}, http.StatusBadRequest, 0},
+		{"Invalid - get without device", []requestDTO.CommandSequenceRequest{noDevice}, http.StatusBadRequest, 0},
+		{"Invalid - unknown assertion operator", []requestDTO.CommandSequenceRequest{unknownOperator}, http.StatusBadRequest, 0},
+		{"Invalid - unknown step type", []requestDTO.CommandSequenceRequest{unknownType}, http.StatusBadRequest, 0},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			jsonData, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, pkgCommon.ApiCommandSequenceRoute, bytes.NewReader(jsonData))
+
+			// Act
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			err = controller.AddCommandSequence(c)
+			require.NoError(t, err)
+
+			// Assert
+			require.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode != http.StatusMultiStatus {
+				return
+			}
+			var res []commonDTO.BaseWithIdResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			require.Len(t, res, 1)
+			assert.Equal(t, testCase.expectedItemCode, res[0].StatusCode)
+			assert.Equal(t, testCommandSequenceId, res[0].Id)
+		})
+	}
+}
+
+func TestExecuteCommandSequence(t *testing.T) {
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("CommandSequenceByName", testCommandSequenceName).Return(pkgModels.CommandSequence{
+		Name:  testCommandSequenceName,
+		Steps: []pkgModels.CommandSequenceStep{{Type: pkgModels.CommandSequenceStepWait, Duration: "1ms"}},
+	}, nil)
+	dbClientMock.On("CommandSequenceByName", "missing").Return(pkgModels.CommandSequence{},
+		errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "not found", nil))
+	dbClientMock.On("AddCommandSequenceExecution", mock.Anything).Return(pkgModels.CommandSequenceExecution{Id: testExecutionId}, nil)
+	dbClientMock.On("UpdateCommandSequenceExecution", mock.Anything).Return(nil)
+	dic := NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		commandContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewCommandSequenceController(dic)
+
+	tests := []struct {
+		name               string
+		sequenceName       string
+		expectedStatusCode int
+	}{
+		{"Valid", testCommandSequenceName, http.StatusAccepted},
+		{"Invalid - not found", "missing", http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, pkgCommon.ApiExecuteCommandSequenceEchoRoute, http.NoBody)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			c.SetParamNames(common.Name)
+			c.SetParamValues(testCase.sequenceName)
+			err := controller.ExecuteCommandSequence(c)
+			require.NoError(t, err)
+
+			// Assert
+			require.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode != http.StatusAccepted {
+				return
+			}
+			var res commonDTO.BaseWithIdResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, testExecutionId, res.Id)
+		})
+	}
+}
+
+func TestCommandSequenceExecutionById(t *testing.T) {
+	execution := pkgModels.CommandSequenceExecution{
+		Id:           testExecutionId,
+		SequenceName: testCommandSequenceName,
+		Status:       pkgModels.CommandSequenceStatusRolledBack,
+		StepResults: []pkgModels.CommandSequenceStepResult{
+			{Index: 0, Type: pkgModels.CommandSequenceStepGet, Status: pkgModels.CommandSequenceStatusFailed, StatusCode: http.StatusOK},
+		},
+	}
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("CommandSequenceExecutionById", testExecutionId).Return(execution, nil)
+	dbClientMock.On("CommandSequenceExecutionById", "missing").Return(pkgModels.CommandSequenceExecution{},
+		errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "not found", nil))
+	dic := NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		commandContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewCommandSequenceController(dic)
+
+	tests := []struct {
+		name               string
+		id                 string
+		expectedStatusCode int
+	}{
+		{"Valid", testExecutionId, http.StatusOK},
+		{"Invalid - not found", "missing", http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, pkgCommon.ApiCommandSequenceExecutionByIdEchoRoute, http.NoBody)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			c.SetParamNames(common.Id)
+			c.SetParamValues(testCase.id)
+			err := controller.CommandSequenceExecutionById(c)
+			require.NoError(t, err)
+
+			// Assert
+			require.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode != http.StatusOK {
+				return
+			}
+			var res pkgResponses.CommandSequenceExecutionResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, pkgModels.CommandSequenceStatusRolledBack, res.Execution.Status)
+			require.Len(t, res.Execution.StepResults, 1)
+			assert.Equal(t, pkgModels.CommandSequenceStatusFailed, res.Execution.StepResults[0].Status)
+		})
+	}
+}
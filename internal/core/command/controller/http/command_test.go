@@ -11,11 +11,20 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/edgexfoundry/edgex-go/internal"
 	"github.com/edgexfoundry/edgex-go/internal/core/command/application"
 	"github.com/edgexfoundry/edgex-go/internal/core/command/config"
 	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/command/infrastructure/interfaces/mocks"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	requestDTO "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/requests"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
@@ -28,10 +37,13 @@ import (
 	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
 	responseDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	messagingMocks "github.com/edgexfoundry/go-mod-messaging/v3/messaging/mocks"
+	"github.com/edgexfoundry/go-mod-messaging/v3/pkg/types"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -64,6 +76,11 @@ func NewMockDIC() *di.Container {
 		container.LoggingClientInterfaceName: func(get di.Get) interface{} {
 			return logger.NewMockClient()
 		},
+		commandContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			dbClientMock := &dbMock.DBClient{}
+			dbClientMock.On("AddCommandAuditEntry", mock.Anything).Return(pkgModels.CommandAuditEntry{}, nil)
+			return dbClientMock
+		},
 	})
 }
 
@@ -78,7 +95,7 @@ func buildTestSettings() map[string]interface{} {
 	return settings
 }
 
-func buildDeviceCoreCommands(t *testing.T, device dtos.Device, deviceProfile dtos.DeviceProfile) dtos.DeviceCoreCommand {
+func buildDeviceCoreCommands(t *testing.T, device dtos.Device, deviceProfile dtos.DeviceProfile) pkgDtos.DeviceCoreCommand {
 	dcMock := &mocks.DeviceClient{}
 	dpcMock := &mocks.DeviceProfileClient{}
 	dcMock.On("DeviceByName", context.Background(), device.Name).
@@ -148,6 +165,28 @@ func buildDeviceProfileResponse() responseDTO.DeviceProfileResponse {
 	return deviceResponse
 }
 
+// buildSetCommandProfileResponse returns the profile with the set command of the settings built by buildTestSettings
+func buildSetCommandProfileResponse() responseDTO.DeviceProfileResponse {
+	minimum, maximum := 10.0, 35.0
+	return responseDTO.DeviceProfileResponse{
+		Profile: dtos.DeviceProfile{
+			DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: testProfileName},
+			DeviceResources: []dtos.DeviceResource{
+				{Name: "AHU-TargetTemperature", Properties: dtos.ResourceProperties{ValueType: common.ValueTypeFloat32, ReadWrite: common.ReadWrite_RW, Minimum: &minimum, Maximum: &maximum}},
+				{Name: "AHU-TargetBand", Properties: dtos.ResourceProperties{ValueType: common.ValueTypeFloat32, ReadWrite: common.ReadWrite_RW}},
+				{Name: "AHU-TargetHumidity", Properties: dtos.ResourceProperties{ValueType: common.ValueTypeObject, ReadWrite: common.ReadWrite_RW}},
+			},
+			DeviceCommands: []dtos.DeviceCommand{{
+				Name:      testCommandName,
+				ReadWrite: common.ReadWrite_RW,
+				ResourceOperations: []dtos.ResourceOperation{
+					{DeviceResource: "AHU-TargetTemperature"}, {DeviceResource: "AHU-TargetBand"}, {DeviceResource: "AHU-TargetHumidity"},
+				},
+			}},
+		},
+	}
+}
+
 func buildDeviceServiceResponse() responseDTO.DeviceServiceResponse {
 	service := dtos.DeviceService{
 		Name:        testDeviceServiceName,
@@ -176,8 +215,8 @@ func TestAllCommands(t *testing.T) {
 	expectedDeviceProfileResponse := buildDeviceProfileResponse()
 	deviceCoreCommand1 := buildDeviceCoreCommands(t, expectedMultiDevicesResponse.Devices[0], expectedDeviceProfileResponse.Profile)
 	deviceCoreCommand2 := buildDeviceCoreCommands(t, expectedMultiDevicesResponse.Devices[1], expectedDeviceProfileResponse.Profile)
-	expectedMultiDeviceCoreCommandsResponse := responseDTO.MultiDeviceCoreCommandsResponse{
-		DeviceCoreCommands: []dtos.DeviceCoreCommand{deviceCoreCommand1, deviceCoreCommand2},
+	expectedMultiDeviceCoreCommandsResponse := pkgResponses.MultiDeviceCoreCommandsResponse{
+		DeviceCoreCommands: []pkgDtos.DeviceCoreCommand{deviceCoreCommand1, deviceCoreCommand2},
 	}
 
 	dcMock := &mocks.DeviceClient{}
@@ -246,7 +285,7 @@ func TestAllCommands(t *testing.T) {
 				assert.Equal(t, testCase.expectedStatusCode, int(res.StatusCode), "Response status code not as expected")
 				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
 			} else {
-				var res responseDTO.MultiDeviceCoreCommandsResponse
+				var res pkgResponses.MultiDeviceCoreCommandsResponse
 				err = json.Unmarshal(recorder.Body.Bytes(), &res)
 				require.NoError(t, err)
 				assert.Equal(t, common.ApiVersion, res.ApiVersion, "API Version not as expected")
@@ -323,7 +362,7 @@ func TestCommandsByDeviceName(t *testing.T) {
 				assert.Equal(t, testCase.expectedStatusCode, int(res.StatusCode), "Response status code not as expected")
 				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
 			} else {
-				var res responseDTO.DeviceCoreCommandResponse
+				var res pkgResponses.DeviceCoreCommandResponse
 				err = json.Unmarshal(recorder.Body.Bytes(), &res)
 				require.NoError(t, err)
 				assert.Equal(t, common.ApiVersion, res.ApiVersion, "API Version not as expected")
@@ -338,6 +377,7 @@ func TestCommandsByDeviceName(t *testing.T) {
 
 func TestIssueGetCommand(t *testing.T) {
 	var nonExistName = "nonExist"
+	var decommissionedName = "decommissioned"
 
 	expectedEventResponse := buildEventResponse()
 	expectedDeviceResponse := buildDeviceResponse()
@@ -346,6 +386,10 @@ func TestIssueGetCommand(t *testing.T) {
 	dcMock := &mocks.DeviceClient{}
 	dcMock.On("DeviceByName", context.Background(), testDeviceName).Return(expectedDeviceResponse, nil)
 	dcMock.On("DeviceByName", context.Background(), nonExistName).Return(responseDTO.DeviceResponse{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "fail to query device by name", nil))
+	decommissionedDeviceResponse := buildDeviceResponse()
+	decommissionedDeviceResponse.Device.Name = decommissionedName
+	decommissionedDeviceResponse.Device.Properties = map[string]any{pkgModels.DeviceLifecycleStateProperty: pkgModels.Decommissioned}
+	dcMock.On("DeviceByName", context.Background(), decommissionedName).Return(decommissionedDeviceResponse, nil)
 
 	dscMock := &mocks.DeviceServiceClient{}
 	dscMock.On("DeviceServiceByName", context.Background(), testDeviceServiceName).Return(expectedDeviceServiceResponse, nil)
@@ -382,6 +426,7 @@ func TestIssueGetCommand(t *testing.T) {
 		{"Valid - empty query strings", testDeviceName, testCommandName, "", false, http.StatusOK},
 		{"Invalid - execute read command with invalid deviceName", nonExistName, testCommandName, testQueryStrings, true, http.StatusNotFound},
 		{"Invalid - execute read command with invalid commandName", testDeviceName, nonExistName, testQueryStrings, true, http.StatusBadRequest},
+		{"Invalid - execute read command with decommissioned device", decommissionedName, testCommandName, testQueryStrings, true, http.StatusConflict},
 		{"Invalid - empty device name", "", nonExistName, testQueryStrings, true, http.StatusBadRequest},
 		{"Invalid - empty command name", testDeviceName, "", testQueryStrings, true, http.StatusBadRequest},
 		{"Invalid - invalid ds-pushevent paramter", testDeviceName, "", "ds-pushevent=123", true, http.StatusBadRequest},
@@ -440,8 +485,15 @@ func TestIssueSetCommand(t *testing.T) {
 	dscMock := &mocks.DeviceServiceClient{}
 	dscMock.On("DeviceServiceByName", context.Background(), testDeviceServiceName).Return(expectedDeviceServiceResponse, nil)
 
+	dpcMock := &mocks.DeviceProfileClient{}
+	dpcMock.On("DeviceProfileByName", context.Background(), testProfileName).Return(buildSetCommandProfileResponse(), nil)
+
 	testSettings := buildTestSettings()
 	testSettingsJsonStr, _ := json.Marshal(testSettings)
+	outOfRangeSettings := buildTestSettings()
+	outOfRangeSettings["AHU-TargetTemperature"] = "85"
+	outOfRangeSettingsJsonStr, _ := json.Marshal(outOfRangeSettings)
+	unknownResourceSettingsJsonStr, _ := json.Marshal(map[string]any{"AHU-TargetTemprature": "28.5"})
 	dsccMock := &mocks.DeviceServiceCommandClient{}
 	dsccMock.On("SetCommandWithObject", context.Background(), testBaseAddress, testDeviceName, testCommandName, testQueryStrings, testSettings).Return(expectedBaseResponse, nil)
 	dsccMock.On("SetCommandWithObject", context.Background(), testBaseAddress, testDeviceName, testCommandName, "", testSettings).Return(expectedBaseResponse, nil)
@@ -456,6 +508,9 @@ func TestIssueSetCommand(t *testing.T) {
 		bootstrapContainer.DeviceServiceClientName: func(get di.Get) interface{} {
 			return dscMock
 		},
+		bootstrapContainer.DeviceProfileClientName: func(get di.Get) interface{} {
+			return dpcMock
+		},
 		bootstrapContainer.DeviceServiceCommandClientName: func(get di.Get) interface{} {
 			return dsccMock
 		},
@@ -479,6 +534,8 @@ func TestIssueSetCommand(t *testing.T) {
 		{"Invalid - empty device name", "", testCommandName, testQueryStrings, testSettingsJsonStr, true, http.StatusBadRequest},
 		{"Invalid - empty command name", testDeviceName, "", testQueryStrings, testSettingsJsonStr, true, http.StatusBadRequest},
 		{"Invalid - empty settings", testDeviceName, testCommandName, testQueryStrings, []byte{}, true, http.StatusBadRequest},
+		{"Invalid - setting greater than maximum", testDeviceName, testCommandName, testQueryStrings, outOfRangeSettingsJsonStr, true, http.StatusBadRequest},
+		{"Invalid - setting of unknown resource", testDeviceName, testCommandName, testQueryStrings, unknownResourceSettingsJsonStr, true, http.StatusBadRequest},
 	}
 	for _, testCase := range tests {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -513,3 +570,164 @@ func TestIssueSetCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestIssueGroupCommand(t *testing.T) {
+	offlineDeviceName := "offlineDevice"
+	expectedDeviceResponse := buildDeviceResponse()
+	offlineDeviceResponse := buildDeviceResponse()
+	offlineDeviceResponse.Device.Name = offlineDeviceName
+
+	dcMock := &mocks.DeviceClient{}
+	dcMock.On("DeviceByName", mock.Anything, testDeviceName).Return(expectedDeviceResponse, nil)
+	dcMock.On("DeviceByName", mock.Anything, offlineDeviceName).Return(offlineDeviceResponse, nil)
+	dpcMock := &mocks.DeviceProfileClient{}
+	dpcMock.On("DeviceProfileByName", mock.Anything, testProfileName).Return(buildSetCommandProfileResponse(), nil)
+
+	messageBusMock := &messagingMocks.MessageClient{}
+	messageBusMock.On("Request", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(func(envelope types.MessageEnvelope, requestTopic string, responseTopicPrefix string, timeout time.Duration) *types.MessageEnvelope {
+			if strings.Contains(requestTopic, offlineDeviceName) {
+				response := types.NewMessageEnvelopeWithError(envelope.RequestID, "device is offline")
+				return &response
+			}
+			response, err := types.NewMessageEnvelopeForResponse(nil, envelope.RequestID, envelope.CorrelationID, common.ContentTypeJSON)
+			require.NoError(t, err)
+			return &response
+		}, nil)
+
+	dic := NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		commandContainer.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Service:      bootstrapConfig.ServiceInfo{RequestTimeout: "5s"},
+				GroupCommand: config.GroupCommand{MaxParallelism: 5},
+			}
+		},
+		bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+			return dcMock
+		},
+		bootstrapContainer.DeviceProfileClientName: func(get di.Get) interface{} {
+			return dpcMock
+		},
+		bootstrapContainer.MessagingClientName: func(get di.Get) interface{} {
+			return messageBusMock
+		},
+	})
+	cc := NewCommandController(dic)
+	assert.NotNil(t, cc)
+
+	valid := requestDTO.GroupCommandRequest{
+		BaseRequest: commonDTO.NewBaseRequest(),
+		Selector:    pkgDtos.DeviceSelector{DeviceNames: []string{testDeviceName}},
+		CommandName: testCommandName,
+		Method:      requestDTO.GroupCommandMethodSet,
+		Settings:    buildTestSettings(),
+	}
+	partialFailure := valid
+	partialFailure.Selector = pkgDtos.DeviceSelector{DeviceNames: []string{testDeviceName, offlineDeviceName}}
+	noSelector := valid
+	noSelector.Selector = pkgDtos.DeviceSelector{}
+	noSettings := valid
+	noSettings.Settings = nil
+	invalidMethod := valid
+	invalidMethod.Method = "delete"
+
+	tests := []struct {
+		name               string
+		request            requestDTO.GroupCommandRequest
+		errorExpected      bool
+		expectedStatusCode int
+		expectedFailed     int
+	}{
+		{"Valid - all devices succeeded", valid, false, http.StatusOK, 0},
+		{"Valid - some devices failed", partialFailure, false, http.StatusMultiStatus, 1},
+		{"Invalid - no selector", noSelector, true, http.StatusBadRequest, 0},
+		{"Invalid - set command without settings", noSettings, true, http.StatusBadRequest, 0},
+		{"Invalid - invalid method", invalidMethod, true, http.StatusBadRequest, 0},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			jsonData, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, pkgCommon.ApiGroupCommandRoute, bytes.NewReader(jsonData))
+
+			// Act
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			err = cc.IssueGroupCommand(c)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.errorExpected {
+				return
+			}
+			var res pkgResponses.GroupCommandResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, len(testCase.request.Selector.DeviceNames), res.Summary.Total)
+			assert.Equal(t, testCase.expectedFailed, res.Summary.Failed)
+		})
+	}
+}
+
+func TestIssueCommandForbidden(t *testing.T) {
+	dcMock := &mocks.DeviceClient{}
+	dcMock.On("DeviceByName", mock.Anything, testDeviceName).Return(buildDeviceResponse(), nil)
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AddCommandAuditEntry", mock.Anything).Return(pkgModels.CommandAuditEntry{}, nil)
+
+	dic := NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		commandContainer.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Writable: config.WritableInfo{CommandAccess: config.CommandAccess{
+					Enabled:  true,
+					Roles:    map[string][]string{"viewer": {"operator"}},
+					Policies: map[string]config.CommandPolicy{"viewers-read": {Roles: []string{"viewer"}, Permissions: []string{application.CommandPermissionRead}}},
+				}},
+			}
+		},
+		bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+			return dcMock
+		},
+		commandContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	cc := NewCommandController(dic)
+	settingsJsonStr, _ := json.Marshal(buildTestSettings())
+
+	tests := []struct {
+		name    string
+		method  string
+		token   string
+		handler echo.HandlerFunc
+	}{
+		{"Forbidden - set command with read permission", http.MethodPut, testBearerToken("operator"), cc.IssueSetCommandByName},
+		{"Forbidden - get command of anonymous user", http.MethodGet, "", cc.IssueGetCommandByName},
+	}
+	for i, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(testCase.method, "/api/v3/device/name/:name/:command", bytes.NewBuffer(settingsJsonStr))
+			if testCase.token != "" {
+				req.Header.Set(internal.AuthHeaderTitle, testCase.token)
+			}
+
+			// Act
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			c.SetParamNames(common.Name, common.Command)
+			c.SetParamValues(testDeviceName, testCommandName)
+			err := testCase.handler(c)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, http.StatusForbidden, recorder.Result().StatusCode, "HTTP status code not as expected")
+			audit := dbClientMock.Calls[i].Arguments.Get(0).(pkgModels.CommandAuditEntry)
+			assert.Equal(t, http.StatusForbidden, audit.StatusCode, "the rejected command should be audited")
+		})
+	}
+}
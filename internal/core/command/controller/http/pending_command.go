@@ -0,0 +1,116 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/command/application"
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	responseDTO "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PendingCommandController struct {
+	dic *di.Container
+}
+
+// NewPendingCommandController creates and initializes an PendingCommandController
+func NewPendingCommandController(dic *di.Container) *PendingCommandController {
+	return &PendingCommandController{
+		dic: dic,
+	}
+}
+
+func (pc *PendingCommandController) AllPendingCommands(c echo.Context) error {
+	lc := container.LoggingClientFrom(pc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+	config := commandContainer.ConfigurationFrom(pc.dic.Get)
+
+	// parse URL query string for offset, limit and status
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(c, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	status := utils.ParseQueryStringToString(r, common.Status, "")
+	pendingCommands, totalCount, err := application.PendingCommands(status, offset, limit, pc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := responseDTO.NewMultiPendingCommandsResponse("", "", http.StatusOK, totalCount, pendingCommands)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (pc *PendingCommandController) PendingCommandById(c echo.Context) error {
+	lc := container.LoggingClientFrom(pc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+
+	// URL parameters
+	id := c.Param(common.Id)
+
+	pendingCommand, err := application.PendingCommandById(id, pc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := responseDTO.NewPendingCommandResponse("", "", http.StatusOK, pendingCommand)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (pc *PendingCommandController) ApprovePendingCommand(c echo.Context) error {
+	lc := container.LoggingClientFrom(pc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	// the approver is the identity of the caller, who must be another user than the requester
+	ctx := identity.NewContext(r.Context(), identity.FromRequest(r))
+
+	// URL parameters
+	id := c.Param(common.Id)
+
+	response, err := application.ApprovePendingCommand(id, ctx, pc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	utils.WriteHttpHeader(w, ctx, response.StatusCode)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (pc *PendingCommandController) RejectPendingCommand(c echo.Context) error {
+	lc := container.LoggingClientFrom(pc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := identity.NewContext(r.Context(), identity.FromRequest(r))
+
+	// URL parameters
+	id := c.Param(common.Id)
+
+	err := application.RejectPendingCommand(id, ctx, pc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := commonDTO.NewBaseResponse("", "", http.StatusOK)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
@@ -0,0 +1,185 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/command/infrastructure/interfaces/mocks"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+const testPendingCommandId = "b7e1c2d3-4f5a-4b6c-8d7e-9f0a1b2c3d4e"
+
+func testBearerToken(name string) string {
+	return internal.BearerLabel + strings.Join([]string{
+		base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)),
+		base64.RawURLEncoding.EncodeToString([]byte(`{"name":"` + name + `"}`)),
+		"signature",
+	}, ".")
+}
+
+func TestAllPendingCommands(t *testing.T) {
+	pending := pkgModels.PendingCommand{Id: testPendingCommandId, DeviceName: testDeviceName, Status: pkgModels.PendingCommandStatusPending}
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("PendingCommands", pkgModels.PendingCommandStatusPending, 0, 20).Return([]pkgModels.PendingCommand{pending}, uint32(1), nil)
+	dic := NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		commandContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewPendingCommandController(dic)
+
+	tests := []struct {
+		name               string
+		status             string
+		expectedStatusCode int
+	}{
+		{"Valid - by status", pkgModels.PendingCommandStatusPending, http.StatusOK},
+		{"Invalid - unknown status", "WAITING", http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, pkgCommon.ApiAllPendingCommandRoute, http.NoBody)
+			query := req.URL.Query()
+			query.Add(common.Status, testCase.status)
+			req.URL.RawQuery = query.Encode()
+
+			// Act
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			err := controller.AllPendingCommands(c)
+			require.NoError(t, err)
+
+			// Assert
+			require.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode != http.StatusOK {
+				return
+			}
+			var res pkgResponses.MultiPendingCommandsResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, uint32(1), res.TotalCount)
+			require.Len(t, res.PendingCommands, 1)
+			assert.Equal(t, testPendingCommandId, res.PendingCommands[0].Id)
+		})
+	}
+}
+
+func TestPendingCommandById(t *testing.T) {
+	pending := pkgModels.PendingCommand{Id: testPendingCommandId, RequestedBy: "operator", Status: pkgModels.PendingCommandStatusPending}
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("PendingCommandById", testPendingCommandId).Return(pending, nil)
+	dbClientMock.On("PendingCommandById", "missing").Return(pkgModels.PendingCommand{},
+		errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "not found", nil))
+	dic := NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		commandContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewPendingCommandController(dic)
+
+	tests := []struct {
+		name               string
+		id                 string
+		expectedStatusCode int
+	}{
+		{"Valid", testPendingCommandId, http.StatusOK},
+		{"Invalid - not found", "missing", http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, pkgCommon.ApiPendingCommandByIdEchoRoute, http.NoBody)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			c.SetParamNames(common.Id)
+			c.SetParamValues(testCase.id)
+			err := controller.PendingCommandById(c)
+			require.NoError(t, err)
+
+			// Assert
+			require.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode != http.StatusOK {
+				return
+			}
+			var res pkgResponses.PendingCommandResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, "operator", res.PendingCommand.RequestedBy)
+		})
+	}
+}
+
+func TestReviewPendingCommand(t *testing.T) {
+	pending := pkgModels.PendingCommand{Id: testPendingCommandId, DeviceName: testDeviceName, CommandName: testCommandName,
+		RequestedBy: "operator", ExpiresAt: time.Now().Add(time.Hour).UnixMilli(), Status: pkgModels.PendingCommandStatusPending}
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("PendingCommandById", testPendingCommandId).Return(pending, nil)
+	dbClientMock.On("UpdatePendingCommand", mock.Anything).Return(nil)
+	dic := NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		commandContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewPendingCommandController(dic)
+
+	tests := []struct {
+		name               string
+		handler            echo.HandlerFunc
+		authorization      string
+		expectedStatusCode int
+	}{
+		{"Invalid - anonymous approval", controller.ApprovePendingCommand, "", http.StatusForbidden},
+		{"Invalid - approved by the requester", controller.ApprovePendingCommand, testBearerToken("operator"), http.StatusForbidden},
+		{"Valid - withdrawn by the requester", controller.RejectPendingCommand, testBearerToken("operator"), http.StatusOK},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, pkgCommon.ApiApprovePendingCommandEchoRoute, http.NoBody)
+			if testCase.authorization != "" {
+				req.Header.Set(internal.AuthHeaderTitle, testCase.authorization)
+			}
+
+			// Act
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			c.SetParamNames(common.Id)
+			c.SetParamValues(testPendingCommandId)
+			err := testCase.handler(c)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+		})
+	}
+	dbClientMock.AssertNumberOfCalls(t, "UpdatePendingCommand", 1)
+}
@@ -0,0 +1,138 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/command/application"
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	"github.com/edgexfoundry/edgex-go/internal/io"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	requestDTO "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/requests"
+	responseDTO "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ScheduledCommandController struct {
+	reader io.DtoReader
+	dic    *di.Container
+}
+
+// NewScheduledCommandController creates and initializes an ScheduledCommandController
+func NewScheduledCommandController(dic *di.Container) *ScheduledCommandController {
+	return &ScheduledCommandController{
+		reader: io.NewJsonDtoReader(),
+		dic:    dic,
+	}
+}
+
+func (sc *ScheduledCommandController) AddScheduledCommand(c echo.Context) error {
+	r := c.Request()
+	w := c.Response()
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := container.LoggingClientFrom(sc.dic.Get)
+	// the scheduled commands are audited with the identity of the caller once they're executed
+	ctx := identity.NewContext(r.Context(), identity.FromRequest(r))
+	correlationId := correlation.FromContext(ctx)
+
+	var reqDTOs []requestDTO.AddScheduledCommandRequest
+	err := sc.reader.Read(r.Body, &reqDTOs)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	var addResponses []interface{}
+	for _, req := range reqDTOs {
+		var response interface{}
+		newId, err := application.AddScheduledCommand(req.ScheduledCommand, ctx, sc.dic)
+		if err != nil {
+			lc.Error(err.Error(), common.CorrelationHeader, correlationId)
+			lc.Debug(err.DebugMessages(), common.CorrelationHeader, correlationId)
+			response = commonDTO.NewBaseResponse(req.RequestId, err.Error(), utils.StatusCode(err))
+		} else {
+			response = commonDTO.NewBaseWithIdResponse(req.RequestId, "", http.StatusCreated, newId)
+		}
+		addResponses = append(addResponses, response)
+	}
+
+	utils.WriteHttpHeader(w, ctx, http.StatusMultiStatus)
+	return pkg.EncodeAndWriteResponse(addResponses, w, lc)
+}
+
+func (sc *ScheduledCommandController) AllScheduledCommands(c echo.Context) error {
+	lc := container.LoggingClientFrom(sc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+	config := commandContainer.ConfigurationFrom(sc.dic.Get)
+
+	// parse URL query string for offset, limit and status
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(c, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	status := utils.ParseQueryStringToString(r, common.Status, "")
+	scheduledCommands, totalCount, err := application.ScheduledCommands(status, offset, limit, sc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := responseDTO.NewMultiScheduledCommandsResponse("", "", http.StatusOK, totalCount, scheduledCommands)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (sc *ScheduledCommandController) ScheduledCommandById(c echo.Context) error {
+	lc := container.LoggingClientFrom(sc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+
+	// URL parameters
+	id := c.Param(common.Id)
+
+	scheduledCommand, err := application.ScheduledCommandById(id, sc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := responseDTO.NewScheduledCommandResponse("", "", http.StatusOK, scheduledCommand)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (sc *ScheduledCommandController) CancelScheduledCommand(c echo.Context) error {
+	lc := container.LoggingClientFrom(sc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+
+	// URL parameters
+	id := c.Param(common.Id)
+
+	err := application.CancelScheduledCommand(id, ctx, sc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := commonDTO.NewBaseResponse("", "", http.StatusOK)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
@@ -0,0 +1,265 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	commandContainer "github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/command/infrastructure/interfaces/mocks"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	requestDTO "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/requests"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+const testScheduledCommandId = "2f3a0c2e-8f5b-4b41-9a4f-0b4d1c8e6a77"
+
+func TestAddScheduledCommand(t *testing.T) {
+	profileResponse := buildDeviceProfileResponse()
+	profileResponse.Profile.DeviceResources = []dtos.DeviceResource{
+		{Name: testCommandName, Properties: dtos.ResourceProperties{ValueType: common.ValueTypeString, ReadWrite: common.ReadWrite_RW}},
+	}
+	dcMock := &mocks.DeviceClient{}
+	dcMock.On("DeviceByName", mock.Anything, testDeviceName).Return(buildDeviceResponse(), nil)
+	dpcMock := &mocks.DeviceProfileClient{}
+	dpcMock.On("DeviceProfileByName", mock.Anything, testProfileName).Return(profileResponse, nil)
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AddScheduledCommand", mock.Anything).Return(pkgModels.ScheduledCommand{Id: testScheduledCommandId}, nil)
+
+	dic := NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+			return dcMock
+		},
+		bootstrapContainer.DeviceProfileClientName: func(get di.Get) interface{} {
+			return dpcMock
+		},
+		commandContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewScheduledCommandController(dic)
+	require.NotNil(t, controller)
+
+	valid := requestDTO.AddScheduledCommandRequest{
+		BaseRequest: commonDTO.NewBaseRequest(),
+		ScheduledCommand: pkgDtos.ScheduledCommand{
+			DeviceName:  testDeviceName,
+			CommandName: testCommandName,
+			Settings:    map[string]any{testCommandName: "off"},
+			Delay:       "10m",
+		},
+	}
+	unknownCommand := valid
+	unknownCommand.ScheduledCommand.CommandName = "command1"
+	noExecutionTime := valid
+	noExecutionTime.ScheduledCommand.Delay = ""
+	bothExecutionTimes := valid
+	bothExecutionTimes.ScheduledCommand.ExecuteAt = 1
+	noSettings := valid
+	noSettings.ScheduledCommand.Settings = nil
+
+	tests := []struct {
+		name               string
+		request            []requestDTO.AddScheduledCommandRequest
+		expectedStatusCode int
+		expectedItemCode   int
+	}{
+		{"Valid - delay", []requestDTO.AddScheduledCommandRequest{valid}, http.StatusMultiStatus, http.StatusCreated},
+		{"Invalid - command can't be set", []requestDTO.AddScheduledCommandRequest{unknownCommand}, http.StatusMultiStatus, http.StatusBadRequest},
+		{"Invalid - neither executeAt nor delay", []requestDTO.AddScheduledCommandRequest{noExecutionTime}, http.StatusBadRequest, 0},
+		{"Invalid - both executeAt and delay", []requestDTO.AddScheduledCommandRequest{bothExecutionTimes}, http.StatusBadRequest, 0},
+		{"Invalid - no settings", []requestDTO.AddScheduledCommandRequest{noSettings}, http.StatusBadRequest, 0},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			jsonData, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, pkgCommon.ApiScheduledCommandRoute, bytes.NewReader(jsonData))
+
+			// Act
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			err = controller.AddScheduledCommand(c)
+			require.NoError(t, err)
+
+			// Assert
+			require.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode != http.StatusMultiStatus {
+				return
+			}
+			var res []commonDTO.BaseWithIdResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			require.Len(t, res, 1)
+			assert.Equal(t, testCase.expectedItemCode, res[0].StatusCode)
+			if testCase.expectedItemCode == http.StatusCreated {
+				assert.Equal(t, testScheduledCommandId, res[0].Id)
+			}
+		})
+	}
+}
+
+func TestAllScheduledCommands(t *testing.T) {
+	scheduled := pkgModels.ScheduledCommand{Id: testScheduledCommandId, DeviceName: testDeviceName, Status: pkgModels.ScheduledCommandStatusScheduled}
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("ScheduledCommands", pkgModels.ScheduledCommandStatusScheduled, 0, 20).Return([]pkgModels.ScheduledCommand{scheduled}, uint32(1), nil)
+	dic := NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		commandContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewScheduledCommandController(dic)
+
+	tests := []struct {
+		name               string
+		status             string
+		expectedStatusCode int
+	}{
+		{"Valid - by status", pkgModels.ScheduledCommandStatusScheduled, http.StatusOK},
+		{"Invalid - unknown status", "DONE", http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, pkgCommon.ApiAllScheduledCommandRoute, http.NoBody)
+			query := req.URL.Query()
+			query.Add(common.Status, testCase.status)
+			req.URL.RawQuery = query.Encode()
+
+			// Act
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			err := controller.AllScheduledCommands(c)
+			require.NoError(t, err)
+
+			// Assert
+			require.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode != http.StatusOK {
+				return
+			}
+			var res pkgResponses.MultiScheduledCommandsResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, uint32(1), res.TotalCount)
+			require.Len(t, res.ScheduledCommands, 1)
+			assert.Equal(t, testScheduledCommandId, res.ScheduledCommands[0].Id)
+		})
+	}
+}
+
+func TestScheduledCommandById(t *testing.T) {
+	executed := pkgModels.ScheduledCommand{Id: testScheduledCommandId, Status: pkgModels.ScheduledCommandStatusSucceeded, StatusCode: http.StatusOK}
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("ScheduledCommandById", testScheduledCommandId).Return(executed, nil)
+	dbClientMock.On("ScheduledCommandById", "missing").Return(pkgModels.ScheduledCommand{},
+		errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "not found", nil))
+	dic := NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		commandContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewScheduledCommandController(dic)
+
+	tests := []struct {
+		name               string
+		id                 string
+		expectedStatusCode int
+	}{
+		{"Valid", testScheduledCommandId, http.StatusOK},
+		{"Invalid - not found", "missing", http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, pkgCommon.ApiScheduledCommandByIdEchoRoute, http.NoBody)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			c.SetParamNames(common.Id)
+			c.SetParamValues(testCase.id)
+			err := controller.ScheduledCommandById(c)
+			require.NoError(t, err)
+
+			// Assert
+			require.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode != http.StatusOK {
+				return
+			}
+			var res pkgResponses.ScheduledCommandResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, pkgModels.ScheduledCommandStatusSucceeded, res.ScheduledCommand.Status)
+			assert.Equal(t, http.StatusOK, res.ScheduledCommand.StatusCode)
+		})
+	}
+}
+
+func TestCancelScheduledCommand(t *testing.T) {
+	succeededId := "succeeded"
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("ScheduledCommandById", testScheduledCommandId).
+		Return(pkgModels.ScheduledCommand{Id: testScheduledCommandId, Status: pkgModels.ScheduledCommandStatusScheduled}, nil)
+	dbClientMock.On("ScheduledCommandById", succeededId).
+		Return(pkgModels.ScheduledCommand{Id: succeededId, Status: pkgModels.ScheduledCommandStatusSucceeded}, nil)
+	dbClientMock.On("UpdateScheduledCommand", mock.Anything).Return(nil)
+	dic := NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		commandContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewScheduledCommandController(dic)
+
+	tests := []struct {
+		name               string
+		id                 string
+		expectedStatusCode int
+	}{
+		{"Valid", testScheduledCommandId, http.StatusOK},
+		{"Invalid - already executed", succeededId, http.StatusConflict},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, pkgCommon.ApiCancelScheduledCommandEchoRoute, http.NoBody)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			c.SetParamNames(common.Id)
+			c.SetParamValues(testCase.id)
+			err := controller.CancelScheduledCommand(c)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+		})
+	}
+	dbClientMock.AssertNumberOfCalls(t, "UpdateScheduledCommand", 1)
+}
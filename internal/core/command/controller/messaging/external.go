@@ -1,5 +1,5 @@
 //
-// Copyright (C) 2022-2023 IOTech Ltd
+// Copyright (C) 2022-2024 IOTech Ltd
 // Copyright (C) 2023 Intel Inc.
 //
 // SPDX-License-Identifier: Apache-2.0
@@ -7,8 +7,10 @@
 package messaging
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 	"time"
@@ -21,7 +23,12 @@ import (
 
 	"github.com/edgexfoundry/go-mod-messaging/v3/pkg/types"
 
+	"github.com/edgexfoundry/edgex-go/internal/core/command/application"
 	"github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
 )
 
 func OnConnectHandler(requestTimeout time.Duration, dic *di.Container) mqtt.OnConnectHandler {
@@ -44,6 +51,17 @@ func OnConnectHandler(requestTimeout time.Duration, dic *di.Container) mqtt.OnCo
 		} else {
 			lc.Debugf("Subscribed to topic '%s' on external MQTT broker", requestCommandTopic)
 		}
+
+		// the command sequences are only executed over the ExternalMQTT when their request topic is configured
+		requestSequenceTopic := externalTopics[pkgCommon.CommandSequenceRequestTopicKey]
+		if requestSequenceTopic == "" {
+			return
+		}
+		if token := client.Subscribe(requestSequenceTopic, qos, commandSequenceRequestHandler(dic)); token.Wait() && token.Error() != nil {
+			lc.Errorf("could not subscribe to topic '%s': %s", requestSequenceTopic, token.Error().Error())
+		} else {
+			lc.Debugf("Subscribed to topic '%s' on external MQTT broker", requestSequenceTopic)
+		}
 	}
 }
 
@@ -95,7 +113,6 @@ func commandQueryHandler(dic *di.Container) mqtt.MessageHandler {
 func commandRequestHandler(requestTimeout time.Duration, dic *di.Container) mqtt.MessageHandler {
 	return func(client mqtt.Client, message mqtt.Message) {
 		lc := bootstrapContainer.LoggingClientFrom(dic.Get)
-		config := container.ConfigurationFrom(dic.Get)
 		lc.Debugf("Received command request from external message broker on topic '%s' with %d bytes", message.Topic(), len(message.Payload()))
 
 		externalMQTTInfo := container.ConfigurationFrom(dic.Get).ExternalMQTT
@@ -138,48 +155,132 @@ func commandRequestHandler(requestTimeout time.Duration, dic *di.Container) mqtt
 		}
 
 		externalResponseTopic := common.BuildTopic(externalMQTTInfo.Topics[common.CommandResponseTopicPrefixKey], deviceName, commandName, method)
+		actor := envelopeIdentity(&requestEnvelope, dic)
+		responseEnvelope := processExternalCommandRequest(pkgModels.CommandAuditOriginMQTT, actor,
+			commandAuditActor(actor, externalMQTTInfo.Topics[common.CommandRequestTopicKey], message.Topic()),
+			requestEnvelope, deviceName, commandName, method, externalResponseTopic, requestTimeout, dic)
+		publishMessage(client, externalResponseTopic, qos, retain, responseEnvelope, lc)
+	}
+}
+
+// commandSequenceRequestHandler starts an execution of the command sequence named by the last level of the request
+// topic, and responds with the id of the execution on the response topic of the sequence
+func commandSequenceRequestHandler(dic *di.Container) mqtt.MessageHandler {
+	return func(client mqtt.Client, message mqtt.Message) {
+		lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+		lc.Debugf("Received command sequence request from external message broker on topic '%s' with %d bytes", message.Topic(), len(message.Payload()))
 
-		internalBaseTopic := config.MessageBus.GetBaseTopicPrefix()
-		topicPrefix := common.BuildTopic(internalBaseTopic, common.CoreCommandDeviceRequestPublishTopic)
+		externalMQTTInfo := container.ConfigurationFrom(dic.Get).ExternalMQTT
+		qos := externalMQTTInfo.QoS
+		retain := externalMQTTInfo.Retain
 
-		deviceServiceName, err := retrieveServiceNameByDevice(deviceName, dic)
+		requestEnvelope, err := types.NewMessageEnvelopeFromJSON(message.Payload())
 		if err != nil {
-			responseEnvelope := types.NewMessageEnvelopeWithError(requestEnvelope.RequestID, err.Error())
-			publishMessage(client, externalResponseTopic, qos, retain, responseEnvelope, lc)
+			lc.Errorf("Failed to decode request MessageEnvelope: %s", err.Error())
+			lc.Warn("Not publishing error message back due to insufficient information on response topic")
 			return
 		}
 
-		err = validateGetCommandQueryParameters(requestEnvelope.QueryParams)
+		// expected external command sequence request/response topic scheme: #/<sequence-name>
+		topicLevels := strings.Split(message.Topic(), "/")
+		sequenceName, err := url.PathUnescape(topicLevels[len(topicLevels)-1])
 		if err != nil {
-			responseEnvelope := types.NewMessageEnvelopeWithError(requestEnvelope.RequestID, err.Error())
-			publishMessage(client, externalResponseTopic, qos, retain, responseEnvelope, lc)
+			lc.Errorf("Failed to unescape command sequence name from '%s': %s", topicLevels[len(topicLevels)-1], err.Error())
+			lc.Warn("Not publishing error message back due to insufficient information on response topic")
 			return
 		}
 
-		deviceRequestTopic := common.NewPathBuilder().EnableNameFieldEscape(config.Service.EnableNameFieldEscape).
-			SetPath(topicPrefix).SetNameFieldPath(deviceServiceName).SetNameFieldPath(deviceName).SetNameFieldPath(commandName).SetPath(method).BuildPath()
-		deviceResponseTopicPrefix := common.NewPathBuilder().EnableNameFieldEscape(config.Service.EnableNameFieldEscape).
-			SetPath(internalBaseTopic).SetPath(common.ResponseTopic).SetNameFieldPath(deviceServiceName).BuildPath()
+		// the steps of the sequence are authorized for the identity verified from the token of the request only
+		ctx := identity.NewContext(context.Background(), envelopeIdentity(&requestEnvelope, dic))
+		var responseEnvelope types.MessageEnvelope
+		executionId, edgexErr := application.ExecuteCommandSequence(sequenceName, pkgModels.CommandAuditOriginMQTT, ctx, dic)
+		if edgexErr != nil {
+			responseEnvelope = types.NewMessageEnvelopeWithError(requestEnvelope.RequestID, edgexErr.Error())
+		} else if responseEnvelope, err = newCommandSequenceResponseEnvelope(requestEnvelope, executionId); err != nil {
+			responseEnvelope = types.NewMessageEnvelopeWithError(requestEnvelope.RequestID, err.Error())
+		}
+
+		responseTopic := common.BuildTopic(externalMQTTInfo.Topics[pkgCommon.CommandSequenceResponseTopicPrefixKey], sequenceName)
+		responseEnvelope.ReceivedTopic = responseTopic
+		publishMessage(client, responseTopic, qos, retain, responseEnvelope, lc)
+	}
+}
 
-		lc.Debugf("Sending Command request to internal MessageBus. Topic: %s, Request-id: %s Correlation-id: %s", deviceRequestTopic, requestEnvelope.RequestID, requestEnvelope.CorrelationID)
-		lc.Debugf("Expecting response on topic: %s/%s", deviceResponseTopicPrefix, requestEnvelope.RequestID)
+// processExternalCommandRequest forwards the command request received from an external transport to the device service
+// via the internal MessageBus, and returns the response envelope to send back on the response topic. The command is
+// authorized for the actor, whose identity has been verified, and it's audited with its origin, audit actor and outcome.
+func processExternalCommandRequest(origin string, actor string, auditActor string, requestEnvelope types.MessageEnvelope, deviceName string, commandName string,
+	method string, responseTopic string, requestTimeout time.Duration, dic *di.Container) types.MessageEnvelope {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	config := container.ConfigurationFrom(dic.Get)
+
+	// every command is audited with its outcome once the device, command and method are known
+	start := time.Now()
+	audit := newCommandAuditEntry(origin, auditActor, requestEnvelope, deviceName, commandName, method)
+	defer func() { application.RecordCommandAudit(audit, start, dic) }()
+
+	internalBaseTopic := config.MessageBus.GetBaseTopicPrefix()
+	topicPrefix := common.BuildTopic(internalBaseTopic, common.CoreCommandDeviceRequestPublishTopic)
+
+	deviceServiceName, err := retrieveServiceNameByDevice(deviceName, dic)
+	if err != nil {
+		recordCommandAuditOutcome(&audit, http.StatusBadRequest, err, nil)
+		return types.NewMessageEnvelopeWithError(requestEnvelope.RequestID, err.Error())
+	}
 
-		internalMessageBus := bootstrapContainer.MessagingClientFrom(dic.Get)
+	err = validateGetCommandQueryParameters(requestEnvelope.QueryParams)
+	if err != nil {
+		recordCommandAuditOutcome(&audit, http.StatusBadRequest, err, nil)
+		return types.NewMessageEnvelopeWithError(requestEnvelope.RequestID, err.Error())
+	}
 
-		// Request waits for the response and returns it.
-		response, err := internalMessageBus.Request(requestEnvelope, deviceRequestTopic, deviceResponseTopicPrefix, requestTimeout)
-		if err != nil {
-			errorMessage := fmt.Sprintf("Failed to send DeviceCommand request with internal MessageBus: %v", err)
-			responseEnvelope := types.NewMessageEnvelopeWithError(requestEnvelope.RequestID, errorMessage)
-			publishMessage(client, externalResponseTopic, qos, retain, responseEnvelope, lc)
-			return
+	if edgexErr := application.AuthorizeCommand(actor, deviceName, commandName, method, dic); edgexErr != nil {
+		recordCommandAuditOutcome(&audit, utils.StatusCode(edgexErr), edgexErr, nil)
+		return types.NewMessageEnvelopeWithError(requestEnvelope.RequestID, edgexErr.Error())
+	}
+
+	if strings.EqualFold(method, pkgModels.CommandMethodSet) {
+		pendingCommandId, edgexErr := checkSetCommandPayload(actor, audit, requestEnvelope.Payload, dic)
+		if edgexErr != nil {
+			recordCommandAuditOutcome(&audit, utils.StatusCode(edgexErr), edgexErr, nil)
+			return types.NewMessageEnvelopeWithError(requestEnvelope.RequestID, edgexErr.Error())
+		}
+		if pendingCommandId != "" {
+			audit.StatusCode, audit.Message = http.StatusAccepted, application.PendingApprovalMessage(pendingCommandId)
+			responseEnvelope, err := newPendingCommandResponseEnvelope(requestEnvelope, pendingCommandId)
+			if err != nil {
+				return types.NewMessageEnvelopeWithError(requestEnvelope.RequestID, err.Error())
+			}
+			return responseEnvelope
 		}
+	}
+
+	deviceRequestTopic := common.NewPathBuilder().EnableNameFieldEscape(config.Service.EnableNameFieldEscape).
+		SetPath(topicPrefix).SetNameFieldPath(deviceServiceName).SetNameFieldPath(deviceName).SetNameFieldPath(commandName).SetPath(method).BuildPath()
+	deviceResponseTopicPrefix := common.NewPathBuilder().EnableNameFieldEscape(config.Service.EnableNameFieldEscape).
+		SetPath(internalBaseTopic).SetPath(common.ResponseTopic).SetNameFieldPath(deviceServiceName).BuildPath()
 
-		lc.Debugf("Command response received from internal MessageBus. Topic: %s, Request-id: %s Correlation-id: %s", response.ReceivedTopic, response.RequestID, response.CorrelationID)
+	lc.Debugf("Sending Command request to internal MessageBus. Topic: %s, Request-id: %s Correlation-id: %s", deviceRequestTopic, requestEnvelope.RequestID, requestEnvelope.CorrelationID)
+	lc.Debugf("Expecting response on topic: %s/%s", deviceResponseTopicPrefix, requestEnvelope.RequestID)
 
-		response.ReceivedTopic = externalResponseTopic
-		publishMessage(client, externalResponseTopic, qos, retain, *response, lc)
+	internalMessageBus := bootstrapContainer.MessagingClientFrom(dic.Get)
+
+	// Request waits for the response and returns it.
+	response, err := internalMessageBus.Request(requestEnvelope, deviceRequestTopic, deviceResponseTopicPrefix, requestTimeout)
+	if err != nil {
+		errorMessage := fmt.Sprintf("Failed to send DeviceCommand request with internal MessageBus: %v", err)
+		recordCommandAuditOutcome(&audit, http.StatusServiceUnavailable, err, nil)
+		return types.NewMessageEnvelopeWithError(requestEnvelope.RequestID, errorMessage)
+	}
+
+	lc.Debugf("Command response received from internal MessageBus. Topic: %s, Request-id: %s Correlation-id: %s", response.ReceivedTopic, response.RequestID, response.CorrelationID)
+	recordCommandAuditOutcome(&audit, 0, nil, response)
+	if strings.EqualFold(method, pkgModels.CommandMethodSet) && response.ErrorCode == 0 {
+		application.InvalidateCachedGetCommands(deviceName)
 	}
+
+	response.ReceivedTopic = responseTopic
+	return *response
 }
 
 func publishMessage(client mqtt.Client, responseTopic string, qos byte, retain bool, message types.MessageEnvelope, lc logger.LoggingClient) {
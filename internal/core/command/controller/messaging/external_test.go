@@ -8,6 +8,7 @@ package messaging
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -15,6 +16,7 @@ import (
 	"time"
 
 	clientMocks "github.com/edgexfoundry/go-mod-core-contracts/v3/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
 	lcMocks "github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger/mocks"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
@@ -25,14 +27,20 @@ import (
 	"github.com/stretchr/testify/require"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	bootstrapMocks "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces/mocks"
 	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v3/config"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
 	internalMessagingMocks "github.com/edgexfoundry/go-mod-messaging/v3/messaging/mocks"
 	"github.com/edgexfoundry/go-mod-messaging/v3/pkg/types"
 
+	"github.com/edgexfoundry/edgex-go/internal/core/command/application"
 	"github.com/edgexfoundry/edgex-go/internal/core/command/config"
 	"github.com/edgexfoundry/edgex-go/internal/core/command/container"
 	"github.com/edgexfoundry/edgex-go/internal/core/command/controller/messaging/mocks"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/command/infrastructure/interfaces/mocks"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
 )
 
 const (
@@ -283,6 +291,16 @@ func Test_commandRequestHandler(t *testing.T) {
 	dsc.On("DeviceServiceByName", context.Background(), unknownService).Return(responses.DeviceServiceResponse{}, edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, "unknown device service", nil))
 	client := &internalMessagingMocks.MessageClient{}
 	client.On("Request", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(expectedResponse, nil)
+	maximum := 100.0
+	dpc := &clientMocks.DeviceProfileClient{}
+	dpc.On("DeviceProfileByName", context.Background(), testProfileName).Return(responses.DeviceProfileResponse{Profile: dtos.DeviceProfile{
+		DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: testProfileName},
+		DeviceResources: []dtos.DeviceResource{
+			{Name: testCommandName, Properties: dtos.ResourceProperties{ValueType: common.ValueTypeInt16, ReadWrite: common.ReadWrite_RW, Maximum: &maximum}},
+		},
+	}}, nil)
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AddCommandAuditEntry", mock.Anything).Return(pkgModels.CommandAuditEntry{}, nil)
 	dic := di.NewContainer(di.ServiceConstructorMap{
 		container.ConfigurationName: func(get di.Get) interface{} {
 			return &config.ConfigurationStruct{
@@ -298,6 +316,7 @@ func Test_commandRequestHandler(t *testing.T) {
 					QoS:    0,
 					Retain: true,
 					Topics: map[string]string{
+						common.CommandRequestTopicKey:        testExternalCommandRequestTopic,
 						common.CommandResponseTopicPrefixKey: testExternalCommandResponseTopicPrefix,
 					},
 				},
@@ -312,9 +331,15 @@ func Test_commandRequestHandler(t *testing.T) {
 		bootstrapContainer.DeviceServiceClientName: func(get di.Get) interface{} {
 			return dsc
 		},
+		bootstrapContainer.DeviceProfileClientName: func(get di.Get) interface{} {
+			return dpc
+		},
 		bootstrapContainer.MessagingClientName: func(get di.Get) interface{} {
 			return client
 		},
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
 	})
 
 	validPayload := testCommandRequestPayload()
@@ -323,6 +348,7 @@ func Test_commandRequestHandler(t *testing.T) {
 	invalidQueryParamsPayload := testCommandQueryPayload()
 	invalidQueryParamsPayload.QueryParams[common.PushEvent] = "invalid"
 	invalidQueryParamsPayload.QueryParams[common.ReturnEvent] = "invalid"
+	outOfRangeSetPayload := types.NewMessageEnvelopeForRequest([]byte(`{"testCommand":"200"}`), nil)
 
 	tests := []struct {
 		name                 string
@@ -338,6 +364,7 @@ func Test_commandRequestHandler(t *testing.T) {
 		{"invalid - device not found", "unittest/request/unknown-device/testCommand/get", validPayload, true, true},
 		{"invalid - device service not found", "unittest/request/unknownService-device/testCommand/get", validPayload, true, true},
 		{"invalid - invalid device service reserved query parameters", testExternalCommandRequestTopicExample, invalidQueryParamsPayload, true, true},
+		{"invalid - set command setting greater than maximum", "unittest/external/request/testDevice/testCommand/set", outOfRangeSetPayload, true, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -370,10 +397,189 @@ func Test_commandRequestHandler(t *testing.T) {
 			expectedInternalRequestTopic := common.BuildTopic(baseTopic, common.CoreCommandDeviceRequestPublishTopic, testDeviceServiceName, testDeviceName, testCommandName, testMethod)
 			expectedInternalResponseTopicPrefix := common.BuildTopic(baseTopic, common.ResponseTopic, testDeviceServiceName)
 			client.AssertCalled(t, "Request", tt.payload, expectedInternalRequestTopic, expectedInternalResponseTopicPrefix, mock.Anything)
+			dbClientMock.AssertCalled(t, "AddCommandAuditEntry", mock.MatchedBy(func(e pkgModels.CommandAuditEntry) bool {
+				return e.Origin == pkgModels.CommandAuditOriginMQTT && e.Actor == identity.Anonymous && e.DeviceName == testDeviceName &&
+					e.CommandName == testCommandName && e.Method == testMethod && e.StatusCode == http.StatusOK
+			}))
 		})
 	}
 }
 
+func Test_commandAuditActor(t *testing.T) {
+	tests := []struct {
+		name          string
+		verifiedActor string
+		receivedTopic string
+		expectedActor string
+	}{
+		{"verified identity", "hmi", "unittest/external/request/admin/testDevice/testCommand/get", "hmi"},
+		{"client id", identity.Anonymous, "unittest/external/request/client-1/testDevice/testCommand/get", "topic:client-1"},
+		{"multi-level client id", identity.Anonymous, "unittest/external/request/site-a/client-1/testDevice/testCommand/get", "topic:site-a/client-1"},
+		{"no client id", identity.Anonymous, testExternalCommandRequestTopicExample, identity.Anonymous},
+		{"not subscribed topic", identity.Anonymous, "unittest/other/client-1/testDevice/testCommand/get", identity.Anonymous},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expectedActor, commandAuditActor(tt.verifiedActor, testExternalCommandRequestTopic, tt.receivedTopic))
+		})
+	}
+}
+
+func Test_envelopeIdentity(t *testing.T) {
+	token := "eyJhbGciOiJFUzM4NCJ9." + base64.RawURLEncoding.EncodeToString([]byte(`{"name":"hmi"}`)) + ".c2lnbmF0dXJl"
+	secretProvider := &bootstrapMocks.SecretProviderExt{}
+	secretProvider.On("IsJWTValid", token).Return(true, nil)
+	secretProvider.On("IsJWTValid", mock.Anything).Return(false, nil)
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		bootstrapContainer.SecretProviderExtName: func(get di.Get) interface{} {
+			return secretProvider
+		},
+	})
+
+	tests := []struct {
+		name             string
+		queryParams      map[string]string
+		expectedIdentity string
+	}{
+		{"verified token", map[string]string{pkgCommon.AuthToken: token, common.PushEvent: common.ValueTrue}, "hmi"},
+		{"invalid token", map[string]string{pkgCommon.AuthToken: "eyJhbGciOiJFUzM4NCJ9." + base64.RawURLEncoding.EncodeToString([]byte(`{"name":"admin"}`)) + ".Zm9yZ2Vk"}, identity.Anonymous},
+		{"no token", map[string]string{common.PushEvent: common.ValueTrue}, identity.Anonymous},
+		{"no query parameters", nil, identity.Anonymous},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envelope := types.NewMessageEnvelopeForRequest(nil, tt.queryParams)
+			require.Equal(t, tt.expectedIdentity, envelopeIdentity(&envelope, dic))
+			require.NotContains(t, envelope.QueryParams, pkgCommon.AuthToken, "the token shouldn't be forwarded to the device service")
+		})
+	}
+}
+
+func Test_processExternalCommandRequestForbidden(t *testing.T) {
+	dc := &clientMocks.DeviceClient{}
+	dc.On("DeviceByName", context.Background(), testDeviceName).Return(responses.DeviceResponse{
+		Device: dtos.Device{Name: testDeviceName, ProfileName: testProfileName, ServiceName: testDeviceServiceName},
+	}, nil)
+	dsc := &clientMocks.DeviceServiceClient{}
+	dsc.On("DeviceServiceByName", context.Background(), testDeviceServiceName).Return(responses.DeviceServiceResponse{
+		Service: dtos.DeviceService{Name: testDeviceServiceName},
+	}, nil)
+	client := &internalMessagingMocks.MessageClient{}
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AddCommandAuditEntry", mock.Anything).Return(pkgModels.CommandAuditEntry{}, nil)
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Writable: config.WritableInfo{CommandAccess: config.CommandAccess{
+					Enabled:  true,
+					Policies: map[string]config.CommandPolicy{"hmi-read": {Users: []string{"hmi"}, Permissions: []string{"read"}}},
+				}},
+				MessageBus: bootstrapConfig.MessageBusInfo{BaseTopicPrefix: "edgex"},
+			}
+		},
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+			return dc
+		},
+		bootstrapContainer.DeviceServiceClientName: func(get di.Get) interface{} {
+			return dsc
+		},
+		bootstrapContainer.MessagingClientName: func(get di.Get) interface{} {
+			return client
+		},
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	payload := types.NewMessageEnvelopeForRequest([]byte(`{"testCommand":"10"}`), nil)
+	response := processExternalCommandRequest(pkgModels.CommandAuditOriginMQTT, "hmi", "hmi", payload, testDeviceName, testCommandName, pkgModels.CommandMethodSet,
+		testExternalCommandResponseTopicPrefix, time.Second, dic)
+
+	require.Equal(t, 1, response.ErrorCode)
+	require.Contains(t, string(response.Payload), "isn't allowed")
+	client.AssertNotCalled(t, "Request", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	dbClientMock.AssertCalled(t, "AddCommandAuditEntry", mock.MatchedBy(func(e pkgModels.CommandAuditEntry) bool {
+		return e.Actor == "hmi" && e.Method == pkgModels.CommandMethodSet && e.StatusCode == http.StatusForbidden
+	}))
+}
+
+func Test_processExternalCommandRequestInvalidatesCache(t *testing.T) {
+	dc := &clientMocks.DeviceClient{}
+	dc.On("DeviceByName", mock.Anything, testDeviceName).Return(responses.DeviceResponse{
+		Device: dtos.Device{Name: testDeviceName, ProfileName: testProfileName, ServiceName: testDeviceServiceName},
+	}, nil)
+	dsc := &clientMocks.DeviceServiceClient{}
+	dsc.On("DeviceServiceByName", mock.Anything, testDeviceServiceName).Return(responses.DeviceServiceResponse{
+		Service: dtos.DeviceService{Name: testDeviceServiceName, BaseAddress: "http://localhost:59900"},
+	}, nil)
+	dpc := &clientMocks.DeviceProfileClient{}
+	dpc.On("DeviceProfileByName", mock.Anything, testProfileName).Return(responses.DeviceProfileResponse{Profile: dtos.DeviceProfile{
+		DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: testProfileName},
+		DeviceResources: []dtos.DeviceResource{
+			{Name: testCommandName, Properties: dtos.ResourceProperties{ValueType: common.ValueTypeInt16, ReadWrite: common.ReadWrite_RW}},
+		},
+	}}, nil)
+	dscc := &clientMocks.DeviceServiceCommandClient{}
+	dscc.On("GetCommand", mock.Anything, "http://localhost:59900", testDeviceName, testCommandName, "").
+		Return(&responses.EventResponse{BaseResponse: commonDTO.NewBaseResponse("", "", http.StatusOK)}, nil)
+	client := &internalMessagingMocks.MessageClient{}
+	client.On("Request", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&types.MessageEnvelope{}, nil)
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AddCommandAuditEntry", mock.Anything).Return(pkgModels.CommandAuditEntry{}, nil)
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Writable: config.WritableInfo{CommandCache: config.CommandCache{Commands: map[string]config.CachedCommand{
+					"test": {DeviceName: testDeviceName, CommandName: testCommandName, TTL: "1m"},
+				}}},
+				MessageBus: bootstrapConfig.MessageBusInfo{BaseTopicPrefix: "edgex"},
+			}
+		},
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+			return dc
+		},
+		bootstrapContainer.DeviceServiceClientName: func(get di.Get) interface{} {
+			return dsc
+		},
+		bootstrapContainer.DeviceProfileClientName: func(get di.Get) interface{} {
+			return dpc
+		},
+		bootstrapContainer.DeviceServiceCommandClientName: func(get di.Get) interface{} {
+			return dscc
+		},
+		bootstrapContainer.MessagingClientName: func(get di.Get) interface{} {
+			return client
+		},
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	for i := 0; i < 2; i++ {
+		_, err := application.IssueGetCommandByName(testDeviceName, testCommandName, "", dic)
+		require.NoError(t, err)
+	}
+	dscc.AssertNumberOfCalls(t, "GetCommand", 1)
+
+	payload := types.NewMessageEnvelopeForRequest([]byte(`{"testCommand":"10"}`), nil)
+	response := processExternalCommandRequest(pkgModels.CommandAuditOriginMQTT, identity.Anonymous, identity.Anonymous, payload, testDeviceName, testCommandName,
+		pkgModels.CommandMethodSet, testExternalCommandResponseTopicPrefix, time.Second, dic)
+	require.Equal(t, 0, response.ErrorCode)
+
+	_, err := application.IssueGetCommandByName(testDeviceName, testCommandName, "", dic)
+	require.NoError(t, err)
+	dscc.AssertNumberOfCalls(t, "GetCommand", 2)
+}
+
 func testCommandQueryPayload() types.MessageEnvelope {
 	payload := types.NewMessageEnvelopeForRequest(nil, nil)
 
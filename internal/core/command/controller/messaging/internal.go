@@ -1,5 +1,5 @@
 //
-// Copyright (C) 2022-2023 IOTech Ltd
+// Copyright (C) 2022-2024 IOTech Ltd
 // Copyright (C) 2023 Intel Inc.
 //
 // SPDX-License-Identifier: Apache-2.0
@@ -9,6 +9,7 @@ package messaging
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 	"time"
@@ -23,7 +24,10 @@ import (
 
 	"github.com/edgexfoundry/go-mod-messaging/v3/pkg/types"
 
+	"github.com/edgexfoundry/edgex-go/internal/core/command/application"
 	"github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
 )
 
 // SubscribeCommandRequests subscribes command requests from EdgeX service (e.g., Application Service)
@@ -128,12 +132,23 @@ func processDeviceCommandRequest(
 		return
 	}
 
+	// the command is authorized for the identity verified from the token of the request only
+	actor := envelopeIdentity(&requestEnvelope, dic)
+
+	// every command is audited with its outcome once the device, command and method are known
+	start := time.Now()
+	requestCommandTopic := common.BuildTopic(baseTopic, common.CoreCommandRequestSubscribeTopic)
+	audit := newCommandAuditEntry(pkgModels.CommandAuditOriginMessageBus, commandAuditActor(actor, requestCommandTopic, requestEnvelope.ReceivedTopic),
+		requestEnvelope, deviceName, commandName, method)
+	defer func() { application.RecordCommandAudit(audit, start, dic) }()
+
 	topicPrefix := common.BuildTopic(baseTopic, common.CoreCommandDeviceRequestPublishTopic)
 	// internal command request topic scheme: <DeviceRequestTopicPrefix>/<device-service>/<device>/<command-name>/<method>
 	deviceServiceName, err := retrieveServiceNameByDevice(deviceName, dic)
 	if err != nil {
 		err = fmt.Errorf("invalid request topic: %s", err.Error())
 		lc.Error(err.Error())
+		recordCommandAuditOutcome(&audit, http.StatusBadRequest, err, nil)
 		responseEnvelope := types.NewMessageEnvelopeWithError(requestEnvelope.RequestID, err.Error())
 		err = messageBus.Publish(responseEnvelope, internalResponseTopic)
 		if err != nil {
@@ -145,6 +160,7 @@ func processDeviceCommandRequest(
 	err = validateGetCommandQueryParameters(requestEnvelope.QueryParams)
 	if err != nil {
 		lc.Errorf(err.Error())
+		recordCommandAuditOutcome(&audit, http.StatusBadRequest, err, nil)
 		responseEnvelope := types.NewMessageEnvelopeWithError(requestEnvelope.RequestID, err.Error())
 		err = messageBus.Publish(responseEnvelope, internalResponseTopic)
 		if err != nil {
@@ -153,6 +169,43 @@ func processDeviceCommandRequest(
 		return
 	}
 
+	if edgexErr := application.AuthorizeCommand(actor, deviceName, commandName, method, dic); edgexErr != nil {
+		recordCommandAuditOutcome(&audit, utils.StatusCode(edgexErr), edgexErr, nil)
+		responseEnvelope := types.NewMessageEnvelopeWithError(requestEnvelope.RequestID, edgexErr.Error())
+		err = messageBus.Publish(responseEnvelope, internalResponseTopic)
+		if err != nil {
+			lc.Errorf("Could not publish to topic '%s': %s", internalResponseTopic, err.Error())
+		}
+		return
+	}
+
+	if strings.EqualFold(method, pkgModels.CommandMethodSet) {
+		pendingCommandId, edgexErr := checkSetCommandPayload(actor, audit, requestEnvelope.Payload, dic)
+		if edgexErr != nil {
+			lc.Error(edgexErr.Error())
+			recordCommandAuditOutcome(&audit, utils.StatusCode(edgexErr), edgexErr, nil)
+			responseEnvelope := types.NewMessageEnvelopeWithError(requestEnvelope.RequestID, edgexErr.Error())
+			err = messageBus.Publish(responseEnvelope, internalResponseTopic)
+			if err != nil {
+				lc.Errorf("Could not publish to topic '%s': %s", internalResponseTopic, err.Error())
+			}
+			return
+		}
+		if pendingCommandId != "" {
+			audit.StatusCode, audit.Message = http.StatusAccepted, application.PendingApprovalMessage(pendingCommandId)
+			responseEnvelope, err := newPendingCommandResponseEnvelope(requestEnvelope, pendingCommandId)
+			if err != nil {
+				lc.Error(err.Error())
+				return
+			}
+			err = messageBus.Publish(responseEnvelope, internalResponseTopic)
+			if err != nil {
+				lc.Errorf("Could not publish to topic '%s': %s", internalResponseTopic, err.Error())
+			}
+			return
+		}
+	}
+
 	deviceRequestTopic := common.NewPathBuilder().EnableNameFieldEscape(config.Service.EnableNameFieldEscape).
 		SetPath(topicPrefix).SetNameFieldPath(deviceServiceName).SetNameFieldPath(deviceName).SetNameFieldPath(commandName).SetPath(method).BuildPath()
 	deviceResponseTopicPrefix := common.NewPathBuilder().EnableNameFieldEscape(config.Service.EnableNameFieldEscape).
@@ -164,8 +217,13 @@ func processDeviceCommandRequest(
 	response, err := messageBus.Request(requestEnvelope, deviceRequestTopic, deviceResponseTopicPrefix, requestTimeout)
 	if err != nil {
 		lc.Errorf("Request to topic '%s' failed: %s", deviceRequestTopic, err.Error())
+		recordCommandAuditOutcome(&audit, http.StatusServiceUnavailable, err, nil)
 		return
 	}
+	recordCommandAuditOutcome(&audit, 0, nil, response)
+	if strings.EqualFold(method, pkgModels.CommandMethodSet) && response.ErrorCode == 0 {
+		application.InvalidateCachedGetCommands(deviceName)
+	}
 
 	// original request is from internal MessageBus
 	err = messageBus.Publish(*response, internalResponseTopic)
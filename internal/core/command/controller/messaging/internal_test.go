@@ -6,6 +6,7 @@ package messaging
 
 import (
 	"context"
+	"net/http"
 	"strings"
 	"sync"
 	"testing"
@@ -28,6 +29,8 @@ import (
 
 	"github.com/edgexfoundry/edgex-go/internal/core/command/config"
 	"github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/command/infrastructure/interfaces/mocks"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
 )
 
 var expectedResponseTopicPrefix = "edgex/response"
@@ -53,6 +56,7 @@ func TestSubscribeCommandRequests(t *testing.T) {
 	mockDeviceProfileClient := &mocks2.DeviceProfileClient{}
 	mockDeviceServiceClient := &mocks2.DeviceServiceClient{}
 	mockMessaging := &mocks.MessageClient{}
+	dbClientMock := &dbMock.DBClient{}
 
 	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 
@@ -105,6 +109,12 @@ func TestSubscribeCommandRequests(t *testing.T) {
 		},
 		nil)
 
+	// the topic level between the subscribed topic and the device identifies the actor of the command
+	dbClientMock.On("AddCommandAuditEntry", mock.MatchedBy(func(e pkgModels.CommandAuditEntry) bool {
+		return e.Origin == pkgModels.CommandAuditOriginMessageBus && e.Actor == unverifiedActorPrefix+expectedServiceName && e.DeviceName == expectedDevice &&
+			e.CommandName == expectedResource && e.Method == expectedMethod && e.CorrelationId == expectedCorrelationId && e.StatusCode == http.StatusOK
+	})).Return(pkgModels.CommandAuditEntry{}, nil)
+
 	dic := di.NewContainer(di.ServiceConstructorMap{
 		container.ConfigurationName: func(get di.Get) interface{} {
 			return &config.ConfigurationStruct{
@@ -113,6 +123,9 @@ func TestSubscribeCommandRequests(t *testing.T) {
 				},
 			}
 		},
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
 		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
 			return mockLogger
 		},
@@ -141,6 +154,7 @@ func TestSubscribeCommandRequests(t *testing.T) {
 	wg.Wait()
 
 	mockMessaging.AssertExpectations(t)
+	dbClientMock.AssertExpectations(t)
 }
 
 func TestSubscribeCommandQueryRequests(t *testing.T) {
@@ -1,5 +1,5 @@
 //
-// Copyright (C) 2022 IOTech Ltd
+// Copyright (C) 2022-2024 IOTech Ltd
 // Copyright (C) 2023 Intel Inc.
 //
 // SPDX-License-Identifier: Apache-2.0
@@ -13,15 +13,21 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
-	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
 
 	"github.com/edgexfoundry/go-mod-messaging/v3/pkg/types"
 
 	"github.com/edgexfoundry/edgex-go/internal/core/command/application"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
 )
 
 // retrieveServiceNameByDevice validates the existence of device and device service,
@@ -36,6 +42,9 @@ func retrieveServiceNameByDevice(deviceName string, dic *di.Container) (string,
 	if err != nil {
 		return "", fmt.Errorf("failed to get Device by name %s: %v", deviceName, err)
 	}
+	if pkgModels.DeviceLifecycleState(deviceResponse.Device.Properties) == pkgModels.Decommissioned {
+		return "", fmt.Errorf("device %s is decommissioned", deviceName)
+	}
 
 	// retrieve device service information through Metadata DeviceClient
 	dsc := bootstrapContainer.DeviceServiceClientFrom(dic.Get)
@@ -65,6 +74,51 @@ func validateGetCommandQueryParameters(queryParams map[string]string) error {
 	return nil
 }
 
+// checkSetCommandPayload validates the settings carried by the payload of the set command request against the
+// device resources of the device. A critical set command becomes a pending command awaiting approval on behalf of the
+// actor, and the id of the pending command is returned. Any other set command is checked against the
+// interlock rules and rate limits before it's issued.
+func checkSetCommandPayload(actor string, audit pkgModels.CommandAuditEntry, payload []byte, dic *di.Container) (string, edgexErr.EdgeX) {
+	var settings map[string]any
+	if err := json.Unmarshal(payload, &settings); err != nil {
+		return "", edgexErr.NewCommonEdgeX(edgexErr.KindContractInvalid, "failed to decode the settings of the set command", err)
+	}
+	if err := application.ValidateSetCommand(audit.DeviceName, audit.CommandName, settings, dic); err != nil {
+		return "", err
+	}
+	ctx := identity.NewContext(context.Background(), actor)
+	pendingCommandId, err := application.RequestSetCommandApproval(audit.Origin, audit.DeviceName, audit.CommandName, audit.QueryParams, settings, ctx, dic)
+	if err != nil || pendingCommandId != "" {
+		return pendingCommandId, err
+	}
+	if err := application.CheckSetCommandSafety(audit.DeviceName, audit.CommandName, settings, dic); err != nil {
+		return "", err
+	}
+	return "", nil
+}
+
+// newPendingCommandResponseEnvelope returns the MessageEnvelope responding to a critical set command request that the
+// command awaits approval as the pending command
+func newPendingCommandResponseEnvelope(requestEnvelope types.MessageEnvelope, pendingCommandId string) (types.MessageEnvelope, error) {
+	response := commonDTO.NewBaseWithIdResponse(requestEnvelope.RequestID, application.PendingApprovalMessage(pendingCommandId), http.StatusAccepted, pendingCommandId)
+	payload, err := json.Marshal(response)
+	if err != nil {
+		return types.MessageEnvelope{}, fmt.Errorf("failed to encode the pending command response: %v", err)
+	}
+	return types.NewMessageEnvelopeForResponse(payload, requestEnvelope.RequestID, requestEnvelope.CorrelationID, common.ContentTypeJSON)
+}
+
+// newCommandSequenceResponseEnvelope returns the MessageEnvelope responding to a command sequence request with the id
+// of the execution started
+func newCommandSequenceResponseEnvelope(requestEnvelope types.MessageEnvelope, executionId string) (types.MessageEnvelope, error) {
+	response := commonDTO.NewBaseWithIdResponse(requestEnvelope.RequestID, "", http.StatusAccepted, executionId)
+	payload, err := json.Marshal(response)
+	if err != nil {
+		return types.MessageEnvelope{}, fmt.Errorf("failed to encode the command sequence response: %v", err)
+	}
+	return types.NewMessageEnvelopeForResponse(payload, requestEnvelope.RequestID, requestEnvelope.CorrelationID, common.ContentTypeJSON)
+}
+
 // getCommandQueryResponseEnvelope returns the MessageEnvelope containing the DeviceCoreCommand payload bytes
 func getCommandQueryResponseEnvelope(requestEnvelope types.MessageEnvelope, deviceName string, dic *di.Container) (types.MessageEnvelope, error) {
 	var commandsResponse any
@@ -115,3 +169,96 @@ func getCommandQueryResponseEnvelope(requestEnvelope types.MessageEnvelope, devi
 
 	return responseEnvelope, nil
 }
+
+// unverifiedActorPrefix marks the audited actor taken from a topic level chosen by the publisher
+const unverifiedActorPrefix = "topic:"
+
+// envelopeIdentity returns the identity of the requester carried by the JWT of the AuthToken query parameter of the
+// envelope, which is verified with the secret provider. The requester is anonymous when the envelope carries no token
+// or the token isn't valid. The token is removed from the query parameters, so that it's neither forwarded to the
+// device service nor audited.
+func envelopeIdentity(requestEnvelope *types.MessageEnvelope, dic *di.Container) string {
+	token, ok := requestEnvelope.QueryParams[pkgCommon.AuthToken]
+	if !ok {
+		return identity.Anonymous
+	}
+	delete(requestEnvelope.QueryParams, pkgCommon.AuthToken)
+
+	actor, valid := tokenIdentity(token, dic)
+	if !valid {
+		bootstrapContainer.LoggingClientFrom(dic.Get).Warnf("Invalid token carried by the request %s, the requester is anonymous", requestEnvelope.RequestID)
+	}
+	return actor
+}
+
+// tokenIdentity verifies the JWT with the secret provider and returns the identity of its claims along with whether
+// it's valid. The identity is anonymous when the token can't be verified.
+func tokenIdentity(token string, dic *di.Container) (string, bool) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	secretProvider := bootstrapContainer.SecretProviderExtFrom(dic.Get)
+	if secretProvider == nil {
+		lc.Warn("Unable to verify the token without the secret provider, the requester is anonymous")
+		return identity.Anonymous, false
+	}
+	valid, err := secretProvider.IsJWTValid(token)
+	if err != nil {
+		lc.Errorf("Error checking JWT validity: %v", err)
+		return identity.Anonymous, false
+	}
+	if !valid {
+		return identity.Anonymous, false
+	}
+	return identity.FromToken(token), true
+}
+
+// commandAuditActor returns the actor audited for the command request received on the topic. It's the verified
+// identity of the requester when there's one, otherwise it's the topic levels between the subscribed topic prefix and
+// the '<device>/<command>/<method>' levels, e.g. the client ID of the request topic
+// '<CommandRequestTopic>/<client-id>/<device>/<command>/<method>'. Since the publisher chooses the topic, its levels are
+// prefixed with 'topic:' to mark them as unverified. It's anonymous when the topic carries no actor either.
+func commandAuditActor(verifiedActor string, subscribedTopic string, receivedTopic string) string {
+	if verifiedActor != identity.Anonymous {
+		return verifiedActor
+	}
+	prefix := strings.TrimSuffix(subscribedTopic, "#")
+	if !strings.HasPrefix(receivedTopic, prefix) {
+		return identity.Anonymous
+	}
+	levels := strings.Split(strings.TrimPrefix(receivedTopic, prefix), "/")
+	if len(levels) <= 3 {
+		return identity.Anonymous
+	}
+	return unverifiedActorPrefix + strings.Join(levels[:len(levels)-3], "/")
+}
+
+// newCommandAuditEntry creates the audit entry of the command request received from the origin, the outcome of the
+// command is recorded in the entry by the caller
+func newCommandAuditEntry(origin string, actor string, requestEnvelope types.MessageEnvelope, deviceName string, commandName string, method string) pkgModels.CommandAuditEntry {
+	entry := pkgModels.CommandAuditEntry{
+		Origin:        origin,
+		Actor:         actor,
+		CorrelationId: requestEnvelope.CorrelationID,
+		DeviceName:    deviceName,
+		CommandName:   commandName,
+		Method:        strings.ToLower(method),
+		QueryParams:   requestEnvelope.QueryParams,
+	}
+	if entry.Method == pkgModels.CommandMethodSet && len(requestEnvelope.Payload) > 0 {
+		// the payload is forwarded to the device service as is, so it's recorded on the best effort
+		_ = json.Unmarshal(requestEnvelope.Payload, &entry.Parameters)
+	}
+	return entry
+}
+
+// recordCommandAuditOutcome records the outcome of the command in the audit entry, the command fails with the status
+// code when err isn't nil, otherwise the outcome is taken from the response received from the device service
+func recordCommandAuditOutcome(entry *pkgModels.CommandAuditEntry, statusCode int, err error, response *types.MessageEnvelope) {
+	switch {
+	case err != nil:
+		entry.StatusCode, entry.Message = statusCode, err.Error()
+	case response.ErrorCode != 0:
+		entry.StatusCode, entry.Message = http.StatusInternalServerError, string(response.Payload)
+	default:
+		entry.StatusCode = http.StatusOK
+	}
+}
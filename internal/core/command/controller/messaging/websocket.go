@@ -0,0 +1,456 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-messaging/v3/pkg/types"
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/core/command/application"
+	"github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
+)
+
+// The topics of the WebSocket messages, which mirror the ExternalMQTT topic schemes:
+//   - command/request/<device-name>/<command-name>/<method> is answered on command/response/<device-name>/<command-name>/<method>
+//   - commandquery/request/<device-name> or commandquery/request/all is answered on commandquery/response
+//   - events/subscribe/<device-name> or events/subscribe/all starts streaming the device events on
+//     events/device/<device-service>/<profile>/<device-name>/<source>, and events/unsubscribe/<device-name> stops it,
+//     both are answered on events/response
+//   - a message which can't be processed is answered on error
+const (
+	webSocketCommandRequestTopic       = "command/request"
+	webSocketCommandResponseTopic      = "command/response"
+	webSocketCommandQueryRequestTopic  = "commandquery/request"
+	webSocketCommandQueryResponseTopic = "commandquery/response"
+	webSocketEventSubscribeTopic       = "events/subscribe"
+	webSocketEventUnsubscribeTopic     = "events/unsubscribe"
+	webSocketEventResponseTopic        = "events/response"
+	webSocketErrorTopic                = "error"
+)
+
+// webSocketProtocolBearer is the subprotocol a browser, which can't set the Authorization header of the upgrade request,
+// offers followed by its JWT, i.e. Sec-WebSocket-Protocol: bearer, <token>
+const webSocketProtocolBearer = "bearer"
+
+// WebSocketMessage is the message exchanged over a WebSocket connection. Since a WebSocket connection has no topics,
+// the message carries the topic along with the message envelope.
+type WebSocketMessage struct {
+	Topic    string                `json:"topic"`
+	Envelope types.MessageEnvelope `json:"envelope"`
+}
+
+// webSocketConnections holds the open WebSocket connections the device events are streamed to
+var webSocketConnections = struct {
+	mutex       sync.RWMutex
+	connections map[*webSocketConnection]struct{}
+}{connections: make(map[*webSocketConnection]struct{})}
+
+// webSocketConnection is an open WebSocket connection. Every message sent to the client goes through the send queue,
+// which is drained by a single writer since a WebSocket connection supports one concurrent writer only.
+type webSocketConnection struct {
+	conn  *websocket.Conn
+	actor string
+	send  chan WebSocketMessage
+	done  chan struct{}
+	// inFlight bounds the requests of the connection processed concurrently
+	inFlight chan struct{}
+	// devices are the device names whose events are streamed, common.All streams the events of every device
+	mutex   sync.Mutex
+	devices map[string]struct{}
+}
+
+type WebSocketController struct {
+	requestTimeout time.Duration
+	dic            *di.Container
+}
+
+// NewWebSocketController creates and initializes a WebSocketController
+func NewWebSocketController(requestTimeout time.Duration, dic *di.Container) *WebSocketController {
+	return &WebSocketController{
+		requestTimeout: requestTimeout,
+		dic:            dic,
+	}
+}
+
+// WebSocketAuthenticationFunc returns the authentication of the WebSocket upgrade requests. Since browsers can't set the
+// Authorization header of the upgrade request, the JWT is also accepted from the authtoken query parameter or from the
+// Sec-WebSocket-Protocol header. The identity of the verified JWT is carried by the request context, and the requester
+// is anonymous otherwise. The request is rejected with 401 unless its JWT is verified when authRequired.
+func WebSocketAuthenticationFunc(authRequired bool, dic *di.Container) echo.MiddlewareFunc {
+	return func(inner echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+			r := c.Request()
+
+			actor, valid := identity.Anonymous, false
+			if token := webSocketToken(r); token != "" {
+				actor, valid = tokenIdentity(token, dic)
+			}
+			if authRequired && !valid {
+				lc.Warnf("WebSocket upgrade request to '%s' from %s UNAUTHORIZED", r.URL.Path, r.RemoteAddr)
+				return echo.NewHTTPError(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+			}
+			c.SetRequest(r.WithContext(identity.NewContext(r.Context(), actor)))
+			return inner(c)
+		}
+	}
+}
+
+// webSocketToken returns the JWT of the upgrade request, carried by either the Authorization header, the authtoken
+// query parameter or the Sec-WebSocket-Protocol header after the bearer protocol. It's empty when there's none.
+func webSocketToken(r *http.Request) string {
+	if authHeader := r.Header.Get(internal.AuthHeaderTitle); strings.HasPrefix(authHeader, internal.BearerLabel) {
+		return strings.TrimPrefix(authHeader, internal.BearerLabel)
+	}
+	if token := r.URL.Query().Get(pkgCommon.AuthToken); token != "" {
+		return token
+	}
+	protocols := websocket.Subprotocols(r)
+	if i := slices.Index(protocols, webSocketProtocolBearer); i >= 0 && i+1 < len(protocols) {
+		return protocols[i+1]
+	}
+	return ""
+}
+
+// Connect upgrades the request to a WebSocket connection and serves the messages of the connection until it's closed.
+// The commands are authorized and audited, and the device events filtered, for the identity verified by
+// WebSocketAuthenticationFunc.
+func (wc *WebSocketController) Connect(c echo.Context) error {
+	lc := bootstrapContainer.LoggingClientFrom(wc.dic.Get)
+	config := container.ConfigurationFrom(wc.dic.Get).WebSocket
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+
+	webSocketConnections.mutex.RLock()
+	count := len(webSocketConnections.connections)
+	webSocketConnections.mutex.RUnlock()
+	if config.MaxConnections > 0 && count >= config.MaxConnections {
+		err := errors.NewCommonEdgeX(errors.KindServiceUnavailable, fmt.Sprintf("the maximum of %d WebSocket connections is reached", config.MaxConnections), nil)
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	// the bearer protocol offered along with the JWT is selected, since a browser fails the connection otherwise
+	upgrader := websocket.Upgrader{CheckOrigin: checkWebSocketOrigin(config.AllowedOrigins), Subprotocols: []string{webSocketProtocolBearer}}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader has already replied with an HTTP error
+		lc.Errorf("Failed to upgrade to a WebSocket connection: %v", err)
+		return nil
+	}
+
+	connection := &webSocketConnection{
+		conn:     conn,
+		actor:    identity.FromContext(ctx),
+		send:     make(chan WebSocketMessage, max(config.SendBuffer, 1)),
+		done:     make(chan struct{}),
+		inFlight: make(chan struct{}, max(config.MaxInFlight, 1)),
+		devices:  make(map[string]struct{}),
+	}
+	webSocketConnections.mutex.Lock()
+	webSocketConnections.connections[connection] = struct{}{}
+	webSocketConnections.mutex.Unlock()
+	lc.Debugf("WebSocket connection opened from %s by '%s'", r.RemoteAddr, connection.actor)
+
+	go connection.writeMessages(lc)
+	connection.readMessages(wc.requestTimeout, lc, wc.dic)
+
+	webSocketConnections.mutex.Lock()
+	delete(webSocketConnections.connections, connection)
+	webSocketConnections.mutex.Unlock()
+	close(connection.done)
+	_ = conn.Close()
+	lc.Debugf("WebSocket connection closed from %s", r.RemoteAddr)
+	return nil
+}
+
+// checkWebSocketOrigin returns the origin check of the upgrade requests, allowing the configured origins besides the
+// same host
+func checkWebSocketOrigin(allowedOrigins []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" || slices.Contains(allowedOrigins, "*") || slices.Contains(allowedOrigins, origin) {
+			return true
+		}
+		u, err := url.Parse(origin)
+		return err == nil && strings.EqualFold(u.Host, r.Host)
+	}
+}
+
+// readMessages processes the messages received on the connection until it's closed, the requests are processed
+// concurrently so that a slow device doesn't hold up the other requests. A request received while the maximum of
+// requests is in flight is rejected with an error reply instead of being processed.
+func (connection *webSocketConnection) readMessages(requestTimeout time.Duration, lc logger.LoggingClient, dic *di.Container) {
+	for {
+		_, data, err := connection.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				lc.Errorf("Failed to read from the WebSocket connection: %v", err)
+			}
+			return
+		}
+
+		var message WebSocketMessage
+		if err = json.Unmarshal(data, &message); err != nil {
+			connection.reply(webSocketErrorTopic, types.NewMessageEnvelopeWithError("", fmt.Sprintf("failed to decode the WebSocket message: %v", err)))
+			continue
+		}
+		select {
+		case connection.inFlight <- struct{}{}:
+		default:
+			connection.reply(webSocketErrorTopic, types.NewMessageEnvelopeWithError(message.Envelope.RequestID,
+				fmt.Sprintf("the maximum of %d requests in flight is reached, the request to '%s' is rejected", cap(connection.inFlight), message.Topic)))
+			continue
+		}
+		go func() {
+			defer func() { <-connection.inFlight }()
+			topic, response := connection.processMessage(message, requestTimeout, dic)
+			connection.reply(topic, response)
+		}()
+	}
+}
+
+// processMessage processes the message received on the connection, and returns the topic and envelope of the response
+func (connection *webSocketConnection) processMessage(message WebSocketMessage, requestTimeout time.Duration, dic *di.Container) (string, types.MessageEnvelope) {
+	requestEnvelope := message.Envelope
+	topic := strings.Trim(message.Topic, "/")
+	switch {
+	case strings.HasPrefix(topic, webSocketCommandRequestTopic+"/"):
+		// expected topic scheme: command/request/<device-name>/<command-name>/<method>
+		levels := strings.Split(strings.TrimPrefix(topic, webSocketCommandRequestTopic+"/"), "/")
+		if len(levels) != 3 {
+			return webSocketErrorTopic, types.NewMessageEnvelopeWithError(requestEnvelope.RequestID,
+				fmt.Sprintf("invalid topic '%s', expected '%s/<device-name>/<command-name>/<method>'", message.Topic, webSocketCommandRequestTopic))
+		}
+		deviceName, err := url.PathUnescape(levels[0])
+		if err != nil {
+			return webSocketErrorTopic, types.NewMessageEnvelopeWithError(requestEnvelope.RequestID, fmt.Sprintf("failed to unescape device name from '%s': %v", levels[0], err))
+		}
+		commandName, err := url.PathUnescape(levels[1])
+		if err != nil {
+			return webSocketErrorTopic, types.NewMessageEnvelopeWithError(requestEnvelope.RequestID, fmt.Sprintf("failed to unescape command name from '%s': %v", levels[1], err))
+		}
+		method := levels[2]
+		if !strings.EqualFold(method, pkgModels.CommandMethodGet) && !strings.EqualFold(method, pkgModels.CommandMethodSet) {
+			return webSocketErrorTopic, types.NewMessageEnvelopeWithError(requestEnvelope.RequestID,
+				fmt.Sprintf("unknown request method: %s, only 'get' or 'set' is allowed", method))
+		}
+		responseTopic := common.BuildTopic(append([]string{webSocketCommandResponseTopic}, levels...)...)
+		return responseTopic, processExternalCommandRequest(pkgModels.CommandAuditOriginWebSocket, connection.actor, connection.actor, requestEnvelope,
+			deviceName, commandName, method, responseTopic, requestTimeout, dic)
+	case strings.HasPrefix(topic, webSocketCommandQueryRequestTopic+"/"):
+		deviceName, err := url.PathUnescape(strings.TrimPrefix(topic, webSocketCommandQueryRequestTopic+"/"))
+		if err != nil {
+			return webSocketErrorTopic, types.NewMessageEnvelopeWithError(requestEnvelope.RequestID, fmt.Sprintf("failed to unescape device name: %v", err))
+		}
+		if strings.EqualFold(deviceName, common.All) {
+			deviceName = common.All
+		}
+		responseEnvelope, err := getCommandQueryResponseEnvelope(requestEnvelope, deviceName, dic)
+		if err != nil {
+			responseEnvelope = types.NewMessageEnvelopeWithError(requestEnvelope.RequestID, err.Error())
+		}
+		return webSocketCommandQueryResponseTopic, responseEnvelope
+	case strings.HasPrefix(topic, webSocketEventSubscribeTopic+"/"), strings.HasPrefix(topic, webSocketEventUnsubscribeTopic+"/"):
+		subscribe := strings.HasPrefix(topic, webSocketEventSubscribeTopic+"/")
+		deviceName, err := url.PathUnescape(topic[strings.LastIndex(topic, "/")+1:])
+		if err != nil {
+			return webSocketErrorTopic, types.NewMessageEnvelopeWithError(requestEnvelope.RequestID, fmt.Sprintf("failed to unescape device name: %v", err))
+		}
+		if strings.EqualFold(deviceName, common.All) {
+			deviceName = common.All
+		}
+		connection.mutex.Lock()
+		if subscribe {
+			connection.devices[deviceName] = struct{}{}
+		} else {
+			delete(connection.devices, deviceName)
+		}
+		connection.mutex.Unlock()
+
+		payload, err := json.Marshal(commonDTO.NewBaseResponse(requestEnvelope.RequestID, "", http.StatusOK))
+		if err != nil {
+			return webSocketErrorTopic, types.NewMessageEnvelopeWithError(requestEnvelope.RequestID, fmt.Sprintf("failed to encode the response: %v", err))
+		}
+		responseEnvelope, err := types.NewMessageEnvelopeForResponse(payload, requestEnvelope.RequestID, requestEnvelope.CorrelationID, common.ContentTypeJSON)
+		if err != nil {
+			return webSocketErrorTopic, types.NewMessageEnvelopeWithError(requestEnvelope.RequestID, err.Error())
+		}
+		return webSocketEventResponseTopic, responseEnvelope
+	default:
+		return webSocketErrorTopic, types.NewMessageEnvelopeWithError(requestEnvelope.RequestID, fmt.Sprintf("unknown topic '%s'", message.Topic))
+	}
+}
+
+// reply queues the response on the connection, waiting for room in the queue unless the connection is closed
+func (connection *webSocketConnection) reply(topic string, envelope types.MessageEnvelope) {
+	select {
+	case connection.send <- WebSocketMessage{Topic: topic, Envelope: envelope}:
+	case <-connection.done:
+	}
+}
+
+// writeMessages writes the queued messages to the connection until it's closed
+func (connection *webSocketConnection) writeMessages(lc logger.LoggingClient) {
+	for {
+		select {
+		case <-connection.done:
+			return
+		case message := <-connection.send:
+			if message.Envelope.ErrorCode == 1 {
+				lc.Error(string(message.Envelope.Payload))
+			}
+			if err := connection.conn.WriteJSON(message); err != nil {
+				lc.Errorf("Failed to write to the WebSocket connection: %v", err)
+				// closing the connection ends the read loop, which cleans the connection up
+				_ = connection.conn.Close()
+				return
+			}
+		}
+	}
+}
+
+// SubscribeWebSocketEvents subscribes the device events from the internal MessageBus and streams them to the WebSocket
+// connections subscribed to their device. An event is dropped for a connection whose send queue is full, so that a
+// slow client never holds up the others.
+func SubscribeWebSocketEvents(ctx context.Context, dic *di.Container) errors.EdgeX {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	config := container.ConfigurationFrom(dic.Get)
+	baseTopic := config.MessageBus.GetBaseTopicPrefix()
+	eventTopic := common.BuildTopic(baseTopic, common.CoreDataEventSubscribeTopic)
+
+	messages := make(chan types.MessageEnvelope)
+	messageErrors := make(chan error)
+	topics := []types.TopicChannel{
+		{
+			Topic:    eventTopic,
+			Messages: messages,
+		},
+	}
+
+	messageBus := bootstrapContainer.MessagingClientFrom(dic.Get)
+	err := messageBus.Subscribe(topics, messageErrors)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				lc.Infof("Exiting waiting for MessageBus '%s' topic messages", eventTopic)
+				return
+			case err = <-messageErrors:
+				lc.Error(err.Error())
+			case envelope := <-messages:
+				streamWebSocketEvent(envelope, baseTopic, lc, dic)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// streamWebSocketEvent queues the event on the WebSocket connections subscribed to its device, whose actor has the read
+// permission of the source of the event. The topic of the event is the MessageBus topic without the base topic, i.e.
+// events/device/<device-service>/<profile>/<device-name>/<source>.
+func streamWebSocketEvent(envelope types.MessageEnvelope, baseTopic string, lc logger.LoggingClient, dic *di.Container) {
+	topic := strings.TrimPrefix(strings.TrimPrefix(envelope.ReceivedTopic, baseTopic), "/")
+	levels := strings.Split(topic, "/")
+	if len(levels) < 6 {
+		lc.Debugf("Ignoring event received on unexpected topic '%s'", envelope.ReceivedTopic)
+		return
+	}
+	deviceName, err := url.PathUnescape(levels[4])
+	if err != nil {
+		lc.Errorf("Failed to unescape device name from '%s': %s", levels[4], err.Error())
+		return
+	}
+	sourceName, err := url.PathUnescape(levels[5])
+	if err != nil {
+		lc.Errorf("Failed to unescape source name from '%s': %s", levels[5], err.Error())
+		return
+	}
+
+	message := WebSocketMessage{Topic: topic, Envelope: envelope}
+	// the device is queried once for all the connections, and only while the command access is enabled
+	var device *dtos.Device
+	authorized := func(actor string) bool {
+		if !container.ConfigurationFrom(dic.Get).Writable.CommandAccess.Enabled {
+			return true
+		}
+		if device == nil {
+			dc := bootstrapContainer.DeviceClientFrom(dic.Get)
+			if dc == nil {
+				lc.Error("nil DeviceClient returned, the event isn't streamed")
+				return false
+			}
+			deviceResponse, err := dc.DeviceByName(context.Background(), deviceName)
+			if err != nil {
+				lc.Errorf("Failed to query device '%s', the event isn't streamed: %v", deviceName, err)
+				return false
+			}
+			device = &deviceResponse.Device
+		}
+		return application.AuthorizeDeviceCommand(actor, *device, sourceName, pkgModels.CommandMethodGet, dic) == nil
+	}
+
+	webSocketConnections.mutex.RLock()
+	defer webSocketConnections.mutex.RUnlock()
+	for connection := range webSocketConnections.connections {
+		if !connection.subscribed(deviceName) || !authorized(connection.actor) {
+			continue
+		}
+		select {
+		case connection.send <- message:
+		default:
+			lc.Warnf("Dropping event of device '%s' for WebSocket client '%s', whose send queue is full", deviceName, connection.actor)
+		}
+	}
+}
+
+// subscribed returns whether the events of the device are streamed to the connection
+func (connection *webSocketConnection) subscribed(deviceName string) bool {
+	connection.mutex.Lock()
+	defer connection.mutex.Unlock()
+	_, all := connection.devices[common.All]
+	_, device := connection.devices[deviceName]
+	return all || device
+}
+
+// CloseWebSocketConnections closes the open WebSocket connections, which outlive the shutdown of the server since
+// they're hijacked from it
+func CloseWebSocketConnections() {
+	webSocketConnections.mutex.RLock()
+	defer webSocketConnections.mutex.RUnlock()
+	for connection := range webSocketConnections.connections {
+		_ = connection.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, "service shutting down"), time.Now().Add(time.Second))
+		_ = connection.conn.Close()
+	}
+}
@@ -0,0 +1,347 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	bootstrapMocks "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces/mocks"
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	clientMocks "github.com/edgexfoundry/go-mod-core-contracts/v3/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	internalMessagingMocks "github.com/edgexfoundry/go-mod-messaging/v3/messaging/mocks"
+	"github.com/edgexfoundry/go-mod-messaging/v3/pkg/types"
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/core/command/application"
+	"github.com/edgexfoundry/edgex-go/internal/core/command/config"
+	"github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/command/infrastructure/interfaces/mocks"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+func mockWebSocketDic(maxConnections int) (*di.Container, *dbMock.DBClient) {
+	dc := &clientMocks.DeviceClient{}
+	dc.On("DeviceByName", context.Background(), testDeviceName).Return(responses.DeviceResponse{
+		Device: dtos.Device{Name: testDeviceName, ProfileName: testProfileName, ServiceName: testDeviceServiceName},
+	}, nil)
+	dc.On("DeviceByName", context.Background(), "unknown").Return(responses.DeviceResponse{},
+		errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "device not found", nil))
+	dsc := &clientMocks.DeviceServiceClient{}
+	dsc.On("DeviceServiceByName", context.Background(), testDeviceServiceName).Return(responses.DeviceServiceResponse{
+		Service: dtos.DeviceService{Name: testDeviceServiceName},
+	}, nil)
+	// the device service responds with the request ID of the request
+	eventResponse := func(request types.MessageEnvelope, _ string, _ string, _ time.Duration) *types.MessageEnvelope {
+		response, _ := types.NewMessageEnvelopeForResponse([]byte(`{"event":{}}`), request.RequestID, request.CorrelationID, common.ContentTypeJSON)
+		return &response
+	}
+	client := &internalMessagingMocks.MessageClient{}
+	client.On("Request", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(eventResponse, nil)
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AddCommandAuditEntry", mock.Anything).Return(pkgModels.CommandAuditEntry{}, nil)
+
+	return di.NewContainer(di.ServiceConstructorMap{
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				MessageBus: bootstrapConfig.MessageBusInfo{BaseTopicPrefix: baseTopic},
+				WebSocket:  config.WebSocket{Enabled: true, MaxConnections: maxConnections, SendBuffer: 8, MaxInFlight: 8},
+			}
+		},
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+			return dc
+		},
+		bootstrapContainer.DeviceServiceClientName: func(get di.Get) interface{} {
+			return dsc
+		},
+		bootstrapContainer.MessagingClientName: func(get di.Get) interface{} {
+			return client
+		},
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	}), dbClientMock
+}
+
+// dialWebSocket starts a server serving the WebSocket endpoint and connects to it
+func dialWebSocket(t *testing.T, dic *di.Container) (*websocket.Conn, *http.Response, error) {
+	return dialAuthenticatedWebSocket(t, dic, false, "", nil)
+}
+
+// dialAuthenticatedWebSocket starts a server serving the WebSocket endpoint, whose upgrade requests must carry a valid
+// JWT when authRequired, and connects to it with the query and header
+func dialAuthenticatedWebSocket(t *testing.T, dic *di.Container, authRequired bool, query string, header http.Header) (*websocket.Conn, *http.Response, error) {
+	router := echo.New()
+	router.GET(pkgCommon.ApiWebSocketRoute, NewWebSocketController(time.Second, dic).Connect, WebSocketAuthenticationFunc(authRequired, dic))
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+pkgCommon.ApiWebSocketRoute+query, header)
+}
+
+func readWebSocketMessage(t *testing.T, conn *websocket.Conn) WebSocketMessage {
+	var message WebSocketMessage
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	require.NoError(t, conn.ReadJSON(&message))
+	return message
+}
+
+func TestWebSocketCommandRequests(t *testing.T) {
+	dic, dbClientMock := mockWebSocketDic(0)
+	conn, _, err := dialWebSocket(t, dic)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	tests := []struct {
+		name          string
+		topic         string
+		expectedTopic string
+		expectedError bool
+	}{
+		{"get command", "command/request/testDevice/testCommand/get", "command/response/testDevice/testCommand/get", false},
+		{"unknown device", "command/request/unknown/testCommand/get", "command/response/unknown/testCommand/get", true},
+		{"unknown method", "command/request/testDevice/testCommand/delete", "error", true},
+		{"invalid topic scheme", "command/request/testDevice/get", "error", true},
+		{"unknown topic", "device/testDevice", "error", true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := types.NewMessageEnvelopeForRequest(nil, nil)
+			require.NoError(t, conn.WriteJSON(WebSocketMessage{Topic: testCase.topic, Envelope: request}))
+
+			response := readWebSocketMessage(t, conn)
+			assert.Equal(t, testCase.expectedTopic, response.Topic)
+			assert.Equal(t, request.RequestID, response.Envelope.RequestID)
+			if testCase.expectedError {
+				assert.Equal(t, 1, response.Envelope.ErrorCode)
+				return
+			}
+			assert.Equal(t, 0, response.Envelope.ErrorCode)
+		})
+	}
+
+	dbClientMock.AssertCalled(t, "AddCommandAuditEntry", mock.MatchedBy(func(e pkgModels.CommandAuditEntry) bool {
+		return e.Origin == pkgModels.CommandAuditOriginWebSocket && e.DeviceName == testDeviceName && e.StatusCode == http.StatusOK
+	}))
+}
+
+func TestWebSocketEvents(t *testing.T) {
+	dic, _ := mockWebSocketDic(0)
+	conn, _, err := dialWebSocket(t, dic)
+	require.NoError(t, err)
+	defer conn.Close()
+	lc := logger.NewMockClient()
+	event := func(deviceName string) types.MessageEnvelope {
+		envelope := types.NewMessageEnvelopeForRequest([]byte(`{"event":{}}`), nil)
+		envelope.ReceivedTopic = common.BuildTopic(baseTopic, "events/device", testDeviceServiceName, testProfileName, deviceName, testCommandName)
+		return envelope
+	}
+
+	subscribe := types.NewMessageEnvelopeForRequest(nil, nil)
+	require.NoError(t, conn.WriteJSON(WebSocketMessage{Topic: "events/subscribe/" + testDeviceName, Envelope: subscribe}))
+	response := readWebSocketMessage(t, conn)
+	assert.Equal(t, webSocketEventResponseTopic, response.Topic)
+	assert.Equal(t, subscribe.RequestID, response.Envelope.RequestID)
+
+	streamWebSocketEvent(event("otherDevice"), baseTopic, lc, dic)
+	streamWebSocketEvent(event(testDeviceName), baseTopic, lc, dic)
+	message := readWebSocketMessage(t, conn)
+	assert.Equal(t, common.BuildTopic("events/device", testDeviceServiceName, testProfileName, testDeviceName, testCommandName), message.Topic)
+
+	unsubscribe := types.NewMessageEnvelopeForRequest(nil, nil)
+	require.NoError(t, conn.WriteJSON(WebSocketMessage{Topic: "events/unsubscribe/" + testDeviceName, Envelope: unsubscribe}))
+	response = readWebSocketMessage(t, conn)
+	assert.Equal(t, unsubscribe.RequestID, response.Envelope.RequestID)
+	streamWebSocketEvent(event(testDeviceName), baseTopic, lc, dic)
+
+	// no event is streamed once unsubscribed, so the next message is the response of the next request
+	query := types.NewMessageEnvelopeForRequest(nil, nil)
+	require.NoError(t, conn.WriteJSON(WebSocketMessage{Topic: "unknown", Envelope: query}))
+	response = readWebSocketMessage(t, conn)
+	assert.Equal(t, webSocketErrorTopic, response.Topic)
+	assert.Equal(t, query.RequestID, response.Envelope.RequestID)
+}
+
+func TestWebSocketMaxConnections(t *testing.T) {
+	dic, _ := mockWebSocketDic(1)
+	conn, _, err := dialWebSocket(t, dic)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.Eventually(t, func() bool {
+		webSocketConnections.mutex.RLock()
+		defer webSocketConnections.mutex.RUnlock()
+		return len(webSocketConnections.connections) == 1
+	}, time.Second, time.Millisecond)
+
+	_, res, err := dialWebSocket(t, dic)
+	require.Error(t, err)
+	require.NotNil(t, res)
+	assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+}
+
+func TestWebSocketMaxInFlight(t *testing.T) {
+	dic, _ := mockWebSocketDic(0)
+	container.ConfigurationFrom(dic.Get).WebSocket.MaxInFlight = 1
+	// the device service holds the responses until released
+	release := make(chan struct{})
+	client := &internalMessagingMocks.MessageClient{}
+	client.On("Request", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(func(request types.MessageEnvelope, _ string, _ string, _ time.Duration) *types.MessageEnvelope {
+		<-release
+		response, _ := types.NewMessageEnvelopeForResponse([]byte(`{"event":{}}`), request.RequestID, request.CorrelationID, common.ContentTypeJSON)
+		return &response
+	}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		bootstrapContainer.MessagingClientName: func(get di.Get) interface{} {
+			return client
+		},
+	})
+	conn, _, err := dialWebSocket(t, dic)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	first := types.NewMessageEnvelopeForRequest(nil, nil)
+	require.NoError(t, conn.WriteJSON(WebSocketMessage{Topic: "command/request/testDevice/testCommand/get", Envelope: first}))
+	second := types.NewMessageEnvelopeForRequest(nil, nil)
+	require.NoError(t, conn.WriteJSON(WebSocketMessage{Topic: "command/request/testDevice/testCommand/get", Envelope: second}))
+
+	// the second request is rejected while the first one is in flight
+	response := readWebSocketMessage(t, conn)
+	assert.Equal(t, webSocketErrorTopic, response.Topic)
+	assert.Equal(t, second.RequestID, response.Envelope.RequestID)
+	assert.Equal(t, 1, response.Envelope.ErrorCode)
+
+	close(release)
+	response = readWebSocketMessage(t, conn)
+	assert.Equal(t, "command/response/testDevice/testCommand/get", response.Topic)
+	assert.Equal(t, first.RequestID, response.Envelope.RequestID)
+	assert.Equal(t, 0, response.Envelope.ErrorCode)
+}
+
+func TestWebSocketAuthentication(t *testing.T) {
+	token := "eyJhbGciOiJFUzM4NCJ9." + base64.RawURLEncoding.EncodeToString([]byte(`{"name":"hmi"}`)) + ".c2lnbmF0dXJl"
+	forged := "eyJhbGciOiJFUzM4NCJ9." + base64.RawURLEncoding.EncodeToString([]byte(`{"name":"admin"}`)) + ".Zm9yZ2Vk"
+	secretProvider := &bootstrapMocks.SecretProviderExt{}
+	secretProvider.On("IsJWTValid", token).Return(true, nil)
+	secretProvider.On("IsJWTValid", mock.Anything).Return(false, nil)
+	dic, _ := mockWebSocketDic(0)
+	dic.Update(di.ServiceConstructorMap{
+		bootstrapContainer.SecretProviderExtName: func(get di.Get) interface{} {
+			return secretProvider
+		},
+	})
+
+	tests := []struct {
+		name               string
+		query              string
+		header             http.Header
+		expectedStatusCode int
+		expectedProtocol   string
+	}{
+		{"Authorization header", "", http.Header{internal.AuthHeaderTitle: {internal.BearerLabel + token}}, http.StatusSwitchingProtocols, ""},
+		{"authtoken query parameter", "?" + pkgCommon.AuthToken + "=" + token, nil, http.StatusSwitchingProtocols, ""},
+		{"bearer protocol", "", http.Header{"Sec-Websocket-Protocol": {webSocketProtocolBearer + ", " + token}}, http.StatusSwitchingProtocols, webSocketProtocolBearer},
+		{"invalid token", "?" + pkgCommon.AuthToken + "=" + forged, nil, http.StatusUnauthorized, ""},
+		{"no token", "", nil, http.StatusUnauthorized, ""},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			conn, res, err := dialAuthenticatedWebSocket(t, dic, true, testCase.query, testCase.header)
+			require.NotNil(t, res)
+			assert.Equal(t, testCase.expectedStatusCode, res.StatusCode)
+			if testCase.expectedStatusCode != http.StatusSwitchingProtocols {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			defer conn.Close()
+			assert.Equal(t, testCase.expectedProtocol, conn.Subprotocol())
+			require.Eventually(t, func() bool {
+				webSocketConnections.mutex.RLock()
+				defer webSocketConnections.mutex.RUnlock()
+				for connection := range webSocketConnections.connections {
+					if connection.actor == "hmi" {
+						return true
+					}
+				}
+				return false
+			}, time.Second, time.Millisecond, "the connection should be opened by the identity of the verified token")
+		})
+	}
+}
+
+func TestWebSocketEvents_ReadAccess(t *testing.T) {
+	dic, _ := mockWebSocketDic(0)
+	configuration := container.ConfigurationFrom(dic.Get)
+	configuration.Writable.CommandAccess = config.CommandAccess{
+		Enabled: true,
+		Policies: map[string]config.CommandPolicy{
+			"hmi-read": {Users: []string{"hmi"}, Permissions: []string{application.CommandPermissionRead}},
+		},
+	}
+	allowed := &webSocketConnection{actor: "hmi", send: make(chan WebSocketMessage, 1), devices: map[string]struct{}{common.All: {}}}
+	denied := &webSocketConnection{actor: "guest", send: make(chan WebSocketMessage, 1), devices: map[string]struct{}{common.All: {}}}
+	webSocketConnections.mutex.Lock()
+	webSocketConnections.connections[allowed] = struct{}{}
+	webSocketConnections.connections[denied] = struct{}{}
+	webSocketConnections.mutex.Unlock()
+	t.Cleanup(func() {
+		webSocketConnections.mutex.Lock()
+		delete(webSocketConnections.connections, allowed)
+		delete(webSocketConnections.connections, denied)
+		webSocketConnections.mutex.Unlock()
+	})
+
+	envelope := types.NewMessageEnvelopeForRequest([]byte(`{"event":{}}`), nil)
+	envelope.ReceivedTopic = common.BuildTopic(baseTopic, "events/device", testDeviceServiceName, testProfileName, testDeviceName, testCommandName)
+	streamWebSocketEvent(envelope, baseTopic, logger.NewMockClient(), dic)
+
+	assert.Len(t, allowed.send, 1)
+	assert.Empty(t, denied.send, "the event shouldn't be streamed to an actor without the read permission")
+}
+
+func TestCheckWebSocketOrigin(t *testing.T) {
+	tests := []struct {
+		name           string
+		allowedOrigins []string
+		origin         string
+		expected       bool
+	}{
+		{"no origin", nil, "", true},
+		{"same host", nil, "http://core-command:59883", true},
+		{"other host", nil, "http://hmi:8080", false},
+		{"allowed origin", []string{"http://hmi:8080"}, "http://hmi:8080", true},
+		{"any origin", []string{"*"}, "http://hmi:8080", true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "http://core-command:59883"+pkgCommon.ApiWebSocketRoute, http.NoBody)
+			if testCase.origin != "" {
+				r.Header.Set("Origin", testCase.origin)
+			}
+			assert.Equal(t, testCase.expected, checkWebSocketOrigin(testCase.allowedOrigins)(r))
+		})
+	}
+}
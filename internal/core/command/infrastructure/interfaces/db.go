@@ -0,0 +1,42 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package interfaces
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+type DBClient interface {
+	CloseSession()
+
+	AddScheduledCommand(sc models.ScheduledCommand) (models.ScheduledCommand, errors.EdgeX)
+	ScheduledCommandById(id string) (models.ScheduledCommand, errors.EdgeX)
+	ScheduledCommands(status string, offset int, limit int) ([]models.ScheduledCommand, uint32, errors.EdgeX)
+	DueScheduledCommands(executeAt int64, limit int) ([]models.ScheduledCommand, errors.EdgeX)
+	UpdateScheduledCommand(sc models.ScheduledCommand) errors.EdgeX
+
+	AddPendingCommand(pc models.PendingCommand) (models.PendingCommand, errors.EdgeX)
+	PendingCommandById(id string) (models.PendingCommand, errors.EdgeX)
+	PendingCommands(status string, offset int, limit int) ([]models.PendingCommand, uint32, errors.EdgeX)
+	ExpiredPendingCommands(expiresAt int64, limit int) ([]models.PendingCommand, errors.EdgeX)
+	UpdatePendingCommand(pc models.PendingCommand) errors.EdgeX
+
+	AddCommandAuditEntry(e models.CommandAuditEntry) (models.CommandAuditEntry, errors.EdgeX)
+	CommandAuditEntries(deviceName string, start int64, end int64, offset int, limit int) ([]models.CommandAuditEntry, uint32, errors.EdgeX)
+
+	AddCommandSequence(cs models.CommandSequence) (models.CommandSequence, errors.EdgeX)
+	UpdateCommandSequence(cs models.CommandSequence) errors.EdgeX
+	CommandSequenceByName(name string) (models.CommandSequence, errors.EdgeX)
+	AllCommandSequences(offset int, limit int) ([]models.CommandSequence, uint32, errors.EdgeX)
+	DeleteCommandSequenceByName(name string) errors.EdgeX
+	AddCommandSequenceExecution(e models.CommandSequenceExecution) (models.CommandSequenceExecution, errors.EdgeX)
+	CommandSequenceExecutionById(id string) (models.CommandSequenceExecution, errors.EdgeX)
+	CommandSequenceExecutions(status string, offset int, limit int) ([]models.CommandSequenceExecution, uint32, errors.EdgeX)
+	CommandSequenceExecutionsBySequenceName(name string, offset int, limit int) ([]models.CommandSequenceExecution, uint32, errors.EdgeX)
+	UpdateCommandSequenceExecution(e models.CommandSequenceExecution) errors.EdgeX
+}
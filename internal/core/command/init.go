@@ -19,7 +19,9 @@ package command
 import (
 	"context"
 	"sync"
+	"time"
 
+	"github.com/edgexfoundry/edgex-go/internal/core/command/application"
 	"github.com/edgexfoundry/edgex-go/internal/core/command/container"
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/secret"
@@ -67,5 +69,39 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, _
 		},
 	})
 
+	interval, err := time.ParseDuration(config.ScheduledCommand.Interval)
+	if err != nil {
+		lc.Errorf("Failed to parse scheduled command execution interval, %v", err)
+		return false
+	}
+	var expireAfter time.Duration
+	if config.ScheduledCommand.ExpireAfter != "" {
+		expireAfter, err = time.ParseDuration(config.ScheduledCommand.ExpireAfter)
+		if err != nil {
+			lc.Errorf("Failed to parse scheduled command expiration, %v", err)
+			return false
+		}
+	}
+	application.AsyncExecuteScheduledCommands(interval, expireAfter, ctx, dic)
+
+	if _, err = time.ParseDuration(config.CommandApproval.ExpireAfter); err != nil {
+		lc.Errorf("Failed to parse pending command expiration, %v", err)
+		return false
+	}
+	interval, err = time.ParseDuration(config.CommandApproval.Interval)
+	if err != nil {
+		lc.Errorf("Failed to parse pending command expiration interval, %v", err)
+		return false
+	}
+	application.AsyncExpirePendingCommands(interval, ctx, dic)
+
+	application.RegisterCommandCacheMetrics(dic)
+	application.WarnAdvisoryCommandAccess(dic)
+	application.WarnUnverifiedCommandApproval(dic)
+
+	if err := application.FailInterruptedCommandSequenceExecutions(dic); err != nil {
+		lc.Errorf("Failed to record the interrupted command sequence executions, %v", err)
+	}
+
 	return true
 }
@@ -35,6 +35,7 @@ import (
 	"github.com/edgexfoundry/edgex-go/internal/core/command/config"
 	"github.com/edgexfoundry/edgex-go/internal/core/command/container"
 	"github.com/edgexfoundry/edgex-go/internal/core/command/controller/messaging"
+	pkgHandlers "github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/handlers"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
 
@@ -75,6 +76,7 @@ func Main(ctx context.Context, cancel context.CancelFunc, router *echo.Echo) {
 		true,
 		bootstrapConfig.ServiceTypeOther,
 		[]interfaces.BootstrapHandler{
+			pkgHandlers.NewDatabase(httpServer, configuration, container.DBClientInterfaceName).BootstrapHandler, // add db client bootstrap handler
 			handlers.NewClientsBootstrap().BootstrapHandler,
 			MessagingBootstrapHandler,
 			handlers.NewServiceMetrics(common.CoreCommandServiceKey).BootstrapHandler, // Must be after Messaging
@@ -121,5 +123,11 @@ func MessagingBootstrapHandler(ctx context.Context, wg *sync.WaitGroup, startupT
 		return false
 	}
 
+	if configuration.WebSocket.Enabled {
+		if !NewWebSocketServer(requestTimeout).BootstrapHandler(ctx, wg, startupTimer, dic) {
+			return false
+		}
+	}
+
 	return true
 }
@@ -9,6 +9,7 @@ package command
 import (
 	"github.com/edgexfoundry/edgex-go"
 	commandController "github.com/edgexfoundry/edgex-go/internal/core/command/controller/http"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/controller"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/handlers"
@@ -32,4 +33,37 @@ func LoadRestRoutes(r *echo.Echo, dic *di.Container, serviceName string) {
 	r.GET(common.ApiDeviceByNameEchoRoute, cmd.CommandsByDeviceName, authenticationHook)
 	r.GET(common.ApiDeviceNameCommandNameEchoRoute, cmd.IssueGetCommandByName, authenticationHook)
 	r.PUT(common.ApiDeviceNameCommandNameEchoRoute, cmd.IssueSetCommandByName, authenticationHook)
+	r.POST(pkgCommon.ApiGroupCommandRoute, cmd.IssueGroupCommand, authenticationHook)
+
+	// Scheduled Command
+	sc := commandController.NewScheduledCommandController(dic)
+	r.POST(pkgCommon.ApiScheduledCommandRoute, sc.AddScheduledCommand, authenticationHook)
+	r.GET(pkgCommon.ApiAllScheduledCommandRoute, sc.AllScheduledCommands, authenticationHook)
+	r.GET(pkgCommon.ApiScheduledCommandByIdEchoRoute, sc.ScheduledCommandById, authenticationHook)
+	r.POST(pkgCommon.ApiCancelScheduledCommandEchoRoute, sc.CancelScheduledCommand, authenticationHook)
+
+	// Command Audit
+	ca := commandController.NewCommandAuditController(dic)
+	r.GET(pkgCommon.ApiAllCommandAuditRoute, ca.AllCommandAuditEntries, authenticationHook)
+	r.GET(pkgCommon.ApiCommandAuditByDeviceNameEchoRoute, ca.CommandAuditEntriesByDeviceName, authenticationHook)
+	r.GET(pkgCommon.ApiCommandAuditByTimeRangeEchoRoute, ca.CommandAuditEntriesByTimeRange, authenticationHook)
+
+	// Pending Command
+	pc := commandController.NewPendingCommandController(dic)
+	r.GET(pkgCommon.ApiAllPendingCommandRoute, pc.AllPendingCommands, authenticationHook)
+	r.GET(pkgCommon.ApiPendingCommandByIdEchoRoute, pc.PendingCommandById, authenticationHook)
+	r.POST(pkgCommon.ApiApprovePendingCommandEchoRoute, pc.ApprovePendingCommand, authenticationHook)
+	r.POST(pkgCommon.ApiRejectPendingCommandEchoRoute, pc.RejectPendingCommand, authenticationHook)
+
+	// Command Sequence
+	csc := commandController.NewCommandSequenceController(dic)
+	r.POST(pkgCommon.ApiCommandSequenceRoute, csc.AddCommandSequence, authenticationHook)
+	r.PUT(pkgCommon.ApiCommandSequenceRoute, csc.UpdateCommandSequence, authenticationHook)
+	r.GET(pkgCommon.ApiAllCommandSequenceRoute, csc.AllCommandSequences, authenticationHook)
+	r.GET(pkgCommon.ApiCommandSequenceByNameEchoRoute, csc.CommandSequenceByName, authenticationHook)
+	r.DELETE(pkgCommon.ApiCommandSequenceByNameEchoRoute, csc.DeleteCommandSequenceByName, authenticationHook)
+	r.POST(pkgCommon.ApiExecuteCommandSequenceEchoRoute, csc.ExecuteCommandSequence, authenticationHook)
+	r.GET(pkgCommon.ApiCommandSequenceExecutionsByNameEchoRoute, csc.CommandSequenceExecutionsByName, authenticationHook)
+	r.GET(pkgCommon.ApiAllCommandSequenceExecutionRoute, csc.AllCommandSequenceExecutions, authenticationHook)
+	r.GET(pkgCommon.ApiCommandSequenceExecutionByIdEchoRoute, csc.CommandSequenceExecutionById, authenticationHook)
 }
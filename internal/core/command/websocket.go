@@ -0,0 +1,101 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/labstack/echo/v4"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/command/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/command/controller/messaging"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
+)
+
+// WebSocketServer serves the WebSocket endpoint of the external command requests. It listens on its own port, since
+// the common middlewares of the REST API time the requests out and don't support hijacking the connection.
+type WebSocketServer struct {
+	requestTimeout time.Duration
+}
+
+// NewWebSocketServer is a factory method that returns an initialized WebSocketServer receiver struct.
+func NewWebSocketServer(requestTimeout time.Duration) *WebSocketServer {
+	return &WebSocketServer{
+		requestTimeout: requestTimeout,
+	}
+}
+
+// BootstrapHandler fulfills the BootstrapHandler contract. It subscribes the device events streamed to the WebSocket
+// connections and starts the WebSocket server, which is shut down along with its connections when ctx is done.
+func (b *WebSocketServer) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	config := container.ConfigurationFrom(dic.Get)
+
+	if config.WebSocket.Port == 0 {
+		lc.Warn("WebSocket endpoint is disabled since WebSocket.Port is 0")
+		return true
+	}
+	if err := messaging.SubscribeWebSocketEvents(ctx, dic); err != nil {
+		lc.Errorf("Failed to subscribe device events from internal message bus, %v", err)
+		return false
+	}
+
+	router := echo.New()
+	router.HideBanner = true
+	router.HidePort = true
+	// the upgrade requests are authenticated like the REST API unless the security or the JWT validation is disabled
+	authenticationHook := messaging.WebSocketAuthenticationFunc(identity.IsJWTValidationEnabled(), dic)
+	wc := messaging.NewWebSocketController(b.requestTimeout, dic)
+	router.GET(pkgCommon.ApiWebSocketRoute, wc.Connect, authenticationHook)
+
+	// the WebSocket endpoint is bound to the same address as the REST API
+	host := config.Service.ServerBindAddr
+	if host == "" {
+		host = config.Service.Host
+	}
+	addr := net.JoinHostPort(host, strconv.Itoa(config.WebSocket.Port))
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		lc.Errorf("WebSocket server failed to listen on %s: %v", addr, err)
+		return false
+	}
+	server := &http.Server{
+		Handler:           router,
+		ReadHeaderTimeout: 5 * time.Second, // averts a potential Slowloris Attack
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		<-ctx.Done()
+		_ = server.Shutdown(context.Background())
+		messaging.CloseWebSocketConnections()
+		lc.Info("WebSocket server shut down")
+	}()
+
+	lc.Infof("WebSocket server starting (%s)", addr)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		if err := server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			lc.Errorf("WebSocket server failed: %v", err)
+		}
+	}()
+
+	return true
+}
@@ -37,12 +37,14 @@ type CoreDataApp struct {
 	lc                       logger.LoggingClient
 	eventsPersistedCounter   gometrics.Counter
 	readingsPersistedCounter gometrics.Counter
+	deviceLifecycles         *deviceLifecycleCache
 }
 
 // NewCoreDataApp create a new initialized Core Data application
 func NewCoreDataApp(dic *di.Container) *CoreDataApp {
 	app := &CoreDataApp{
-		lc: bootstrapContainer.LoggingClientFrom(dic.Get),
+		lc:               bootstrapContainer.LoggingClientFrom(dic.Get),
+		deviceLifecycles: newDeviceLifecycleCache(),
 	}
 
 	app.eventsPersistedCounter = gometrics.NewCounter()
@@ -0,0 +1,126 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/data/container"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// deviceLifecycleCacheTTL defines how long a lifecycle state is trusted before querying core-metadata again. The
+// DECOMMISSIONED state expires as well, since the device may be deleted and created again under the same name.
+const deviceLifecycleCacheTTL = 30 * time.Second
+
+// deviceLifecycleFailureTTL defines how long a failed query of the lifecycle state is cached, so that the events of the
+// device don't query an unavailable core-metadata again one after another
+const deviceLifecycleFailureTTL = 5 * time.Second
+
+// deviceLifecycleWarningInterval is the minimum interval between two warnings of the skipped lifecycle checks
+const deviceLifecycleWarningInterval = time.Minute
+
+type deviceLifecycleEntry struct {
+	decommissioned bool
+	// err is the failure of the query of the lifecycle state
+	err    errors.EdgeX
+	expiry time.Time
+}
+
+// deviceLifecycleCache caches the lifecycle states of devices to avoid querying core-metadata for every event
+type deviceLifecycleCache struct {
+	mutex   sync.RWMutex
+	entries map[string]deviceLifecycleEntry
+	// lastWarning and skipped rate-limit the warnings of the skipped lifecycle checks
+	lastWarning time.Time
+	skipped     int
+}
+
+func newDeviceLifecycleCache() *deviceLifecycleCache {
+	return &deviceLifecycleCache{entries: make(map[string]deviceLifecycleEntry)}
+}
+
+func (c *deviceLifecycleCache) get(deviceName string) (deviceLifecycleEntry, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	entry, ok := c.entries[deviceName]
+	if !ok || time.Now().After(entry.expiry) {
+		return entry, false
+	}
+	return entry, true
+}
+
+func (c *deviceLifecycleCache) set(deviceName string, decommissioned bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries[deviceName] = deviceLifecycleEntry{decommissioned: decommissioned, expiry: time.Now().Add(deviceLifecycleCacheTTL)}
+}
+
+func (c *deviceLifecycleCache) setFailure(deviceName string, err errors.EdgeX) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries[deviceName] = deviceLifecycleEntry{err: err, expiry: time.Now().Add(deviceLifecycleFailureTTL)}
+}
+
+// skip counts a skipped lifecycle check, and returns whether it's due to be warned along with the number of checks
+// skipped since the last warning
+func (c *deviceLifecycleCache) skip() (warn bool, skipped int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.skipped++
+	if time.Since(c.lastWarning) < deviceLifecycleWarningInterval {
+		return false, 0
+	}
+	skipped = c.skipped
+	c.skipped = 0
+	c.lastWarning = time.Now()
+	return true, skipped
+}
+
+// validateDeviceLifecycle rejects the events of decommissioned devices. The check is skipped when core-metadata
+// client is not configured. When the device can't be queried from core-metadata, the event is rejected if
+// Writable.DeviceLifecycle.FailClosed is set, otherwise the check is skipped so that the data ingestion does not
+// depend on core-metadata availability. The failed query is cached for a short while, and the skipped checks are
+// warned at most once per minute.
+func (a *CoreDataApp) validateDeviceLifecycle(deviceName string, ctx context.Context, dic *di.Container) errors.EdgeX {
+	dc := bootstrapContainer.DeviceClientFrom(dic.Get)
+	if dc == nil {
+		return nil
+	}
+
+	entry, ok := a.deviceLifecycles.get(deviceName)
+	if !ok {
+		res, err := dc.DeviceByName(ctx, deviceName)
+		if err != nil {
+			entry.err = err
+			a.deviceLifecycles.setFailure(deviceName, err)
+		} else {
+			entry.decommissioned = pkgModels.DeviceLifecycleState(res.Device.Properties) == pkgModels.Decommissioned
+			a.deviceLifecycles.set(deviceName, entry.decommissioned)
+		}
+	}
+
+	if entry.err != nil {
+		if container.ConfigurationFrom(dic.Get).Writable.DeviceLifecycle.FailClosed {
+			return errors.NewCommonEdgeX(errors.KindServiceUnavailable, fmt.Sprintf("failed to query the lifecycle state of device '%s', event is rejected", deviceName), entry.err)
+		}
+		if warn, skipped := a.deviceLifecycles.skip(); warn {
+			a.lc.Warnf("skipped %d device lifecycle checks since the last warning, failed to query device '%s': %v", skipped, deviceName, entry.err)
+		}
+		return nil
+	}
+	if entry.decommissioned {
+		return errors.NewCommonEdgeX(errors.KindStatusConflict, fmt.Sprintf("device '%s' is decommissioned, event is rejected", deviceName), nil)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/secret"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	clientUtils "github.com/edgexfoundry/go-mod-core-contracts/v3/clients/http/utils"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/data/container"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// deviceQueryPageSize is the number of devices queried from core-metadata at a time, which must not exceed the
+// MaxResultCount of core-metadata
+const deviceQueryPageSize = 1000
+
+// deviceNamesByLocation queries the devices located by the location query parameters from the location query route of
+// core-metadata, page by page, and returns their names
+func deviceNamesByLocation(route string, params url.Values, ctx context.Context, dic *di.Container) ([]string, errors.EdgeX) {
+	clientInfo, ok := container.ConfigurationFrom(dic.Get).Clients[common.CoreMetaDataServiceKey]
+	if !ok || clientInfo == nil {
+		return nil, errors.NewCommonEdgeX(errors.KindServerError, "core-metadata client is not configured", nil)
+	}
+	secretProvider := bootstrapContainer.SecretProviderExtFrom(dic.Get)
+	if secretProvider == nil {
+		return nil, errors.NewCommonEdgeX(errors.KindServerError, "nil SecretProviderExt returned", nil)
+	}
+	authInjector := secret.NewJWTSecretProvider(secretProvider)
+
+	var names []string
+	for offset := 0; ; offset += deviceQueryPageSize {
+		params.Set(common.Offset, strconv.Itoa(offset))
+		params.Set(common.Limit, strconv.Itoa(deviceQueryPageSize))
+		var res responses.MultiDevicesResponse
+		err := clientUtils.GetRequest(ctx, &res, clientInfo.Url(), route, params, authInjector)
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.Kind(err), "failed to query devices by location from core-metadata", err)
+		}
+		for _, d := range res.Devices {
+			names = append(names, d.Name)
+		}
+		if len(res.Devices) < deviceQueryPageSize || offset+len(res.Devices) >= int(res.TotalCount) {
+			return names, nil
+		}
+	}
+}
+
+func formatCoordinate(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// readingsByDeviceNamesAndTimeRange query the readings of the devices with offset, limit and time range
+func readingsByDeviceNamesAndTimeRange(deviceNames []string, start, end, offset, limit int, dic *di.Container) (readings []dtos.BaseReading, totalCount uint32, err errors.EdgeX) {
+	if len(deviceNames) == 0 {
+		return []dtos.BaseReading{}, 0, nil
+	}
+	readingModels, totalCount, err := container.DBClientFrom(dic.Get).ReadingsByDeviceNamesAndTimeRange(deviceNames, start, end, offset, limit)
+	if err == nil {
+		readings, err = convertReadingModelsToDTOs(readingModels)
+	}
+	if err != nil {
+		return readings, totalCount, errors.NewCommonEdgeXWrapper(err)
+	}
+	return readings, totalCount, nil
+}
+
+// ReadingsByGeoCircle query the readings of the devices located within the circle with offset, limit and time range
+func ReadingsByGeoCircle(circle pkgModels.GeoCircle, start, end, offset, limit int, ctx context.Context, dic *di.Container) (readings []dtos.BaseReading, totalCount uint32, err errors.EdgeX) {
+	params := url.Values{}
+	params.Set(pkgCommon.Latitude, formatCoordinate(circle.Latitude))
+	params.Set(pkgCommon.Longitude, formatCoordinate(circle.Longitude))
+	params.Set(pkgCommon.Radius, formatCoordinate(circle.Radius))
+	deviceNames, err := deviceNamesByLocation(pkgCommon.ApiDeviceByLocationRadiusRoute, params, ctx, dic)
+	if err != nil {
+		return readings, totalCount, errors.NewCommonEdgeXWrapper(err)
+	}
+	return readingsByDeviceNamesAndTimeRange(deviceNames, start, end, offset, limit, dic)
+}
+
+// ReadingsByGeoBoundingBox query the readings of the devices located within the bounding box with offset, limit and
+// time range
+func ReadingsByGeoBoundingBox(box pkgModels.GeoBoundingBox, start, end, offset, limit int, ctx context.Context, dic *di.Container) (readings []dtos.BaseReading, totalCount uint32, err errors.EdgeX) {
+	params := url.Values{}
+	params.Set(pkgCommon.MinLatitude, formatCoordinate(box.MinLatitude))
+	params.Set(pkgCommon.MinLongitude, formatCoordinate(box.MinLongitude))
+	params.Set(pkgCommon.MaxLatitude, formatCoordinate(box.MaxLatitude))
+	params.Set(pkgCommon.MaxLongitude, formatCoordinate(box.MaxLongitude))
+	deviceNames, err := deviceNamesByLocation(pkgCommon.ApiDeviceByLocationBoundingBoxRoute, params, ctx, dic)
+	if err != nil {
+		return readings, totalCount, errors.NewCommonEdgeXWrapper(err)
+	}
+	return readingsByDeviceNamesAndTimeRange(deviceNames, start, end, offset, limit, dic)
+}
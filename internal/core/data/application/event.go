@@ -45,6 +45,11 @@ func (a *CoreDataApp) ValidateEvent(e models.Event, profileName string, deviceNa
 // The AddEvent function accepts the new event model from the controller functions
 // and invokes addEvent function in the infrastructure layer
 func (a *CoreDataApp) AddEvent(e models.Event, ctx context.Context, dic *di.Container) (err errors.EdgeX) {
+	err = a.validateDeviceLifecycle(e.DeviceName, ctx, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
 	configuration := container.ConfigurationFrom(dic.Get)
 	if !configuration.Writable.PersistData {
 		return nil
@@ -9,6 +9,7 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -19,9 +20,15 @@ import (
 	"github.com/edgexfoundry/edgex-go/internal/core/data/container"
 	dbMock "github.com/edgexfoundry/edgex-go/internal/core/data/infrastructure/interfaces/mocks"
 	"github.com/edgexfoundry/edgex-go/internal/core/data/mocks"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	clientMocks "github.com/edgexfoundry/go-mod-core-contracts/v3/clients/interfaces/mocks"
+	loggerMocks "github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger/mocks"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
 )
@@ -471,3 +478,109 @@ func TestDeleteEventsByAge(t *testing.T) {
 	err := app.DeleteEventsByAge(0, dic)
 	require.NoError(t, err)
 }
+
+func TestAddEventOfDecommissionedDevice(t *testing.T) {
+	evt := persistedEvent
+	decommissionedDevice := "decommissionedDevice"
+	evt.DeviceName = decommissionedDevice
+
+	dcMock := &clientMocks.DeviceClient{}
+	dcMock.On("DeviceByName", context.Background(), testDeviceName).Return(responses.DeviceResponse{
+		Device: dtos.Device{Name: testDeviceName, Properties: map[string]any{pkgModels.DeviceLifecycleStateProperty: pkgModels.Active}},
+	}, nil)
+	dcMock.On("DeviceByName", context.Background(), decommissionedDevice).Return(responses.DeviceResponse{
+		Device: dtos.Device{Name: decommissionedDevice, Properties: map[string]any{pkgModels.DeviceLifecycleStateProperty: pkgModels.Decommissioned}},
+	}, nil).Once()
+
+	dic := mocks.NewMockDIC()
+	dic.Update(di.ServiceConstructorMap{
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Writable: config.WritableInfo{
+					PersistData: false,
+				},
+			}
+		},
+		bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+			return dcMock
+		},
+	})
+
+	app := NewCoreDataApp(dic)
+	err := app.AddEvent(persistedEvent, context.Background(), dic)
+	require.NoError(t, err)
+
+	err = app.AddEvent(evt, context.Background(), dic)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusConflict, err.Code())
+
+	// the decommissioned state is cached, core-metadata is only queried once
+	err = app.AddEvent(evt, context.Background(), dic)
+	require.Error(t, err)
+	dcMock.AssertExpectations(t)
+
+	// the device is created again under the same name once the cached state expires
+	dcMock.On("DeviceByName", context.Background(), decommissionedDevice).Return(responses.DeviceResponse{
+		Device: dtos.Device{Name: decommissionedDevice, Properties: map[string]any{pkgModels.DeviceLifecycleStateProperty: pkgModels.Active}},
+	}, nil)
+	app.deviceLifecycles.entries[decommissionedDevice] = deviceLifecycleEntry{decommissioned: true, expiry: time.Now().Add(-time.Second)}
+	err = app.AddEvent(evt, context.Background(), dic)
+	require.NoError(t, err)
+}
+
+func TestAddEventOfUnknownDeviceLifecycle(t *testing.T) {
+	tests := []struct {
+		name               string
+		failClosed         bool
+		errorExpected      bool
+		expectedStatusCode int
+	}{
+		{"Valid - fail open", false, false, http.StatusOK},
+		{"Invalid - fail closed", true, true, http.StatusServiceUnavailable},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			dcMock := &clientMocks.DeviceClient{}
+			dcMock.On("DeviceByName", context.Background(), testDeviceName).Return(responses.DeviceResponse{},
+				errors.NewCommonEdgeX(errors.KindServerError, "core-metadata is not reachable", nil))
+			lc := &loggerMocks.LoggingClient{}
+			lc.On("Warnf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+			lc.On("Error", mock.Anything).Return()
+			dic := mocks.NewMockDIC()
+			dic.Update(di.ServiceConstructorMap{
+				container.ConfigurationName: func(get di.Get) interface{} {
+					return &config.ConfigurationStruct{
+						Writable: config.WritableInfo{
+							PersistData:     false,
+							DeviceLifecycle: config.DeviceLifecycleInfo{FailClosed: testCase.failClosed},
+						},
+					}
+				},
+				bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+					return dcMock
+				},
+				bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+					return lc
+				},
+			})
+
+			app := NewCoreDataApp(dic)
+			for i := 0; i < 3; i++ {
+				err := app.AddEvent(persistedEvent, context.Background(), dic)
+				if testCase.errorExpected {
+					require.Error(t, err)
+					assert.Equal(t, testCase.expectedStatusCode, err.Code())
+				} else {
+					require.NoError(t, err)
+				}
+			}
+			// the failed query is cached for a short while, and the skipped checks are warned once
+			dcMock.AssertNumberOfCalls(t, "DeviceByName", 1)
+			if testCase.errorExpected {
+				lc.AssertNotCalled(t, "Warnf", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				lc.AssertNumberOfCalls(t, "Warnf", 1)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ type ConfigurationStruct struct {
 	Database     bootstrapConfig.Database
 	Registry     bootstrapConfig.RegistryInfo
 	Service      bootstrapConfig.ServiceInfo
+	Clients      bootstrapConfig.ClientsCollection
 	MaxEventSize int64
 	Retention    ReadingRetention
 }
@@ -34,6 +35,14 @@ type WritableInfo struct {
 	LogLevel        string
 	InsecureSecrets bootstrapConfig.InsecureSecrets
 	Telemetry       bootstrapConfig.TelemetryInfo
+	DeviceLifecycle DeviceLifecycleInfo
+}
+
+// DeviceLifecycleInfo defines how the events are checked against the lifecycle state of their devices
+type DeviceLifecycleInfo struct {
+	// FailClosed rejects the events of the devices whose lifecycle state can't be queried from core-metadata, otherwise
+	// the events are accepted without the check
+	FailClosed bool
 }
 
 type ReadingRetention struct {
@@ -85,6 +94,7 @@ func (c *ConfigurationStruct) GetBootstrap() bootstrapConfig.BootstrapConfigurat
 		Registry:   &c.Registry,
 		MessageBus: &c.MessageBus,
 		Database:   &c.Database,
+		Clients:    &c.Clients,
 	}
 }
 
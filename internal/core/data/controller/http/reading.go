@@ -293,3 +293,84 @@ func (rc *ReadingController) ReadingsByDeviceNameAndResourceNamesAndTimeRange(c
 	utils.WriteHttpHeader(w, ctx, http.StatusOK)
 	return pkg.EncodeAndWriteResponse(response, w, lc)
 }
+
+// parseLocationQueryTimeRange parses the optional start and end query strings of the location queries, all readings
+// are queried when the time range is not specified
+func parseLocationQueryTimeRange(c echo.Context) (start int, end int, err errors.EdgeX) {
+	start, err = utils.ParseQueryStringToInt(c, common.Start, 0, 0, math.MaxInt64)
+	if err != nil {
+		return start, end, err
+	}
+	end, err = utils.ParseQueryStringToInt(c, common.End, math.MaxInt64, 0, math.MaxInt64)
+	if err != nil {
+		return start, end, err
+	}
+	if end < start {
+		return start, end, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("end's value %v is not allowed to be less than start's value %v", end, start), nil)
+	}
+	return start, end, nil
+}
+
+// ReadingsByLocationRadius query the readings of the devices located within the radius in meters around the latitude
+// and longitude
+func (rc *ReadingController) ReadingsByLocationRadius(c echo.Context) error {
+	lc := container.LoggingClientFrom(rc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+	config := dataContainer.ConfigurationFrom(rc.dic.Get)
+
+	// parse URL query string for location, time range, offset and limit
+	circle, err := utils.ParseGeoCircleQueryString(c)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	start, end, err := parseLocationQueryTimeRange(c)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(c, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	readings, totalCount, err := application.ReadingsByGeoCircle(circle, start, end, offset, limit, ctx, rc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := responseDTO.NewMultiReadingsResponse("", "", http.StatusOK, totalCount, readings)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+// ReadingsByLocationBoundingBox query the readings of the devices located within the bounding box of the minimum and
+// maximum latitude and longitude
+func (rc *ReadingController) ReadingsByLocationBoundingBox(c echo.Context) error {
+	lc := container.LoggingClientFrom(rc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+	config := dataContainer.ConfigurationFrom(rc.dic.Get)
+
+	// parse URL query string for location, time range, offset and limit
+	box, err := utils.ParseGeoBoundingBoxQueryString(c)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	start, end, err := parseLocationQueryTimeRange(c)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(c, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	readings, totalCount, err := application.ReadingsByGeoBoundingBox(box, start, end, offset, limit, ctx, rc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := responseDTO.NewMultiReadingsResponse("", "", http.StatusOK, totalCount, readings)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
@@ -3,11 +3,17 @@ package http
 import (
 	"encoding/json"
 	"io"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
 	"strings"
 	"testing"
 
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	bootstrapMocks "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces/mocks"
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v3/config"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,7 +21,9 @@ import (
 	"github.com/edgexfoundry/edgex-go/internal/core/data/container"
 	dbMock "github.com/edgexfoundry/edgex-go/internal/core/data/infrastructure/interfaces/mocks"
 	"github.com/edgexfoundry/edgex-go/internal/core/data/mocks"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
 	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
 	responseDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
@@ -711,3 +719,75 @@ func TestReadingsByDeviceNameAndResourceNamesAndTimeRange(t *testing.T) {
 		})
 	}
 }
+
+func TestReadingsByLocationRadius(t *testing.T) {
+	// core-metadata returns the devices within the radius, page by page
+	var queries []url.Values
+	metadata := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, pkgCommon.ApiDeviceByLocationRadiusRoute, r.URL.Path)
+		queries = append(queries, r.URL.Query())
+		res := responseDTO.NewMultiDevicesResponse("", "", http.StatusOK, 1, []dtos.Device{{Name: TestDeviceName}})
+		w.Header().Set(common.ContentType, common.ContentTypeJSON)
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+	defer metadata.Close()
+	metadataUrl, err := url.Parse(metadata.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(metadataUrl.Port())
+	require.NoError(t, err)
+
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("ReadingsByDeviceNamesAndTimeRange", []string{TestDeviceName}, 0, math.MaxInt64, 0, 20).Return([]models.Reading{}, uint32(0), nil)
+	dbClientMock.On("ReadingsByDeviceNamesAndTimeRange", []string{TestDeviceName}, 100, 200, 0, 20).Return([]models.Reading{}, uint32(0), nil)
+	dic := mocks.NewMockDIC()
+	container.ConfigurationFrom(dic.Get).Clients = bootstrapConfig.ClientsCollection{
+		common.CoreMetaDataServiceKey: {Protocol: metadataUrl.Scheme, Host: metadataUrl.Hostname(), Port: port},
+	}
+	secretProvider := &bootstrapMocks.SecretProviderExt{}
+	secretProvider.On("GetSelfJWT").Return("", nil)
+	secretProvider.On("HttpTransport").Return(http.DefaultTransport)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+		bootstrapContainer.SecretProviderExtName: func(get di.Get) interface{} {
+			return secretProvider
+		},
+	})
+	controller := NewReadingController(dic)
+
+	tests := []struct {
+		name               string
+		query              map[string]string
+		expectedStatusCode int
+	}{
+		{"Valid - readings within 5 km", map[string]string{pkgCommon.Latitude: "25.04", pkgCommon.Longitude: "121.56", pkgCommon.Radius: "5000"}, http.StatusOK},
+		{"Valid - readings within 5 km and time range", map[string]string{pkgCommon.Latitude: "25.04", pkgCommon.Longitude: "121.56", pkgCommon.Radius: "5000", common.Start: "100", common.End: "200"}, http.StatusOK},
+		{"Invalid - radius is missing", map[string]string{pkgCommon.Latitude: "25.04", pkgCommon.Longitude: "121.56"}, http.StatusBadRequest},
+		{"Invalid - latitude out of range", map[string]string{pkgCommon.Latitude: "95", pkgCommon.Longitude: "121.56", pkgCommon.Radius: "5000"}, http.StatusBadRequest},
+		{"Invalid - end before start", map[string]string{pkgCommon.Latitude: "25.04", pkgCommon.Longitude: "121.56", pkgCommon.Radius: "5000", common.Start: "200", common.End: "100"}, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req, err := http.NewRequest(http.MethodGet, pkgCommon.ApiReadingByLocationRadiusRoute, http.NoBody)
+			require.NoError(t, err)
+			query := req.URL.Query()
+			for k, v := range testCase.query {
+				query.Add(k, v)
+			}
+			req.URL.RawQuery = query.Encode()
+
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			err = controller.ReadingsByLocationRadius(c)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+		})
+	}
+	dbClientMock.AssertExpectations(t)
+	require.NotEmpty(t, queries)
+	assert.Equal(t, "25.04", queries[0].Get(pkgCommon.Latitude))
+	assert.Equal(t, "121.56", queries[0].Get(pkgCommon.Longitude))
+	assert.Equal(t, "5000", queries[0].Get(pkgCommon.Radius))
+}
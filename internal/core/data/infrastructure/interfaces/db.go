@@ -39,6 +39,7 @@ type DBClient interface {
 	ReadingCountByTimeRange(start int, end int) (uint32, errors.EdgeX)
 	ReadingsByResourceNameAndTimeRange(resourceName string, start int, end int, offset int, limit int) ([]model.Reading, errors.EdgeX)
 	ReadingsByDeviceNameAndResourceNamesAndTimeRange(deviceName string, resourceNames []string, start, end, offset, limit int) ([]model.Reading, uint32, errors.EdgeX)
+	ReadingsByDeviceNamesAndTimeRange(deviceNames []string, start, end, offset, limit int) ([]model.Reading, uint32, errors.EdgeX)
 	ReadingsByDeviceNameAndTimeRange(deviceName string, start int, end int, offset int, limit int) ([]model.Reading, errors.EdgeX)
 	ReadingCountByDeviceNameAndTimeRange(deviceName string, start int, end int) (uint32, errors.EdgeX)
 	LatestReadingByOffset(offset uint32) (model.Reading, errors.EdgeX)
@@ -665,6 +665,41 @@ func (_m *DBClient) ReadingsByDeviceNameAndResourceNamesAndTimeRange(deviceName
 	return r0, r1, r2
 }
 
+// ReadingsByDeviceNamesAndTimeRange provides a mock function with given fields: deviceNames, start, end, offset, limit
+func (_m *DBClient) ReadingsByDeviceNamesAndTimeRange(deviceNames []string, start int, end int, offset int, limit int) ([]models.Reading, uint32, errors.EdgeX) {
+	ret := _m.Called(deviceNames, start, end, offset, limit)
+
+	var r0 []models.Reading
+	var r1 uint32
+	var r2 errors.EdgeX
+	if rf, ok := ret.Get(0).(func([]string, int, int, int, int) ([]models.Reading, uint32, errors.EdgeX)); ok {
+		return rf(deviceNames, start, end, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func([]string, int, int, int, int) []models.Reading); ok {
+		r0 = rf(deviceNames, start, end, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Reading)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string, int, int, int, int) uint32); ok {
+		r1 = rf(deviceNames, start, end, offset, limit)
+	} else {
+		r1 = ret.Get(1).(uint32)
+	}
+
+	if rf, ok := ret.Get(2).(func([]string, int, int, int, int) errors.EdgeX); ok {
+		r2 = rf(deviceNames, start, end, offset, limit)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1, r2
+}
+
 // ReadingsByDeviceNameAndTimeRange provides a mock function with given fields: deviceName, start, end, offset, limit
 func (_m *DBClient) ReadingsByDeviceNameAndTimeRange(deviceName string, start int, end int, offset int, limit int) ([]models.Reading, errors.EdgeX) {
 	ret := _m.Called(deviceName, start, end, offset, limit)
@@ -73,6 +73,7 @@ func Main(ctx context.Context, cancel context.CancelFunc, router *echo.Echo) {
 		bootstrapConfig.ServiceTypeOther,
 		[]interfaces.BootstrapHandler{
 			pkgHandlers.NewDatabase(httpServer, configuration, container.DBClientInterfaceName).BootstrapHandler, // add db client bootstrap handler
+			handlers.NewClientsBootstrap().BootstrapHandler,
 			handlers.MessagingBootstrapHandler,
 			handlers.NewServiceMetrics(common.CoreDataServiceKey).BootstrapHandler, // Must be after Messaging
 			application.BootstrapHandler,                                           // Must be after Service Metrics and before next handler
@@ -15,6 +15,7 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
 
 	dataController "github.com/edgexfoundry/edgex-go/internal/core/data/controller/http"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
 
 	"github.com/labstack/echo/v4"
 )
@@ -52,4 +53,6 @@ func LoadRestRoutes(r *echo.Echo, dic *di.Container, serviceName string) {
 	r.GET(common.ApiReadingByDeviceNameAndResourceNameEchoRoute, rc.ReadingsByDeviceNameAndResourceName, authenticationHook)
 	r.GET(common.ApiReadingByDeviceNameAndResourceNameAndTimeRangeEchoRoute, rc.ReadingsByDeviceNameAndResourceNameAndTimeRange, authenticationHook)
 	r.GET(common.ApiReadingByDeviceNameAndTimeRangeEchoRoute, rc.ReadingsByDeviceNameAndResourceNamesAndTimeRange, authenticationHook)
+	r.GET(pkgCommon.ApiReadingByLocationRadiusRoute, rc.ReadingsByLocationRadius, authenticationHook)
+	r.GET(pkgCommon.ApiReadingByLocationBoundingBoxRoute, rc.ReadingsByLocationBoundingBox, authenticationHook)
 }
@@ -33,6 +33,7 @@ import (
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/infrastructure/interfaces"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
 )
 
@@ -42,6 +43,11 @@ const minAutoEventInterval = 1 * time.Millisecond
 // The AddDevice function accepts the new device model from the controller function
 // and then invokes AddDevice function of infrastructure layer to add new device
 func AddDevice(d models.Device, ctx context.Context, dic *di.Container, bypassValidation bool) (id string, edgeXerr errors.EdgeX) {
+	return addDevice(d, ctx, dic, bypassValidation, container.DBClientFrom(dic.Get).AddDevice)
+}
+
+// addDevice validates the device and stores it with add
+func addDevice(d models.Device, ctx context.Context, dic *di.Container, bypassValidation bool, add func(models.Device) (models.Device, errors.EdgeX)) (id string, edgeXerr errors.EdgeX) {
 	dbClient := container.DBClientFrom(dic.Get)
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 
@@ -53,7 +59,27 @@ func AddDevice(d models.Device, ctx context.Context, dic *di.Container, bypassVa
 		return id, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("device service '%s' does not exists", d.ServiceName), nil)
 	}
 
-	err := validateAutoEvent(dic, d)
+	err := validateInitialDeviceLifecycleState(&d)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	err = validateAutoEvent(dic, d)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	err = validateDeviceResourceOverrides(dic, d)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	err = validateDeviceLocation(d)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	err = validateDeviceSecretReferences(dic, d)
 	if err != nil {
 		return "", errors.NewCommonEdgeXWrapper(err)
 	}
@@ -67,7 +93,7 @@ func AddDevice(d models.Device, ctx context.Context, dic *di.Container, bypassVa
 		}
 	}
 
-	addedDevice, err := dbClient.AddDevice(d)
+	addedDevice, err := add(d)
 	if err != nil {
 		return "", errors.NewCommonEdgeXWrapper(err)
 	}
@@ -78,6 +104,10 @@ func AddDevice(d models.Device, ctx context.Context, dic *di.Container, bypassVa
 		correlation.FromContext(ctx),
 	)
 
+	if _, ok := d.Properties[pkgModels.DeviceLifecycleStateProperty]; ok {
+		recordDeviceLifecycleAudit(d.Name, "", pkgModels.DeviceLifecycleState(d.Properties), ctx, dic)
+	}
+
 	// If device is successfully created, check each AutoEvent interval value and display a warning if it's smaller than the suggested 10ms value
 	for _, autoEvent := range d.AutoEvents {
 		utils.CheckMinInterval(autoEvent.Interval, minAutoEventInterval, lc)
@@ -95,11 +125,21 @@ func DeleteDeviceByName(name string, ctx context.Context, dic *di.Container) err
 		return errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
 	}
 	dbClient := container.DBClientFrom(dic.Get)
+	unlock, err := checkMetadataRevision(common.DeviceSystemEventType, name, ctx, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	defer unlock()
+
 	device, err := dbClient.DeviceByName(name)
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
 	}
-	err = dbClient.DeleteDeviceByName(name)
+	if trashRetention(dic) > 0 {
+		err = dbClient.TrashDeviceByName(name)
+	} else {
+		err = dbClient.DeleteDeviceByName(name)
+	}
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
 	}
@@ -125,7 +165,7 @@ func DevicesByServiceName(offset int, limit int, name string, ctx context.Contex
 	}
 	devices = make([]dtos.Device, len(deviceModels))
 	for i, d := range deviceModels {
-		devices[i] = dtos.FromDeviceModelToDTO(d)
+		devices[i] = redactDeviceSecrets(dic, d)
 	}
 	return devices, totalCount, nil
 }
@@ -158,6 +198,15 @@ func PatchDevice(dto dtos.UpdateDevice, ctx context.Context, dic *di.Container,
 		}
 	}
 
+	// the device is locked before it's read, so that neither a concurrent patch nor the OperatingState written by the
+	// device service health probing is overwritten with the stale fields read before
+	name, err := deviceNameByDTO(dbClient, dto)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	unlock := lockMetadataEntity(common.DeviceSystemEventType + ":" + name)
+	defer unlock()
+
 	device, err := deviceByDTO(dbClient, dto)
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
@@ -169,13 +218,44 @@ func PatchDevice(dto dtos.UpdateDevice, ctx context.Context, dic *di.Container,
 		oldServiceName = device.ServiceName
 	}
 
+	oldDevice := device
 	requests.ReplaceDeviceModelFieldsWithDTO(&device, dto)
+	carryOverReservedDeviceProperties(oldDevice, &device)
+
+	fromState, toState, err := applyDeviceLifecycleTransition(oldDevice, &device)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
+	// the OperatingState patched explicitly isn't set back to UP by the device service health probing
+	if dto.OperatingState != nil {
+		clearDeviceServiceDown(&device)
+	}
 
 	err = validateAutoEvent(dic, device)
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
 	}
 
+	err = validateDeviceResourceOverrides(dic, device)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
+	err = validateDeviceLocation(device)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
+	// only the patched protocols are validated, so that the devices holding plain text credentials stored before can
+	// still be patched until their protocols are replaced with the secret references
+	if dto.Protocols != nil {
+		err = validateDeviceSecretReferences(dic, device)
+		if err != nil {
+			return errors.NewCommonEdgeXWrapper(err)
+		}
+	}
+
 	deviceDTO := dtos.FromDeviceModelToDTO(device)
 
 	// Execute the Device Service Validation when bypassValidation is false by default
@@ -187,6 +267,11 @@ func PatchDevice(dto dtos.UpdateDevice, ctx context.Context, dic *di.Container,
 		}
 	}
 
+	err = matchMetadataRevision(common.DeviceSystemEventType, device.Name, ctx, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
 	err = dbClient.UpdateDevice(device)
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
@@ -202,6 +287,10 @@ func PatchDevice(dto dtos.UpdateDevice, ctx context.Context, dic *di.Container,
 		correlation.FromContext(ctx),
 	)
 
+	if fromState != toState {
+		recordDeviceLifecycleAudit(device.Name, fromState, toState, ctx, dic)
+	}
+
 	if oldServiceName != "" {
 		go publishSystemEvent(common.DeviceSystemEventType, common.SystemEventActionUpdate, oldServiceName, deviceDTO, ctx, dic)
 	}
@@ -211,6 +300,18 @@ func PatchDevice(dto dtos.UpdateDevice, ctx context.Context, dic *di.Container,
 	return nil
 }
 
+// deviceNameByDTO returns the name of the device to patch, which is queried by ID when the DTO carries no name
+func deviceNameByDTO(dbClient interfaces.DBClient, dto dtos.UpdateDevice) (string, errors.EdgeX) {
+	if dto.Name != nil && *dto.Name != "" {
+		return *dto.Name, nil
+	}
+	device, edgeXerr := deviceByDTO(dbClient, dto)
+	if edgeXerr != nil {
+		return "", errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return device.Name, nil
+}
+
 func deviceByDTO(dbClient interfaces.DBClient, dto dtos.UpdateDevice) (device models.Device, edgeXerr errors.EdgeX) {
 	// The ID or Name is required by DTO and the DTO also accepts empty string ID if the Name is provided
 	if dto.Id != nil && *dto.Id != "" {
@@ -242,7 +343,7 @@ func AllDevices(offset int, limit int, labels []string, dic *di.Container) (devi
 	}
 	devices = make([]dtos.Device, len(deviceModels))
 	for i, d := range deviceModels {
-		devices[i] = dtos.FromDeviceModelToDTO(d)
+		devices[i] = redactDeviceSecrets(dic, d)
 	}
 	return devices, totalCount, nil
 }
@@ -257,7 +358,7 @@ func DeviceByName(name string, dic *di.Container) (device dtos.Device, err error
 	if err != nil {
 		return device, errors.NewCommonEdgeXWrapper(err)
 	}
-	device = dtos.FromDeviceModelToDTO(d)
+	device = redactDeviceSecrets(dic, d)
 	return device, nil
 }
 
@@ -276,7 +377,7 @@ func DevicesByProfileName(offset int, limit int, profileName string, dic *di.Con
 	}
 	devices = make([]dtos.Device, len(deviceModels))
 	for i, d := range deviceModels {
-		devices[i] = dtos.FromDeviceModelToDTO(d)
+		devices[i] = redactDeviceSecrets(dic, d)
 	}
 	return devices, totalCount, nil
 }
@@ -306,3 +407,34 @@ func validateAutoEvent(dic *di.Container, d models.Device) errors.EdgeX {
 	}
 	return nil
 }
+
+// validateDeviceResourceOverrides checks the resource overrides of the device against the device resources of its
+// profile, the overridden units are also validated when the UoM validation is enabled
+func validateDeviceResourceOverrides(dic *di.Container, d models.Device) errors.EdgeX {
+	overrides, err := pkgModels.DeviceResourceOverrides(d.Properties)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("device '%s' resource overrides are invalid", d.Name), err)
+	}
+	if len(overrides) == 0 {
+		return nil
+	}
+
+	dp, edgeXerr := container.DBClientFrom(dic.Get).DeviceProfileByName(d.ProfileName)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("device profile '%s' not found during validating device '%s' resource overrides", d.ProfileName, d.Name), edgeXerr)
+	}
+	uomValidation := container.ConfigurationFrom(dic.Get).Writable.UoM.Validation
+	for name, o := range overrides {
+		r, edgeXerr := resourceByName(dp.DeviceResources, name)
+		if edgeXerr != nil {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("overridden resource '%s' cannot be found in the device profile '%s'", name, dp.Name), nil)
+		}
+		if err = pkgModels.ValidateResourceOverride(r, o); err != nil {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("device '%s' resource override is invalid", d.Name), err)
+		}
+		if uomValidation && o.Units != nil && !container.UnitsOfMeasureFrom(dic.Get).Validate(*o.Units) {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("device '%s' overridden resource '%s' units %s is invalid", d.Name, name, *o.Units), nil)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+type lifecycleReasonKey struct{}
+
+// NewLifecycleReasonContext returns a copy of the context carrying the reason of the device lifecycle transitions
+func NewLifecycleReasonContext(ctx context.Context, reason string) context.Context {
+	return context.WithValue(ctx, lifecycleReasonKey{}, reason)
+}
+
+func lifecycleReasonFromContext(ctx context.Context) string {
+	reason, _ := ctx.Value(lifecycleReasonKey{}).(string)
+	return reason
+}
+
+// normalizeDeviceLifecycleState upper-cases the lifecycle state stored in the device properties and returns it
+func normalizeDeviceLifecycleState(d *models.Device) string {
+	state := pkgModels.DeviceLifecycleState(d.Properties)
+	if _, ok := d.Properties[pkgModels.DeviceLifecycleStateProperty]; ok {
+		d.Properties[pkgModels.DeviceLifecycleStateProperty] = state
+	}
+	return state
+}
+
+// validateInitialDeviceLifecycleState checks the lifecycle state of a new device, a device can only be created as
+// PROVISIONED, COMMISSIONED or ACTIVE
+func validateInitialDeviceLifecycleState(d *models.Device) errors.EdgeX {
+	state := normalizeDeviceLifecycleState(d)
+	switch state {
+	case pkgModels.Provisioned, pkgModels.Commissioned, pkgModels.Active:
+		return nil
+	default:
+		return errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("device '%s' can not be created with lifecycle state '%s'", d.Name, state), nil)
+	}
+}
+
+// carryOverReservedDeviceProperties keeps the reserved properties of the device which the patched properties omit, so
+// that replacing the properties doesn't drop the lifecycle state, the link to the device template or the resource
+// overrides. The mark of the device service health probing is always carried over, since it's only written by the
+// health probing itself.
+func carryOverReservedDeviceProperties(oldDevice models.Device, d *models.Device) {
+	reserved := []string{
+		pkgModels.DeviceLifecycleStateProperty,
+		pkgModels.DeviceTemplateNameProperty,
+		pkgModels.DeviceTemplateParametersProperty,
+		pkgModels.DeviceResourceOverridesProperty,
+	}
+	for _, key := range reserved {
+		if _, ok := d.Properties[key]; ok {
+			continue
+		}
+		if value, existed := oldDevice.Properties[key]; existed {
+			if d.Properties == nil {
+				d.Properties = make(map[string]any)
+			}
+			d.Properties[key] = value
+		}
+	}
+
+	if down, existed := oldDevice.Properties[pkgModels.DeviceServiceDownProperty]; existed {
+		if d.Properties == nil {
+			d.Properties = make(map[string]any)
+		}
+		d.Properties[pkgModels.DeviceServiceDownProperty] = down
+	} else if _, ok := d.Properties[pkgModels.DeviceServiceDownProperty]; ok {
+		delete(d.Properties, pkgModels.DeviceServiceDownProperty)
+	}
+}
+
+// applyDeviceLifecycleTransition validates the lifecycle transition of the patched device and returns the old and new states.
+// The patched device must carry over the old state when its properties omit it, and a decommissioned device is locked.
+func applyDeviceLifecycleTransition(oldDevice models.Device, d *models.Device) (from string, to string, edgeXerr errors.EdgeX) {
+	from = pkgModels.DeviceLifecycleState(oldDevice.Properties)
+	to = normalizeDeviceLifecycleState(d)
+	if err := pkgModels.ValidateDeviceLifecycleTransition(from, to); err != nil {
+		return from, to, errors.NewCommonEdgeX(errors.KindStatusConflict, fmt.Sprintf("device '%s' lifecycle update failed", d.Name), err)
+	}
+	if to == pkgModels.Decommissioned {
+		d.AdminState = models.Locked
+	}
+	return from, to, nil
+}
+
+// recordDeviceLifecycleAudit appends an audit record for the device lifecycle transition, the failure is only logged
+// because the device itself has already been persisted
+func recordDeviceLifecycleAudit(deviceName, from, to string, ctx context.Context, dic *di.Container) {
+	dbClient := container.DBClientFrom(dic.Get)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	audit := pkgModels.DeviceLifecycleAudit{
+		DeviceName: deviceName,
+		FromState:  from,
+		ToState:    to,
+		Actor:      identity.FromContext(ctx),
+		Reason:     strings.TrimSpace(lifecycleReasonFromContext(ctx)),
+	}
+	if _, err := dbClient.AddDeviceLifecycleAudit(audit); err != nil {
+		lc.Errorf("failed to record the lifecycle transition of device '%s' from '%s' to '%s': %v", deviceName, from, to, err)
+	}
+}
+
+// AllDeviceLifecycleAudits query the device lifecycle audits with offset and limit
+func AllDeviceLifecycleAudits(offset int, limit int, dic *di.Container) (audits []pkgDtos.DeviceLifecycleAudit, totalCount uint32, err errors.EdgeX) {
+	dbClient := container.DBClientFrom(dic.Get)
+	auditModels, err := dbClient.AllDeviceLifecycleAudits(offset, limit)
+	if err == nil {
+		totalCount, err = dbClient.DeviceLifecycleAuditTotalCount()
+	}
+	if err != nil {
+		return audits, totalCount, errors.NewCommonEdgeXWrapper(err)
+	}
+	return pkgDtos.FromDeviceLifecycleAuditModelsToDTOs(auditModels), totalCount, nil
+}
+
+// DeviceLifecycleAuditsByDeviceName query the device lifecycle audits with offset, limit and device name
+func DeviceLifecycleAuditsByDeviceName(offset int, limit int, name string, dic *di.Container) (audits []pkgDtos.DeviceLifecycleAudit, totalCount uint32, err errors.EdgeX) {
+	if name == "" {
+		return audits, totalCount, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := container.DBClientFrom(dic.Get)
+	auditModels, err := dbClient.DeviceLifecycleAuditsByDeviceName(offset, limit, name)
+	if err == nil {
+		totalCount, err = dbClient.DeviceLifecycleAuditCountByDeviceName(name)
+	}
+	if err != nil {
+		return audits, totalCount, errors.NewCommonEdgeXWrapper(err)
+	}
+	return pkgDtos.FromDeviceLifecycleAuditModelsToDTOs(auditModels), totalCount, nil
+}
@@ -0,0 +1,52 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"fmt"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// validateDeviceLocation validates the structured location of the device, the free text locations are kept as is
+func validateDeviceLocation(d models.Device) errors.EdgeX {
+	if _, _, err := pkgModels.ParseDeviceLocation(d.Location); err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("device '%s' location is invalid", d.Name), err)
+	}
+	return nil
+}
+
+// DevicesByGeoCircle query the devices located within the circle with offset and limit, nearest first
+func DevicesByGeoCircle(circle pkgModels.GeoCircle, offset int, limit int, dic *di.Container) (devices []dtos.Device, totalCount uint32, err errors.EdgeX) {
+	deviceModels, totalCount, err := container.DBClientFrom(dic.Get).DevicesByGeoCircle(circle, offset, limit)
+	if err != nil {
+		return devices, totalCount, errors.NewCommonEdgeXWrapper(err)
+	}
+	devices = make([]dtos.Device, len(deviceModels))
+	for i, d := range deviceModels {
+		devices[i] = redactDeviceSecrets(dic, d)
+	}
+	return devices, totalCount, nil
+}
+
+// DevicesByGeoBoundingBox query the devices located within the bounding box with offset and limit
+func DevicesByGeoBoundingBox(box pkgModels.GeoBoundingBox, offset int, limit int, dic *di.Container) (devices []dtos.Device, totalCount uint32, err errors.EdgeX) {
+	deviceModels, totalCount, err := container.DBClientFrom(dic.Get).DevicesByGeoBoundingBox(box, offset, limit)
+	if err != nil {
+		return devices, totalCount, errors.NewCommonEdgeXWrapper(err)
+	}
+	devices = make([]dtos.Device, len(deviceModels))
+	for i, d := range deviceModels {
+		devices[i] = redactDeviceSecrets(dic, d)
+	}
+	return devices, totalCount, nil
+}
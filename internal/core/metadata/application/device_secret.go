@@ -0,0 +1,92 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// validateDeviceSecretReferences validates the secret references in the protocol properties of the device, and
+// rejects the secret protocol properties holding a credential in plain text, so that only the references are stored
+func validateDeviceSecretReferences(dic *di.Container, d models.Device) errors.EdgeX {
+	secretProperties := container.ConfigurationFrom(dic.Get).Writable.ProtocolSecrets.Properties
+	for protocol, properties := range d.Protocols {
+		for name, value := range properties {
+			_, isReference, err := pkgModels.ParseSecretReference(value)
+			if err != nil {
+				return errors.NewCommonEdgeX(errors.KindContractInvalid,
+					fmt.Sprintf("device '%s' protocol '%s' property '%s' is an invalid secret reference", d.Name, protocol, name), err)
+			}
+			if !isReference && pkgModels.IsSecretProtocolProperty(name, secretProperties) {
+				return errors.NewCommonEdgeX(errors.KindContractInvalid,
+					fmt.Sprintf("device '%s' protocol '%s' property '%s' must be a secret reference specifying the secretName and secretKey", d.Name, protocol, name), nil)
+			}
+		}
+	}
+	return nil
+}
+
+// redactDeviceSecrets transforms the device to the DTO where the plain text values of the secret protocol properties
+// are redacted, which is used for every device returned in the responses
+func redactDeviceSecrets(dic *di.Container, d models.Device) dtos.Device {
+	dto := dtos.FromDeviceModelToDTO(d)
+	dto.Protocols = redactProtocolSecrets(dic, dto.Protocols)
+	return dto
+}
+
+// redactDeviceTemplateSecrets transforms the device template to the DTO where the plain text values of the secret
+// protocol properties of its device are redacted, which is used for every device template returned in the responses
+func redactDeviceTemplateSecrets(dic *di.Container, t pkgModels.DeviceTemplate) pkgDtos.DeviceTemplate {
+	dto := pkgDtos.FromDeviceTemplateModelToDTO(t)
+	dto.Device.Protocols = redactProtocolSecrets(dic, dto.Device.Protocols)
+	return dto
+}
+
+// redactDeviceTemplateParameters returns a copy of the parameters of a device template instance where the parameters
+// rendering the plain text values of the secret protocol properties of the device template are redacted
+func redactDeviceTemplateParameters(dic *di.Container, t pkgModels.DeviceTemplate, parameters map[string]string) map[string]string {
+	secretProperties := container.ConfigurationFrom(dic.Get).Writable.ProtocolSecrets.Properties
+	secretParameters := pkgModels.DeviceTemplateSecretPlaceholders(t.Device, secretProperties)
+	if len(secretParameters) == 0 {
+		return parameters
+	}
+	redacted := maps.Clone(parameters)
+	for _, name := range secretParameters {
+		if _, ok := redacted[name]; ok {
+			redacted[name] = pkgModels.RedactedValue
+		}
+	}
+	return redacted
+}
+
+// redactEntitySecrets redacts the secret protocol properties of the entity DTO when it's a device or a device
+// template, e.g. the entity of a metadata change or a trashed entity
+func redactEntitySecrets(dic *di.Container, entity any) any {
+	switch e := entity.(type) {
+	case dtos.Device:
+		e.Protocols = redactProtocolSecrets(dic, e.Protocols)
+		return e
+	case pkgDtos.DeviceTemplate:
+		e.Device.Protocols = redactProtocolSecrets(dic, e.Device.Protocols)
+		return e
+	}
+	return entity
+}
+
+// redactProtocolSecrets redacts the plain text values of the secret protocol properties
+func redactProtocolSecrets(dic *di.Container, protocols map[string]dtos.ProtocolProperties) map[string]dtos.ProtocolProperties {
+	return pkgModels.RedactProtocolSecrets(protocols, container.ConfigurationFrom(dic.Get).Writable.ProtocolSecrets.Properties)
+}
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/infrastructure/interfaces/mocks"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
 
@@ -76,3 +77,59 @@ func TestValidateAutoEvents(t *testing.T) {
 		})
 	}
 }
+
+func TestCarryOverReservedDeviceProperties(t *testing.T) {
+	oldDevice := models.Device{Name: "device-01", Properties: map[string]any{
+		pkgModels.DeviceLifecycleStateProperty:     pkgModels.Active,
+		pkgModels.DeviceTemplateNameProperty:       "template-01",
+		pkgModels.DeviceTemplateParametersProperty: map[string]any{"address": "10.0.0.1"},
+		pkgModels.DeviceResourceOverridesProperty:  map[string]any{"temperature": map[string]any{"units": "F"}},
+		pkgModels.DeviceServiceDownProperty:        true,
+		"foo":                                      "bar",
+	}}
+
+	tests := []struct {
+		name       string
+		properties map[string]any
+		expected   map[string]any
+	}{
+		{"replaced properties", map[string]any{"foo": "baz"}, map[string]any{
+			pkgModels.DeviceLifecycleStateProperty:     pkgModels.Active,
+			pkgModels.DeviceTemplateNameProperty:       "template-01",
+			pkgModels.DeviceTemplateParametersProperty: map[string]any{"address": "10.0.0.1"},
+			pkgModels.DeviceResourceOverridesProperty:  map[string]any{"temperature": map[string]any{"units": "F"}},
+			pkgModels.DeviceServiceDownProperty:        true,
+			"foo":                                      "baz",
+		}},
+		{"patched reserved properties", map[string]any{
+			pkgModels.DeviceLifecycleStateProperty:    pkgModels.Maintenance,
+			pkgModels.DeviceResourceOverridesProperty: map[string]any{},
+			pkgModels.DeviceServiceDownProperty:       false,
+		}, map[string]any{
+			pkgModels.DeviceLifecycleStateProperty:     pkgModels.Maintenance,
+			pkgModels.DeviceTemplateNameProperty:       "template-01",
+			pkgModels.DeviceTemplateParametersProperty: map[string]any{"address": "10.0.0.1"},
+			pkgModels.DeviceResourceOverridesProperty:  map[string]any{},
+			pkgModels.DeviceServiceDownProperty:        true,
+		}},
+		{"emptied properties", nil, map[string]any{
+			pkgModels.DeviceLifecycleStateProperty:     pkgModels.Active,
+			pkgModels.DeviceTemplateNameProperty:       "template-01",
+			pkgModels.DeviceTemplateParametersProperty: map[string]any{"address": "10.0.0.1"},
+			pkgModels.DeviceResourceOverridesProperty:  map[string]any{"temperature": map[string]any{"units": "F"}},
+			pkgModels.DeviceServiceDownProperty:        true,
+		}},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			d := models.Device{Name: oldDevice.Name, Properties: testCase.properties}
+			carryOverReservedDeviceProperties(oldDevice, &d)
+			assert.Equal(t, testCase.expected, d.Properties)
+		})
+	}
+
+	// the mark of the health probing can't be set through the properties
+	d := models.Device{Name: "device-02", Properties: map[string]any{pkgModels.DeviceServiceDownProperty: true}}
+	carryOverReservedDeviceProperties(models.Device{Name: d.Name}, &d)
+	assert.Empty(t, d.Properties)
+}
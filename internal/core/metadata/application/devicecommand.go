@@ -11,6 +11,7 @@ import (
 	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/requests"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
@@ -23,6 +24,12 @@ func AddDeviceProfileDeviceCommand(profileName string, deviceCommand models.Devi
 	dbClient := container.DBClientFrom(dic.Get)
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 
+	unlock, err := checkMetadataRevision(common.DeviceProfileSystemEventType, profileName, ctx, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	defer unlock()
+
 	profile, err := dbClient.DeviceProfileByName(profileName)
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
@@ -51,6 +58,12 @@ func PatchDeviceProfileDeviceCommand(profileName string, dto dtos.UpdateDeviceCo
 	dbClient := container.DBClientFrom(dic.Get)
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 
+	unlock, err := checkMetadataRevision(common.DeviceProfileSystemEventType, profileName, ctx, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	defer unlock()
+
 	profile, err := dbClient.DeviceProfileByName(profileName)
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
@@ -104,6 +117,12 @@ func DeleteDeviceCommandByName(profileName string, commandName string, ctx conte
 		return errors.NewCommonEdgeX(errors.KindStatusConflict, "fail to update the device profile when associated device exists", nil)
 	}
 
+	unlock, err := checkMetadataRevision(common.DeviceProfileSystemEventType, profileName, ctx, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	defer unlock()
+
 	profile, err := dbClient.DeviceProfileByName(profileName)
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
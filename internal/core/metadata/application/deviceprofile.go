@@ -63,6 +63,17 @@ func UpdateDeviceProfile(d models.DeviceProfile, ctx context.Context, dic *di.Co
 		return errors.NewCommonEdgeXWrapper(err)
 	}
 
+	unlock, err := checkMetadataRevision(common.DeviceProfileSystemEventType, d.Name, ctx, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	defer unlock()
+
+	err = checkDeviceProfileChange(d, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
 	err = dbClient.UpdateDeviceProfile(d)
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
@@ -108,11 +119,21 @@ func DeleteDeviceProfileByName(name string, ctx context.Context, dic *di.Contain
 		return errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
 	}
 	dbClient := container.DBClientFrom(dic.Get)
+	unlock, err := checkMetadataRevision(common.DeviceProfileSystemEventType, name, ctx, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	defer unlock()
+
 	profile, err := dbClient.DeviceProfileByName(name)
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
 	}
-	err = dbClient.DeleteDeviceProfileByName(name)
+	if trashRetention(dic) > 0 {
+		err = dbClient.TrashDeviceProfileByName(name)
+	} else {
+		err = dbClient.DeleteDeviceProfileByName(name)
+	}
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
 	}
@@ -204,12 +225,24 @@ func PatchDeviceProfileBasicInfo(ctx context.Context, dto dtos.UpdateDeviceProfi
 	dbClient := container.DBClientFrom(dic.Get)
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 
+	name, err := deviceProfileNameByDTO(dbClient, dto)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	unlock := lockMetadataEntity(common.DeviceProfileSystemEventType + ":" + name)
+	defer unlock()
+
 	deviceProfile, err := deviceProfileByDTO(dbClient, dto)
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
 	}
 
 	requests.ReplaceDeviceProfileModelBasicInfoFieldsWithDTO(&deviceProfile, dto)
+	err = matchMetadataRevision(common.DeviceProfileSystemEventType, name, ctx, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
 	err = dbClient.UpdateDeviceProfile(deviceProfile)
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
@@ -226,6 +259,19 @@ func PatchDeviceProfileBasicInfo(ctx context.Context, dto dtos.UpdateDeviceProfi
 	return nil
 }
 
+// deviceProfileNameByDTO returns the name of the device profile to patch, which is queried by ID when the DTO carries
+// no name
+func deviceProfileNameByDTO(dbClient interfaces.DBClient, dto dtos.UpdateDeviceProfileBasicInfo) (string, errors.EdgeX) {
+	if dto.Name != nil && *dto.Name != "" {
+		return *dto.Name, nil
+	}
+	deviceProfile, edgeXerr := deviceProfileByDTO(dbClient, dto)
+	if edgeXerr != nil {
+		return "", errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return deviceProfile.Name, nil
+}
+
 func deviceProfileByDTO(dbClient interfaces.DBClient, dto dtos.UpdateDeviceProfileBasicInfo) (deviceProfile models.DeviceProfile, err errors.EdgeX) {
 	// The ID or Name is required by DTO and the DTO also accepts empty string ID if the Name is provided
 	if dto.Id != nil && *dto.Id != "" {
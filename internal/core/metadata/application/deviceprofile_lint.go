@@ -0,0 +1,301 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"fmt"
+	"strings"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/infrastructure/interfaces"
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// profileDependents holds the devices and provision watchers using a device profile
+type profileDependents struct {
+	devices           []models.Device
+	provisionWatchers []models.ProvisionWatcher
+}
+
+// LintDeviceProfile checks the device profile on its own and, if a device profile with the same name exists,
+// compares it with the stored version against the devices and provision watchers using it. The findings are flagged
+// as blocking according to the Writable.ProfileChange.BlockingSeverity policy.
+func LintDeviceProfile(profile models.DeviceProfile, dic *di.Container) (findings []pkgDtos.DeviceProfileFinding, blocked bool, err errors.EdgeX) {
+	dbClient := container.DBClientFrom(dic.Get)
+
+	findings = lintDeviceProfileProperties(profile, dic)
+
+	oldProfile, err := dbClient.DeviceProfileByName(profile.Name)
+	if err != nil && errors.Kind(err) != errors.KindEntityDoesNotExist {
+		return nil, false, errors.NewCommonEdgeXWrapper(err)
+	}
+	if err == nil {
+		var dependents profileDependents
+		dependents.devices, err = dbClient.DevicesByProfileName(0, -1, profile.Name)
+		if err != nil {
+			return nil, false, errors.NewCommonEdgeXWrapper(err)
+		}
+		dependents.provisionWatchers, err = dbClient.ProvisionWatchersByProfileName(0, -1, profile.Name)
+		if err != nil {
+			return nil, false, errors.NewCommonEdgeXWrapper(err)
+		}
+		findings = append(findings, diffDeviceProfiles(oldProfile, profile, dependents)...)
+	}
+
+	blockingSeverity := container.ConfigurationFrom(dic.Get).Writable.ProfileChange.BlockingSeverity
+	if !pkgModels.IsValidProfileFindingBlockingSeverity(blockingSeverity) {
+		lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+		lc.Warnf("invalid ProfileChange.BlockingSeverity %s, no device profile change is blocked", blockingSeverity)
+	}
+	for i := range findings {
+		if pkgModels.IsProfileFindingBlocked(findings[i].Severity, blockingSeverity) {
+			findings[i].Blocking = true
+			blocked = true
+		}
+	}
+	return findings, blocked, nil
+}
+
+// checkDeviceProfileChange lints the device profile before it is updated, and returns a StatusConflict error listing
+// the blocking findings if the change is rejected by the blocking policy. The other findings are only logged.
+func checkDeviceProfileChange(profile models.DeviceProfile, dic *di.Container) errors.EdgeX {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	findings, blocked, err := LintDeviceProfile(profile, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	var messages []string
+	for _, f := range findings {
+		if f.Blocking {
+			messages = append(messages, fmt.Sprintf("%s %s: %s", f.Severity, f.Code, f.Message))
+		} else if f.Severity != pkgModels.ProfileFindingSeverityInfo {
+			lc.Warnf("device profile %s change: %s %s: %s", profile.Name, f.Severity, f.Code, f.Message)
+		}
+	}
+	if !blocked {
+		return nil
+	}
+	return errors.NewCommonEdgeX(errors.KindStatusConflict,
+		fmt.Sprintf("device profile %s change is blocked by the profile change policy: %s", profile.Name, strings.Join(messages, "; ")), nil)
+}
+
+// lintDeviceProfileProperties checks the resource properties which are not verified by the DTO validation
+func lintDeviceProfileProperties(profile models.DeviceProfile, dic *di.Container) []pkgDtos.DeviceProfileFinding {
+	var findings []pkgDtos.DeviceProfileFinding
+	uom, _ := dic.Get(container.UnitsOfMeasureInterfaceName).(interfaces.UnitsOfMeasure)
+
+	referenced := make(map[string]bool)
+	for _, c := range profile.DeviceCommands {
+		for _, ro := range c.ResourceOperations {
+			referenced[ro.DeviceResource] = true
+		}
+	}
+
+	for _, r := range profile.DeviceResources {
+		p := r.Properties
+		if p.Minimum != nil && p.Maximum != nil && *p.Minimum > *p.Maximum {
+			findings = append(findings, pkgDtos.DeviceProfileFinding{
+				Severity:     pkgModels.ProfileFindingSeverityError,
+				Code:         pkgModels.ProfileFindingInvalidRange,
+				Message:      fmt.Sprintf("resource %s minimum %v is greater than maximum %v", r.Name, *p.Minimum, *p.Maximum),
+				ResourceName: r.Name,
+			})
+		}
+		if p.Units != "" && uom != nil && !uom.Validate(p.Units) {
+			findings = append(findings, pkgDtos.DeviceProfileFinding{
+				Severity:     pkgModels.ProfileFindingSeverityWarning,
+				Code:         pkgModels.ProfileFindingUnknownUnits,
+				Message:      fmt.Sprintf("resource %s units %s is not defined in the units of measure", r.Name, p.Units),
+				ResourceName: r.Name,
+			})
+		}
+		if r.IsHidden && !referenced[r.Name] {
+			findings = append(findings, pkgDtos.DeviceProfileFinding{
+				Severity:     pkgModels.ProfileFindingSeverityInfo,
+				Code:         pkgModels.ProfileFindingUnreferencedResource,
+				Message:      fmt.Sprintf("hidden resource %s is not referenced by any device command", r.Name),
+				ResourceName: r.Name,
+			})
+		}
+	}
+	return findings
+}
+
+// diffDeviceProfiles reports the changes from the old to the new device profile which may break the dependents
+func diffDeviceProfiles(oldProfile, newProfile models.DeviceProfile, dependents profileDependents) []pkgDtos.DeviceProfileFinding {
+	var findings []pkgDtos.DeviceProfileFinding
+	inUse := len(dependents.devices) > 0
+
+	// severity of a change which only breaks the dependents when the profile is in use
+	severityInUse := func(severity string) string {
+		if inUse {
+			return severity
+		}
+		return pkgModels.ProfileFindingSeverityInfo
+	}
+
+	newResources := make(map[string]models.DeviceResource, len(newProfile.DeviceResources))
+	for _, r := range newProfile.DeviceResources {
+		newResources[r.Name] = r
+	}
+	newCommands := make(map[string]models.DeviceCommand, len(newProfile.DeviceCommands))
+	for _, c := range newProfile.DeviceCommands {
+		newCommands[c.Name] = c
+	}
+
+	for _, oldResource := range oldProfile.DeviceResources {
+		newResource, ok := newResources[oldResource.Name]
+		if !ok {
+			devices, pws := autoEventDependents(oldResource.Name, dependents)
+			if len(devices) > 0 || len(pws) > 0 {
+				findings = append(findings, pkgDtos.DeviceProfileFinding{
+					Severity:          pkgModels.ProfileFindingSeverityError,
+					Code:              pkgModels.ProfileFindingAutoEventSource,
+					Message:           fmt.Sprintf("resource %s is removed but still referenced by AutoEvents", oldResource.Name),
+					ResourceName:      oldResource.Name,
+					Devices:           devices,
+					ProvisionWatchers: pws,
+				})
+				continue
+			}
+			findings = append(findings, pkgDtos.DeviceProfileFinding{
+				Severity:     severityInUse(pkgModels.ProfileFindingSeverityWarning),
+				Code:         pkgModels.ProfileFindingResourceRemoved,
+				Message:      fmt.Sprintf("resource %s is removed", oldResource.Name),
+				ResourceName: oldResource.Name,
+				Devices:      deviceNames(dependents.devices),
+			})
+			continue
+		}
+
+		oldProps, newProps := oldResource.Properties, newResource.Properties
+		if oldProps.ValueType != newProps.ValueType {
+			findings = append(findings, pkgDtos.DeviceProfileFinding{
+				Severity:     severityInUse(pkgModels.ProfileFindingSeverityError),
+				Code:         pkgModels.ProfileFindingValueTypeChanged,
+				Message:      fmt.Sprintf("resource %s value type is changed from %s to %s", oldResource.Name, oldProps.ValueType, newProps.ValueType),
+				ResourceName: oldResource.Name,
+				Devices:      deviceNames(dependents.devices),
+			})
+		}
+		if lost := lostAccess(oldProps.ReadWrite, newProps.ReadWrite); lost != "" {
+			severity := severityInUse(pkgModels.ProfileFindingSeverityWarning)
+			devices, pws := autoEventDependents(oldResource.Name, dependents)
+			if strings.Contains(lost, common.ReadWrite_R) && (len(devices) > 0 || len(pws) > 0) {
+				// the AutoEvents can no longer read the resource
+				severity = pkgModels.ProfileFindingSeverityError
+			} else {
+				devices, pws = deviceNames(dependents.devices), nil
+			}
+			findings = append(findings, pkgDtos.DeviceProfileFinding{
+				Severity:          severity,
+				Code:              pkgModels.ProfileFindingReadWriteReduced,
+				Message:           fmt.Sprintf("resource %s readWrite is reduced from %s to %s", oldResource.Name, oldProps.ReadWrite, newProps.ReadWrite),
+				ResourceName:      oldResource.Name,
+				Devices:           devices,
+				ProvisionWatchers: pws,
+			})
+		}
+		if oldProps.Units != newProps.Units {
+			findings = append(findings, pkgDtos.DeviceProfileFinding{
+				Severity:     severityInUse(pkgModels.ProfileFindingSeverityWarning),
+				Code:         pkgModels.ProfileFindingUnitsChanged,
+				Message:      fmt.Sprintf("resource %s units is changed from %s to %s", oldResource.Name, oldProps.Units, newProps.Units),
+				ResourceName: oldResource.Name,
+				Devices:      deviceNames(dependents.devices),
+			})
+		}
+	}
+
+	for _, oldCommand := range oldProfile.DeviceCommands {
+		newCommand, ok := newCommands[oldCommand.Name]
+		if !ok {
+			devices, pws := autoEventDependents(oldCommand.Name, dependents)
+			if len(devices) > 0 || len(pws) > 0 {
+				findings = append(findings, pkgDtos.DeviceProfileFinding{
+					Severity:          pkgModels.ProfileFindingSeverityError,
+					Code:              pkgModels.ProfileFindingAutoEventSource,
+					Message:           fmt.Sprintf("command %s is removed or renamed but still referenced by AutoEvents", oldCommand.Name),
+					CommandName:       oldCommand.Name,
+					Devices:           devices,
+					ProvisionWatchers: pws,
+				})
+				continue
+			}
+			findings = append(findings, pkgDtos.DeviceProfileFinding{
+				Severity:    severityInUse(pkgModels.ProfileFindingSeverityWarning),
+				Code:        pkgModels.ProfileFindingCommandRemoved,
+				Message:     fmt.Sprintf("command %s is removed or renamed", oldCommand.Name),
+				CommandName: oldCommand.Name,
+				Devices:     deviceNames(dependents.devices),
+			})
+			continue
+		}
+		if lost := lostAccess(oldCommand.ReadWrite, newCommand.ReadWrite); lost != "" {
+			findings = append(findings, pkgDtos.DeviceProfileFinding{
+				Severity:    severityInUse(pkgModels.ProfileFindingSeverityWarning),
+				Code:        pkgModels.ProfileFindingReadWriteReduced,
+				Message:     fmt.Sprintf("command %s readWrite is reduced from %s to %s", oldCommand.Name, oldCommand.ReadWrite, newCommand.ReadWrite),
+				CommandName: oldCommand.Name,
+				Devices:     deviceNames(dependents.devices),
+			})
+		}
+	}
+	return findings
+}
+
+// lostAccess returns the access modes, R and/or W, granted by the old readWrite but not by the new one
+func lostAccess(oldReadWrite, newReadWrite string) string {
+	var lost string
+	for _, mode := range []string{common.ReadWrite_R, common.ReadWrite_W} {
+		if strings.Contains(oldReadWrite, mode) && !strings.Contains(newReadWrite, mode) {
+			lost += mode
+		}
+	}
+	return lost
+}
+
+// autoEventDependents returns the names of the devices and provision watchers having an AutoEvent on the source
+func autoEventDependents(sourceName string, dependents profileDependents) (devices []string, provisionWatchers []string) {
+	for _, d := range dependents.devices {
+		if hasAutoEventSource(d.AutoEvents, sourceName) {
+			devices = append(devices, d.Name)
+		}
+	}
+	for _, pw := range dependents.provisionWatchers {
+		if hasAutoEventSource(pw.DiscoveredDevice.AutoEvents, sourceName) {
+			provisionWatchers = append(provisionWatchers, pw.Name)
+		}
+	}
+	return devices, provisionWatchers
+}
+
+func hasAutoEventSource(autoEvents []models.AutoEvent, sourceName string) bool {
+	for _, a := range autoEvents {
+		if a.SourceName == sourceName {
+			return true
+		}
+	}
+	return false
+}
+
+func deviceNames(devices []models.Device) []string {
+	if len(devices) == 0 {
+		return nil
+	}
+	names := make([]string, len(devices))
+	for i, d := range devices {
+		names[i] = d.Name
+	}
+	return names
+}
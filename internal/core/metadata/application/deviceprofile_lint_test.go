@@ -0,0 +1,102 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+func lintTestProfile() models.DeviceProfile {
+	return models.DeviceProfile{
+		Name: "thermostat",
+		DeviceResources: []models.DeviceResource{
+			{Name: "Temperature", Properties: models.ResourceProperties{ValueType: common.ValueTypeFloat32, ReadWrite: common.ReadWrite_R, Units: "C"}},
+			{Name: "SetPoint", Properties: models.ResourceProperties{ValueType: common.ValueTypeFloat32, ReadWrite: common.ReadWrite_RW, Units: "C"}},
+		},
+		DeviceCommands: []models.DeviceCommand{
+			{Name: "Climate", ReadWrite: common.ReadWrite_RW, ResourceOperations: []models.ResourceOperation{{DeviceResource: "Temperature"}, {DeviceResource: "SetPoint"}}},
+		},
+	}
+}
+
+func findingByCode(findings []pkgDtos.DeviceProfileFinding, code string) *pkgDtos.DeviceProfileFinding {
+	for i := range findings {
+		if findings[i].Code == code {
+			return &findings[i]
+		}
+	}
+	return nil
+}
+
+func TestDiffDeviceProfiles(t *testing.T) {
+	oldProfile := lintTestProfile()
+	dependents := profileDependents{
+		devices: []models.Device{
+			{Name: "thermostat-01", AutoEvents: []models.AutoEvent{{SourceName: "Climate"}}},
+			{Name: "thermostat-02"},
+		},
+		provisionWatchers: []models.ProvisionWatcher{
+			{Name: "thermostat-watcher", DiscoveredDevice: models.DiscoveredDevice{AutoEvents: []models.AutoEvent{{SourceName: "Temperature"}}}},
+		},
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		assert.Empty(t, diffDeviceProfiles(oldProfile, lintTestProfile(), dependents))
+	})
+
+	t.Run("command renamed while referenced by AutoEvent", func(t *testing.T) {
+		newProfile := lintTestProfile()
+		newProfile.DeviceCommands[0].Name = "ClimateControl"
+		findings := diffDeviceProfiles(oldProfile, newProfile, dependents)
+		require.Len(t, findings, 1)
+		assert.Equal(t, pkgModels.ProfileFindingAutoEventSource, findings[0].Code)
+		assert.Equal(t, pkgModels.ProfileFindingSeverityError, findings[0].Severity)
+		assert.Equal(t, "Climate", findings[0].CommandName)
+		assert.Equal(t, []string{"thermostat-01"}, findings[0].Devices)
+	})
+
+	t.Run("resource read access removed while referenced by provision watcher AutoEvent", func(t *testing.T) {
+		newProfile := lintTestProfile()
+		newProfile.DeviceResources[0].Properties.ReadWrite = common.ReadWrite_W
+		finding := findingByCode(diffDeviceProfiles(oldProfile, newProfile, dependents), pkgModels.ProfileFindingReadWriteReduced)
+		require.NotNil(t, finding)
+		assert.Equal(t, pkgModels.ProfileFindingSeverityError, finding.Severity)
+		assert.Equal(t, []string{"thermostat-watcher"}, finding.ProvisionWatchers)
+	})
+
+	t.Run("value type and units changed", func(t *testing.T) {
+		newProfile := lintTestProfile()
+		newProfile.DeviceResources[1].Properties.ValueType = common.ValueTypeInt32
+		newProfile.DeviceResources[1].Properties.Units = "F"
+		findings := diffDeviceProfiles(oldProfile, newProfile, dependents)
+		valueType := findingByCode(findings, pkgModels.ProfileFindingValueTypeChanged)
+		require.NotNil(t, valueType)
+		assert.Equal(t, pkgModels.ProfileFindingSeverityError, valueType.Severity)
+		assert.Len(t, valueType.Devices, 2)
+		units := findingByCode(findings, pkgModels.ProfileFindingUnitsChanged)
+		require.NotNil(t, units)
+		assert.Equal(t, pkgModels.ProfileFindingSeverityWarning, units.Severity)
+	})
+
+	t.Run("changes without dependents are informational", func(t *testing.T) {
+		newProfile := lintTestProfile()
+		newProfile.DeviceResources = newProfile.DeviceResources[:1]
+		newProfile.DeviceCommands = nil
+		findings := diffDeviceProfiles(oldProfile, newProfile, profileDependents{})
+		require.Len(t, findings, 2)
+		for _, f := range findings {
+			assert.Equal(t, pkgModels.ProfileFindingSeverityInfo, f.Severity)
+		}
+	})
+}
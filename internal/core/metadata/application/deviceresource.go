@@ -11,9 +11,11 @@ import (
 
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/requests"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
@@ -42,6 +44,46 @@ func DeviceResourceByProfileNameAndResourceName(profileName string, resourceName
 	return resource, nil
 }
 
+// DeviceResourcesByDeviceName query the effective device resources of the device, i.e. the device resources of its
+// profile with the resource overrides of the device applied
+func DeviceResourcesByDeviceName(deviceName string, dic *di.Container) (resources []dtos.DeviceResource, err errors.EdgeX) {
+	if deviceName == "" {
+		return resources, errors.NewCommonEdgeX(errors.KindContractInvalid, "device name is empty", nil)
+	}
+	dbClient := container.DBClientFrom(dic.Get)
+	device, err := dbClient.DeviceByName(deviceName)
+	if err != nil {
+		return resources, errors.NewCommonEdgeXWrapper(err)
+	}
+	overrides, goErr := pkgModels.DeviceResourceOverrides(device.Properties)
+	if goErr != nil {
+		return resources, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("device '%s' resource overrides are invalid", deviceName), goErr)
+	}
+	profile, err := dbClient.DeviceProfileByName(device.ProfileName)
+	if err != nil {
+		return resources, errors.NewCommonEdgeXWrapper(err)
+	}
+
+	return dtos.FromDeviceResourceModelsToDTOs(pkgModels.EffectiveDeviceResources(profile.DeviceResources, overrides)), nil
+}
+
+// DeviceResourceByDeviceNameAndResourceName query the effective device resource of the device by resourceName
+func DeviceResourceByDeviceNameAndResourceName(deviceName string, resourceName string, dic *di.Container) (resource dtos.DeviceResource, err errors.EdgeX) {
+	if resourceName == "" {
+		return resource, errors.NewCommonEdgeX(errors.KindContractInvalid, "resource name is empty", nil)
+	}
+	resources, err := DeviceResourcesByDeviceName(deviceName, dic)
+	if err != nil {
+		return resource, errors.NewCommonEdgeXWrapper(err)
+	}
+	for _, r := range resources {
+		if r.Name == resourceName {
+			return r, nil
+		}
+	}
+	return resource, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("resource %s not exists", resourceName), nil)
+}
+
 func resourceByName(resources []models.DeviceResource, resourceName string) (models.DeviceResource, errors.EdgeX) {
 	for _, r := range resources {
 		if r.Name == resourceName {
@@ -55,6 +97,12 @@ func AddDeviceProfileResource(profileName string, resource models.DeviceResource
 	dbClient := container.DBClientFrom(dic.Get)
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 
+	unlock, err := checkMetadataRevision(common.DeviceProfileSystemEventType, profileName, ctx, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	defer unlock()
+
 	profile, err := dbClient.DeviceProfileByName(profileName)
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
@@ -88,6 +136,12 @@ func PatchDeviceProfileResource(profileName string, dto dtos.UpdateDeviceResourc
 	dbClient := container.DBClientFrom(dic.Get)
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 
+	unlock, err := checkMetadataRevision(common.DeviceProfileSystemEventType, profileName, ctx, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	defer unlock()
+
 	profile, err := dbClient.DeviceProfileByName(profileName)
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
@@ -141,6 +195,12 @@ func DeleteDeviceResourceByName(profileName string, resourceName string, ctx con
 		return errors.NewCommonEdgeX(errors.KindStatusConflict, "fail to update the device profile when associated device exists", nil)
 	}
 
+	unlock, err := checkMetadataRevision(common.DeviceProfileSystemEventType, profileName, ctx, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	defer unlock()
+
 	profile, err := dbClient.DeviceProfileByName(profileName)
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
@@ -62,6 +62,13 @@ func PatchDeviceService(dto dtos.UpdateDeviceService, ctx context.Context, dic *
 	dbClient := container.DBClientFrom(dic.Get)
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 
+	name, err := deviceServiceNameByDTO(dbClient, dto)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	unlock := lockMetadataEntity(common.DeviceServiceSystemEventType + ":" + name)
+	defer unlock()
+
 	deviceService, err := deviceServiceByDTO(dbClient, dto)
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
@@ -69,6 +76,11 @@ func PatchDeviceService(dto dtos.UpdateDeviceService, ctx context.Context, dic *
 
 	requests.ReplaceDeviceServiceModelFieldsWithDTO(&deviceService, dto)
 
+	err = matchMetadataRevision(common.DeviceServiceSystemEventType, name, ctx, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
 	err = dbClient.UpdateDeviceService(deviceService)
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
@@ -83,6 +95,19 @@ func PatchDeviceService(dto dtos.UpdateDeviceService, ctx context.Context, dic *
 	return nil
 }
 
+// deviceServiceNameByDTO returns the name of the device service to patch, which is queried by ID when the DTO carries
+// no name
+func deviceServiceNameByDTO(dbClient interfaces.DBClient, dto dtos.UpdateDeviceService) (string, errors.EdgeX) {
+	if dto.Name != nil && *dto.Name != "" {
+		return *dto.Name, nil
+	}
+	deviceService, edgeXerr := deviceServiceByDTO(dbClient, dto)
+	if edgeXerr != nil {
+		return "", errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return deviceService.Name, nil
+}
+
 func deviceServiceByDTO(dbClient interfaces.DBClient, dto dtos.UpdateDeviceService) (deviceService models.DeviceService, err errors.EdgeX) {
 	// The ID or Name is required by DTO and the DTO also accepts empty string ID if the Name is provided
 	if dto.Id != nil && *dto.Id != "" {
@@ -108,6 +133,12 @@ func DeleteDeviceServiceByName(name string, ctx context.Context, dic *di.Contain
 		return errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
 	}
 	dbClient := container.DBClientFrom(dic.Get)
+	unlock, err := checkMetadataRevision(common.DeviceServiceSystemEventType, name, ctx, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	defer unlock()
+
 	deviceService, err := dbClient.DeviceServiceByName(name)
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
@@ -0,0 +1,264 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/secret"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/http"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// Constants related to the device service health probing methods
+const (
+	DeviceServiceHealthMethodHttp       = "http"
+	DeviceServiceHealthMethodMessageBus = "messagebus"
+)
+
+var asyncProbeDeviceServicesOnce sync.Once
+
+// deviceServiceHealthState holds the probing result of a device service. The devices set to DOWN because the device
+// service was unreachable are marked with the DeviceServiceDown property, so that they can be set back to UP by a
+// later core-metadata process as well. A device service seen for the first time may have such devices left by a
+// previous process, until they're recovered.
+type deviceServiceHealthState struct {
+	failures  int
+	down      bool
+	recovered bool
+}
+
+// deviceServiceHealthChecker probes the registered device services and updates the OperatingState of their devices
+type deviceServiceHealthChecker struct {
+	dic              *di.Container
+	failureThreshold int
+	probe            func(ds models.DeviceService, ctx context.Context) errors.EdgeX
+	states           map[string]*deviceServiceHealthState
+}
+
+func newDeviceServiceHealthChecker(method string, failureThreshold int, dic *di.Container) (*deviceServiceHealthChecker, errors.EdgeX) {
+	checker := &deviceServiceHealthChecker{
+		dic:              dic,
+		failureThreshold: max(failureThreshold, 1),
+		states:           make(map[string]*deviceServiceHealthState),
+	}
+	switch method {
+	case DeviceServiceHealthMethodHttp, "":
+		checker.probe = checker.pingDeviceService
+	case DeviceServiceHealthMethodMessageBus:
+		// the device services have no ping request over the MessageBus, and probing them with another request depends
+		// on how they handle it
+		bootstrapContainer.LoggingClientFrom(dic.Get).Warnf("device service health probing method '%s' is deprecated, the ping API is probed instead", method)
+		checker.probe = checker.pingDeviceService
+	default:
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("unsupported device service health probing method '%s'", method), nil)
+	}
+	return checker, nil
+}
+
+// AsyncProbeDeviceServices periodically probes the registered device services. The devices of an unreachable device
+// service are set to DOWN after the failure threshold is reached, and are set back to UP once the service responds again.
+func AsyncProbeDeviceServices(interval time.Duration, method string, failureThreshold int, ctx context.Context, dic *di.Container) errors.EdgeX {
+	checker, err := newDeviceServiceHealthChecker(method, failureThreshold, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	asyncProbeDeviceServicesOnce.Do(func() {
+		go func() {
+			lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+			timer := time.NewTimer(interval)
+			for {
+				timer.Reset(interval) // since probing might take lots of time, restart the timer to recount the time
+				select {
+				case <-ctx.Done():
+					lc.Info("Exiting device service health probing")
+					return
+				case <-timer.C:
+					err := checker.checkDeviceServices(ctx)
+					if err != nil {
+						lc.Errorf("Failed to probe device services, %v", err)
+					}
+				}
+			}
+		}()
+	})
+	return nil
+}
+
+// pingDeviceService probes the device service by invoking its ping API
+func (c *deviceServiceHealthChecker) pingDeviceService(ds models.DeviceService, ctx context.Context) errors.EdgeX {
+	secretProvider := bootstrapContainer.SecretProviderExtFrom(c.dic.Get)
+	client := http.NewCommonClient(ds.BaseAddress, secret.NewJWTSecretProvider(secretProvider))
+
+	requestTimeout, err := time.ParseDuration(container.ConfigurationFrom(c.dic.Get).Service.RequestTimeout)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindServerError, "failed to parse service.RequestTimeout", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	_, edgeXerr := client.Ping(ctx)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.KindServiceUnavailable, fmt.Sprintf("failed to ping device service '%s'", ds.Name), edgeXerr)
+	}
+	return nil
+}
+
+// checkDeviceServices probes every registered device service once and updates the health states
+func (c *deviceServiceHealthChecker) checkDeviceServices(ctx context.Context) errors.EdgeX {
+	lc := bootstrapContainer.LoggingClientFrom(c.dic.Get)
+	dbClient := container.DBClientFrom(c.dic.Get)
+
+	services, err := dbClient.AllDeviceServices(0, -1, nil)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
+	registered := make(map[string]bool, len(services))
+	for _, ds := range services {
+		registered[ds.Name] = true
+		state, ok := c.states[ds.Name]
+		if !ok {
+			state = &deviceServiceHealthState{}
+			c.states[ds.Name] = state
+		}
+
+		if probeErr := c.probe(ds, ctx); probeErr != nil {
+			state.failures++
+			lc.Debugf("Device service '%s' health probing failed %d time(s): %v", ds.Name, state.failures, probeErr)
+			if !state.down && state.failures >= c.failureThreshold {
+				lc.Warnf("Device service '%s' is unreachable, setting its devices to %s", ds.Name, models.Down)
+				state.down = true
+				state.recovered = false
+				c.setDevicesDown(ds, ctx)
+				go publishSystemEvent(common.DeviceServiceSystemEventType, pkgCommon.SystemEventActionDown, ds.Name, dtos.FromDeviceServiceModelToDTO(ds), ctx, c.dic)
+			}
+			continue
+		}
+
+		state.failures = 0
+		if state.down {
+			lc.Infof("Device service '%s' is reachable again, setting its devices to %s", ds.Name, models.Up)
+			go publishSystemEvent(common.DeviceServiceSystemEventType, pkgCommon.SystemEventActionUp, ds.Name, dtos.FromDeviceServiceModelToDTO(ds), ctx, c.dic)
+		}
+		if !state.recovered {
+			state.recovered = c.setDevicesUp(ds, ctx)
+			state.down = false
+		}
+	}
+
+	// forget the removed device services
+	for name := range c.states {
+		if !registered[name] {
+			delete(c.states, name)
+		}
+	}
+	return nil
+}
+
+// setDevicesDown sets the UP devices of the device service to DOWN and marks them with the DeviceServiceDown property
+func (c *deviceServiceHealthChecker) setDevicesDown(ds models.DeviceService, ctx context.Context) {
+	lc := bootstrapContainer.LoggingClientFrom(c.dic.Get)
+	dbClient := container.DBClientFrom(c.dic.Get)
+
+	devices, err := dbClient.DevicesByServiceName(0, -1, ds.Name)
+	if err != nil {
+		lc.Errorf("failed to query the devices of device service '%s': %v", ds.Name, err)
+		return
+	}
+	for _, d := range devices {
+		if d.OperatingState == models.Up {
+			c.updateOperatingState(ds, d.Name, models.Up, models.Down, ctx)
+		}
+	}
+}
+
+// setDevicesUp sets the devices of the device service marked with the DeviceServiceDown property back to UP if they
+// are still DOWN, and returns whether the devices are queried
+func (c *deviceServiceHealthChecker) setDevicesUp(ds models.DeviceService, ctx context.Context) bool {
+	lc := bootstrapContainer.LoggingClientFrom(c.dic.Get)
+	dbClient := container.DBClientFrom(c.dic.Get)
+
+	devices, err := dbClient.DevicesByServiceName(0, -1, ds.Name)
+	if err != nil {
+		lc.Errorf("failed to query the devices of device service '%s': %v", ds.Name, err)
+		return false
+	}
+	for _, d := range devices {
+		if isDownByDeviceService(d) {
+			c.updateOperatingState(ds, d.Name, models.Down, models.Up, ctx)
+		}
+	}
+	return true
+}
+
+// isDownByDeviceService returns whether the device is marked as set to DOWN by the device service health probing
+func isDownByDeviceService(d models.Device) bool {
+	down, _ := d.Properties[pkgModels.DeviceServiceDownProperty].(bool)
+	return down
+}
+
+// clearDeviceServiceDown removes the mark of the devices set to DOWN by the device service health probing, the
+// Properties are copied so that the map shared with the stored device isn't modified
+func clearDeviceServiceDown(d *models.Device) {
+	if _, ok := d.Properties[pkgModels.DeviceServiceDownProperty]; !ok {
+		return
+	}
+	d.Properties = maps.Clone(d.Properties)
+	delete(d.Properties, pkgModels.DeviceServiceDownProperty)
+}
+
+// updateOperatingState sets the OperatingState of the device from the specified state to the new state, and returns
+// whether it's updated. The device is read again while its metadata entity lock is held, the same lock held by the
+// device writes of the API, so that only its OperatingState is changed and a concurrent update isn't overwritten. The
+// device is skipped when it has been moved to another device service or its OperatingState has changed meanwhile, and
+// a device set back to UP must still be marked as set to DOWN by the health probing.
+func (c *deviceServiceHealthChecker) updateOperatingState(ds models.DeviceService, name string, from models.OperatingState, state models.OperatingState, ctx context.Context) bool {
+	lc := bootstrapContainer.LoggingClientFrom(c.dic.Get)
+	dbClient := container.DBClientFrom(c.dic.Get)
+
+	unlock := lockMetadataEntity(common.DeviceSystemEventType + ":" + name)
+	defer unlock()
+	d, err := dbClient.DeviceByName(name)
+	if err != nil {
+		lc.Debugf("skip setting the OperatingState of device '%s' of device service '%s' to %s: %v", name, ds.Name, state, err)
+		return false
+	}
+	if d.ServiceName != ds.Name || d.OperatingState != from || (state == models.Up && !isDownByDeviceService(d)) {
+		return false
+	}
+
+	d.OperatingState = state
+	properties := maps.Clone(d.Properties)
+	if properties == nil {
+		properties = make(map[string]any)
+	}
+	d.Properties = properties
+	if state == models.Down {
+		properties[pkgModels.DeviceServiceDownProperty] = true
+	} else {
+		clearDeviceServiceDown(&d)
+	}
+	if err := dbClient.UpdateDevice(d); err != nil {
+		lc.Errorf("failed to set the OperatingState of device '%s' to %s: %v", d.Name, state, err)
+		return false
+	}
+	go publishSystemEvent(common.DeviceSystemEventType, common.SystemEventActionUpdate, d.ServiceName, dtos.FromDeviceModelToDTO(d), ctx, c.dic)
+	return true
+}
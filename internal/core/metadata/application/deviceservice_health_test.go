@@ -0,0 +1,147 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"testing"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/config"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/infrastructure/interfaces/mocks"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+func TestCheckDeviceServices(t *testing.T) {
+	service := models.DeviceService{Name: "device-modbus", BaseAddress: "http://localhost:59901"}
+	upDevice := models.Device{Name: "up-device", ServiceName: service.Name, OperatingState: models.Up}
+	downDevice := models.Device{Name: "down-device", ServiceName: service.Name, OperatingState: models.Down}
+
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{}
+		},
+	})
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("AllDeviceServices", 0, -1, []string(nil)).Return([]models.DeviceService{service}, nil)
+	// the devices are read from and written to the stored devices, so that the concurrent updates can be simulated
+	stored := map[string]models.Device{upDevice.Name: upDevice, downDevice.Name: downDevice}
+	dbClientMock.On("DevicesByServiceName", 0, -1, service.Name).Return(func(int, int, string) []models.Device {
+		return []models.Device{stored[upDevice.Name], stored[downDevice.Name]}
+	}, nil)
+	dbClientMock.On("UpdateDevice", mock.Anything).Run(func(args mock.Arguments) {
+		d := args.Get(0).(models.Device)
+		stored[d.Name] = d
+	}).Return(nil)
+	dbClientMock.On("DeviceByName", mock.Anything).Return(func(name string) models.Device { return stored[name] }, nil)
+	flippedDevice := upDevice
+	flippedDevice.OperatingState = models.Down
+	flippedDevice.Properties = map[string]any{pkgModels.DeviceServiceDownProperty: true}
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	checker, err := newDeviceServiceHealthChecker(DeviceServiceHealthMethodHttp, 2, dic)
+	require.NoError(t, err)
+	reachable := false
+	checker.probe = func(ds models.DeviceService, _ context.Context) errors.EdgeX {
+		if reachable {
+			return nil
+		}
+		return errors.NewCommonEdgeX(errors.KindServiceUnavailable, "unreachable", nil)
+	}
+
+	// the devices are kept UP until the failure threshold is reached
+	require.NoError(t, checker.checkDeviceServices(context.Background()))
+	dbClientMock.AssertNotCalled(t, "UpdateDevice", mock.Anything)
+
+	require.NoError(t, checker.checkDeviceServices(context.Background()))
+	dbClientMock.AssertNumberOfCalls(t, "UpdateDevice", 1)
+	dbClientMock.AssertCalled(t, "UpdateDevice", flippedDevice)
+	assert.True(t, checker.states[service.Name].down)
+
+	// the devices are not updated again while the device service stays unreachable
+	require.NoError(t, checker.checkDeviceServices(context.Background()))
+	dbClientMock.AssertNumberOfCalls(t, "UpdateDevice", 1)
+
+	// the device is patched while the device service is unreachable
+	patchedDevice := stored[upDevice.Name]
+	patchedDevice.Labels = []string{"patched"}
+	stored[upDevice.Name] = patchedDevice
+
+	// only the devices set to DOWN by the health probing are recovered, keeping the concurrent updates
+	reachable = true
+	require.NoError(t, checker.checkDeviceServices(context.Background()))
+	dbClientMock.AssertNumberOfCalls(t, "UpdateDevice", 2)
+	recoveredDevice := upDevice
+	recoveredDevice.Labels = patchedDevice.Labels
+	recoveredDevice.Properties = map[string]any{}
+	dbClientMock.AssertCalled(t, "UpdateDevice", recoveredDevice)
+	assert.False(t, checker.states[service.Name].down)
+	assert.Zero(t, checker.states[service.Name].failures)
+
+	// the devices aren't queried again once recovered
+	require.NoError(t, checker.checkDeviceServices(context.Background()))
+	dbClientMock.AssertNumberOfCalls(t, "DevicesByServiceName", 2)
+}
+
+func TestCheckDeviceServicesAfterRestart(t *testing.T) {
+	service := models.DeviceService{Name: "device-modbus", BaseAddress: "http://localhost:59901"}
+	// the device set to DOWN by the health probing of a previous core-metadata process
+	markedDevice := models.Device{Name: "marked-device", ServiceName: service.Name, OperatingState: models.Down,
+		Properties: map[string]any{pkgModels.DeviceServiceDownProperty: true, "foo": "bar"}}
+	downDevice := models.Device{Name: "down-device", ServiceName: service.Name, OperatingState: models.Down}
+
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("AllDeviceServices", 0, -1, []string(nil)).Return([]models.DeviceService{service}, nil)
+	dbClientMock.On("DevicesByServiceName", 0, -1, service.Name).Return([]models.Device{markedDevice, downDevice}, nil)
+	dbClientMock.On("DeviceByName", markedDevice.Name).Return(markedDevice, nil)
+	dbClientMock.On("UpdateDevice", mock.Anything).Return(nil)
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{}
+		},
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	checker, err := newDeviceServiceHealthChecker(DeviceServiceHealthMethodHttp, 2, dic)
+	require.NoError(t, err)
+	checker.probe = func(models.DeviceService, context.Context) errors.EdgeX { return nil }
+
+	require.NoError(t, checker.checkDeviceServices(context.Background()))
+	recoveredDevice := markedDevice
+	recoveredDevice.OperatingState = models.Up
+	recoveredDevice.Properties = map[string]any{"foo": "bar"}
+	dbClientMock.AssertNumberOfCalls(t, "UpdateDevice", 1)
+	dbClientMock.AssertCalled(t, "UpdateDevice", recoveredDevice)
+	// the stored device isn't modified in place
+	assert.Equal(t, true, markedDevice.Properties[pkgModels.DeviceServiceDownProperty])
+}
+
+func TestNewDeviceServiceHealthCheckerInvalidMethod(t *testing.T) {
+	_, err := newDeviceServiceHealthChecker("invalid", 3, di.NewContainer(di.ServiceConstructorMap{}))
+	require.Error(t, err)
+	assert.Equal(t, errors.KindContractInvalid, errors.Kind(err))
+}
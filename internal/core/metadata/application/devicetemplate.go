@@ -0,0 +1,282 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// DeviceTemplateRolloutResult holds the outcome of applying a device template change to one of its instances
+type DeviceTemplateRolloutResult struct {
+	DeviceName string
+	Err        errors.EdgeX
+}
+
+// AddDeviceTemplate validates the device template and adds it into DB
+func AddDeviceTemplate(t pkgModels.DeviceTemplate, ctx context.Context, dic *di.Container) (id string, err errors.EdgeX) {
+	dbClient := container.DBClientFrom(dic.Get)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	err = validateDeviceTemplate(t, dic)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	added, err := dbClient.AddDeviceTemplate(t)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	lc.Debugf("DeviceTemplate created on DB successfully. DeviceTemplate ID: %s, Correlation-ID: %s ", added.Id, correlation.FromContext(ctx))
+	return added.Id, nil
+}
+
+// UpdateDeviceTemplate validates the device template and replaces the stored one of the same name. The existing
+// instances are not changed until the template is rolled out.
+func UpdateDeviceTemplate(t pkgModels.DeviceTemplate, ctx context.Context, dic *di.Container) errors.EdgeX {
+	dbClient := container.DBClientFrom(dic.Get)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	unlock, err := checkMetadataRevision(pkgCommon.DeviceTemplate, t.Name, ctx, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	defer unlock()
+
+	err = validateDeviceTemplate(t, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
+	err = dbClient.UpdateDeviceTemplate(t)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
+	lc.Debugf("DeviceTemplate updated on DB successfully. Correlation-ID: %s ", correlation.FromContext(ctx))
+	return nil
+}
+
+// DeviceTemplateByName query the device template by name
+func DeviceTemplateByName(name string, dic *di.Container) (template pkgDtos.DeviceTemplate, err errors.EdgeX) {
+	if name == "" {
+		return template, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := container.DBClientFrom(dic.Get)
+	t, err := dbClient.DeviceTemplateByName(name)
+	if err != nil {
+		return template, errors.NewCommonEdgeXWrapper(err)
+	}
+	return redactDeviceTemplateSecrets(dic, t), nil
+}
+
+// AllDeviceTemplates query the device templates with offset, limit and labels
+func AllDeviceTemplates(offset int, limit int, labels []string, dic *di.Container) (templates []pkgDtos.DeviceTemplate, totalCount uint32, err errors.EdgeX) {
+	dbClient := container.DBClientFrom(dic.Get)
+	ts, err := dbClient.AllDeviceTemplates(offset, limit, labels)
+	if err == nil {
+		totalCount, err = dbClient.DeviceTemplateCountByLabels(labels)
+	}
+	if err != nil {
+		return templates, totalCount, errors.NewCommonEdgeXWrapper(err)
+	}
+	templates = make([]pkgDtos.DeviceTemplate, len(ts))
+	for i, t := range ts {
+		templates[i] = redactDeviceTemplateSecrets(dic, t)
+	}
+	return templates, totalCount, nil
+}
+
+// DeleteDeviceTemplateByName deletes the device template by name, a device template having instances can't be deleted
+func DeleteDeviceTemplateByName(name string, ctx context.Context, dic *di.Container) errors.EdgeX {
+	if name == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := container.DBClientFrom(dic.Get)
+	unlock, err := checkMetadataRevision(pkgCommon.DeviceTemplate, name, ctx, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	defer unlock()
+
+	count, err := dbClient.DeviceCountByTemplateName(name)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	if count > 0 {
+		return errors.NewCommonEdgeX(errors.KindStatusConflict, fmt.Sprintf("fail to delete the device template %s when instantiated devices exist", name), nil)
+	}
+	err = dbClient.DeleteDeviceTemplateByName(name)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return nil
+}
+
+// DeviceTemplateInstances query the devices instantiated from the device template with offset and limit
+func DeviceTemplateInstances(name string, offset int, limit int, dic *di.Container) (instances []pkgDtos.DeviceTemplateInstance, totalCount uint32, err errors.EdgeX) {
+	if name == "" {
+		return instances, totalCount, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := container.DBClientFrom(dic.Get)
+	t, err := dbClient.DeviceTemplateByName(name)
+	if err != nil {
+		return instances, totalCount, errors.NewCommonEdgeXWrapper(err)
+	}
+	devices, err := dbClient.DevicesByTemplateName(offset, limit, name)
+	if err == nil {
+		totalCount, err = dbClient.DeviceCountByTemplateName(name)
+	}
+	if err != nil {
+		return instances, totalCount, errors.NewCommonEdgeXWrapper(err)
+	}
+	instances = make([]pkgDtos.DeviceTemplateInstance, len(devices))
+	for i, d := range devices {
+		_, parameters := pkgModels.DeviceTemplateParameters(d)
+		instances[i] = pkgDtos.DeviceTemplateInstance{DeviceName: d.Name, Parameters: redactDeviceTemplateParameters(dic, t, parameters)}
+	}
+	return instances, totalCount, nil
+}
+
+// InstantiateDeviceTemplate renders the device template with the parameters and adds the resulting device, which
+// stays linked to the device template through its properties
+func InstantiateDeviceTemplate(name string, parameters map[string]string, ctx context.Context, dic *di.Container, bypassValidation bool) (id string, err errors.EdgeX) {
+	dbClient := container.DBClientFrom(dic.Get)
+	t, err := dbClient.DeviceTemplateByName(name)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	device, err := renderDeviceTemplate(t, parameters)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	id, err = AddDevice(device, ctx, dic, bypassValidation)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	return id, nil
+}
+
+// RolloutDeviceTemplate renders the device template again for every instance with the parameters the instance was
+// created with, and patches the instance with the result. The device name, AdminState and OperatingState of the
+// instances are kept, as well as the lifecycle state.
+func RolloutDeviceTemplate(name string, ctx context.Context, dic *di.Container, bypassValidation bool) (results []DeviceTemplateRolloutResult, err errors.EdgeX) {
+	dbClient := container.DBClientFrom(dic.Get)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	t, err := dbClient.DeviceTemplateByName(name)
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+	// the instances are loaded at once since patching an instance reorders the index they are paged from
+	devices, err := dbClient.DevicesByTemplateName(0, -1, name)
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+
+	results = make([]DeviceTemplateRolloutResult, len(devices))
+	for i, d := range devices {
+		results[i].DeviceName = d.Name
+		_, parameters := pkgModels.DeviceTemplateParameters(d)
+		rendered, err := renderDeviceTemplate(t, parameters)
+		if err != nil {
+			results[i].Err = errors.NewCommonEdgeXWrapper(err)
+			continue
+		}
+		if state, ok := d.Properties[pkgModels.DeviceLifecycleStateProperty]; ok {
+			rendered.Properties[pkgModels.DeviceLifecycleStateProperty] = state
+		}
+
+		renderedDTO := dtos.FromDeviceModelToDTO(rendered)
+		dto := dtos.UpdateDevice{
+			Name:        &d.Name,
+			Parent:      &renderedDTO.Parent,
+			Description: &renderedDTO.Description,
+			ServiceName: &renderedDTO.ServiceName,
+			ProfileName: &renderedDTO.ProfileName,
+			Labels:      renderedDTO.Labels,
+			Location:    renderedDTO.Location,
+			AutoEvents:  renderedDTO.AutoEvents,
+			Protocols:   renderedDTO.Protocols,
+			Tags:        renderedDTO.Tags,
+			Properties:  renderedDTO.Properties,
+		}
+		if dto.AutoEvents == nil {
+			dto.AutoEvents = []dtos.AutoEvent{}
+		}
+		err = PatchDevice(dto, ctx, dic, bypassValidation)
+		if err != nil {
+			results[i].Err = errors.NewCommonEdgeXWrapper(err)
+		}
+	}
+
+	lc.Debugf("DeviceTemplate %s rolled out to %d devices. Correlation-ID: %s ", name, len(devices), correlation.FromContext(ctx))
+	return results, nil
+}
+
+// renderDeviceTemplate renders the device of the device template with the parameters, fills the default states and
+// links the device to the device template. The rendered device is validated as a device DTO.
+func renderDeviceTemplate(t pkgModels.DeviceTemplate, parameters map[string]string) (models.Device, errors.EdgeX) {
+	device, err := pkgModels.RenderDeviceTemplate(t.Device, parameters)
+	if err != nil {
+		return device, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("failed to render device template %s", t.Name), err)
+	}
+	device.Id = ""
+	if device.AdminState == "" {
+		device.AdminState = models.Unlocked
+	}
+	if device.OperatingState == "" {
+		device.OperatingState = models.Up
+	}
+	properties := make(map[string]any, len(device.Properties)+2)
+	maps.Copy(properties, device.Properties)
+	properties[pkgModels.DeviceTemplateNameProperty] = t.Name
+	properties[pkgModels.DeviceTemplateParametersProperty] = maps.Clone(parameters)
+	device.Properties = properties
+
+	if err = common.Validate(dtos.FromDeviceModelToDTO(device)); err != nil {
+		return device, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("device rendered from device template %s is invalid", t.Name), err)
+	}
+	return device, nil
+}
+
+// validateDeviceTemplate renders the device template with every placeholder replaced by its name to check that the
+// instantiated devices would be valid, including that their secret protocol properties are secret references
+func validateDeviceTemplate(t pkgModels.DeviceTemplate, dic *di.Container) errors.EdgeX {
+	placeholders, err := pkgModels.DeviceTemplatePlaceholders(t.Device)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid device template %s", t.Name), err)
+	}
+	samples := make(map[string]string, len(placeholders))
+	for _, p := range placeholders {
+		samples[p] = p
+	}
+	device, edgeXerr := renderDeviceTemplate(t, samples)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	if edgeXerr = validateDeviceSecretReferences(dic, device); edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("device rendered from device template %s is invalid", t.Name), edgeXerr)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	metadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/infrastructure/interfaces"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// MetadataIntegrity checks the secondary indexes and the references between the metadata entities. When repair is
+// true, the indexes are rebuilt from the stored entities before the references are checked, so that the entities
+// missing from the indexes are checked as well.
+func MetadataIntegrity(repair bool, dic *di.Container) (pkgDtos.MetadataIntegrityReport, errors.EdgeX) {
+	dbClient := metadataContainer.DBClientFrom(dic.Get)
+	lc := container.LoggingClientFrom(dic.Get)
+
+	var report pkgDtos.MetadataIntegrityReport
+	integrity, err := dbClient.CheckMetadataIndexes(repair)
+	if err != nil {
+		return report, errors.NewCommonEdgeXWrapper(err)
+	}
+	if integrity.Rebuilt {
+		lc.Infof("Rebuilt the metadata indexes to repair %d inconsistencies", len(integrity.Inconsistencies))
+	}
+
+	references, err := danglingReferences(dbClient)
+	if err != nil {
+		return report, errors.NewCommonEdgeXWrapper(err)
+	}
+
+	report.Entities = integrity.Entities
+	report.Repaired = integrity.Rebuilt
+	report.IndexInconsistencies = make([]pkgDtos.IndexInconsistency, len(integrity.Inconsistencies))
+	for i, inconsistency := range integrity.Inconsistencies {
+		report.IndexInconsistencies[i] = pkgDtos.FromIndexInconsistencyModelToDTO(inconsistency)
+	}
+	report.DanglingReferences = make([]pkgDtos.DanglingReference, len(references))
+	for i, reference := range references {
+		report.DanglingReferences[i] = pkgDtos.FromDanglingReferenceModelToDTO(reference)
+	}
+	return report, nil
+}
+
+// danglingReferences returns the references of the devices, provision watchers and pending devices to the device
+// services, device profiles, device templates and parent devices which don't exist. The entities are paged through in
+// batches, so that only their names are held in memory.
+func danglingReferences(dbClient interfaces.DBClient) ([]pkgModels.DanglingReference, errors.EdgeX) {
+	serviceNames := make(map[string]bool)
+	err := forEachSearchBatch(dbClient.AllDeviceServices, func(s models.DeviceService) errors.EdgeX {
+		serviceNames[s.Name] = true
+		return nil
+	})
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+	profileNames := make(map[string]bool)
+	err = forEachSearchBatch(dbClient.AllDeviceProfiles, func(p models.DeviceProfile) errors.EdgeX {
+		profileNames[p.Name] = true
+		return nil
+	})
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+	templateNames := make(map[string]bool)
+	err = forEachSearchBatch(dbClient.AllDeviceTemplates, func(t pkgModels.DeviceTemplate) errors.EdgeX {
+		templateNames[t.Name] = true
+		return nil
+	})
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+
+	var references []pkgModels.DanglingReference
+	check := func(exists map[string]bool, entityType, name, field, reference string) {
+		if reference != "" && !exists[reference] {
+			references = append(references, pkgModels.DanglingReference{EntityType: entityType, Name: name, Field: field, Reference: reference})
+		}
+	}
+
+	// the parent devices are checked once all the device names are known
+	deviceNames := make(map[string]bool)
+	var children []models.Device
+	err = forEachSearchBatch(dbClient.AllDevices, func(d models.Device) errors.EdgeX {
+		deviceNames[d.Name] = true
+		check(serviceNames, common.DeviceSystemEventType, d.Name, "serviceName", d.ServiceName)
+		check(profileNames, common.DeviceSystemEventType, d.Name, "profileName", d.ProfileName)
+		templateName, _ := pkgModels.DeviceTemplateParameters(d)
+		check(templateNames, common.DeviceSystemEventType, d.Name, "properties."+pkgModels.DeviceTemplateNameProperty, templateName)
+		if d.Parent != "" {
+			children = append(children, models.Device{Name: d.Name, Parent: d.Parent})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+	for _, d := range children {
+		check(deviceNames, common.DeviceSystemEventType, d.Name, "parent", d.Parent)
+	}
+
+	err = forEachSearchBatch(dbClient.AllProvisionWatchers, func(pw models.ProvisionWatcher) errors.EdgeX {
+		check(serviceNames, common.ProvisionWatcherSystemEventType, pw.Name, "serviceName", pw.ServiceName)
+		check(profileNames, common.ProvisionWatcherSystemEventType, pw.Name, "discoveredDevice.profileName", pw.DiscoveredDevice.ProfileName)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+
+	allPendingDevices := func(offset int, limit int, _ []string) ([]pkgModels.PendingDevice, errors.EdgeX) {
+		pds, _, err := dbClient.PendingDevices("", offset, limit)
+		return pds, err
+	}
+	err = forEachSearchBatch(allPendingDevices, func(pd pkgModels.PendingDevice) errors.EdgeX {
+		check(serviceNames, pkgCommon.PendingDevice, pd.Device.Name, "device.serviceName", pd.Device.ServiceName)
+		check(profileNames, pkgCommon.PendingDevice, pd.Device.Name, "device.profileName", pd.Device.ProfileName)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+	return references, nil
+}
@@ -0,0 +1,140 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+)
+
+// metadataChangePollInterval is the interval a long-polling request checks the change log for new changes
+const metadataChangePollInterval = 500 * time.Millisecond
+
+var asyncPurgeMetadataChangesOnce sync.Once
+
+// MetadataChangesSince queries the metadata changes following the since cursor in the order they were made, and returns
+// the cursor to request the next changes. When there is no new change, the request waits for new changes up to the wait
+// duration. A since of 0 returns the changes from the oldest retained one, while a cursor whose following changes have
+// been purged is rejected, the consumer should resynchronize the whole metadata and continue with the latest cursor.
+func MetadataChangesSince(since uint64, limit int, wait time.Duration, ctx context.Context, dic *di.Container) (changes []pkgDtos.MetadataChange, cursor uint64, err errors.EdgeX) {
+	dbClient := container.DBClientFrom(dic.Get)
+
+	oldest, latest, err := dbClient.MetadataChangeSequenceRange()
+	if err != nil {
+		return nil, since, errors.NewCommonEdgeXWrapper(err)
+	}
+	if since > latest {
+		return nil, since, errors.NewCommonEdgeX(errors.KindRangeNotSatisfiable, fmt.Sprintf("cursor %d is ahead of the latest change %d, the metadata must be resynchronized", since, latest), nil)
+	}
+	if since > 0 && since < latest && (oldest == 0 || oldest > since+1) {
+		return nil, since, errors.NewCommonEdgeX(errors.KindRangeNotSatisfiable, fmt.Sprintf("changes following cursor %d have been purged, the metadata must be resynchronized", since), nil)
+	}
+
+	if since == latest && wait > 0 {
+		latest, err = waitForMetadataChanges(since, wait, ctx, dic)
+		if err != nil {
+			return nil, since, errors.NewCommonEdgeXWrapper(err)
+		}
+	}
+	if since == latest {
+		return []pkgDtos.MetadataChange{}, since, nil
+	}
+
+	changeModels, err := dbClient.MetadataChangesSince(since, limit)
+	if err != nil {
+		return nil, since, errors.NewCommonEdgeXWrapper(err)
+	}
+	cursor = since
+	changes = make([]pkgDtos.MetadataChange, len(changeModels))
+	for i, c := range changeModels {
+		dto, convertErr := pkgDtos.FromMetadataChangeModelToDTO(c)
+		if convertErr != nil {
+			return nil, since, errors.NewCommonEdgeX(errors.KindServerError, "failed to convert metadata change", convertErr)
+		}
+		dto.Entity = redactEntitySecrets(dic, dto.Entity)
+		changes[i] = dto
+		cursor = c.Sequence
+	}
+	return changes, cursor, nil
+}
+
+// waitForMetadataChanges polls the change log until a change following the since cursor is made, the wait duration
+// is elapsed or the request is cancelled, and returns the latest sequence
+func waitForMetadataChanges(since uint64, wait time.Duration, ctx context.Context, dic *di.Container) (uint64, errors.EdgeX) {
+	dbClient := container.DBClientFrom(dic.Get)
+
+	timeout := time.NewTimer(wait)
+	defer timeout.Stop()
+	ticker := time.NewTicker(metadataChangePollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return since, nil
+		case <-timeout.C:
+			return since, nil
+		case <-ticker.C:
+			_, latest, err := dbClient.MetadataChangeSequenceRange()
+			if err != nil {
+				return since, errors.NewCommonEdgeXWrapper(err)
+			}
+			if latest != since {
+				return latest, nil
+			}
+		}
+	}
+}
+
+// AsyncPurgeMetadataChanges purges the oldest metadata changes according to the change log capacity
+func AsyncPurgeMetadataChanges(interval time.Duration, ctx context.Context, dic *di.Container) {
+	asyncPurgeMetadataChangesOnce.Do(func() {
+		go func() {
+			lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+			timer := time.NewTimer(interval)
+			for {
+				timer.Reset(interval) // since the deletion might take lots of time, restart the timer to recount the time
+				select {
+				case <-ctx.Done():
+					lc.Info("Exiting metadata change log purging")
+					return
+				case <-timer.C:
+					err := purgeMetadataChanges(dic)
+					if err != nil {
+						lc.Errorf("Failed to purge metadata changes, %v", err)
+					}
+				}
+			}
+		}()
+	})
+}
+
+func purgeMetadataChanges(dic *di.Container) errors.EdgeX {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	dbClient := container.DBClientFrom(dic.Get)
+	config := container.ConfigurationFrom(dic.Get)
+
+	total, err := dbClient.MetadataChangeTotalCount()
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.Kind(err), "failed to query metadata change total count", err)
+	}
+	if total >= config.ChangeLog.MaxCap {
+		lc.Debugf("Purging the metadata change amount %d to the minimum capacity %d", total, config.ChangeLog.MinCap)
+		err = dbClient.TrimMetadataChanges(config.ChangeLog.MinCap)
+		if err != nil {
+			return errors.NewCommonEdgeXWrapper(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/config"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/infrastructure/interfaces/mocks"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+func TestMetadataChangesSince(t *testing.T) {
+	device := models.Device{Name: "boiler-01", ServiceName: "device-modbus", ProfileName: "boiler-profile",
+		Protocols: map[string]models.ProtocolProperties{"snmp": {"Address": "10.0.0.1", "Community": "public"}}}
+	entity, err := json.Marshal(device)
+	require.NoError(t, err)
+	changes := []pkgModels.MetadataChange{
+		{Sequence: 11, Type: common.DeviceSystemEventType, Action: common.SystemEventActionAdd, Name: device.Name, Entity: entity},
+		{Sequence: 12, Type: common.DeviceSystemEventType, Action: common.SystemEventActionDelete, Name: device.Name, Entity: entity},
+	}
+
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{Writable: config.WritableInfo{ProtocolSecrets: config.ProtocolSecrets{Properties: []string{"Community"}}}}
+		},
+	})
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("MetadataChangeSequenceRange").Return(uint64(10), uint64(12), nil)
+	dbClientMock.On("MetadataChangesSince", uint64(10), 20).Return(changes, nil)
+	dbClientMock.On("MetadataChangesSince", uint64(0), 20).Return(changes, nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	result, cursor, edgeXerr := MetadataChangesSince(10, 20, 0, context.Background(), dic)
+	require.NoError(t, edgeXerr)
+	assert.Equal(t, uint64(12), cursor)
+	require.Len(t, result, 2)
+	assert.Equal(t, common.SystemEventActionDelete, result[1].Action)
+	require.IsType(t, dtos.Device{}, result[0].Entity)
+	assert.Equal(t, device.ServiceName, result[0].Entity.(dtos.Device).ServiceName)
+	assert.Equal(t, pkgModels.RedactedValue, result[0].Entity.(dtos.Device).Protocols["snmp"]["Community"], "the plain text secret should be redacted")
+
+	// a since of 0 returns the retained changes even if the older changes have been purged
+	_, cursor, edgeXerr = MetadataChangesSince(0, 20, 0, context.Background(), dic)
+	require.NoError(t, edgeXerr)
+	assert.Equal(t, uint64(12), cursor)
+
+	// the cursor is the latest sequence, no change is returned
+	result, cursor, edgeXerr = MetadataChangesSince(12, 20, 0, context.Background(), dic)
+	require.NoError(t, edgeXerr)
+	assert.Equal(t, uint64(12), cursor)
+	assert.Empty(t, result)
+
+	// the changes following the cursor have been purged
+	_, _, edgeXerr = MetadataChangesSince(5, 20, 0, context.Background(), dic)
+	require.Error(t, edgeXerr)
+	assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, edgeXerr.Code())
+
+	// the cursor is ahead of the change log
+	_, _, edgeXerr = MetadataChangesSince(13, 20, 0, context.Background(), dic)
+	require.Error(t, edgeXerr)
+	assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, edgeXerr.Code())
+}
+
+func TestMetadataChangesSinceWait(t *testing.T) {
+	change := pkgModels.MetadataChange{Sequence: 4, Type: common.DeviceServiceSystemEventType, Action: common.SystemEventActionUpdate, Name: "device-modbus"}
+
+	dic := di.NewContainer(di.ServiceConstructorMap{})
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("MetadataChangeSequenceRange").Return(uint64(1), uint64(3), nil).Twice()
+	dbClientMock.On("MetadataChangeSequenceRange").Return(uint64(1), uint64(4), nil)
+	dbClientMock.On("MetadataChangesSince", uint64(3), 20).Return([]pkgModels.MetadataChange{change}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	result, cursor, edgeXerr := MetadataChangesSince(3, 20, 10*time.Second, context.Background(), dic)
+	require.NoError(t, edgeXerr)
+	assert.Equal(t, uint64(4), cursor)
+	require.Len(t, result, 1)
+	assert.Equal(t, change.Name, result[0].Name)
+
+	// the request returns without changes once the wait duration is elapsed
+	dbClientMock = &mocks.DBClient{}
+	dbClientMock.On("MetadataChangeSequenceRange").Return(uint64(1), uint64(3), nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	result, cursor, edgeXerr = MetadataChangesSince(3, 20, time.Millisecond, context.Background(), dic)
+	require.NoError(t, edgeXerr)
+	assert.Equal(t, uint64(3), cursor)
+	assert.Empty(t, result)
+}
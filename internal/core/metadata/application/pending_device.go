@@ -0,0 +1,199 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// StageDiscoveredDevice stages the device as a pending device instead of adding it when the discovery approval is
+// required and the device is added by its device service, or is marked with the name of the provision watcher in the
+// ProvisionWatcherName property. A rediscovered pending device keeps the staged device, which may have been edited by
+// an operator, and a rejected device stays rejected. The staged result is false when the device must be added as usual.
+func StageDiscoveredDevice(d models.Device, ctx context.Context, dic *di.Container) (id string, staged bool, edgeXerr errors.EdgeX) {
+	if !container.ConfigurationFrom(dic.Get).Writable.Discovery.ApprovalRequired {
+		return "", false, nil
+	}
+	dbClient := container.DBClientFrom(dic.Get)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	// the duplicate device is rejected by adding it
+	exists, edgeXerr := dbClient.DeviceNameExists(d.Name)
+	if edgeXerr != nil {
+		return "", false, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return "", false, nil
+	}
+	pwName := discoveringProvisionWatcher(d)
+	if pwName == "" && !addedByDeviceService(d, ctx) {
+		return "", false, nil
+	}
+
+	pd, edgeXerr := dbClient.PendingDeviceByName(d.Name)
+	if edgeXerr != nil && errors.Kind(edgeXerr) != errors.KindEntityDoesNotExist {
+		return "", false, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if edgeXerr == nil {
+		pd.LastDiscovered = pkgCommon.MakeTimestamp()
+		if edgeXerr = dbClient.UpdatePendingDevice(pd); edgeXerr != nil {
+			return "", false, errors.NewCommonEdgeXWrapper(edgeXerr)
+		}
+		lc.Debugf("Device %s is rediscovered while %s. Correlation-ID: %s", d.Name, pd.Status, correlation.FromContext(ctx))
+		return pd.Id, true, nil
+	}
+
+	pd, edgeXerr = dbClient.AddPendingDevice(pkgModels.PendingDevice{
+		Status:               pkgModels.PendingDeviceStatusPending,
+		ProvisionWatcherName: pwName,
+		LastDiscovered:       pkgCommon.MakeTimestamp(),
+		Device:               d,
+	})
+	if edgeXerr != nil {
+		return "", false, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	lc.Infof("Device %s added by device service %s is pending approval. Correlation-ID: %s", d.Name, d.ServiceName, correlation.FromContext(ctx))
+	return pd.Id, true, nil
+}
+
+// discoveringProvisionWatcher returns the name of the provision watcher which the device service marks the discovered
+// device with, the name is empty when the device isn't discovered
+func discoveringProvisionWatcher(d models.Device) string {
+	name, _ := d.Properties[pkgModels.ProvisionWatcherNameProperty].(string)
+	return name
+}
+
+// addedByDeviceService checks whether the device is added by its device service, which authenticates with its service
+// key as identity. The origin can't be determined when the JWTs aren't verified, the device is then considered as
+// added by its device service so that the approval fails closed.
+func addedByDeviceService(d models.Device, ctx context.Context) bool {
+	if !identity.IsJWTValidationEnabled() {
+		return true
+	}
+	return identity.FromContext(ctx) == d.ServiceName
+}
+
+// PendingDevices queries the pending devices by status, all the pending devices are queried when the status is empty
+func PendingDevices(status string, offset int, limit int, dic *di.Container) (pendingDevices []pkgDtos.PendingDevice, totalCount uint32, err errors.EdgeX) {
+	if status != "" && status != pkgModels.PendingDeviceStatusPending && status != pkgModels.PendingDeviceStatusRejected {
+		return nil, 0, errors.NewCommonEdgeX(errors.KindContractInvalid,
+			fmt.Sprintf("status %s is invalid, must be %s or %s", status, pkgModels.PendingDeviceStatusPending, pkgModels.PendingDeviceStatusRejected), nil)
+	}
+	pds, totalCount, err := container.DBClientFrom(dic.Get).PendingDevices(status, offset, limit)
+	if err != nil {
+		return nil, 0, errors.NewCommonEdgeXWrapper(err)
+	}
+	pendingDevices = make([]pkgDtos.PendingDevice, len(pds))
+	for i, pd := range pds {
+		pendingDevices[i] = pkgDtos.FromPendingDeviceModelToDTO(pd)
+		pendingDevices[i].Device.Protocols = redactProtocolSecrets(dic, pendingDevices[i].Device.Protocols)
+	}
+	return pendingDevices, totalCount, nil
+}
+
+// PendingDeviceByName queries the pending device by the name of the device
+func PendingDeviceByName(name string, dic *di.Container) (pkgDtos.PendingDevice, errors.EdgeX) {
+	if name == "" {
+		return pkgDtos.PendingDevice{}, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	pd, err := container.DBClientFrom(dic.Get).PendingDeviceByName(name)
+	if err != nil {
+		return pkgDtos.PendingDevice{}, errors.NewCommonEdgeXWrapper(err)
+	}
+	pendingDevice := pkgDtos.FromPendingDeviceModelToDTO(pd)
+	pendingDevice.Device.Protocols = redactProtocolSecrets(dic, pendingDevice.Device.Protocols)
+	return pendingDevice, nil
+}
+
+// PatchPendingDevice edits the staged device before it's approved, the name of the device can't be changed
+func PatchPendingDevice(name string, patch dtos.UpdateDevice, dic *di.Container) errors.EdgeX {
+	if patch.Name != nil && *patch.Name != name {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("the name of pending device %s can't be changed", name), nil)
+	}
+	dbClient := container.DBClientFrom(dic.Get)
+	pd, err := dbClient.PendingDeviceByName(name)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	requests.ReplaceDeviceModelFieldsWithDTO(&pd.Device, patch)
+	if err = validateDeviceLocation(pd.Device); err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	if patch.Protocols != nil {
+		if err = validateDeviceSecretReferences(dic, pd.Device); err != nil {
+			return errors.NewCommonEdgeXWrapper(err)
+		}
+	}
+	if err = dbClient.UpdatePendingDevice(pd); err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return nil
+}
+
+// ApprovePendingDevice adds the staged device as a device and removes the pending device in a single DB transaction.
+// The device is validated and added the same way as a device added through the API, so the pending device is kept
+// when the device is invalid.
+func ApprovePendingDevice(name string, bypassValidation bool, ctx context.Context, dic *di.Container) (string, errors.EdgeX) {
+	if name == "" {
+		return "", errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := container.DBClientFrom(dic.Get)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	pd, err := dbClient.PendingDeviceByName(name)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	id, err := addDevice(pd.Device, ctx, dic, bypassValidation, dbClient.ApprovePendingDevice)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	lc.Infof("Pending device %s is approved. Correlation-ID: %s", name, correlation.FromContext(ctx))
+	return id, nil
+}
+
+// RejectPendingDevice rejects the pending device, which is kept as rejected so that it isn't staged again when
+// rediscovered until it's deleted
+func RejectPendingDevice(name string, ctx context.Context, dic *di.Container) errors.EdgeX {
+	if name == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := container.DBClientFrom(dic.Get)
+	pd, err := dbClient.PendingDeviceByName(name)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	pd.Status = pkgModels.PendingDeviceStatusRejected
+	if err = dbClient.UpdatePendingDevice(pd); err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	bootstrapContainer.LoggingClientFrom(dic.Get).Infof("Pending device %s is rejected. Correlation-ID: %s", name, correlation.FromContext(ctx))
+	return nil
+}
+
+// DeletePendingDeviceByName deletes the pending device, a deleted device is staged again when rediscovered
+func DeletePendingDeviceByName(name string, dic *di.Container) errors.EdgeX {
+	if name == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	if err := container.DBClientFrom(dic.Get).DeletePendingDeviceByName(name); err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return nil
+}
@@ -123,6 +123,12 @@ func DeleteProvisionWatcherByName(ctx context.Context, name string, dic *di.Cont
 		return errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
 	}
 	dbClient := container.DBClientFrom(dic.Get)
+	unlock, err := checkMetadataRevision(common.ProvisionWatcherSystemEventType, name, ctx, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	defer unlock()
+
 	pw, err := dbClient.ProvisionWatcherByName(name)
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
@@ -140,6 +146,13 @@ func PatchProvisionWatcher(ctx context.Context, dto dtos.UpdateProvisionWatcher,
 	dbClient := container.DBClientFrom(dic.Get)
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 
+	name, err := provisionWatcherNameByDTO(dbClient, dto)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	unlock := lockMetadataEntity(common.ProvisionWatcherSystemEventType + ":" + name)
+	defer unlock()
+
 	pw, err := provisionWatcherByDTO(dbClient, dto)
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
@@ -153,6 +166,11 @@ func PatchProvisionWatcher(ctx context.Context, dto dtos.UpdateProvisionWatcher,
 
 	requests.ReplaceProvisionWatcherModelFieldsWithDTO(&pw, dto)
 
+	err = matchMetadataRevision(common.ProvisionWatcherSystemEventType, name, ctx, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
 	err = dbClient.UpdateProvisionWatcher(pw)
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
@@ -167,6 +185,19 @@ func PatchProvisionWatcher(ctx context.Context, dto dtos.UpdateProvisionWatcher,
 	return nil
 }
 
+// provisionWatcherNameByDTO returns the name of the provision watcher to patch, which is queried by ID when the DTO carries
+// no name
+func provisionWatcherNameByDTO(dbClient interfaces.DBClient, dto dtos.UpdateProvisionWatcher) (string, errors.EdgeX) {
+	if dto.Name != nil && *dto.Name != "" {
+		return *dto.Name, nil
+	}
+	pw, edgeXerr := provisionWatcherByDTO(dbClient, dto)
+	if edgeXerr != nil {
+		return "", errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return pw.Name, nil
+}
+
 func provisionWatcherByDTO(dbClient interfaces.DBClient, dto dtos.UpdateProvisionWatcher) (pw models.ProvisionWatcher, edgexErr errors.EdgeX) {
 	// The ID or Name is required by DTO and the DTO also accepts empty string ID if the Name is provided
 	if dto.Id != nil && *dto.Id != "" {
@@ -0,0 +1,178 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// ProvisionWatcherDryRunResult holds the outcome of matching a candidate device against the provision watchers
+type ProvisionWatcherDryRunResult struct {
+	Matches                 []pkgDtos.ProvisionWatcherMatch
+	MatchedProvisionWatcher string
+	DeviceExists            bool
+	Device                  *dtos.Device
+}
+
+// DryRunProvisionWatchers simulates the provisioning of a discovered device. Every provision watcher, or only the
+// ones of the specified device service, is evaluated the same way a device service does after a discovery: the
+// watcher must be UNLOCKED, a single protocol of the device must hold every identifier with a value matched by the
+// identifier regex, and no protocol may hold a blocking identifier equal to one of its values. Nothing is written to
+// the database.
+func DryRunProvisionWatchers(candidate pkgDtos.CandidateDevice, serviceName string, dic *di.Container) (result ProvisionWatcherDryRunResult, err errors.EdgeX) {
+	dbClient := container.DBClientFrom(dic.Get)
+
+	var pws []models.ProvisionWatcher
+	if serviceName == "" {
+		pws, err = dbClient.AllProvisionWatchers(0, -1, nil)
+	} else {
+		pws, err = dbClient.ProvisionWatchersByServiceName(0, -1, serviceName)
+	}
+	if err != nil {
+		return result, errors.NewCommonEdgeXWrapper(err)
+	}
+
+	result.DeviceExists, err = dbClient.DeviceNameExists(candidate.Name)
+	if err != nil {
+		return result, errors.NewCommonEdgeXWrapper(err)
+	}
+
+	protocols := dtos.ToProtocolModels(candidate.Protocols)
+	result.Matches = make([]pkgDtos.ProvisionWatcherMatch, len(pws))
+	for i, pw := range pws {
+		match := matchProvisionWatcher(pw, protocols)
+		result.Matches[i] = match
+		if match.Matched && !match.Blocked && result.MatchedProvisionWatcher == "" {
+			result.MatchedProvisionWatcher = pw.Name
+			if !result.DeviceExists {
+				device := redactDeviceSecrets(dic, provisionedDevice(pw, candidate, protocols))
+				result.Device = &device
+			}
+		}
+	}
+	return result, nil
+}
+
+// matchProvisionWatcher evaluates the identifiers and blocking identifiers of the provision watcher against the
+// protocol properties of the candidate device as the device services do, and explains why the provision watcher
+// doesn't apply. The identifiers are matched when any single protocol satisfies all of them, and the device is blocked
+// when any protocol holds a blocked value.
+func matchProvisionWatcher(pw models.ProvisionWatcher, protocols map[string]models.ProtocolProperties) pkgDtos.ProvisionWatcherMatch {
+	match := pkgDtos.ProvisionWatcherMatch{
+		Name:        pw.Name,
+		ServiceName: pw.ServiceName,
+		Matched:     true,
+	}
+	if pw.AdminState == models.Locked {
+		match.Matched = false
+		match.Reasons = append(match.Reasons, "provision watcher is LOCKED")
+	}
+
+	identifiersMatched := false
+	var mismatches []string
+	for _, protocol := range sortedKeys(protocols) {
+		reasons := identifierMismatches(pw.Identifiers, protocols[protocol])
+		if len(reasons) == 0 {
+			identifiersMatched = true
+			break
+		}
+		for _, reason := range reasons {
+			mismatches = append(mismatches, fmt.Sprintf("protocol '%s': %s", protocol, reason))
+		}
+	}
+	if !identifiersMatched {
+		match.Matched = false
+		if len(protocols) == 0 {
+			mismatches = append(mismatches, "no protocol properties to match the identifiers")
+		}
+		match.Reasons = append(match.Reasons, mismatches...)
+	}
+
+	for _, name := range sortedKeys(pw.BlockingIdentifiers) {
+		for _, protocol := range sortedKeys(protocols) {
+			value, ok := protocols[protocol][name]
+			if !ok {
+				continue
+			}
+			for _, blocked := range pw.BlockingIdentifiers[name] {
+				if value == any(blocked) {
+					match.Blocked = true
+					match.Reasons = append(match.Reasons, fmt.Sprintf("protocol '%s': blocking identifier '%s' value '%s' is blocked", protocol, name, blocked))
+				}
+			}
+		}
+	}
+	return match
+}
+
+// identifierMismatches returns why the properties of a protocol don't satisfy every identifier, it's empty when they do
+func identifierMismatches(identifiers map[string]string, properties models.ProtocolProperties) []string {
+	var reasons []string
+	for _, name := range sortedKeys(identifiers) {
+		pattern := identifiers[name]
+		value, ok := properties[name]
+		if !ok {
+			reasons = append(reasons, fmt.Sprintf("identifier '%s' not found", name))
+			continue
+		}
+		s := fmt.Sprintf("%v", value)
+		if s == "" {
+			reasons = append(reasons, fmt.Sprintf("identifier '%s' is empty", name))
+			continue
+		}
+		matched, err := regexp.MatchString(pattern, s)
+		if err != nil {
+			reasons = append(reasons, fmt.Sprintf("identifier '%s' has an invalid regex '%s': %v", name, pattern, err))
+		} else if !matched {
+			reasons = append(reasons, fmt.Sprintf("identifier '%s' value '%s' doesn't match regex '%s'", name, s, pattern))
+		}
+	}
+	return reasons
+}
+
+// provisionedDevice builds the device which the device service would create from the candidate device with the
+// provision watcher, the properties of the candidate device take precedence over the provision watcher ones and the
+// device is marked as discovered by the provision watcher
+func provisionedDevice(pw models.ProvisionWatcher, candidate pkgDtos.CandidateDevice, protocols map[string]models.ProtocolProperties) models.Device {
+	properties := make(map[string]any, len(pw.DiscoveredDevice.Properties)+len(candidate.Properties)+1)
+	maps.Copy(properties, pw.DiscoveredDevice.Properties)
+	maps.Copy(properties, candidate.Properties)
+	properties[pkgModels.ProvisionWatcherNameProperty] = pw.Name
+	return models.Device{
+		Name:           candidate.Name,
+		Description:    candidate.Description,
+		Labels:         candidate.Labels,
+		ServiceName:    pw.ServiceName,
+		ProfileName:    pw.DiscoveredDevice.ProfileName,
+		AdminState:     pw.DiscoveredDevice.AdminState,
+		OperatingState: models.Up,
+		AutoEvents:     pw.DiscoveredDevice.AutoEvents,
+		Protocols:      protocols,
+		Properties:     properties,
+	}
+}
+
+// sortedKeys returns the keys of the map in ascending order so that the reasons are reported in a stable order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
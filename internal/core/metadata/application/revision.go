@@ -0,0 +1,129 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
+)
+
+type ifMatchKey struct{}
+
+// NewIfMatchContext returns a copy of the context carrying the If-Match header of a conditional write
+func NewIfMatchContext(ctx context.Context, ifMatch string) context.Context {
+	return context.WithValue(ctx, ifMatchKey{}, strings.TrimSpace(ifMatch))
+}
+
+func ifMatchFromContext(ctx context.Context) string {
+	ifMatch, _ := ctx.Value(ifMatchKey{}).(string)
+	return ifMatch
+}
+
+// MetadataETag formats the revision of a metadata entity as an entity tag
+func MetadataETag(revision uint64) string {
+	return strconv.Quote(strconv.FormatUint(revision, 10))
+}
+
+// MetadataEntityETag returns the entity tag of the metadata entity with the specified type and name
+func MetadataEntityETag(entityType string, name string, dic *di.Container) (string, errors.EdgeX) {
+	revision, err := container.DBClientFrom(dic.Get).MetadataRevision(entityType, name)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	return MetadataETag(revision), nil
+}
+
+// matchIfMatch checks whether any entity tag listed in the If-Match header matches the revision. The weak entity tags
+// are compared by their value, and '*' matches any revision.
+func matchIfMatch(ifMatch string, revision uint64) bool {
+	etag := MetadataETag(revision)
+	for _, tag := range strings.Split(ifMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// metadataEntityLocks serializes the writes of the same metadata entity, so that the revision checked against the
+// If-Match header can't be changed by another write until the conditional write completes
+var metadataEntityLocks = struct {
+	mutex sync.Mutex
+	locks map[string]*metadataEntityLock
+}{locks: make(map[string]*metadataEntityLock)}
+
+type metadataEntityLock struct {
+	sync.Mutex
+	refs int
+}
+
+func lockMetadataEntity(key string) (unlock func()) {
+	metadataEntityLocks.mutex.Lock()
+	lock, ok := metadataEntityLocks.locks[key]
+	if !ok {
+		lock = &metadataEntityLock{}
+		metadataEntityLocks.locks[key] = lock
+	}
+	lock.refs++
+	metadataEntityLocks.mutex.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		metadataEntityLocks.mutex.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(metadataEntityLocks.locks, key)
+		}
+		metadataEntityLocks.mutex.Unlock()
+	}
+}
+
+// checkMetadataRevision locks the metadata entity for writing and checks its revision against the If-Match header
+// carried by the context, the returned unlock function must be called once the write completes. The check is skipped
+// when the context carries no If-Match header, and the error wraps utils.ErrPreconditionFailed when the revision
+// doesn't match.
+//
+// The lock must be taken before the entity is read, so that the entity written is the one checked against the If-Match
+// header, and a concurrent write isn't overwritten with the fields read before it.
+func checkMetadataRevision(entityType string, name string, ctx context.Context, dic *di.Container) (unlock func(), edgeXerr errors.EdgeX) {
+	unlock = lockMetadataEntity(entityType + ":" + name)
+	if err := matchMetadataRevision(entityType, name, ctx, dic); err != nil {
+		unlock()
+		return nil, err
+	}
+	return unlock, nil
+}
+
+// matchMetadataRevision checks the revision of the metadata entity against the If-Match header carried by the context,
+// the caller must hold the lock of the entity taken by lockMetadataEntity
+func matchMetadataRevision(entityType string, name string, ctx context.Context, dic *di.Container) errors.EdgeX {
+	ifMatch := ifMatchFromContext(ctx)
+	if ifMatch == "" {
+		return nil
+	}
+
+	revision, err := container.DBClientFrom(dic.Get).MetadataRevision(entityType, name)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	if !matchIfMatch(ifMatch, revision) {
+		return errors.NewCommonEdgeX(errors.KindStatusConflict,
+			fmt.Sprintf("%s '%s' has been modified, the current entity tag %s doesn't match If-Match %s", entityType, name, MetadataETag(revision), ifMatch),
+			utils.ErrPreconditionFailed)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/config"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/infrastructure/interfaces/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
+)
+
+func TestMatchIfMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		ifMatch  string
+		revision uint64
+		matched  bool
+	}{
+		{"strong entity tag", `"7"`, 7, true},
+		{"weak entity tag", `W/"7"`, 7, true},
+		{"entity tag list", `"5", "7"`, 7, true},
+		{"any entity tag", "*", 7, true},
+		{"stale entity tag", `"5"`, 7, false},
+		{"unquoted entity tag", "7", 7, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.matched, matchIfMatch(testCase.ifMatch, testCase.revision))
+		})
+	}
+}
+
+func TestCheckMetadataRevision(t *testing.T) {
+	dic := di.NewContainer(di.ServiceConstructorMap{})
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("MetadataRevision", common.DeviceSystemEventType, "device-01").Return(uint64(7), nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	unlock, err := checkMetadataRevision(common.DeviceSystemEventType, "device-01", context.Background(), dic)
+	require.NoError(t, err)
+	unlock()
+	dbClientMock.AssertNotCalled(t, "MetadataRevision", common.DeviceSystemEventType, "device-01")
+
+	unlock, err = checkMetadataRevision(common.DeviceSystemEventType, "device-01", NewIfMatchContext(context.Background(), `"7"`), dic)
+	require.NoError(t, err)
+	unlock()
+
+	_, err = checkMetadataRevision(common.DeviceSystemEventType, "device-01", NewIfMatchContext(context.Background(), `"6"`), dic)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusPreconditionFailed, utils.StatusCode(err))
+
+	// the entity is unlocked after the failed check
+	unlock, err = checkMetadataRevision(common.DeviceSystemEventType, "device-01", context.Background(), dic)
+	require.NoError(t, err)
+	unlock()
+	assert.Empty(t, metadataEntityLocks.locks)
+}
+
+func TestPatchDeviceProfileResourceConcurrently(t *testing.T) {
+	resourceNames := []string{"temperature", "humidity", "pressure", "voltage"}
+	profile := models.DeviceProfile{Name: "sensor"}
+	for _, name := range resourceNames {
+		profile.DeviceResources = append(profile.DeviceResources, models.DeviceResource{Name: name})
+	}
+
+	// the profile is read from and written to the stored profile, and each read is slowed down so that the concurrent
+	// patches would overwrite each other if the profile was read before it's locked
+	var mutex sync.Mutex
+	stored := profile
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("DeviceProfileByName", profile.Name).Return(func(string) models.DeviceProfile {
+		mutex.Lock()
+		p := stored
+		p.DeviceResources = append([]models.DeviceResource(nil), stored.DeviceResources...)
+		mutex.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		return p
+	}, nil)
+	dbClientMock.On("UpdateDeviceProfile", mock.Anything).Run(func(args mock.Arguments) {
+		mutex.Lock()
+		stored = args.Get(0).(models.DeviceProfile)
+		mutex.Unlock()
+	}).Return(nil)
+	dbClientMock.On("DevicesByProfileName", 0, -1, profile.Name).Return([]models.Device{}, nil)
+	dbClientMock.On("DeviceCountByProfileName", profile.Name).Return(uint32(0), nil)
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{}
+		},
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	var wg sync.WaitGroup
+	for _, name := range resourceNames {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			description := name + " patched"
+			err := PatchDeviceProfileResource(profile.Name, dtos.UpdateDeviceResource{Name: &name, Description: &description}, context.Background(), dic)
+			assert.NoError(t, err)
+		}(name)
+	}
+	wg.Wait()
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	for i, name := range resourceNames {
+		assert.Equal(t, name+" patched", stored.DeviceResources[i].Description, "the patch of %s is lost", name)
+	}
+}
@@ -0,0 +1,325 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+)
+
+// SearchTypes lists the entity types supported by the metadata search, in the order of the search result
+var SearchTypes = []string{pkgCommon.SearchTypeDevice, pkgCommon.SearchTypeDeviceProfile, pkgCommon.SearchTypeDeviceService}
+
+// searchBatchSize is the number of entities of a type loaded from the DB at once while searching
+var searchBatchSize = 500
+
+// searchTerm is a single condition of the search query. A term without path is a full-text term which matches any
+// string value of the entity, otherwise every value must match at least one entry found under the path.
+type searchTerm struct {
+	path     []string
+	patterns []*regexp.Regexp
+	negate   bool
+}
+
+// SearchResult holds a page of the matched entities and the number of matched entities per type
+type SearchResult struct {
+	Counts         map[string]uint32
+	TotalCount     uint32
+	Devices        []dtos.Device
+	DeviceProfiles []dtos.DeviceProfile
+	DeviceServices []dtos.DeviceService
+}
+
+// parseSearchQuery parses the query into search terms. The query is a space separated list of terms which must all
+// match, where each term is one of
+//   - field=value, the dotted field path is resolved against the JSON representation of the entity, e.g. protocols.modbus-tcp.Address=10.0.*
+//   - field!=value, the field doesn't match the value
+//   - value, a full-text term matching any string value of the entity
+//
+// Values are case-insensitive and support the '*' and '?' wildcards, a comma separated value like labels=a,b requires
+// every value to be matched. Double quotes can be used for the terms containing spaces.
+func parseSearchQuery(query string) ([]searchTerm, errors.EdgeX) {
+	tokens, err := tokenizeSearchQuery(query)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "invalid search query", err)
+	}
+	if len(tokens) == 0 {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "search query is empty", nil)
+	}
+
+	terms := make([]searchTerm, 0, len(tokens))
+	for _, token := range tokens {
+		var term searchTerm
+		field, value, found := strings.Cut(token, "=")
+		if !found {
+			term.patterns = []*regexp.Regexp{wildcardToRegexp("*" + strings.Trim(token, "*") + "*")}
+			terms = append(terms, term)
+			continue
+		}
+		if strings.HasSuffix(field, "!") {
+			term.negate = true
+			field = strings.TrimSuffix(field, "!")
+		}
+		if field == "" {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("missing field name in search term '%s'", token), nil)
+		}
+		term.path = strings.Split(field, ".")
+		for _, v := range strings.Split(value, ",") {
+			term.patterns = append(term.patterns, wildcardToRegexp(v))
+		}
+		terms = append(terms, term)
+	}
+	return terms, nil
+}
+
+// tokenizeSearchQuery splits the query by spaces, the spaces enclosed by double quotes are kept
+func tokenizeSearchQuery(query string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	quoted := false
+	for _, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ' ' && !quoted:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote in '%s'", query)
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens, nil
+}
+
+// wildcardToRegexp converts the wildcard pattern into a case-insensitive regular expression matching the whole value
+func wildcardToRegexp(pattern string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString("(?i)^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
+
+// matchSearchTerms checks whether the entity matches all the search terms
+func matchSearchTerms(entity any, terms []searchTerm) (bool, errors.EdgeX) {
+	bytes, err := json.Marshal(entity)
+	if err != nil {
+		return false, errors.NewCommonEdgeX(errors.KindServerError, "failed to encode entity for searching", err)
+	}
+	var document any
+	if err = json.Unmarshal(bytes, &document); err != nil {
+		return false, errors.NewCommonEdgeX(errors.KindServerError, "failed to decode entity for searching", err)
+	}
+
+	for _, term := range terms {
+		var values []string
+		if term.path == nil {
+			values = collectSearchValues(document, nil, true)
+		} else {
+			values = collectSearchValues(document, term.path, false)
+		}
+		if matchSearchTerm(values, term) == term.negate {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// matchSearchTerm checks whether every pattern of the term matches at least one of the values
+func matchSearchTerm(values []string, term searchTerm) bool {
+	for _, pattern := range term.patterns {
+		if !slices.ContainsFunc(values, pattern.MatchString) {
+			return false
+		}
+	}
+	return true
+}
+
+// collectSearchValues returns the scalar values found under the path, the arrays on the path are flattened.
+// The map keys are matched case-insensitively. All the nested values are collected when recursive is true.
+func collectSearchValues(node any, path []string, recursive bool) []string {
+	switch v := node.(type) {
+	case map[string]any:
+		if len(path) == 0 {
+			if !recursive {
+				return nil
+			}
+			var values []string
+			for _, child := range v {
+				values = append(values, collectSearchValues(child, nil, true)...)
+			}
+			return values
+		}
+		for key, child := range v {
+			if strings.EqualFold(key, path[0]) {
+				return collectSearchValues(child, path[1:], recursive)
+			}
+		}
+		return nil
+	case []any:
+		var values []string
+		for _, child := range v {
+			values = append(values, collectSearchValues(child, path, recursive)...)
+		}
+		return values
+	case nil:
+		return nil
+	default:
+		if len(path) > 0 {
+			return nil
+		}
+		return []string{fmt.Sprint(v)}
+	}
+}
+
+// forEachSearchBatch loads the entities with all in successive batches of searchBatchSize and calls f for each of them,
+// so that the search never holds more than a batch of the entities of a large fleet. The wildcard and case-insensitive
+// terms can't be resolved by the exact DB indexes, so every entity is still matched once.
+func forEachSearchBatch[M any](all func(offset int, limit int, labels []string) ([]M, errors.EdgeX), f func(M) errors.EdgeX) errors.EdgeX {
+	for offset := 0; ; offset += searchBatchSize {
+		batch, err := all(offset, searchBatchSize, nil)
+		if errors.Kind(err) == errors.KindRangeNotSatisfiable {
+			// the remaining entities were deleted since the previous batch
+			return nil
+		}
+		if err != nil {
+			return errors.NewCommonEdgeXWrapper(err)
+		}
+		for _, entity := range batch {
+			if err = f(entity); err != nil {
+				return errors.NewCommonEdgeXWrapper(err)
+			}
+		}
+		if len(batch) < searchBatchSize {
+			return nil
+		}
+	}
+}
+
+// Search queries the devices, device profiles and device services matching the query. The matched entities are
+// ordered by type as listed in SearchTypes, and the offset and limit apply to the whole result.
+func Search(query string, types []string, offset int, limit int, dic *di.Container) (result SearchResult, err errors.EdgeX) {
+	terms, err := parseSearchQuery(query)
+	if err != nil {
+		return result, errors.NewCommonEdgeXWrapper(err)
+	}
+	if len(types) == 0 {
+		types = SearchTypes
+	}
+	for _, t := range types {
+		if !slices.Contains(SearchTypes, t) {
+			return result, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("unsupported search type '%s', must be one of %v", t, SearchTypes), nil)
+		}
+	}
+
+	dbClient := container.DBClientFrom(dic.Get)
+	result.Counts = make(map[string]uint32, len(types))
+	for _, t := range types {
+		result.Counts[t] = 0
+	}
+	// skip counts the matched entities to skip, remaining counts the matched entities to return, -1 means unlimited
+	skip, remaining := offset, limit
+	page := func() bool {
+		if skip > 0 {
+			skip--
+			return false
+		}
+		if remaining == 0 {
+			return false
+		}
+		if remaining > 0 {
+			remaining--
+		}
+		return true
+	}
+
+	if slices.Contains(types, pkgCommon.SearchTypeDevice) {
+		err = forEachSearchBatch(dbClient.AllDevices, func(d models.Device) errors.EdgeX {
+			dto := redactDeviceSecrets(dic, d)
+			matched, err := matchSearchTerms(dto, terms)
+			if matched {
+				result.Counts[pkgCommon.SearchTypeDevice]++
+				if page() {
+					result.Devices = append(result.Devices, dto)
+				}
+			}
+			return err
+		})
+		if err != nil {
+			return result, errors.NewCommonEdgeXWrapper(err)
+		}
+	}
+
+	if slices.Contains(types, pkgCommon.SearchTypeDeviceProfile) {
+		err = forEachSearchBatch(dbClient.AllDeviceProfiles, func(p models.DeviceProfile) errors.EdgeX {
+			dto := dtos.FromDeviceProfileModelToDTO(p)
+			matched, err := matchSearchTerms(dto, terms)
+			if matched {
+				result.Counts[pkgCommon.SearchTypeDeviceProfile]++
+				if page() {
+					result.DeviceProfiles = append(result.DeviceProfiles, dto)
+				}
+			}
+			return err
+		})
+		if err != nil {
+			return result, errors.NewCommonEdgeXWrapper(err)
+		}
+	}
+
+	if slices.Contains(types, pkgCommon.SearchTypeDeviceService) {
+		err = forEachSearchBatch(dbClient.AllDeviceServices, func(s models.DeviceService) errors.EdgeX {
+			dto := dtos.FromDeviceServiceModelToDTO(s)
+			matched, err := matchSearchTerms(dto, terms)
+			if matched {
+				result.Counts[pkgCommon.SearchTypeDeviceService]++
+				if page() {
+					result.DeviceServices = append(result.DeviceServices, dto)
+				}
+			}
+			return err
+		})
+		if err != nil {
+			return result, errors.NewCommonEdgeXWrapper(err)
+		}
+	}
+
+	for _, count := range result.Counts {
+		result.TotalCount += count
+	}
+	if offset > 0 && uint32(offset) > result.TotalCount {
+		return result, errors.NewCommonEdgeX(errors.KindRangeNotSatisfiable, fmt.Sprintf("query objects bounds out of range. length:%v", result.TotalCount), nil)
+	}
+	return result, nil
+}
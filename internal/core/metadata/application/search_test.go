@@ -0,0 +1,130 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/config"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/infrastructure/interfaces/mocks"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+)
+
+var searchTestDevice = models.Device{
+	Name:        "boiler-01",
+	Description: "Boiler room sensor",
+	Labels:      []string{"modbus", "temperature"},
+	ServiceName: "device-modbus",
+	ProfileName: "boiler-profile",
+	AdminState:  models.Unlocked,
+	Protocols: map[string]models.ProtocolProperties{
+		"modbus-tcp": {"Address": "10.0.1.12", "Port": "502"},
+	},
+	Properties: map[string]any{"Site": "Plant A"},
+}
+
+func TestMatchSearchTerms(t *testing.T) {
+	device := dtos.FromDeviceModelToDTO(searchTestDevice)
+
+	tests := []struct {
+		name          string
+		query         string
+		matched       bool
+		errorExpected bool
+	}{
+		{"field equality", "name=boiler-01", true, false},
+		{"field equality is case-insensitive", "ServiceName=DEVICE-MODBUS", true, false},
+		{"prefix wildcard on protocol properties", "protocols.modbus-tcp.Address=10.0.*", true, false},
+		{"prefix wildcard not matched", "protocols.modbus-tcp.Address=192.168.*", false, false},
+		{"label set", "labels=modbus,temperature", true, false},
+		{"label set partially matched", "labels=modbus,humidity", false, false},
+		{"properties value with quotes", `properties.Site="Plant A"`, true, false},
+		{"negated field", "adminState!=LOCKED", true, false},
+		{"full-text term", "room", true, false},
+		{"full-text term on nested value", "10.0.1.12", true, false},
+		{"multiple terms", "labels=modbus name=boiler-* room", true, false},
+		{"multiple terms partially matched", "labels=modbus name=pump-*", false, false},
+		{"unknown field", "unknown=abc", false, false},
+		{"empty query", "  ", false, true},
+		{"missing field name", "=abc", false, true},
+		{"unterminated quote", `name="boiler`, false, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			terms, err := parseSearchQuery(testCase.query)
+			if testCase.errorExpected {
+				require.Error(t, err)
+				assert.Equal(t, http.StatusBadRequest, err.Code())
+				return
+			}
+			require.NoError(t, err)
+			matched, err := matchSearchTerms(device, terms)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.matched, matched)
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	otherDevice := searchTestDevice
+	otherDevice.Name = "boiler-02"
+	otherDevice.Protocols = map[string]models.ProtocolProperties{"modbus-tcp": {"Address": "10.0.1.13"}}
+	pumpDevice := searchTestDevice
+	pumpDevice.Name = "pump-01"
+	pumpDevice.Protocols = map[string]models.ProtocolProperties{"modbus-tcp": {"Address": "192.168.0.2"}}
+	profile := models.DeviceProfile{Name: "boiler-profile", Labels: []string{"modbus"}}
+	service := models.DeviceService{Name: "device-modbus", Labels: []string{"modbus"}}
+
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{}
+		},
+	})
+	dbClientMock := &mocks.DBClient{}
+	// the entities are loaded in batches of 2
+	batchSize := searchBatchSize
+	searchBatchSize = 2
+	t.Cleanup(func() { searchBatchSize = batchSize })
+	dbClientMock.On("AllDevices", 0, 2, []string(nil)).Return([]models.Device{searchTestDevice, otherDevice}, nil)
+	dbClientMock.On("AllDevices", 2, 2, []string(nil)).Return([]models.Device{pumpDevice}, nil)
+	dbClientMock.On("AllDeviceProfiles", 0, 2, []string(nil)).Return([]models.DeviceProfile{profile}, nil)
+	dbClientMock.On("AllDeviceServices", 0, 2, []string(nil)).Return([]models.DeviceService{service}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	result, err := Search("protocols.modbus-tcp.Address=10.0.*", []string{pkgCommon.SearchTypeDevice}, 0, -1, dic)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), result.TotalCount)
+	assert.Len(t, result.Devices, 2)
+
+	result, err = Search("labels=modbus", nil, 1, 2, dic)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(5), result.TotalCount)
+	assert.Equal(t, map[string]uint32{pkgCommon.SearchTypeDevice: 3, pkgCommon.SearchTypeDeviceProfile: 1, pkgCommon.SearchTypeDeviceService: 1}, result.Counts)
+	require.Len(t, result.Devices, 2)
+	assert.Equal(t, otherDevice.Name, result.Devices[0].Name)
+	assert.Equal(t, pumpDevice.Name, result.Devices[1].Name)
+	assert.Empty(t, result.DeviceProfiles)
+
+	_, err = Search("labels=modbus", nil, 10, 2, dic)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, err.Code())
+
+	_, err = Search("labels=modbus", []string{"unknown"}, 0, 2, dic)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.Code())
+}
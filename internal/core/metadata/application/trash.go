@@ -0,0 +1,206 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+)
+
+// trashEntityTypes are the types of the metadata entities kept in the trash when deleted
+var trashEntityTypes = []string{common.DeviceSystemEventType, common.DeviceProfileSystemEventType}
+
+var asyncPurgeTrashOnce sync.Once
+
+// ParseTrashRetention parses the configured trash retention, an empty value means no retention
+func ParseTrashRetention(retention string) (time.Duration, error) {
+	if retention == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(retention)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("negative trash retention %s", retention)
+	}
+	return d, nil
+}
+
+// trashRetention returns the configured trash retention, the deleted devices and device profiles are kept in the trash
+// only when it's positive. The configuration is validated on bootstrap, so an invalid value is treated as no retention.
+func trashRetention(dic *di.Container) time.Duration {
+	retention, err := ParseTrashRetention(container.ConfigurationFrom(dic.Get).Trash.Retention)
+	if err != nil {
+		return 0
+	}
+	return retention
+}
+
+func validateTrashEntityType(entityType string) errors.EdgeX {
+	for _, t := range trashEntityTypes {
+		if entityType == t {
+			return nil
+		}
+	}
+	return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("entity type %s is not supported by the trash, must be one of %v", entityType, trashEntityTypes), nil)
+}
+
+// TrashedEntities queries the trashed entities of the type with offset and limit, the latest deleted entity comes first
+func TrashedEntities(entityType string, offset int, limit int, dic *di.Container) (entities []pkgDtos.TrashedEntity, totalCount uint32, err errors.EdgeX) {
+	if err = validateTrashEntityType(entityType); err != nil {
+		return entities, totalCount, errors.NewCommonEdgeXWrapper(err)
+	}
+	dbClient := container.DBClientFrom(dic.Get)
+	models, err := dbClient.TrashedEntities(entityType, offset, limit)
+	if err == nil {
+		totalCount, err = dbClient.TrashedEntityTotalCount(entityType)
+	}
+	if err != nil {
+		return entities, totalCount, errors.NewCommonEdgeXWrapper(err)
+	}
+
+	retention := trashRetention(dic).Milliseconds()
+	entities = make([]pkgDtos.TrashedEntity, len(models))
+	for i, m := range models {
+		entity, e := pkgDtos.FromTrashedEntityModelToDTO(m, retention)
+		if e != nil {
+			return entities, totalCount, errors.NewCommonEdgeX(errors.KindServerError, "failed to convert the trashed entity", e)
+		}
+		entity.Entity = redactEntitySecrets(dic, entity.Entity)
+		entities[i] = entity
+	}
+	return entities, totalCount, nil
+}
+
+// TrashedEntityByName queries the trashed entity by type and name
+func TrashedEntityByName(entityType string, name string, dic *di.Container) (entity pkgDtos.TrashedEntity, err errors.EdgeX) {
+	if err = validateTrashEntityType(entityType); err != nil {
+		return entity, errors.NewCommonEdgeXWrapper(err)
+	}
+	if name == "" {
+		return entity, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	m, err := container.DBClientFrom(dic.Get).TrashedEntityByName(entityType, name)
+	if err != nil {
+		return entity, errors.NewCommonEdgeXWrapper(err)
+	}
+	entity, e := pkgDtos.FromTrashedEntityModelToDTO(m, trashRetention(dic).Milliseconds())
+	if e != nil {
+		return entity, errors.NewCommonEdgeX(errors.KindServerError, "failed to convert the trashed entity", e)
+	}
+	entity.Entity = redactEntitySecrets(dic, entity.Entity)
+	return entity, nil
+}
+
+// RestoreTrashedEntity moves the trashed entity back to the metadata and publishes the add system event of the
+// restored entity. A device can only be restored when its device profile and device service exist.
+func RestoreTrashedEntity(entityType string, name string, ctx context.Context, dic *di.Container) errors.EdgeX {
+	if err := validateTrashEntityType(entityType); err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	if name == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	unlock, err := checkMetadataRevision(entityType, name, ctx, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	defer unlock()
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	dbClient := container.DBClientFrom(dic.Get)
+	switch entityType {
+	case common.DeviceSystemEventType:
+		device, err := dbClient.RestoreTrashedDevice(name)
+		if err != nil {
+			return errors.NewCommonEdgeXWrapper(err)
+		}
+		deviceDTO := dtos.FromDeviceModelToDTO(device)
+		go publishSystemEvent(common.DeviceSystemEventType, common.SystemEventActionAdd, device.ServiceName, deviceDTO, ctx, dic)
+	case common.DeviceProfileSystemEventType:
+		profile, err := dbClient.RestoreTrashedDeviceProfile(name)
+		if err != nil {
+			return errors.NewCommonEdgeXWrapper(err)
+		}
+		profileDTO := dtos.FromDeviceProfileModelToDTO(profile)
+		go publishSystemEvent(common.DeviceProfileSystemEventType, common.SystemEventActionAdd, common.CoreMetaDataServiceKey, profileDTO, ctx, dic)
+	}
+
+	lc.Debugf("Trashed %s %s restored on DB successfully. Correlation-ID: %s ", entityType, name, correlation.FromContext(ctx))
+	return nil
+}
+
+// PurgeTrashedEntity permanently deletes the trashed entity by type and name
+func PurgeTrashedEntity(entityType string, name string, dic *di.Container) errors.EdgeX {
+	if err := validateTrashEntityType(entityType); err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	if name == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	err := container.DBClientFrom(dic.Get).PurgeTrashedEntity(entityType, name)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return nil
+}
+
+// AsyncPurgeTrash permanently deletes the trashed entities whose retention has expired
+func AsyncPurgeTrash(interval time.Duration, ctx context.Context, dic *di.Container) {
+	asyncPurgeTrashOnce.Do(func() {
+		go func() {
+			lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+			timer := time.NewTimer(interval)
+			for {
+				timer.Reset(interval) // since the deletion might take lots of time, restart the timer to recount the time
+				select {
+				case <-ctx.Done():
+					lc.Info("Exiting trash purging")
+					return
+				case <-timer.C:
+					err := purgeTrash(dic)
+					if err != nil {
+						lc.Errorf("Failed to purge the trash, %v", err)
+					}
+				}
+			}
+		}()
+	})
+}
+
+func purgeTrash(dic *di.Container) errors.EdgeX {
+	retention := trashRetention(dic)
+	if retention <= 0 {
+		return nil
+	}
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	dbClient := container.DBClientFrom(dic.Get)
+	age := pkgCommon.MakeTimestamp() - retention.Milliseconds()
+	for _, entityType := range trashEntityTypes {
+		count, err := dbClient.PurgeTrashedEntitiesByAge(entityType, age)
+		if err != nil {
+			return errors.NewCommonEdgeX(errors.Kind(err), fmt.Sprintf("failed to purge the expired trashed %s", entityType), err)
+		}
+		if count > 0 {
+			lc.Debugf("Purged %d expired trashed %s", count, entityType)
+		}
+	}
+	return nil
+}
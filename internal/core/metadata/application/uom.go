@@ -0,0 +1,189 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// unitsOfMeasureReloadMutex serializes the reloads of the units of measure, so that a reload based on the older custom
+// unit categories can't replace the units of measure reloaded after a later change
+var unitsOfMeasureReloadMutex sync.Mutex
+
+// ReloadUnitsOfMeasure merges the custom unit categories stored in DB with the units of measure loaded from the UoM
+// file, and replaces the units of measure in the DIC so that the unit validation takes effect immediately
+func ReloadUnitsOfMeasure(dic *di.Container) errors.EdgeX {
+	unitsOfMeasureReloadMutex.Lock()
+	defer unitsOfMeasureReloadMutex.Unlock()
+
+	categories, err := container.DBClientFrom(dic.Get).AllUnitOfMeasureCategories(0, -1)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.Kind(err), "failed to query the custom unit of measure categories", err)
+	}
+	uom := container.UnitsOfMeasureFrom(dic.Get).WithCustomUnits(categories)
+	dic.Update(di.ServiceConstructorMap{
+		container.UnitsOfMeasureInterfaceName: func(get di.Get) interface{} {
+			return uom
+		},
+	})
+	return nil
+}
+
+// reloadUnitsOfMeasureAfterChange reloads the units of measure after a custom unit category is changed. The change has
+// been stored in DB, so the failed reload is only logged and retried by the next change.
+func reloadUnitsOfMeasureAfterChange(ctx context.Context, dic *di.Container) {
+	if err := ReloadUnitsOfMeasure(dic); err != nil {
+		lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+		lc.Errorf("Failed to reload the units of measure, %v. Correlation-ID: %s ", err, correlation.FromContext(ctx))
+	}
+}
+
+// checkRemovedUnitsInUse returns a conflict error when a device profile resource still uses a unit of the custom
+// category, which is no longer valid once the category is replaced by the given one, or deleted when nil. The units
+// kept by the UoM file or by other custom categories remain valid. The caller must hold the lock of the categories.
+func checkRemovedUnitsInUse(name string, replacement *pkgModels.UnitOfMeasureCategory, dic *di.Container) errors.EdgeX {
+	dbClient := container.DBClientFrom(dic.Get)
+	categories, err := dbClient.AllUnitOfMeasureCategories(0, -1)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.Kind(err), "failed to query the custom unit of measure categories", err)
+	}
+	var old *pkgModels.UnitOfMeasureCategory
+	others := make([]pkgModels.UnitOfMeasureCategory, 0, len(categories))
+	for i, c := range categories {
+		if c.Name == name {
+			old = &categories[i]
+		} else {
+			others = append(others, c)
+		}
+	}
+	if old == nil {
+		// the category not found is reported by the following DB operation
+		return nil
+	}
+	if replacement != nil {
+		others = append(others, *replacement)
+	}
+	uom := container.UnitsOfMeasureFrom(dic.Get).WithCustomUnits(others)
+	var removed []string
+	for _, v := range old.Values {
+		if !uom.Validate(v) {
+			removed = append(removed, v)
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+
+	return forEachSearchBatch(dbClient.AllDeviceProfiles, func(p models.DeviceProfile) errors.EdgeX {
+		for _, r := range p.DeviceResources {
+			if slices.Contains(removed, r.Properties.Units) {
+				return errors.NewCommonEdgeX(errors.KindStatusConflict,
+					fmt.Sprintf("unit %s of the category %s is used by the resource %s of device profile %s", r.Properties.Units, name, r.Name, p.Name), nil)
+			}
+		}
+		return nil
+	})
+}
+
+// AddUnitOfMeasureCategory adds the custom unit of measure category into DB and reloads the units of measure
+func AddUnitOfMeasureCategory(c pkgModels.UnitOfMeasureCategory, ctx context.Context, dic *di.Container) (id string, err errors.EdgeX) {
+	dbClient := container.DBClientFrom(dic.Get)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	added, err := dbClient.AddUnitOfMeasureCategory(c)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	reloadUnitsOfMeasureAfterChange(ctx, dic)
+
+	lc.Debugf("UnitOfMeasureCategory created on DB successfully. UnitOfMeasureCategory ID: %s, Correlation-ID: %s ", added.Id, correlation.FromContext(ctx))
+	return added.Id, nil
+}
+
+// UpdateUnitOfMeasureCategory replaces the custom unit of measure category of the same name and reloads the units of measure
+func UpdateUnitOfMeasureCategory(c pkgModels.UnitOfMeasureCategory, ctx context.Context, dic *di.Container) errors.EdgeX {
+	dbClient := container.DBClientFrom(dic.Get)
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	unlock := lockMetadataEntity(pkgCommon.UnitOfMeasureCategory)
+	defer unlock()
+	err := checkRemovedUnitsInUse(c.Name, &c, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	err = dbClient.UpdateUnitOfMeasureCategory(c)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	reloadUnitsOfMeasureAfterChange(ctx, dic)
+
+	lc.Debugf("UnitOfMeasureCategory updated on DB successfully. Correlation-ID: %s ", correlation.FromContext(ctx))
+	return nil
+}
+
+// UnitOfMeasureCategoryByName query the custom unit of measure category by name
+func UnitOfMeasureCategoryByName(name string, dic *di.Container) (category pkgDtos.UnitOfMeasureCategory, err errors.EdgeX) {
+	if name == "" {
+		return category, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	c, err := container.DBClientFrom(dic.Get).UnitOfMeasureCategoryByName(name)
+	if err != nil {
+		return category, errors.NewCommonEdgeXWrapper(err)
+	}
+	return pkgDtos.FromUnitOfMeasureCategoryModelToDTO(c), nil
+}
+
+// AllUnitOfMeasureCategories query the custom unit of measure categories with offset and limit
+func AllUnitOfMeasureCategories(offset int, limit int, dic *di.Container) (categories []pkgDtos.UnitOfMeasureCategory, totalCount uint32, err errors.EdgeX) {
+	dbClient := container.DBClientFrom(dic.Get)
+	cs, err := dbClient.AllUnitOfMeasureCategories(offset, limit)
+	if err == nil {
+		totalCount, err = dbClient.UnitOfMeasureCategoryTotalCount()
+	}
+	if err != nil {
+		return categories, totalCount, errors.NewCommonEdgeXWrapper(err)
+	}
+	categories = make([]pkgDtos.UnitOfMeasureCategory, len(cs))
+	for i, c := range cs {
+		categories[i] = pkgDtos.FromUnitOfMeasureCategoryModelToDTO(c)
+	}
+	return categories, totalCount, nil
+}
+
+// DeleteUnitOfMeasureCategoryByName deletes the custom unit of measure category by name and reloads the units of
+// measure, the units of the category loaded from the UoM file are kept
+func DeleteUnitOfMeasureCategoryByName(name string, ctx context.Context, dic *di.Container) errors.EdgeX {
+	if name == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+
+	unlock := lockMetadataEntity(pkgCommon.UnitOfMeasureCategory)
+	defer unlock()
+	err := checkRemovedUnitsInUse(name, nil, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	err = container.DBClientFrom(dic.Get).DeleteUnitOfMeasureCategoryByName(name)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	reloadUnitsOfMeasureAfterChange(ctx, dic)
+	return nil
+}
@@ -27,12 +27,20 @@ type ConfigurationStruct struct {
 	Service    bootstrapConfig.ServiceInfo
 	MessageBus bootstrapConfig.MessageBusInfo
 	UoM        UoM
+	// DeviceServiceHealth contains the configuration of the device service health probing
+	DeviceServiceHealth DeviceServiceHealth
+	// ChangeLog contains the configuration of the metadata change log
+	ChangeLog ChangeLog
+	// Trash contains the configuration of the soft deleted devices and device profiles
+	Trash Trash
 }
 
 type WritableInfo struct {
 	LogLevel        string
 	ProfileChange   ProfileChange
 	UoM             WritableUoM
+	Discovery       Discovery
+	ProtocolSecrets ProtocolSecrets
 	InsecureSecrets bootstrapConfig.InsecureSecrets
 	Telemetry       bootstrapConfig.TelemetryInfo
 }
@@ -40,16 +48,65 @@ type WritableInfo struct {
 type ProfileChange struct {
 	StrictDeviceProfileChanges bool
 	StrictDeviceProfileDeletes bool
+	// BlockingSeverity rejects the device profile updates having a lint finding at least as severe as the value,
+	// one of NONE, INFO, WARNING or ERROR
+	BlockingSeverity string
 }
 
 type WritableUoM struct {
 	Validation bool
 }
 
+type Discovery struct {
+	// ApprovalRequired stages the devices added by their device services, and the devices marked as discovered by a
+	// provision watcher through the ProvisionWatcherName device property, as pending devices, which only become
+	// devices when approved. Every added device is staged while the JWTs aren't verified, since the origin of the
+	// device can't be determined then.
+	ApprovalRequired bool
+}
+
+type ProtocolSecrets struct {
+	// Properties are the names of the protocol properties holding credentials, matched case-insensitively. Such a
+	// property of a device must be a secret reference resolved by the device service, and any other value stored
+	// before is redacted in the responses.
+	Properties []string
+}
+
 type UoM struct {
 	UoMFile string
 }
 
+type DeviceServiceHealth struct {
+	// Enabled indicates whether the registered device services are probed periodically
+	Enabled bool
+	// Interval is the duration between two probing rounds, e.g. 30s
+	Interval string
+	// Method is the probing method, http to invoke the ping API. messagebus is deprecated and probes the ping API as well
+	Method string
+	// FailureThreshold is the number of consecutive failed probes before the devices of the device service are set to DOWN
+	FailureThreshold int
+}
+
+type ChangeLog struct {
+	// Interval is the duration between two purges of the change log, e.g. 10m
+	Interval string
+	// MaxCap is the count of changes above which the oldest changes are purged
+	MaxCap uint32
+	// MinCap is the count of the latest changes kept by a purge
+	MinCap uint32
+	// MaxWait is the longest duration a long-polling request waits for new changes, e.g. 30s. The wait is also bounded
+	// by the Service.RequestTimeout
+	MaxWait string
+}
+
+type Trash struct {
+	// Retention is the duration a deleted device or device profile is kept in the trash before it's purged, e.g. 168h.
+	// The devices and device profiles are deleted permanently when the value is empty or zero.
+	Retention string
+	// Interval is the duration between two purges of the expired entities in the trash, e.g. 1h
+	Interval string
+}
+
 // UpdateFromRaw converts configuration received from the registry to a service-specific configuration struct which is
 // then used to overwrite the service's existing configuration struct.
 func (c *ConfigurationStruct) UpdateFromRaw(rawConfig interface{}) bool {
@@ -6,6 +6,7 @@
 package http
 
 import (
+	"fmt"
 	"math"
 	"net/http"
 
@@ -13,7 +14,10 @@ import (
 	metadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
 	"github.com/edgexfoundry/edgex-go/internal/io"
 	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
@@ -51,7 +55,7 @@ func (dc *DeviceController) AddDevice(c echo.Context) error {
 
 	lc := container.LoggingClientFrom(dc.dic.Get)
 
-	ctx := r.Context()
+	ctx := identity.NewContext(r.Context(), identity.FromRequest(r))
 	correlationId := correlation.FromContext(ctx)
 
 	var bypassValidation bool
@@ -72,7 +76,19 @@ func (dc *DeviceController) AddDevice(c echo.Context) error {
 	for i, d := range devices {
 		var response interface{}
 		reqId := reqDTOs[i].RequestId
-		newId, err := application.AddDevice(d, ctx, dc.dic, bypassValidation)
+		pendingId, staged, err := application.StageDiscoveredDevice(d, ctx, dc.dic)
+		if err == nil && staged {
+			addResponses = append(addResponses, commonDTO.NewBaseWithIdResponse(
+				reqId,
+				fmt.Sprintf("device %s is pending approval", d.Name),
+				http.StatusAccepted,
+				pendingId))
+			continue
+		}
+		newId := ""
+		if err == nil {
+			newId, err = application.AddDevice(d, ctx, dc.dic, bypassValidation)
+		}
 		if err != nil {
 			lc.Error(err.Error(), common.CorrelationHeader, correlationId)
 			lc.Debug(err.DebugMessages(), common.CorrelationHeader, correlationId)
@@ -103,6 +119,7 @@ func (dc *DeviceController) DeleteDeviceByName(c echo.Context) error {
 	// URL parameters
 	name := c.Param(common.Name)
 
+	ctx = application.NewIfMatchContext(ctx, r.Header.Get(pkgCommon.IfMatchHeader))
 	err := application.DeleteDeviceByName(name, ctx, dc.dic)
 	if err != nil {
 		return utils.WriteErrorResponse(w, ctx, lc, err, "")
@@ -174,7 +191,8 @@ func (dc *DeviceController) PatchDevice(c echo.Context) error {
 
 	lc := container.LoggingClientFrom(dc.dic.Get)
 
-	ctx := r.Context()
+	ctx := identity.NewContext(r.Context(), identity.FromRequest(r))
+	ctx = application.NewLifecycleReasonContext(ctx, utils.ParseQueryStringToString(r, pkgCommon.Reason, ""))
 	correlationId := correlation.FromContext(ctx)
 
 	var bypassValidation bool
@@ -189,6 +207,10 @@ func (dc *DeviceController) PatchDevice(c echo.Context) error {
 	if err != nil {
 		return utils.WriteErrorResponse(w, ctx, lc, err, "")
 	}
+	ctx, err = ifMatchContext(ctx, r, len(reqDTOs))
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
 
 	var updateResponses []interface{}
 	for _, dto := range reqDTOs {
@@ -201,7 +223,7 @@ func (dc *DeviceController) PatchDevice(c echo.Context) error {
 			response = commonDTO.NewBaseResponse(
 				reqId,
 				err.Message(),
-				err.Code())
+				utils.StatusCode(err))
 		} else {
 			response = commonDTO.NewBaseResponse(
 				reqId,
@@ -252,6 +274,10 @@ func (dc *DeviceController) DeviceByName(c echo.Context) error {
 	}
 
 	response := responseDTO.NewDeviceResponse("", "", http.StatusOK, device)
+	err = setETagHeader(w, common.DeviceSystemEventType, name, dc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
 	utils.WriteHttpHeader(w, ctx, http.StatusOK)
 	return pkg.EncodeAndWriteResponse(response, w, lc)
 }
@@ -279,3 +305,105 @@ func (dc *DeviceController) DevicesByProfileName(c echo.Context) error {
 	utils.WriteHttpHeader(w, ctx, http.StatusOK)
 	return pkg.EncodeAndWriteResponse(response, w, lc)
 }
+
+// DevicesByLocationRadius query the devices located within the radius in meters around the latitude and longitude,
+// nearest first
+func (dc *DeviceController) DevicesByLocationRadius(c echo.Context) error {
+	lc := container.LoggingClientFrom(dc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+	config := metadataContainer.ConfigurationFrom(dc.dic.Get)
+
+	// parse URL query string for location, offset and limit
+	circle, err := utils.ParseGeoCircleQueryString(c)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(c, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	devices, totalCount, err := application.DevicesByGeoCircle(circle, offset, limit, dc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := responseDTO.NewMultiDevicesResponse("", "", http.StatusOK, totalCount, devices)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+// DevicesByLocationBoundingBox query the devices located within the bounding box of the minimum and maximum latitude
+// and longitude
+func (dc *DeviceController) DevicesByLocationBoundingBox(c echo.Context) error {
+	lc := container.LoggingClientFrom(dc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+	config := metadataContainer.ConfigurationFrom(dc.dic.Get)
+
+	// parse URL query string for location, offset and limit
+	box, err := utils.ParseGeoBoundingBoxQueryString(c)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(c, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	devices, totalCount, err := application.DevicesByGeoBoundingBox(box, offset, limit, dc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := responseDTO.NewMultiDevicesResponse("", "", http.StatusOK, totalCount, devices)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (dc *DeviceController) AllDeviceLifecycleAudits(c echo.Context) error {
+	lc := container.LoggingClientFrom(dc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+	config := metadataContainer.ConfigurationFrom(dc.dic.Get)
+
+	// parse URL query string for offset, limit
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(c, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	audits, totalCount, err := application.AllDeviceLifecycleAudits(offset, limit, dc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := pkgResponses.NewMultiDeviceLifecycleAuditsResponse("", "", http.StatusOK, totalCount, audits)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (dc *DeviceController) DeviceLifecycleAuditsByDeviceName(c echo.Context) error {
+	lc := container.LoggingClientFrom(dc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+	config := metadataContainer.ConfigurationFrom(dc.dic.Get)
+
+	name := c.Param(common.Name)
+
+	// parse URL query string for offset, limit
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(c, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	audits, totalCount, err := application.DeviceLifecycleAuditsByDeviceName(offset, limit, name, dc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := pkgResponses.NewMultiDeviceLifecycleAuditsResponse("", "", http.StatusOK, totalCount, audits)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
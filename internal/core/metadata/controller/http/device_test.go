@@ -19,10 +19,15 @@ import (
 	"github.com/edgexfoundry/go-mod-messaging/v3/pkg/types"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/config"
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
 	dbMock "github.com/edgexfoundry/edgex-go/internal/core/metadata/infrastructure/interfaces/mocks"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v3/config"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
@@ -352,6 +357,86 @@ func TestDeleteDeviceByName(t *testing.T) {
 	}
 }
 
+func TestDeleteDeviceByNameIfMatch(t *testing.T) {
+	device := dtos.ToDeviceModel(buildTestDeviceRequest().Device)
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("MetadataRevision", common.DeviceSystemEventType, device.Name).Return(uint64(3), nil)
+	dbClientMock.On("DeleteDeviceByName", device.Name).Return(nil)
+	dbClientMock.On("DeviceByName", device.Name).Return(device, nil)
+	dbClientMock.On("DeviceServiceByName", device.ServiceName).Return(models.DeviceService{BaseAddress: testBaseAddress}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewDeviceController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		ifMatch            string
+		expectedStatusCode int
+	}{
+		{"Valid - current entity tag", `"3"`, http.StatusOK},
+		{"Valid - weak entity tag in the list", `"2", W/"3"`, http.StatusOK},
+		{"Valid - any entity tag", "*", http.StatusOK},
+		{"Invalid - stale entity tag", `"2"`, http.StatusPreconditionFailed},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			reqPath := fmt.Sprintf("%s/%s", common.ApiDeviceByNameEchoRoute, device.Name)
+			req, err := http.NewRequest(http.MethodDelete, reqPath, http.NoBody)
+			require.NoError(t, err)
+			req.Header.Set(pkgCommon.IfMatchHeader, testCase.ifMatch)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			c.SetParamNames(common.Name)
+			c.SetParamValues(device.Name)
+
+			err = controller.DeleteDeviceByName(c)
+			require.NoError(t, err)
+			var res commonDTO.BaseResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, int(res.StatusCode), "Response status code not as expected")
+		})
+	}
+	dbClientMock.AssertNumberOfCalls(t, "DeleteDeviceByName", 3)
+}
+
+func TestPatchDeviceIfMatchWithMultipleDevices(t *testing.T) {
+	dic := mockDic()
+	controller := NewDeviceController(dic)
+	require.NotNil(t, controller)
+
+	reqs := []requests.UpdateDeviceRequest{buildTestUpdateDeviceRequest(), buildTestUpdateDeviceRequest()}
+	jsonData, err := json.Marshal(reqs)
+	require.NoError(t, err)
+
+	e := echo.New()
+	req, err := http.NewRequest(http.MethodPatch, common.ApiDeviceRoute, strings.NewReader(string(jsonData)))
+	require.NoError(t, err)
+	req.Header.Set(pkgCommon.IfMatchHeader, `"3"`)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	c := e.NewContext(req, recorder)
+	err = controller.PatchDevice(c)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode, "HTTP status code not as expected")
+}
+
 func TestAllDeviceByServiceName(t *testing.T) {
 	device := dtos.ToDeviceModel(buildTestDeviceRequest().Device)
 	testServiceA := "testServiceA"
@@ -790,6 +875,7 @@ func TestDeviceByName(t *testing.T) {
 	dic := mockDic()
 	dbClientMock := &dbMock.DBClient{}
 	dbClientMock.On("DeviceByName", device.Name).Return(device, nil)
+	dbClientMock.On("MetadataRevision", common.DeviceSystemEventType, device.Name).Return(uint64(5), nil)
 	dbClientMock.On("DeviceByName", notFoundName).Return(models.Device{}, edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, "device doesn't exist in the database", nil))
 	dic.Update(di.ServiceConstructorMap{
 		container.DBClientInterfaceName: func(get di.Get) interface{} {
@@ -843,6 +929,7 @@ func TestDeviceByName(t *testing.T) {
 				assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
 				assert.Equal(t, testCase.expectedStatusCode, int(res.StatusCode), "Response status code not as expected")
 				assert.Equal(t, testCase.deviceName, res.Device.Name, "Name not as expected")
+				assert.Equal(t, `"5"`, recorder.Header().Get(pkgCommon.ETagHeader), "ETag not as expected")
 				assert.Empty(t, res.Message, "Message should be empty when it is successful")
 			}
 		})
@@ -933,3 +1020,462 @@ func TestDevicesByProfileName(t *testing.T) {
 		})
 	}
 }
+
+func TestPatchDeviceLifecycle(t *testing.T) {
+	deviceName := TestDeviceName
+	activeDevice := models.Device{
+		Id:          ExampleUUID,
+		Name:        deviceName,
+		ServiceName: TestDeviceServiceName,
+		ProfileName: TestDeviceProfileName,
+		AdminState:  models.Unlocked,
+		Properties:  map[string]any{pkgModels.DeviceLifecycleStateProperty: pkgModels.Active},
+	}
+	decommissionedDeviceName := "decommissionedDevice"
+	decommissionedDevice := activeDevice
+	decommissionedDevice.Name = decommissionedDeviceName
+	decommissionedDevice.Properties = map[string]any{pkgModels.DeviceLifecycleStateProperty: pkgModels.Decommissioned}
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("DeviceByName", deviceName).Return(activeDevice, nil)
+	dbClientMock.On("DeviceByName", decommissionedDeviceName).Return(decommissionedDevice, nil)
+	dbClientMock.On("DeviceProfileByName", TestDeviceProfileName).Return(models.DeviceProfile{Name: TestDeviceProfileName}, nil)
+	dbClientMock.On("UpdateDevice", mock.Anything).Return(nil)
+	dbClientMock.On("AddDeviceLifecycleAudit", mock.MatchedBy(func(a pkgModels.DeviceLifecycleAudit) bool {
+		return a.DeviceName == deviceName && a.FromState == pkgModels.Active && a.ToState == pkgModels.Maintenance && a.Reason == "firmware upgrade"
+	})).Return(pkgModels.DeviceLifecycleAudit{}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewDeviceController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name                 string
+		deviceName           string
+		state                string
+		expectedResponseCode int
+		expectedAudit        bool
+	}{
+		{"Valid - active to maintenance", deviceName, "maintenance", http.StatusOK, true},
+		{"Valid - lifecycle state unchanged", deviceName, pkgModels.Active, http.StatusOK, false},
+		{"Invalid - active to provisioned", deviceName, pkgModels.Provisioned, http.StatusConflict, false},
+		{"Invalid - unknown state", deviceName, "RETIRED", http.StatusConflict, false},
+		{"Invalid - decommissioned is terminal", decommissionedDeviceName, pkgModels.Active, http.StatusConflict, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			dbClientMock.Calls = nil
+			name := testCase.deviceName
+			req := requests.UpdateDeviceRequest{
+				BaseRequest: commonDTO.BaseRequest{Versionable: commonDTO.NewVersionable()},
+				Device: dtos.UpdateDevice{
+					Name:       &name,
+					Properties: map[string]any{pkgModels.DeviceLifecycleStateProperty: testCase.state},
+				},
+			}
+			jsonData, err := json.Marshal([]requests.UpdateDeviceRequest{req})
+			require.NoError(t, err)
+
+			e := echo.New()
+			reader := strings.NewReader(string(jsonData))
+			httpReq, err := http.NewRequest(http.MethodPatch, common.ApiDeviceRoute, reader)
+			require.NoError(t, err)
+			query := httpReq.URL.Query()
+			query.Add(bypassValidationQueryParam, common.ValueTrue)
+			query.Add(pkgCommon.Reason, "firmware upgrade")
+			httpReq.URL.RawQuery = query.Encode()
+
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(httpReq, recorder)
+			err = controller.PatchDevice(c)
+			require.NoError(t, err)
+
+			var res []commonDTO.BaseResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			require.Len(t, res, 1)
+			assert.Equal(t, testCase.expectedResponseCode, int(res[0].StatusCode), "Response status code not as expected")
+			if testCase.expectedAudit {
+				dbClientMock.AssertCalled(t, "AddDeviceLifecycleAudit", mock.Anything)
+			} else {
+				dbClientMock.AssertNotCalled(t, "AddDeviceLifecycleAudit", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestDeviceLifecycleAuditsByDeviceName(t *testing.T) {
+	audits := []pkgModels.DeviceLifecycleAudit{
+		{Id: ExampleUUID, DeviceName: TestDeviceName, FromState: pkgModels.Active, ToState: pkgModels.Maintenance, Actor: "operator"},
+		{Id: ExampleUUID, DeviceName: TestDeviceName, FromState: pkgModels.Commissioned, ToState: pkgModels.Active, Actor: "operator"},
+	}
+	expectedTotalCount := uint32(len(audits))
+	notFoundName := "notFoundName"
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("DeviceLifecycleAuditCountByDeviceName", TestDeviceName).Return(expectedTotalCount, nil)
+	dbClientMock.On("DeviceLifecycleAuditsByDeviceName", 0, 10, TestDeviceName).Return(audits, nil)
+	dbClientMock.On("DeviceLifecycleAuditsByDeviceName", 0, 10, notFoundName).Return([]pkgModels.DeviceLifecycleAudit{}, edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, "query objects bounds out of range.", nil))
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewDeviceController(dic)
+	assert.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		deviceName         string
+		errorExpected      bool
+		expectedCount      int
+		expectedStatusCode int
+	}{
+		{"Valid - get lifecycle audits by device name", TestDeviceName, false, len(audits), http.StatusOK},
+		{"Invalid - empty device name", "", true, 0, http.StatusBadRequest},
+		{"Invalid - not found", notFoundName, true, 0, http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req, err := http.NewRequest(http.MethodGet, pkgCommon.ApiDeviceLifecycleByNameEchoRoute, http.NoBody)
+			require.NoError(t, err)
+			query := req.URL.Query()
+			query.Add(common.Offset, "0")
+			query.Add(common.Limit, "10")
+			req.URL.RawQuery = query.Encode()
+
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			c.SetParamNames(common.Name)
+			c.SetParamValues(testCase.deviceName)
+			err = controller.DeviceLifecycleAuditsByDeviceName(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.errorExpected {
+				var res commonDTO.BaseResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+			} else {
+				var res pkgResponses.MultiDeviceLifecycleAuditsResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedCount, len(res.Audits), "Audit count not as expected")
+				assert.Equal(t, expectedTotalCount, res.TotalCount, "Total count not as expected")
+			}
+		})
+	}
+}
+
+func TestAddDeviceWithResourceOverrides(t *testing.T) {
+	maximum := float64(100)
+	profile := models.DeviceProfile{
+		Name: TestDeviceProfileName,
+		DeviceResources: []models.DeviceResource{
+			{Name: "TestResource", Properties: models.ResourceProperties{ValueType: common.ValueTypeFloat32, Maximum: &maximum}},
+			{Name: "TestStringResource", Properties: models.ResourceProperties{ValueType: common.ValueTypeString}},
+		},
+	}
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("DeviceServiceNameExists", TestDeviceServiceName).Return(true, nil)
+	dbClientMock.On("DeviceProfileByName", TestDeviceProfileName).Return(profile, nil)
+	dbClientMock.On("AddDevice", mock.Anything).Return(models.Device{Id: ExampleUUID}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewDeviceController(dic)
+
+	withOverrides := func(overrides any) requests.AddDeviceRequest {
+		req := buildTestDeviceRequest()
+		req.Device.Properties = map[string]any{pkgModels.DeviceResourceOverridesProperty: overrides}
+		return req
+	}
+
+	tests := []struct {
+		name                 string
+		request              requests.AddDeviceRequest
+		expectedResponseCode int
+	}{
+		{"Valid - override scale and units", withOverrides(map[string]any{"TestResource": map[string]any{"scale": 0.1, "units": "percent"}}), http.StatusCreated},
+		{"Invalid - malformed overrides", withOverrides("invalid"), http.StatusBadRequest},
+		{"Invalid - unknown override property", withOverrides(map[string]any{"TestResource": map[string]any{"valueType": common.ValueTypeString}}), http.StatusBadRequest},
+		{"Invalid - resource not in profile", withOverrides(map[string]any{"notFoundResource": map[string]any{"scale": 0.1}}), http.StatusBadRequest},
+		{"Invalid - scale of non-numeric resource", withOverrides(map[string]any{"TestStringResource": map[string]any{"scale": 0.1}}), http.StatusBadRequest},
+		{"Invalid - minimum greater than maximum", withOverrides(map[string]any{"TestResource": map[string]any{"minimum": 200}}), http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			var wg sync.WaitGroup
+			mockMessaging := &messagingMocks.MessageClient{}
+			if testCase.expectedResponseCode == http.StatusCreated {
+				wg.Add(1)
+				mockMessaging.On("Publish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					wg.Done()
+				}).Return(nil)
+			}
+			dic.Update(di.ServiceConstructorMap{
+				bootstrapContainer.MessagingClientName: func(get di.Get) interface{} {
+					return mockMessaging
+				},
+			})
+
+			e := echo.New()
+			jsonData, err := json.Marshal([]requests.AddDeviceRequest{testCase.request})
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost, common.ApiDeviceRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+			query := req.URL.Query()
+			query.Add(bypassValidationQueryParam, common.ValueTrue)
+			req.URL.RawQuery = query.Encode()
+
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			err = controller.AddDevice(c)
+			require.NoError(t, err)
+
+			var res []commonDTO.BaseResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusMultiStatus, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedResponseCode, res[0].StatusCode, "BaseResponse status code not as expected")
+
+			wg.Wait()
+			mockMessaging.AssertExpectations(t)
+		})
+	}
+	dbClientMock.AssertNumberOfCalls(t, "AddDevice", 1)
+}
+
+func TestAddDeviceWithLocation(t *testing.T) {
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("DeviceServiceNameExists", TestDeviceServiceName).Return(true, nil)
+	dbClientMock.On("DeviceProfileByName", TestDeviceProfileName).Return(models.DeviceProfile{Name: TestDeviceProfileName, DeviceResources: []models.DeviceResource{{Name: "TestResource"}}}, nil)
+	dbClientMock.On("AddDevice", mock.Anything).Return(models.Device{Id: ExampleUUID}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewDeviceController(dic)
+
+	tests := []struct {
+		name                 string
+		location             any
+		expectedResponseCode int
+	}{
+		{"Valid - coordinates", map[string]any{"latitude": 25.033, "longitude": 121.565, "altitude": 12}, http.StatusCreated},
+		{"Valid - site reference", map[string]any{"site": "Cabinet-42", "building": "B1", "floor": "1F"}, http.StatusCreated},
+		{"Invalid - longitude without latitude", map[string]any{"longitude": 121.565}, http.StatusBadRequest},
+		{"Invalid - latitude out of range", map[string]any{"latitude": 91, "longitude": 121.565}, http.StatusBadRequest},
+		{"Invalid - unknown field", map[string]any{"lat": 25.033, "lon": 121.565}, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			var wg sync.WaitGroup
+			mockMessaging := &messagingMocks.MessageClient{}
+			if testCase.expectedResponseCode == http.StatusCreated {
+				wg.Add(1)
+				mockMessaging.On("Publish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					wg.Done()
+				}).Return(nil)
+			}
+			dic.Update(di.ServiceConstructorMap{
+				bootstrapContainer.MessagingClientName: func(get di.Get) interface{} {
+					return mockMessaging
+				},
+			})
+
+			addRequest := buildTestDeviceRequest()
+			addRequest.Device.Location = testCase.location
+			jsonData, err := json.Marshal([]requests.AddDeviceRequest{addRequest})
+			require.NoError(t, err)
+			e := echo.New()
+			req, err := http.NewRequest(http.MethodPost, common.ApiDeviceRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+			query := req.URL.Query()
+			query.Add(bypassValidationQueryParam, common.ValueTrue)
+			req.URL.RawQuery = query.Encode()
+
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			err = controller.AddDevice(c)
+			require.NoError(t, err)
+
+			var res []commonDTO.BaseResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedResponseCode, res[0].StatusCode, "BaseResponse status code not as expected")
+
+			wg.Wait()
+			mockMessaging.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDevicesByLocation(t *testing.T) {
+	device := dtos.ToDeviceModel(buildTestDeviceRequest().Device)
+	device.Location = map[string]any{"latitude": 25.033, "longitude": 121.565}
+	circle := pkgModels.GeoCircle{Latitude: 25.04, Longitude: 121.56, Radius: 5000}
+	box := pkgModels.GeoBoundingBox{MinLatitude: 25, MinLongitude: 121.5, MaxLatitude: 25.1, MaxLongitude: 121.6}
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("DevicesByGeoCircle", circle, 0, 20).Return([]models.Device{device}, uint32(1), nil)
+	dbClientMock.On("DevicesByGeoBoundingBox", box, 0, 20).Return([]models.Device{device}, uint32(1), nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewDeviceController(dic)
+
+	tests := []struct {
+		name               string
+		route              string
+		query              map[string]string
+		expectedStatusCode int
+	}{
+		{"Valid - devices within radius", pkgCommon.ApiDeviceByLocationRadiusRoute, map[string]string{pkgCommon.Latitude: "25.04", pkgCommon.Longitude: "121.56", pkgCommon.Radius: "5000"}, http.StatusOK},
+		{"Valid - devices within bounding box", pkgCommon.ApiDeviceByLocationBoundingBoxRoute, map[string]string{pkgCommon.MinLatitude: "25", pkgCommon.MinLongitude: "121.5", pkgCommon.MaxLatitude: "25.1", pkgCommon.MaxLongitude: "121.6"}, http.StatusOK},
+		{"Invalid - negative radius", pkgCommon.ApiDeviceByLocationRadiusRoute, map[string]string{pkgCommon.Latitude: "25.04", pkgCommon.Longitude: "121.56", pkgCommon.Radius: "-1"}, http.StatusBadRequest},
+		{"Invalid - invalid latitude format", pkgCommon.ApiDeviceByLocationRadiusRoute, map[string]string{pkgCommon.Latitude: "north", pkgCommon.Longitude: "121.56", pkgCommon.Radius: "5000"}, http.StatusBadRequest},
+		{"Invalid - minimum latitude greater than maximum", pkgCommon.ApiDeviceByLocationBoundingBoxRoute, map[string]string{pkgCommon.MinLatitude: "26", pkgCommon.MinLongitude: "121.5", pkgCommon.MaxLatitude: "25", pkgCommon.MaxLongitude: "121.6"}, http.StatusBadRequest},
+		{"Invalid - maximum longitude is missing", pkgCommon.ApiDeviceByLocationBoundingBoxRoute, map[string]string{pkgCommon.MinLatitude: "25", pkgCommon.MinLongitude: "121.5", pkgCommon.MaxLatitude: "25.1"}, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req, err := http.NewRequest(http.MethodGet, testCase.route, http.NoBody)
+			require.NoError(t, err)
+			query := req.URL.Query()
+			for k, v := range testCase.query {
+				query.Add(k, v)
+			}
+			req.URL.RawQuery = query.Encode()
+
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			if testCase.route == pkgCommon.ApiDeviceByLocationRadiusRoute {
+				err = controller.DevicesByLocationRadius(c)
+			} else {
+				err = controller.DevicesByLocationBoundingBox(c)
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode != http.StatusOK {
+				return
+			}
+			var res responseDTO.MultiDevicesResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, uint32(1), res.TotalCount)
+			require.Len(t, res.Devices, 1)
+			assert.Equal(t, device.Name, res.Devices[0].Name)
+		})
+	}
+}
+
+func TestDeviceProtocolSecrets(t *testing.T) {
+	plainText := buildTestDeviceRequest()
+	plainText.Device.Protocols = map[string]dtos.ProtocolProperties{"snmp": {"Address": "10.0.0.1", "Community": "public"}}
+	reference := buildTestDeviceRequest()
+	reference.Device.Protocols = map[string]dtos.ProtocolProperties{"snmp": {"Address": "10.0.0.1", "Community": map[string]any{"secretName": "snmp-agent", "secretKey": "community"}}}
+	invalidReference := buildTestDeviceRequest()
+	invalidReference.Device.Protocols = map[string]dtos.ProtocolProperties{"snmp": {"Address": "10.0.0.1", "Community": map[string]any{"secretName": "snmp-agent"}}}
+	storedDevice := dtos.ToDeviceModel(plainText.Device)
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("DeviceServiceNameExists", TestDeviceServiceName).Return(true, nil)
+	dbClientMock.On("DeviceProfileByName", mock.Anything).Return(models.DeviceProfile{Name: TestDeviceProfileName, DeviceResources: []models.DeviceResource{{Name: "TestResource"}}}, nil)
+	dbClientMock.On("AddDevice", mock.Anything).Return(dtos.ToDeviceModel(reference.Device), nil)
+	dbClientMock.On("DeviceByName", TestDeviceName).Return(storedDevice, nil)
+	dbClientMock.On("MetadataRevision", common.DeviceSystemEventType, TestDeviceName).Return(uint64(1), nil)
+	mockMessaging := &messagingMocks.MessageClient{}
+	mockMessaging.On("Publish", mock.Anything, mock.Anything).Return(nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Writable: config.WritableInfo{ProtocolSecrets: config.ProtocolSecrets{Properties: []string{"Community"}}},
+				Service:  bootstrapConfig.ServiceInfo{MaxResultCount: 30},
+			}
+		},
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+		bootstrapContainer.MessagingClientName: func(get di.Get) interface{} {
+			return mockMessaging
+		},
+	})
+	controller := NewDeviceController(dic)
+
+	tests := []struct {
+		name                 string
+		request              requests.AddDeviceRequest
+		expectedResponseCode int
+	}{
+		{"Valid - secret reference", reference, http.StatusCreated},
+		{"Invalid - plain text secret", plainText, http.StatusBadRequest},
+		{"Invalid - incomplete secret reference", invalidReference, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			jsonData, err := json.Marshal([]requests.AddDeviceRequest{testCase.request})
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost, common.ApiDeviceRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+			query := req.URL.Query()
+			query.Add(bypassValidationQueryParam, common.ValueTrue)
+			req.URL.RawQuery = query.Encode()
+
+			// Act
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			err = controller.AddDevice(c)
+			require.NoError(t, err)
+
+			var res []commonDTO.BaseResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			// Assert
+			require.Len(t, res, 1)
+			assert.Equal(t, testCase.expectedResponseCode, int(res[0].StatusCode), "Response status code not as expected")
+		})
+	}
+
+	t.Run("Valid - plain text secret stored before is redacted", func(t *testing.T) {
+		e := echo.New()
+		req, err := http.NewRequest(http.MethodGet, common.ApiDeviceByNameEchoRoute, http.NoBody)
+		require.NoError(t, err)
+
+		// Act
+		recorder := httptest.NewRecorder()
+		c := e.NewContext(req, recorder)
+		c.SetParamNames(common.Name)
+		c.SetParamValues(TestDeviceName)
+		err = controller.DeviceByName(c)
+		require.NoError(t, err)
+
+		var res responseDTO.DeviceResponse
+		err = json.Unmarshal(recorder.Body.Bytes(), &res)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, recorder.Result().StatusCode, "HTTP status code not as expected")
+		assert.Equal(t, pkgModels.RedactedValue, res.Device.Protocols["snmp"]["Community"])
+		assert.Equal(t, "10.0.0.1", res.Device.Protocols["snmp"]["Address"])
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/application"
 	"github.com/edgexfoundry/edgex-go/internal/io"
 	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
@@ -53,6 +54,10 @@ func (dc *DeviceCommandController) AddDeviceProfileDeviceCommand(c echo.Context)
 	if err != nil {
 		return utils.WriteErrorResponse(w, ctx, lc, err, "")
 	}
+	ctx, err = ifMatchContext(ctx, r, len(reqDTOs))
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
 
 	var addResponses []interface{}
 	for _, dto := range reqDTOs {
@@ -67,7 +72,7 @@ func (dc *DeviceCommandController) AddDeviceProfileDeviceCommand(c echo.Context)
 			response = commonDTO.NewBaseResponse(
 				reqId,
 				err.Message(),
-				err.Code())
+				utils.StatusCode(err))
 		} else {
 			response = commonDTO.NewBaseResponse(
 				reqId,
@@ -98,6 +103,10 @@ func (dc *DeviceCommandController) PatchDeviceProfileDeviceCommand(c echo.Contex
 	if err != nil {
 		return utils.WriteErrorResponse(w, ctx, lc, err, "")
 	}
+	ctx, err = ifMatchContext(ctx, r, len(reqDTOs))
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
 
 	var updateResponses []interface{}
 	for _, dto := range reqDTOs {
@@ -111,7 +120,7 @@ func (dc *DeviceCommandController) PatchDeviceProfileDeviceCommand(c echo.Contex
 			response = commonDTO.NewBaseResponse(
 				reqId,
 				err.Message(),
-				err.Code())
+				utils.StatusCode(err))
 		} else {
 			response = commonDTO.NewBaseResponse(
 				reqId,
@@ -135,6 +144,7 @@ func (dc *DeviceCommandController) DeleteDeviceCommandByName(c echo.Context) err
 	profileName := c.Param(common.Name)
 	commandName := c.Param(common.CommandName)
 
+	ctx = application.NewIfMatchContext(ctx, r.Header.Get(pkgCommon.IfMatchHeader))
 	err := application.DeleteDeviceCommandByName(profileName, commandName, ctx, dc.dic)
 	if err != nil {
 		return utils.WriteErrorResponse(w, ctx, lc, err, "")
@@ -13,7 +13,9 @@ import (
 	metadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
 	"github.com/edgexfoundry/edgex-go/internal/io"
 	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
@@ -113,6 +115,10 @@ func (dc *DeviceProfileController) UpdateDeviceProfile(c echo.Context) error {
 	if err != nil {
 		return utils.WriteErrorResponse(w, ctx, lc, err, "")
 	}
+	ctx, err = ifMatchContext(ctx, r, len(reqDTOs))
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
 	deviceProfiles := requestDTO.DeviceProfileReqToDeviceProfileModels(reqDTOs)
 
 	var responses []interface{}
@@ -126,7 +132,7 @@ func (dc *DeviceProfileController) UpdateDeviceProfile(c echo.Context) error {
 			response = commonDTO.NewBaseResponse(
 				reqId,
 				err.Message(),
-				err.Code())
+				utils.StatusCode(err))
 		} else {
 			response = commonDTO.NewBaseResponse(
 				reqId,
@@ -204,6 +210,7 @@ func (dc *DeviceProfileController) UpdateDeviceProfileByYaml(c echo.Context) err
 	}
 
 	deviceProfile := dtos.ToDeviceProfileModel(deviceProfileDTO)
+	ctx = application.NewIfMatchContext(ctx, r.Header.Get(pkgCommon.IfMatchHeader))
 	err = application.UpdateDeviceProfile(deviceProfile, ctx, dc.dic)
 	if err != nil {
 		return utils.WriteErrorResponse(w, ctx, lc, err, "")
@@ -229,6 +236,10 @@ func (dc *DeviceProfileController) DeviceProfileByName(c echo.Context) error {
 	}
 
 	response := responseDTO.NewDeviceProfileResponse("", "", http.StatusOK, deviceProfile)
+	err = setETagHeader(w, common.DeviceProfileSystemEventType, name, dc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
 	utils.WriteHttpHeader(w, ctx, http.StatusOK)
 	return pkg.EncodeAndWriteResponse(response, w, lc) // encode and send out the response
 }
@@ -242,6 +253,7 @@ func (dc *DeviceProfileController) DeleteDeviceProfileByName(c echo.Context) err
 	// URL parameters
 	name := c.Param(common.Name)
 
+	ctx = application.NewIfMatchContext(ctx, r.Header.Get(pkgCommon.IfMatchHeader))
 	err := application.DeleteDeviceProfileByName(name, ctx, dc.dic)
 	if err != nil {
 		return utils.WriteErrorResponse(w, ctx, lc, err, "")
@@ -363,6 +375,10 @@ func (dc *DeviceProfileController) PatchDeviceProfileBasicInfo(c echo.Context) e
 	if err != nil {
 		return utils.WriteErrorResponse(w, ctx, lc, err, "")
 	}
+	ctx, err = ifMatchContext(ctx, r, len(reqDTOs))
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
 
 	var updateResponses []interface{}
 	for _, dto := range reqDTOs {
@@ -372,7 +388,7 @@ func (dc *DeviceProfileController) PatchDeviceProfileBasicInfo(c echo.Context) e
 		if err != nil {
 			lc.Error(err.Error(), common.CorrelationHeader, correlationId)
 			lc.Debug(err.DebugMessages(), common.CorrelationHeader, correlationId)
-			response = commonDTO.NewBaseResponse(reqId, err.Message(), err.Code())
+			response = commonDTO.NewBaseResponse(reqId, err.Message(), utils.StatusCode(err))
 		} else {
 			response = commonDTO.NewBaseResponse(reqId, "", http.StatusOK)
 		}
@@ -382,3 +398,32 @@ func (dc *DeviceProfileController) PatchDeviceProfileBasicInfo(c echo.Context) e
 	utils.WriteHttpHeader(w, ctx, http.StatusMultiStatus)
 	return pkg.EncodeAndWriteResponse(updateResponses, w, lc)
 }
+
+// ValidateDeviceProfile lints the device profile and reports the changes which may break the devices and provision
+// watchers using the stored version, the device profile is not persisted
+func (dc *DeviceProfileController) ValidateDeviceProfile(c echo.Context) error {
+	r := c.Request()
+	w := c.Response()
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := container.LoggingClientFrom(dc.dic.Get)
+	ctx := r.Context()
+
+	var reqDTO requestDTO.DeviceProfileRequest
+	err := dc.jsonDtoReader.Read(r.Body, &reqDTO)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	deviceProfile := requestDTO.DeviceProfileReqToDeviceProfileModel(reqDTO)
+
+	findings, blocked, err := application.LintDeviceProfile(deviceProfile, dc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, reqDTO.RequestId)
+	}
+
+	response := pkgResponses.NewDeviceProfileLintResponse(reqDTO.RequestId, "", http.StatusOK, findings, blocked)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
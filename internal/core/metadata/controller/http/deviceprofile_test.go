@@ -34,6 +34,9 @@ import (
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/config"
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/infrastructure/interfaces/mocks"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -506,6 +509,8 @@ func TestUpdateDeviceProfile(t *testing.T) {
 	dbClientMock.On("DevicesByProfileName", 0, -1, deviceProfileModel.Name).Return([]models.Device{{ServiceName: testDeviceServiceName}}, nil)
 	dbClientMock.On("DeviceServiceByName", testDeviceServiceName).Return(models.DeviceService{}, nil)
 	dbClientMock.On("DeviceProfileByName", deviceProfileModel.Name).Return(deviceProfileModel, nil)
+	dbClientMock.On("DeviceProfileByName", notFoundDeviceProfileModel.Name).Return(models.DeviceProfile{}, notFoundDBError)
+	dbClientMock.On("ProvisionWatchersByProfileName", 0, -1, deviceProfileModel.Name).Return([]models.ProvisionWatcher{}, nil)
 	dic.Update(di.ServiceConstructorMap{
 		container.DBClientInterfaceName: func(get di.Get) interface{} {
 			return dbClientMock
@@ -950,6 +955,8 @@ func TestUpdateDeviceProfileByYaml(t *testing.T) {
 	dbClientMock.On("DevicesByProfileName", 0, -1, validDeviceProfileModel.Name).Return([]models.Device{{ServiceName: testDeviceServiceName}}, nil)
 	dbClientMock.On("DeviceServiceByName", testDeviceServiceName).Return(models.DeviceService{}, nil)
 	dbClientMock.On("DeviceProfileByName", validDeviceProfileModel.Name).Return(validDeviceProfileModel, nil)
+	dbClientMock.On("DeviceProfileByName", notFoundDeviceProfileModel.Name).Return(models.DeviceProfile{}, notFoundDBError)
+	dbClientMock.On("ProvisionWatchersByProfileName", 0, -1, validDeviceProfileModel.Name).Return([]models.ProvisionWatcher{}, nil)
 	dic.Update(di.ServiceConstructorMap{
 		container.DBClientInterfaceName: func(get di.Get) interface{} {
 			return dbClientMock
@@ -1046,6 +1053,7 @@ func TestDeviceProfileByName(t *testing.T) {
 	dic := mockDic()
 	dbClientMock := &mocks.DBClient{}
 	dbClientMock.On("DeviceProfileByName", deviceProfile.Name).Return(deviceProfile, nil)
+	dbClientMock.On("MetadataRevision", common.DeviceProfileSystemEventType, deviceProfile.Name).Return(uint64(5), nil)
 	dbClientMock.On("DeviceProfileByName", notFoundName).Return(models.DeviceProfile{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "device profile doesn't exist in the database", nil))
 	dic.Update(di.ServiceConstructorMap{
 		container.DBClientInterfaceName: func(get di.Get) interface{} {
@@ -1098,6 +1106,7 @@ func TestDeviceProfileByName(t *testing.T) {
 				assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
 				assert.Equal(t, testCase.expectedStatusCode, int(res.StatusCode), "Response status code not as expected")
 				assert.Equal(t, testCase.deviceProfileName, res.Profile.Name, "Event Id not as expected")
+				assert.Equal(t, `"5"`, recorder.Header().Get(pkgCommon.ETagHeader), "ETag not as expected")
 				assert.Empty(t, res.Message, "Message should be empty when it is successful")
 			}
 		})
@@ -1521,3 +1530,98 @@ func TestDeviceProfilesByManufacturerAndModel(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateDeviceProfile(t *testing.T) {
+	storedProfile := requests.DeviceProfileReqToDeviceProfileModel(buildTestDeviceProfileRequest())
+	device := models.Device{
+		Name:        TestDeviceName,
+		ProfileName: storedProfile.Name,
+		AutoEvents:  []models.AutoEvent{{SourceName: TestDeviceResourceName, Interval: "10s"}},
+	}
+
+	unchanged := buildTestDeviceProfileRequest()
+	resourceRemoved := buildTestDeviceProfileRequest()
+	resourceRemoved.Profile.DeviceResources = resourceRemoved.Profile.DeviceResources[1:]
+	resourceRemoved.Profile.DeviceCommands = nil
+	newProfile := buildTestDeviceProfileRequest()
+	newProfile.Profile.Name = "newProfile"
+	notFoundDBError := errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "device profile newProfile does not exists", nil)
+
+	dic := mockDic()
+	container.ConfigurationFrom(dic.Get).Writable.ProfileChange.BlockingSeverity = pkgModels.ProfileFindingSeverityError
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("DeviceProfileByName", storedProfile.Name).Return(storedProfile, nil)
+	dbClientMock.On("DeviceProfileByName", newProfile.Profile.Name).Return(models.DeviceProfile{}, notFoundDBError)
+	dbClientMock.On("DevicesByProfileName", 0, -1, storedProfile.Name).Return([]models.Device{device}, nil)
+	dbClientMock.On("ProvisionWatchersByProfileName", 0, -1, storedProfile.Name).Return([]models.ProvisionWatcher{}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewDeviceProfileController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name            string
+		request         requests.DeviceProfileRequest
+		expectedBlocked bool
+		expectedCode    string
+	}{
+		{"Valid - unchanged profile", unchanged, false, ""},
+		{"Valid - new profile", newProfile, false, ""},
+		{"Valid - resource referenced by AutoEvent removed", resourceRemoved, true, pkgModels.ProfileFindingAutoEventSource},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			jsonData, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+
+			reader := strings.NewReader(string(jsonData))
+			req, err := http.NewRequest(http.MethodPost, pkgCommon.ApiDeviceProfileValidateRoute, reader)
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			err = controller.ValidateDeviceProfile(c)
+			require.NoError(t, err)
+
+			var res pkgResponses.DeviceProfileLintResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedBlocked, res.Blocked)
+			if testCase.expectedCode == "" {
+				assert.Empty(t, res.Findings)
+				return
+			}
+			require.NotEmpty(t, res.Findings)
+			assert.Equal(t, testCase.expectedCode, res.Findings[0].Code)
+			assert.Equal(t, pkgModels.ProfileFindingSeverityError, res.Findings[0].Severity)
+			assert.Equal(t, []string{device.Name}, res.Findings[0].Devices)
+			assert.True(t, res.Findings[0].Blocking)
+		})
+	}
+
+	// the update is rejected by the blocking policy
+	dbClientMock.On("UpdateDeviceProfile", mock.Anything).Return(nil)
+	e := echo.New()
+	jsonData, err := json.Marshal([]requests.DeviceProfileRequest{resourceRemoved})
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPut, common.ApiDeviceProfileRoute, strings.NewReader(string(jsonData)))
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	err = controller.UpdateDeviceProfile(e.NewContext(req, recorder))
+	require.NoError(t, err)
+	var res []commonDTO.BaseResponse
+	err = json.Unmarshal(recorder.Body.Bytes(), &res)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, http.StatusConflict, res[0].StatusCode)
+	dbClientMock.AssertNotCalled(t, "UpdateDeviceProfile", mock.Anything)
+}
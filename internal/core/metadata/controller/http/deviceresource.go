@@ -11,7 +11,9 @@ import (
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/application"
 	"github.com/edgexfoundry/edgex-go/internal/io"
 	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
@@ -59,6 +61,48 @@ func (dc *DeviceResourceController) DeviceResourceByProfileNameAndResourceName(c
 	return pkg.EncodeAndWriteResponse(response, w, lc)
 }
 
+// DeviceResourcesByDeviceName query the effective device resources of the device, i.e. with the resource overrides
+// of the device applied to the device resources of its profile
+func (dc *DeviceResourceController) DeviceResourcesByDeviceName(c echo.Context) error {
+	lc := container.LoggingClientFrom(dc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+
+	// URL parameters
+	name := c.Param(common.Name)
+
+	resources, err := application.DeviceResourcesByDeviceName(name, dc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := pkgResponses.NewMultiDeviceResourcesResponse("", "", http.StatusOK, uint32(len(resources)), resources)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+// DeviceResourceByDeviceNameAndResourceName query the effective device resource of the device by resourceName
+func (dc *DeviceResourceController) DeviceResourceByDeviceNameAndResourceName(c echo.Context) error {
+	lc := container.LoggingClientFrom(dc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+
+	// URL parameters
+	name := c.Param(common.Name)
+	resourceName := c.Param(common.ResourceName)
+
+	resource, err := application.DeviceResourceByDeviceNameAndResourceName(name, resourceName, dc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := responseDTO.NewDeviceResourceResponse("", "", http.StatusOK, resource)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
 func (dc *DeviceResourceController) AddDeviceProfileResource(c echo.Context) error {
 	r := c.Request()
 	w := c.Response()
@@ -75,6 +119,10 @@ func (dc *DeviceResourceController) AddDeviceProfileResource(c echo.Context) err
 	if err != nil {
 		return utils.WriteErrorResponse(w, ctx, lc, err, "")
 	}
+	ctx, err = ifMatchContext(ctx, r, len(reqDTOs))
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
 
 	var addResponses []interface{}
 	for _, dto := range reqDTOs {
@@ -89,7 +137,7 @@ func (dc *DeviceResourceController) AddDeviceProfileResource(c echo.Context) err
 			response = commonDTO.NewBaseResponse(
 				reqId,
 				err.Message(),
-				err.Code())
+				utils.StatusCode(err))
 		} else {
 			response = commonDTO.NewBaseResponse(
 				reqId,
@@ -119,6 +167,10 @@ func (dc *DeviceResourceController) PatchDeviceProfileResource(c echo.Context) e
 	if err != nil {
 		return utils.WriteErrorResponse(w, ctx, lc, err, "")
 	}
+	ctx, err = ifMatchContext(ctx, r, len(reqDTOs))
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
 
 	var updateResponses []interface{}
 	for _, dto := range reqDTOs {
@@ -132,7 +184,7 @@ func (dc *DeviceResourceController) PatchDeviceProfileResource(c echo.Context) e
 			response = commonDTO.NewBaseResponse(
 				reqId,
 				err.Message(),
-				err.Code())
+				utils.StatusCode(err))
 		} else {
 			response = commonDTO.NewBaseResponse(
 				reqId,
@@ -157,6 +209,7 @@ func (dc *DeviceResourceController) DeleteDeviceResourceByName(c echo.Context) e
 	profileName := c.Param(common.Name)
 	resourceName := c.Param(common.ResourceName)
 
+	ctx = application.NewIfMatchContext(ctx, r.Header.Get(pkgCommon.IfMatchHeader))
 	err := application.DeleteDeviceResourceByName(profileName, resourceName, ctx, dc.dic)
 	if err != nil {
 		return utils.WriteErrorResponse(w, ctx, lc, err, "")
@@ -15,6 +15,9 @@ import (
 
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/infrastructure/interfaces/mocks"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
@@ -537,3 +540,118 @@ func TestDeleteDeviceResourceByName_StrictProfileChanges(t *testing.T) {
 	assert.Equal(t, common.ApiVersion, res.ApiVersion, "API Version not as expected")
 	assert.Equal(t, http.StatusLocked, res.StatusCode, "BaseResponse status code not as expected")
 }
+
+func TestDeviceResourcesByDeviceName(t *testing.T) {
+	deviceProfile := dtos.ToDeviceProfileModel(buildTestDeviceProfileRequest().Profile)
+	device := dtos.ToDeviceModel(buildTestDeviceRequest().Device)
+	device.Properties = map[string]any{
+		pkgModels.DeviceResourceOverridesProperty: map[string]any{
+			TestDeviceResourceName: map[string]any{"units": "percent", "scale": 0.1},
+		},
+	}
+	notFoundName := "notFoundName"
+
+	dic := mockDic()
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("DeviceByName", device.Name).Return(device, nil)
+	dbClientMock.On("DeviceByName", notFoundName).Return(models.Device{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "device doesn't exist in the database", nil))
+	dbClientMock.On("DeviceProfileByName", device.ProfileName).Return(deviceProfile, nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewDeviceResourceController(dic)
+
+	tests := []struct {
+		name               string
+		deviceName         string
+		expectedStatusCode int
+	}{
+		{"Valid - effective device resources by device name", device.Name, http.StatusOK},
+		{"Invalid - device name is empty", "", http.StatusBadRequest},
+		{"Invalid - device not found", notFoundName, http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req, err := http.NewRequest(http.MethodGet, pkgCommon.ApiDeviceResourceByDeviceEchoRoute, http.NoBody)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			c.SetParamNames(common.Name)
+			c.SetParamValues(testCase.deviceName)
+
+			err = controller.DeviceResourcesByDeviceName(c)
+			require.NoError(t, err)
+
+			require.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode != http.StatusOK {
+				return
+			}
+			var res pkgResponses.MultiDeviceResourcesResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			require.Len(t, res.Resources, len(deviceProfile.DeviceResources))
+			assert.Equal(t, uint32(len(deviceProfile.DeviceResources)), res.TotalCount)
+			assert.Equal(t, "percent", res.Resources[0].Properties.Units)
+			assert.Equal(t, 0.1, *res.Resources[0].Properties.Scale)
+			assert.Equal(t, TestUnits, res.Resources[1].Properties.Units, "resources without overrides are inherited from the profile")
+		})
+	}
+}
+
+func TestDeviceResourceByDeviceNameAndResourceName(t *testing.T) {
+	deviceProfile := dtos.ToDeviceProfileModel(buildTestDeviceProfileRequest().Profile)
+	device := dtos.ToDeviceModel(buildTestDeviceRequest().Device)
+	device.Properties = map[string]any{
+		pkgModels.DeviceResourceOverridesProperty: map[string]any{
+			TestDeviceResourceName: map[string]any{"units": "percent"},
+		},
+	}
+
+	dic := mockDic()
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("DeviceByName", device.Name).Return(device, nil)
+	dbClientMock.On("DeviceProfileByName", device.ProfileName).Return(deviceProfile, nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewDeviceResourceController(dic)
+
+	tests := []struct {
+		name               string
+		resourceName       string
+		expectedStatusCode int
+	}{
+		{"Valid - effective device resource", TestDeviceResourceName, http.StatusOK},
+		{"Invalid - resource name is empty", "", http.StatusBadRequest},
+		{"Invalid - resource not found", "resourceNotFoundName", http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req, err := http.NewRequest(http.MethodGet, pkgCommon.ApiDeviceResourceByDeviceAndResourceEchoRoute, http.NoBody)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			c.SetParamNames(common.Name, common.ResourceName)
+			c.SetParamValues(device.Name, testCase.resourceName)
+
+			err = controller.DeviceResourceByDeviceNameAndResourceName(c)
+			require.NoError(t, err)
+
+			require.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode != http.StatusOK {
+				return
+			}
+			var res responseDTO.DeviceResourceResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.resourceName, res.Resource.Name)
+			assert.Equal(t, "percent", res.Resource.Properties.Units)
+		})
+	}
+}
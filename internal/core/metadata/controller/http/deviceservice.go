@@ -13,6 +13,7 @@ import (
 	metadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
 	"github.com/edgexfoundry/edgex-go/internal/io"
 	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
 
@@ -100,6 +101,10 @@ func (dc *DeviceServiceController) DeviceServiceByName(c echo.Context) error {
 	}
 
 	response := responseDTO.NewDeviceServiceResponse("", "", http.StatusOK, deviceService)
+	err = setETagHeader(w, common.DeviceServiceSystemEventType, name, dc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
 	utils.WriteHttpHeader(w, ctx, http.StatusOK)
 	return pkg.EncodeAndWriteResponse(response, w, lc)
 }
@@ -121,6 +126,10 @@ func (dc *DeviceServiceController) PatchDeviceService(c echo.Context) error {
 	if err != nil {
 		return utils.WriteErrorResponse(w, ctx, lc, err, "")
 	}
+	ctx, err = ifMatchContext(ctx, r, len(reqDTOs))
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
 
 	var updateResponses []interface{}
 	for _, dto := range reqDTOs {
@@ -133,7 +142,7 @@ func (dc *DeviceServiceController) PatchDeviceService(c echo.Context) error {
 			response = commonDTO.NewBaseResponse(
 				reqId,
 				err.Message(),
-				err.Code())
+				utils.StatusCode(err))
 		} else {
 			response = commonDTO.NewBaseResponse(
 				reqId,
@@ -156,6 +165,7 @@ func (dc *DeviceServiceController) DeleteDeviceServiceByName(c echo.Context) err
 	// URL parameters
 	name := c.Param(common.Name)
 
+	ctx = application.NewIfMatchContext(ctx, r.Header.Get(pkgCommon.IfMatchHeader))
 	err := application.DeleteDeviceServiceByName(name, ctx, dc.dic)
 	if err != nil {
 		return utils.WriteErrorResponse(w, ctx, lc, err, "")
@@ -15,6 +15,7 @@ import (
 
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
 	dbMock "github.com/edgexfoundry/edgex-go/internal/core/metadata/infrastructure/interfaces/mocks"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
@@ -204,6 +205,7 @@ func TestDeviceServiceByName(t *testing.T) {
 	dic := mockDic()
 	dbClientMock := &dbMock.DBClient{}
 	dbClientMock.On("DeviceServiceByName", deviceService.Name).Return(deviceService, nil)
+	dbClientMock.On("MetadataRevision", common.DeviceServiceSystemEventType, deviceService.Name).Return(uint64(5), nil)
 	dbClientMock.On("DeviceServiceByName", notFoundName).Return(models.DeviceService{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "device service doesn't exist in the database", nil))
 	dic.Update(di.ServiceConstructorMap{
 		container.DBClientInterfaceName: func(get di.Get) interface{} {
@@ -256,6 +258,7 @@ func TestDeviceServiceByName(t *testing.T) {
 				assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
 				assert.Equal(t, testCase.expectedStatusCode, int(res.StatusCode), "Response status code not as expected")
 				assert.Equal(t, testCase.deviceServiceName, res.Service.Name, "Name not as expected")
+				assert.Equal(t, `"5"`, recorder.Header().Get(pkgCommon.ETagHeader), "ETag not as expected")
 				assert.Empty(t, res.Message, "Message should be empty when it is successful")
 			}
 		})
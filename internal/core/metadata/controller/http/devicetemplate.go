@@ -0,0 +1,283 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/application"
+	metadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	"github.com/edgexfoundry/edgex-go/internal/io"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	pkgRequests "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/requests"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type DeviceTemplateController struct {
+	reader io.DtoReader
+	dic    *di.Container
+}
+
+// NewDeviceTemplateController creates and initializes a DeviceTemplateController
+func NewDeviceTemplateController(dic *di.Container) *DeviceTemplateController {
+	return &DeviceTemplateController{
+		reader: io.NewJsonDtoReader(),
+		dic:    dic,
+	}
+}
+
+func (dtc *DeviceTemplateController) AddDeviceTemplate(c echo.Context) error {
+	r := c.Request()
+	w := c.Response()
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := container.LoggingClientFrom(dtc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	var reqDTOs []pkgRequests.DeviceTemplateRequest
+	err := dtc.reader.Read(r.Body, &reqDTOs)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	templates := pkgRequests.DeviceTemplateReqToDeviceTemplateModels(reqDTOs)
+
+	var addResponses []interface{}
+	for i, t := range templates {
+		var response interface{}
+		reqId := reqDTOs[i].RequestId
+		newId, err := application.AddDeviceTemplate(t, ctx, dtc.dic)
+		if err != nil {
+			lc.Error(err.Error(), common.CorrelationHeader, correlationId)
+			lc.Debug(err.DebugMessages(), common.CorrelationHeader, correlationId)
+			response = commonDTO.NewBaseResponse(reqId, err.Error(), utils.StatusCode(err))
+		} else {
+			response = commonDTO.NewBaseWithIdResponse(reqId, "", http.StatusCreated, newId)
+		}
+		addResponses = append(addResponses, response)
+	}
+
+	utils.WriteHttpHeader(w, ctx, http.StatusMultiStatus)
+	return pkg.EncodeAndWriteResponse(addResponses, w, lc)
+}
+
+func (dtc *DeviceTemplateController) UpdateDeviceTemplate(c echo.Context) error {
+	r := c.Request()
+	w := c.Response()
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := container.LoggingClientFrom(dtc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	var reqDTOs []pkgRequests.DeviceTemplateRequest
+	err := dtc.reader.Read(r.Body, &reqDTOs)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	ctx, err = ifMatchContext(ctx, r, len(reqDTOs))
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	templates := pkgRequests.DeviceTemplateReqToDeviceTemplateModels(reqDTOs)
+
+	var responses []interface{}
+	for i, t := range templates {
+		var response interface{}
+		reqId := reqDTOs[i].RequestId
+		err := application.UpdateDeviceTemplate(t, ctx, dtc.dic)
+		if err != nil {
+			lc.Error(err.Error(), common.CorrelationHeader, correlationId)
+			lc.Debug(err.DebugMessages(), common.CorrelationHeader, correlationId)
+			response = commonDTO.NewBaseResponse(reqId, err.Error(), utils.StatusCode(err))
+		} else {
+			response = commonDTO.NewBaseResponse(reqId, "", http.StatusOK)
+		}
+		responses = append(responses, response)
+	}
+
+	utils.WriteHttpHeader(w, ctx, http.StatusMultiStatus)
+	return pkg.EncodeAndWriteResponse(responses, w, lc)
+}
+
+func (dtc *DeviceTemplateController) DeviceTemplateByName(c echo.Context) error {
+	lc := container.LoggingClientFrom(dtc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+
+	// URL parameters
+	name := c.Param(common.Name)
+
+	template, err := application.DeviceTemplateByName(name, dtc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := pkgResponses.NewDeviceTemplateResponse("", "", http.StatusOK, template)
+	err = setETagHeader(w, pkgCommon.DeviceTemplate, name, dtc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (dtc *DeviceTemplateController) AllDeviceTemplates(c echo.Context) error {
+	lc := container.LoggingClientFrom(dtc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+	config := metadataContainer.ConfigurationFrom(dtc.dic.Get)
+
+	// parse URL query string for offset, limit, and labels
+	offset, limit, labels, err := utils.ParseGetAllObjectsRequestQueryString(c, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	templates, totalCount, err := application.AllDeviceTemplates(offset, limit, labels, dtc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := pkgResponses.NewMultiDeviceTemplatesResponse("", "", http.StatusOK, totalCount, templates)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (dtc *DeviceTemplateController) DeleteDeviceTemplateByName(c echo.Context) error {
+	lc := container.LoggingClientFrom(dtc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+
+	// URL parameters
+	name := c.Param(common.Name)
+
+	ctx = application.NewIfMatchContext(ctx, r.Header.Get(pkgCommon.IfMatchHeader))
+	err := application.DeleteDeviceTemplateByName(name, ctx, dtc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := commonDTO.NewBaseResponse("", "", http.StatusOK)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (dtc *DeviceTemplateController) DeviceTemplateInstances(c echo.Context) error {
+	lc := container.LoggingClientFrom(dtc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+	config := metadataContainer.ConfigurationFrom(dtc.dic.Get)
+
+	// URL parameters
+	name := c.Param(common.Name)
+
+	// parse URL query string for offset and limit
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(c, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	instances, totalCount, err := application.DeviceTemplateInstances(name, offset, limit, dtc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := pkgResponses.NewMultiDeviceTemplateInstancesResponse("", "", http.StatusOK, totalCount, instances)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (dtc *DeviceTemplateController) InstantiateDeviceTemplate(c echo.Context) error {
+	r := c.Request()
+	w := c.Response()
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := container.LoggingClientFrom(dtc.dic.Get)
+	ctx := identity.NewContext(r.Context(), identity.FromRequest(r))
+	correlationId := correlation.FromContext(ctx)
+
+	// URL parameters
+	name := c.Param(common.Name)
+	bypassValidation := utils.ParseQueryStringToString(r, bypassValidationQueryParam, common.ValueFalse) == common.ValueTrue
+
+	var reqDTO pkgRequests.InstantiateDeviceTemplateRequest
+	err := dtc.reader.Read(r.Body, &reqDTO)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	if _, err = application.DeviceTemplateByName(name, dtc.dic); err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, reqDTO.RequestId)
+	}
+
+	var addResponses []interface{}
+	for _, parameters := range reqDTO.Parameters {
+		var response interface{}
+		newId, err := application.InstantiateDeviceTemplate(name, parameters, ctx, dtc.dic, bypassValidation)
+		if err != nil {
+			lc.Error(err.Error(), common.CorrelationHeader, correlationId)
+			lc.Debug(err.DebugMessages(), common.CorrelationHeader, correlationId)
+			response = commonDTO.NewBaseResponse(reqDTO.RequestId, err.Error(), utils.StatusCode(err))
+		} else {
+			response = commonDTO.NewBaseWithIdResponse(reqDTO.RequestId, "", http.StatusCreated, newId)
+		}
+		addResponses = append(addResponses, response)
+	}
+
+	utils.WriteHttpHeader(w, ctx, http.StatusMultiStatus)
+	return pkg.EncodeAndWriteResponse(addResponses, w, lc)
+}
+
+func (dtc *DeviceTemplateController) RolloutDeviceTemplate(c echo.Context) error {
+	lc := container.LoggingClientFrom(dtc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := identity.NewContext(r.Context(), identity.FromRequest(r))
+	correlationId := correlation.FromContext(ctx)
+
+	// URL parameters
+	name := c.Param(common.Name)
+	bypassValidation := utils.ParseQueryStringToString(r, bypassValidationQueryParam, common.ValueFalse) == common.ValueTrue
+
+	results, err := application.RolloutDeviceTemplate(name, ctx, dtc.dic, bypassValidation)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	responses := make([]interface{}, len(results))
+	for i, result := range results {
+		if result.Err != nil {
+			lc.Error(result.Err.Error(), common.CorrelationHeader, correlationId)
+			lc.Debug(result.Err.DebugMessages(), common.CorrelationHeader, correlationId)
+			responses[i] = pkgResponses.NewDeviceTemplateRolloutResponse("", result.Err.Error(), utils.StatusCode(result.Err), result.DeviceName)
+		} else {
+			responses[i] = pkgResponses.NewDeviceTemplateRolloutResponse("", "", http.StatusOK, result.DeviceName)
+		}
+	}
+
+	utils.WriteHttpHeader(w, ctx, http.StatusMultiStatus)
+	return pkg.EncodeAndWriteResponse(responses, w, lc)
+}
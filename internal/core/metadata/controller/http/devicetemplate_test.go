@@ -0,0 +1,387 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/config"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/infrastructure/interfaces/mocks"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	pkgRequests "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/requests"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+const testDeviceTemplateName = "modbus-sensor"
+
+func buildTestDeviceTemplateRequest() pkgRequests.DeviceTemplateRequest {
+	return pkgRequests.DeviceTemplateRequest{
+		BaseRequest: commonDTO.BaseRequest{
+			RequestId:   ExampleUUID,
+			Versionable: commonDTO.NewVersionable(),
+		},
+		DeviceTemplate: pkgDtos.DeviceTemplate{
+			Name:   testDeviceTemplateName,
+			Labels: []string{"modbus"},
+			Device: dtos.Device{
+				Name:        "sensor-${id}",
+				ServiceName: TestDeviceServiceName,
+				ProfileName: TestDeviceProfileName,
+				Protocols: map[string]dtos.ProtocolProperties{
+					"modbus-tcp": {"Address": "${address}", "Port": "502"},
+				},
+			},
+		},
+	}
+}
+
+func buildTestDeviceTemplateModel() pkgModels.DeviceTemplate {
+	return pkgDtos.ToDeviceTemplateModel(buildTestDeviceTemplateRequest().DeviceTemplate)
+}
+
+func TestAddDeviceTemplate(t *testing.T) {
+	valid := buildTestDeviceTemplateRequest()
+	noName := buildTestDeviceTemplateRequest()
+	noName.DeviceTemplate.Name = ""
+	noServiceName := buildTestDeviceTemplateRequest()
+	noServiceName.DeviceTemplate.Device.ServiceName = ""
+	noDeviceName := buildTestDeviceTemplateRequest()
+	noDeviceName.DeviceTemplate.Device.Name = ""
+
+	dic := mockDic()
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("AddDeviceTemplate", mock.Anything).Return(pkgModels.DeviceTemplate{Id: ExampleUUID}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewDeviceTemplateController(dic)
+
+	tests := []struct {
+		name               string
+		request            []pkgRequests.DeviceTemplateRequest
+		expectedStatusCode int
+	}{
+		{"Valid", []pkgRequests.DeviceTemplateRequest{valid}, http.StatusCreated},
+		{"Invalid - no template name", []pkgRequests.DeviceTemplateRequest{noName}, http.StatusBadRequest},
+		{"Invalid - rendered device has no service name", []pkgRequests.DeviceTemplateRequest{noServiceName}, http.StatusBadRequest},
+		{"Invalid - rendered device has no name", []pkgRequests.DeviceTemplateRequest{noDeviceName}, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			jsonData, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost, pkgCommon.ApiDeviceTemplateRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			err = controller.AddDeviceTemplate(c)
+			require.NoError(t, err)
+
+			if testCase.expectedStatusCode == http.StatusBadRequest && testCase.request[0].DeviceTemplate.Name == "" {
+				assert.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+				return
+			}
+			require.Equal(t, http.StatusMultiStatus, recorder.Result().StatusCode)
+			var res []commonDTO.BaseWithIdResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			require.Len(t, res, 1)
+			assert.Equal(t, testCase.expectedStatusCode, res[0].StatusCode)
+			if testCase.expectedStatusCode == http.StatusCreated {
+				assert.Equal(t, ExampleUUID, res[0].Id)
+			}
+		})
+	}
+}
+
+func TestInstantiateDeviceTemplate(t *testing.T) {
+	template := buildTestDeviceTemplateModel()
+	var added models.Device
+
+	dic := mockDic()
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("DeviceTemplateByName", testDeviceTemplateName).Return(template, nil)
+	dbClientMock.On("DeviceServiceNameExists", TestDeviceServiceName).Return(true, nil)
+	dbClientMock.On("DeviceProfileByName", TestDeviceProfileName).Return(models.DeviceProfile{Name: TestDeviceProfileName}, nil)
+	dbClientMock.On("AddDevice", mock.Anything).Run(func(args mock.Arguments) {
+		added = args.Get(0).(models.Device)
+	}).Return(models.Device{Id: ExampleUUID, ServiceName: TestDeviceServiceName}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewDeviceTemplateController(dic)
+
+	tests := []struct {
+		name               string
+		parameters         map[string]string
+		expectedStatusCode int
+	}{
+		{"Valid", map[string]string{"id": "01", "address": "10.0.0.1"}, http.StatusCreated},
+		{"Invalid - missing parameter", map[string]string{"id": "02"}, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			request := pkgRequests.InstantiateDeviceTemplateRequest{
+				BaseRequest: commonDTO.NewBaseRequest(),
+				Parameters:  []map[string]string{testCase.parameters},
+			}
+			jsonData, err := json.Marshal(request)
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost, pkgCommon.ApiDeviceTemplateInstantiateEchoRoute+"?"+bypassValidationQueryParam+"="+common.ValueTrue, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			c.SetParamNames(common.Name)
+			c.SetParamValues(testDeviceTemplateName)
+			err = controller.InstantiateDeviceTemplate(c)
+			require.NoError(t, err)
+
+			require.Equal(t, http.StatusMultiStatus, recorder.Result().StatusCode)
+			var res []commonDTO.BaseWithIdResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			require.Len(t, res, 1)
+			assert.Equal(t, testCase.expectedStatusCode, res[0].StatusCode)
+			if testCase.expectedStatusCode == http.StatusCreated {
+				assert.Equal(t, "sensor-01", added.Name)
+				assert.Equal(t, "10.0.0.1", added.Protocols["modbus-tcp"]["Address"])
+				assert.Equal(t, testDeviceTemplateName, added.Properties[pkgModels.DeviceTemplateNameProperty])
+			}
+		})
+	}
+}
+
+func TestRolloutDeviceTemplate(t *testing.T) {
+	template := buildTestDeviceTemplateModel()
+	template.Device.Labels = []string{"rolled-out"}
+	instance := models.Device{
+		Name:           "sensor-01",
+		ServiceName:    TestDeviceServiceName,
+		ProfileName:    TestDeviceProfileName,
+		AdminState:     models.Locked,
+		OperatingState: models.Up,
+		Protocols:      map[string]models.ProtocolProperties{"modbus-tcp": {"Address": "10.0.0.1", "Port": "502"}},
+		Properties: map[string]any{
+			pkgModels.DeviceTemplateNameProperty:       testDeviceTemplateName,
+			pkgModels.DeviceTemplateParametersProperty: map[string]any{"id": "01", "address": "10.0.0.1"},
+		},
+	}
+	var updated models.Device
+
+	dic := mockDic()
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("DeviceTemplateByName", testDeviceTemplateName).Return(template, nil)
+	dbClientMock.On("DevicesByTemplateName", 0, -1, testDeviceTemplateName).Return([]models.Device{instance}, nil)
+	dbClientMock.On("DeviceByName", instance.Name).Return(instance, nil)
+	dbClientMock.On("DeviceServiceNameExists", TestDeviceServiceName).Return(true, nil)
+	dbClientMock.On("DeviceProfileByName", TestDeviceProfileName).Return(models.DeviceProfile{Name: TestDeviceProfileName}, nil)
+	dbClientMock.On("UpdateDevice", mock.Anything).Run(func(args mock.Arguments) {
+		updated = args.Get(0).(models.Device)
+	}).Return(nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewDeviceTemplateController(dic)
+
+	e := echo.New()
+	req, err := http.NewRequest(http.MethodPost, pkgCommon.ApiDeviceTemplateRolloutEchoRoute+"?"+bypassValidationQueryParam+"="+common.ValueTrue, http.NoBody)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	c := e.NewContext(req, recorder)
+	c.SetParamNames(common.Name)
+	c.SetParamValues(testDeviceTemplateName)
+	err = controller.RolloutDeviceTemplate(c)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusMultiStatus, recorder.Result().StatusCode)
+	var res []pkgResponses.DeviceTemplateRolloutResponse
+	err = json.Unmarshal(recorder.Body.Bytes(), &res)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, http.StatusOK, res[0].StatusCode)
+	assert.Equal(t, instance.Name, res[0].DeviceName)
+	assert.Equal(t, []string{"rolled-out"}, updated.Labels)
+	assert.Equal(t, models.AdminState(models.Locked), updated.AdminState)
+}
+
+func TestDeleteDeviceTemplateByName(t *testing.T) {
+	unusedTemplateName := "unused"
+
+	dic := mockDic()
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("DeviceCountByTemplateName", testDeviceTemplateName).Return(uint32(1), nil)
+	dbClientMock.On("DeviceCountByTemplateName", unusedTemplateName).Return(uint32(0), nil)
+	dbClientMock.On("DeleteDeviceTemplateByName", unusedTemplateName).Return(nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewDeviceTemplateController(dic)
+
+	tests := []struct {
+		name               string
+		templateName       string
+		expectedStatusCode int
+	}{
+		{"Valid", unusedTemplateName, http.StatusOK},
+		{"Invalid - template has instances", testDeviceTemplateName, http.StatusConflict},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req, err := http.NewRequest(http.MethodDelete, pkgCommon.ApiDeviceTemplateByNameEchoRoute, http.NoBody)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			c.SetParamNames(common.Name)
+			c.SetParamValues(testCase.templateName)
+			err = controller.DeleteDeviceTemplateByName(c)
+			require.NoError(t, err)
+
+			var res commonDTO.BaseResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode)
+			assert.Equal(t, testCase.expectedStatusCode, res.StatusCode)
+		})
+	}
+}
+
+func TestDeviceTemplateProtocolSecrets(t *testing.T) {
+	plainText := buildTestDeviceTemplateRequest()
+	plainText.DeviceTemplate.Device.Protocols = map[string]dtos.ProtocolProperties{"snmp": {"Address": "${address}", "Community": "${community}"}}
+	reference := buildTestDeviceTemplateRequest()
+	reference.DeviceTemplate.Device.Protocols = map[string]dtos.ProtocolProperties{
+		"snmp": {"Address": "${address}", "Community": map[string]any{"secretName": "snmp-${id}", "secretKey": "community"}},
+	}
+	// a device template and its instance stored before the secret protocol properties were enforced
+	storedTemplate := pkgDtos.ToDeviceTemplateModel(plainText.DeviceTemplate)
+	instance := models.Device{
+		Name:      "sensor-01",
+		Protocols: map[string]models.ProtocolProperties{"snmp": {"Address": "10.0.0.1", "Community": "private"}},
+		Properties: map[string]any{
+			pkgModels.DeviceTemplateNameProperty:       testDeviceTemplateName,
+			pkgModels.DeviceTemplateParametersProperty: map[string]any{"id": "01", "address": "10.0.0.1", "community": "private"},
+		},
+	}
+
+	dic := mockDic()
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("AddDeviceTemplate", mock.Anything).Return(pkgModels.DeviceTemplate{Id: ExampleUUID}, nil)
+	dbClientMock.On("DeviceTemplateByName", testDeviceTemplateName).Return(storedTemplate, nil)
+	dbClientMock.On("MetadataRevision", pkgCommon.DeviceTemplate, testDeviceTemplateName).Return(uint64(1), nil)
+	dbClientMock.On("DevicesByTemplateName", 0, common.DefaultLimit, testDeviceTemplateName).Return([]models.Device{instance}, nil)
+	dbClientMock.On("DeviceCountByTemplateName", testDeviceTemplateName).Return(uint32(1), nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Writable: config.WritableInfo{ProtocolSecrets: config.ProtocolSecrets{Properties: []string{"Community"}}},
+				Service:  bootstrapConfig.ServiceInfo{MaxResultCount: 30},
+			}
+		},
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewDeviceTemplateController(dic)
+
+	tests := []struct {
+		name               string
+		request            pkgRequests.DeviceTemplateRequest
+		expectedStatusCode int
+	}{
+		{"Valid - secret reference", reference, http.StatusCreated},
+		{"Invalid - plain text secret", plainText, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			jsonData, err := json.Marshal([]pkgRequests.DeviceTemplateRequest{testCase.request})
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost, pkgCommon.ApiDeviceTemplateRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			err = controller.AddDeviceTemplate(c)
+			require.NoError(t, err)
+
+			var res []commonDTO.BaseWithIdResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			require.Len(t, res, 1)
+			assert.Equal(t, testCase.expectedStatusCode, res[0].StatusCode)
+		})
+	}
+
+	t.Run("Valid - plain text secret stored before is redacted", func(t *testing.T) {
+		e := echo.New()
+		req, err := http.NewRequest(http.MethodGet, pkgCommon.ApiDeviceTemplateByNameEchoRoute, http.NoBody)
+		require.NoError(t, err)
+		recorder := httptest.NewRecorder()
+		c := e.NewContext(req, recorder)
+		c.SetParamNames(common.Name)
+		c.SetParamValues(testDeviceTemplateName)
+		err = controller.DeviceTemplateByName(c)
+		require.NoError(t, err)
+
+		var res pkgResponses.DeviceTemplateResponse
+		err = json.Unmarshal(recorder.Body.Bytes(), &res)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+		assert.Equal(t, pkgModels.RedactedValue, res.DeviceTemplate.Device.Protocols["snmp"]["Community"])
+		assert.Equal(t, "${address}", res.DeviceTemplate.Device.Protocols["snmp"]["Address"])
+	})
+
+	t.Run("Valid - secret parameter of an instance is redacted", func(t *testing.T) {
+		e := echo.New()
+		req, err := http.NewRequest(http.MethodGet, pkgCommon.ApiDeviceTemplateInstancesEchoRoute, http.NoBody)
+		require.NoError(t, err)
+		recorder := httptest.NewRecorder()
+		c := e.NewContext(req, recorder)
+		c.SetParamNames(common.Name)
+		c.SetParamValues(testDeviceTemplateName)
+		err = controller.DeviceTemplateInstances(c)
+		require.NoError(t, err)
+
+		var res pkgResponses.MultiDeviceTemplateInstancesResponse
+		err = json.Unmarshal(recorder.Body.Bytes(), &res)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+		require.Len(t, res.Instances, 1)
+		assert.Equal(t, map[string]string{"id": "01", "address": "10.0.0.1", "community": pkgModels.RedactedValue}, res.Instances[0].Parameters)
+	})
+}
@@ -0,0 +1,55 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/application"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type IntegrityController struct {
+	dic *di.Container
+}
+
+// NewIntegrityController creates and initializes an IntegrityController
+func NewIntegrityController(dic *di.Container) *IntegrityController {
+	return &IntegrityController{
+		dic: dic,
+	}
+}
+
+func (ic *IntegrityController) MetadataIntegrity(c echo.Context) error {
+	return ic.metadataIntegrity(c, false)
+}
+
+func (ic *IntegrityController) RepairMetadataIntegrity(c echo.Context) error {
+	return ic.metadataIntegrity(c, true)
+}
+
+func (ic *IntegrityController) metadataIntegrity(c echo.Context, repair bool) error {
+	lc := container.LoggingClientFrom(ic.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+
+	report, err := application.MetadataIntegrity(repair, ic.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := pkgResponses.NewMetadataIntegrityReportResponse("", "", http.StatusOK, report)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
@@ -0,0 +1,146 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/metadata/infrastructure/interfaces/mocks"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+func TestMetadataIntegrity(t *testing.T) {
+	inconsistency := pkgModels.IndexInconsistency{
+		EntityType: common.DeviceSystemEventType,
+		Index:      "md|dv:location",
+		Member:     "md|dv:" + ExampleUUID,
+		Problem:    pkgModels.IndexEntryMissing,
+	}
+	services := []models.DeviceService{{Name: TestDeviceServiceName}}
+	profiles := []models.DeviceProfile{{Name: TestDeviceProfileName}}
+	devices := []models.Device{
+		{Name: TestDeviceName, ServiceName: TestDeviceServiceName, ProfileName: TestDeviceProfileName},
+		{Name: "orphan", ServiceName: "missingService", ProfileName: TestDeviceProfileName, Parent: "missingParent"},
+		{Name: "child", ServiceName: TestDeviceServiceName, ProfileName: TestDeviceProfileName, Parent: TestDeviceName},
+		{Name: "orphanInstance", ServiceName: TestDeviceServiceName, ProfileName: TestDeviceProfileName,
+			Properties: map[string]any{pkgModels.DeviceTemplateNameProperty: "missingTemplate"}},
+		{Name: "instance", ServiceName: TestDeviceServiceName, ProfileName: TestDeviceProfileName,
+			Properties: map[string]any{pkgModels.DeviceTemplateNameProperty: "template"}},
+	}
+	templates := []pkgModels.DeviceTemplate{{Name: "template"}}
+	watchers := []models.ProvisionWatcher{
+		{Name: "watcher", ServiceName: TestDeviceServiceName, DiscoveredDevice: models.DiscoveredDevice{ProfileName: "missingProfile"}},
+	}
+	pendingDevices := []pkgModels.PendingDevice{
+		{Status: pkgModels.PendingDeviceStatusPending, Device: models.Device{Name: "pending", ServiceName: "missingService", ProfileName: TestDeviceProfileName}},
+	}
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("CheckMetadataIndexes", false).Return(pkgModels.IndexIntegrity{
+		Entities:        map[string]uint32{common.DeviceSystemEventType: 3},
+		Inconsistencies: []pkgModels.IndexInconsistency{inconsistency},
+	}, nil)
+	dbClientMock.On("CheckMetadataIndexes", true).Return(pkgModels.IndexIntegrity{
+		Entities:        map[string]uint32{common.DeviceSystemEventType: 3},
+		Inconsistencies: []pkgModels.IndexInconsistency{inconsistency},
+		Rebuilt:         true,
+	}, nil)
+	dbClientMock.On("AllDeviceServices", 0, mock.Anything, []string(nil)).Return(services, nil)
+	dbClientMock.On("AllDeviceProfiles", 0, mock.Anything, []string(nil)).Return(profiles, nil)
+	dbClientMock.On("AllDeviceTemplates", 0, mock.Anything, []string(nil)).Return(templates, nil)
+	dbClientMock.On("AllDevices", 0, mock.Anything, []string(nil)).Return(devices, nil)
+	dbClientMock.On("AllProvisionWatchers", 0, mock.Anything, []string(nil)).Return(watchers, nil)
+	dbClientMock.On("PendingDevices", "", 0, mock.Anything).Return(pendingDevices, uint32(len(pendingDevices)), nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewIntegrityController(dic)
+
+	tests := []struct {
+		name     string
+		method   string
+		route    string
+		repaired bool
+	}{
+		{"Valid - report", http.MethodGet, pkgCommon.ApiIntegrityRoute, false},
+		{"Valid - repair", http.MethodPost, pkgCommon.ApiIntegrityRepairRoute, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req, err := http.NewRequest(testCase.method, testCase.route, http.NoBody)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			if testCase.repaired {
+				err = controller.RepairMetadataIntegrity(c)
+			} else {
+				err = controller.MetadataIntegrity(c)
+			}
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, recorder.Result().StatusCode, "HTTP status code not as expected")
+
+			var res pkgResponses.MetadataIntegrityReportResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.repaired, res.Report.Repaired)
+			assert.Equal(t, uint32(3), res.Report.Entities[common.DeviceSystemEventType])
+			require.Len(t, res.Report.IndexInconsistencies, 1)
+			assert.Equal(t, pkgModels.IndexEntryMissing, res.Report.IndexInconsistencies[0].Problem)
+
+			require.Len(t, res.Report.DanglingReferences, 5)
+			assert.Equal(t, "orphan", res.Report.DanglingReferences[0].Name)
+			assert.Equal(t, "serviceName", res.Report.DanglingReferences[0].Field)
+			assert.Equal(t, "missingService", res.Report.DanglingReferences[0].Reference)
+			assert.Equal(t, "orphanInstance", res.Report.DanglingReferences[1].Name)
+			assert.Equal(t, "missingTemplate", res.Report.DanglingReferences[1].Reference)
+			assert.Equal(t, "parent", res.Report.DanglingReferences[2].Field)
+			assert.Equal(t, common.ProvisionWatcherSystemEventType, res.Report.DanglingReferences[3].EntityType)
+			assert.Equal(t, "missingProfile", res.Report.DanglingReferences[3].Reference)
+			assert.Equal(t, pkgCommon.PendingDevice, res.Report.DanglingReferences[4].EntityType)
+			assert.Equal(t, "device.serviceName", res.Report.DanglingReferences[4].Field)
+		})
+	}
+}
+
+func TestRepairMetadataIntegrityConflict(t *testing.T) {
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("CheckMetadataIndexes", true).Return(pkgModels.IndexIntegrity{}, edgexErr.NewCommonEdgeX(edgexErr.KindStatusConflict, "metadata was modified", nil))
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewIntegrityController(dic)
+
+	e := echo.New()
+	req, err := http.NewRequest(http.MethodPost, pkgCommon.ApiIntegrityRepairRoute, http.NoBody)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	c := e.NewContext(req, recorder)
+	err = controller.RepairMetadataIntegrity(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, recorder.Result().StatusCode, "HTTP status code not as expected")
+}
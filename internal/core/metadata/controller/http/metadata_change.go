@@ -0,0 +1,110 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/application"
+	metadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type MetadataChangeController struct {
+	dic *di.Container
+}
+
+// NewMetadataChangeController creates and initializes a MetadataChangeController
+func NewMetadataChangeController(dic *di.Container) *MetadataChangeController {
+	return &MetadataChangeController{
+		dic: dic,
+	}
+}
+
+func (mc *MetadataChangeController) MetadataChanges(c echo.Context) error {
+	lc := container.LoggingClientFrom(mc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+	config := metadataContainer.ConfigurationFrom(mc.dic.Get)
+
+	// parse URL query string for since, limit and wait
+	var since uint64
+	if value := strings.TrimSpace(c.QueryParam(pkgCommon.Since)); value != "" {
+		var parseErr error
+		since, parseErr = strconv.ParseUint(value, 10, 64)
+		if parseErr != nil {
+			err := errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("failed to parse querystring %s's value %s into cursor", pkgCommon.Since, value), parseErr)
+			return utils.WriteErrorResponse(w, ctx, lc, err, "")
+		}
+	}
+	limit, err := utils.ParseQueryStringToInt(c, common.Limit, common.DefaultLimit, -1, config.Service.MaxResultCount)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	if limit == -1 {
+		limit = config.Service.MaxResultCount
+	}
+	wait, err := parseMetadataChangeWait(c.QueryParam(pkgCommon.Wait), config.ChangeLog.MaxWait, config.Service.RequestTimeout)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	changes, cursor, err := application.MetadataChangesSince(since, limit, wait, ctx, mc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := pkgResponses.NewMetadataChangesResponse("", "", http.StatusOK, changes, cursor)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+// longPollTimeoutMargin is kept between the wait of a long-polling request and the request timeout, so the response
+// is written before the request is timed out by the server
+const longPollTimeoutMargin = time.Second
+
+// parseMetadataChangeWait parses the wait duration of a long-polling request, the duration is capped by the maxWait
+// and by the request timeout of the service
+func parseMetadataChangeWait(value string, maxWait string, requestTimeout string) (time.Duration, errors.EdgeX) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	wait, err := time.ParseDuration(value)
+	if err != nil || wait < 0 {
+		return 0, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("failed to parse querystring %s's value %s into a non-negative duration", pkgCommon.Wait, value), err)
+	}
+	if maxWait != "" {
+		limit, err := time.ParseDuration(maxWait)
+		if err != nil {
+			return 0, errors.NewCommonEdgeX(errors.KindServerError, "failed to parse ChangeLog.MaxWait", err)
+		}
+		wait = min(wait, limit)
+	}
+	if requestTimeout != "" {
+		timeout, err := time.ParseDuration(requestTimeout)
+		if err != nil {
+			return 0, errors.NewCommonEdgeX(errors.KindServerError, "failed to parse Service.RequestTimeout", err)
+		}
+		wait = max(min(wait, timeout-longPollTimeoutMargin), 0)
+	}
+	return wait, nil
+}
@@ -0,0 +1,108 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/metadata/infrastructure/interfaces/mocks"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+func TestMetadataChanges(t *testing.T) {
+	changes := []pkgModels.MetadataChange{
+		{Sequence: 2, Type: common.DeviceServiceSystemEventType, Action: common.SystemEventActionAdd, Name: "device-modbus"},
+		{Sequence: 3, Type: common.DeviceServiceSystemEventType, Action: common.SystemEventActionDelete, Name: "device-modbus"},
+	}
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("MetadataChangeSequenceRange").Return(uint64(1), uint64(3), nil)
+	dbClientMock.On("MetadataChangesSince", uint64(1), mock.Anything).Return(changes, nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewMetadataChangeController(dic)
+
+	tests := []struct {
+		name               string
+		since              string
+		wait               string
+		expectedCursor     uint64
+		expectedCount      int
+		expectedStatusCode int
+	}{
+		{"Valid - changes since cursor", "1", "", 3, 2, http.StatusOK},
+		{"Valid - no new change", "3", "", 3, 0, http.StatusOK},
+		{"Valid - no new change after waiting", "3", "1ms", 3, 0, http.StatusOK},
+		{"Invalid - cursor is not a number", "abc", "", 0, 0, http.StatusBadRequest},
+		{"Invalid - negative wait", "1", "-1s", 0, 0, http.StatusBadRequest},
+		{"Invalid - cursor ahead of the change log", "4", "", 0, 0, http.StatusRequestedRangeNotSatisfiable},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req, err := http.NewRequest(http.MethodGet, pkgCommon.ApiMetadataChangesRoute, http.NoBody)
+			require.NoError(t, err)
+			query := req.URL.Query()
+			query.Add(pkgCommon.Since, testCase.since)
+			query.Add(pkgCommon.Wait, testCase.wait)
+			req.URL.RawQuery = query.Encode()
+
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			err = controller.MetadataChanges(c)
+			require.NoError(t, err)
+
+			require.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode != http.StatusOK {
+				return
+			}
+			var res pkgResponses.MetadataChangesResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedCursor, res.Cursor)
+			assert.Len(t, res.Changes, testCase.expectedCount)
+		})
+	}
+}
+
+func TestParseMetadataChangeWait(t *testing.T) {
+	wait, err := parseMetadataChangeWait("", "30s", "5s")
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), wait)
+
+	wait, err = parseMetadataChangeWait("2s", "30s", "5s")
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Second, wait)
+
+	wait, err = parseMetadataChangeWait("1m", "30s", "60s")
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, wait)
+
+	wait, err = parseMetadataChangeWait("1m", "30s", "5s")
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second-longPollTimeoutMargin, wait)
+
+	_, err = parseMetadataChangeWait("soon", "30s", "5s")
+	require.Error(t, err)
+}
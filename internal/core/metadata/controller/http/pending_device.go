@@ -0,0 +1,170 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/requests"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/application"
+	metadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	"github.com/edgexfoundry/edgex-go/internal/io"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/identity"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PendingDeviceController struct {
+	reader io.DtoReader
+	dic    *di.Container
+}
+
+// NewPendingDeviceController creates and initializes a PendingDeviceController
+func NewPendingDeviceController(dic *di.Container) *PendingDeviceController {
+	return &PendingDeviceController{
+		reader: io.NewJsonDtoReader(),
+		dic:    dic,
+	}
+}
+
+func (pc *PendingDeviceController) AllPendingDevices(c echo.Context) error {
+	lc := container.LoggingClientFrom(pc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+	config := metadataContainer.ConfigurationFrom(pc.dic.Get)
+
+	// parse URL query string for offset, limit and status
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(c, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	status := utils.ParseQueryStringToString(r, common.Status, "")
+	pendingDevices, totalCount, err := application.PendingDevices(status, offset, limit, pc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := pkgResponses.NewMultiPendingDevicesResponse("", "", http.StatusOK, totalCount, pendingDevices)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (pc *PendingDeviceController) PendingDeviceByName(c echo.Context) error {
+	lc := container.LoggingClientFrom(pc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+
+	// URL parameters
+	name := c.Param(common.Name)
+
+	pendingDevice, err := application.PendingDeviceByName(name, pc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := pkgResponses.NewPendingDeviceResponse("", "", http.StatusOK, pendingDevice)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (pc *PendingDeviceController) PatchPendingDevice(c echo.Context) error {
+	r := c.Request()
+	w := c.Response()
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := container.LoggingClientFrom(pc.dic.Get)
+	ctx := r.Context()
+
+	// URL parameters
+	name := c.Param(common.Name)
+
+	var reqDTO requests.UpdateDeviceRequest
+	err := pc.reader.Read(r.Body, &reqDTO)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	err = application.PatchPendingDevice(name, reqDTO.Device, pc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, reqDTO.RequestId)
+	}
+
+	response := commonDTO.NewBaseResponse(reqDTO.RequestId, "", http.StatusOK)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (pc *PendingDeviceController) ApprovePendingDevice(c echo.Context) error {
+	lc := container.LoggingClientFrom(pc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := identity.NewContext(r.Context(), identity.FromRequest(r))
+
+	// URL parameters
+	name := c.Param(common.Name)
+	// the approved device is validated by the device service unless bypassValidation is true, as adding a device does
+	bypassValidation := utils.ParseQueryStringToString(r, bypassValidationQueryParam, common.ValueFalse) == common.ValueTrue
+
+	id, err := application.ApprovePendingDevice(name, bypassValidation, ctx, pc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := commonDTO.NewBaseWithIdResponse("", "", http.StatusCreated, id)
+	utils.WriteHttpHeader(w, ctx, http.StatusCreated)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (pc *PendingDeviceController) RejectPendingDevice(c echo.Context) error {
+	lc := container.LoggingClientFrom(pc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+
+	// URL parameters
+	name := c.Param(common.Name)
+
+	err := application.RejectPendingDevice(name, ctx, pc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := commonDTO.NewBaseResponse("", "", http.StatusOK)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (pc *PendingDeviceController) DeletePendingDeviceByName(c echo.Context) error {
+	lc := container.LoggingClientFrom(pc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+
+	// URL parameters
+	name := c.Param(common.Name)
+
+	err := application.DeletePendingDeviceByName(name, pc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := commonDTO.NewBaseResponse("", "", http.StatusOK)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
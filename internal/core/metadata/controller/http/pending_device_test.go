@@ -0,0 +1,394 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/requests"
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	messagingMocks "github.com/edgexfoundry/go-mod-messaging/v3/messaging/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/config"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/metadata/infrastructure/interfaces/mocks"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+func mockApprovalRequiredDic() *di.Container {
+	dic := mockDic()
+	dic.Update(di.ServiceConstructorMap{
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Writable: config.WritableInfo{
+					LogLevel:  "DEBUG",
+					Discovery: config.Discovery{ApprovalRequired: true},
+				},
+				Service: bootstrapConfig.ServiceInfo{
+					RequestTimeout: "30s",
+					MaxResultCount: 30,
+				},
+			}
+		},
+	})
+	return dic
+}
+
+func buildTestPendingDevice() pkgModels.PendingDevice {
+	return pkgModels.PendingDevice{
+		Id:                   ExampleUUID,
+		Status:               pkgModels.PendingDeviceStatusPending,
+		ProvisionWatcherName: "watcher",
+		Device:               requests.AddDeviceReqToDeviceModels([]requests.AddDeviceRequest{buildTestDeviceRequest()})[0],
+	}
+}
+
+func TestAddDeviceWithDiscoveryApproval(t *testing.T) {
+	discovered := buildTestDeviceRequest()
+	discovered.Device.Id = ""
+	discovered.Device.Properties = map[string]any{pkgModels.ProvisionWatcherNameProperty: "watcher"}
+	// the device added directly isn't staged even though a provision watcher would match it
+	notDiscovered := buildTestDeviceRequest()
+	notDiscovered.Device.Id = ""
+	notDiscovered.Device.Name = "notDiscovered"
+	discoveredModel := requests.AddDeviceReqToDeviceModels([]requests.AddDeviceRequest{discovered})[0]
+
+	dic := mockApprovalRequiredDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("DeviceNameExists", mock.Anything).Return(false, nil)
+	dbClientMock.On("PendingDeviceByName", TestDeviceName).Return(pkgModels.PendingDevice{},
+		edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, "pending device doesn't exist", nil))
+	dbClientMock.On("AddPendingDevice", mock.MatchedBy(func(pd pkgModels.PendingDevice) bool {
+		return pd.Status == pkgModels.PendingDeviceStatusPending && pd.ProvisionWatcherName == "watcher" && pd.Device.Name == discoveredModel.Name
+	})).Return(pkgModels.PendingDevice{Id: ExampleUUID}, nil)
+	dbClientMock.On("DeviceServiceNameExists", TestDeviceServiceName).Return(true, nil)
+	dbClientMock.On("DeviceProfileByName", mock.Anything).Return(models.DeviceProfile{Name: TestDeviceProfileName, DeviceResources: []models.DeviceResource{{Name: "TestResource"}}}, nil)
+	dbClientMock.On("AddDevice", mock.Anything).Return(models.Device{Id: ExampleUUID, Name: notDiscovered.Device.Name}, nil)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	mockMessaging := &messagingMocks.MessageClient{}
+	mockMessaging.On("Publish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		wg.Done()
+	}).Return(nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+		bootstrapContainer.MessagingClientName: func(get di.Get) interface{} {
+			return mockMessaging
+		},
+	})
+	controller := NewDeviceController(dic)
+
+	e := echo.New()
+	jsonData, err := json.Marshal([]requests.AddDeviceRequest{discovered, notDiscovered})
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, common.ApiDeviceRoute+"?"+bypassValidationQueryParam+"="+common.ValueTrue, strings.NewReader(string(jsonData)))
+	require.NoError(t, err)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	c := e.NewContext(req, recorder)
+	err = controller.AddDevice(c)
+	require.NoError(t, err)
+
+	var res []commonDTO.BaseWithIdResponse
+	err = json.Unmarshal(recorder.Body.Bytes(), &res)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, http.StatusMultiStatus, recorder.Result().StatusCode, "HTTP status code not as expected")
+	require.Len(t, res, 2)
+	assert.Equal(t, http.StatusAccepted, res[0].StatusCode, "the discovered device should be pending approval")
+	assert.Equal(t, ExampleUUID, res[0].Id)
+	assert.Equal(t, http.StatusCreated, res[1].StatusCode, "the device not discovered should be added")
+	wg.Wait()
+	dbClientMock.AssertNumberOfCalls(t, "AddPendingDevice", 1)
+	dbClientMock.AssertNumberOfCalls(t, "AddDevice", 1)
+}
+
+func TestAddDeviceOfDeviceServiceWithDiscoveryApproval(t *testing.T) {
+	// the discovery payload of a device service doesn't carry the ProvisionWatcherName property
+	discovered := buildTestDeviceRequest()
+	discovered.Device.Id = ""
+	discovered.Device.Properties = nil
+	deviceServiceToken := "eyJhbGciOiJIUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(`{"name":"`+TestDeviceServiceName+`"}`)) + ".c2lnbmF0dXJl"
+
+	dic := mockApprovalRequiredDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("DeviceNameExists", TestDeviceName).Return(false, nil)
+	dbClientMock.On("PendingDeviceByName", TestDeviceName).Return(pkgModels.PendingDevice{},
+		edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, "pending device doesn't exist", nil))
+	dbClientMock.On("AddPendingDevice", mock.MatchedBy(func(pd pkgModels.PendingDevice) bool {
+		return pd.Status == pkgModels.PendingDeviceStatusPending && pd.ProvisionWatcherName == "" && pd.Device.Name == TestDeviceName
+	})).Return(pkgModels.PendingDevice{Id: ExampleUUID}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewDeviceController(dic)
+
+	tests := []struct {
+		name                 string
+		authorization        string
+		disableJWTValidation string
+	}{
+		{"Valid - added by the device service", internal.BearerLabel + deviceServiceToken, "false"},
+		{"Valid - origin unknown without JWT validation", "", "true"},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Setenv("EDGEX_DISABLE_JWT_VALIDATION", testCase.disableJWTValidation)
+			e := echo.New()
+			jsonData, err := json.Marshal([]requests.AddDeviceRequest{discovered})
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost, common.ApiDeviceRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+			if testCase.authorization != "" {
+				req.Header.Set(internal.AuthHeaderTitle, testCase.authorization)
+			}
+
+			// Act
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			err = controller.AddDevice(c)
+			require.NoError(t, err)
+
+			var res []commonDTO.BaseWithIdResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			// Assert
+			require.Len(t, res, 1)
+			assert.Equal(t, http.StatusAccepted, res[0].StatusCode, "the device added by the device service should be pending approval")
+		})
+	}
+	dbClientMock.AssertNotCalled(t, "AddDevice", mock.Anything)
+}
+
+func TestAllPendingDevices(t *testing.T) {
+	pendingDevice := buildTestPendingDevice()
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("PendingDevices", "", 0, 20).Return([]pkgModels.PendingDevice{pendingDevice}, uint32(1), nil)
+	dbClientMock.On("PendingDevices", pkgModels.PendingDeviceStatusRejected, 0, 20).Return([]pkgModels.PendingDevice{}, uint32(0), nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewPendingDeviceController(dic)
+
+	tests := []struct {
+		name               string
+		status             string
+		errorExpected      bool
+		expectedCount      int
+		expectedStatusCode int
+	}{
+		{"Valid - all pending devices", "", false, 1, http.StatusOK},
+		{"Valid - rejected devices", pkgModels.PendingDeviceStatusRejected, false, 0, http.StatusOK},
+		{"Invalid - invalid status", "UNKNOWN", true, 0, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req, err := http.NewRequest(http.MethodGet, pkgCommon.ApiAllPendingDeviceRoute, http.NoBody)
+			require.NoError(t, err)
+			query := req.URL.Query()
+			query.Add(common.Limit, "20")
+			if testCase.status != "" {
+				query.Add(common.Status, testCase.status)
+			}
+			req.URL.RawQuery = query.Encode()
+
+			// Act
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			err = controller.AllPendingDevices(c)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.errorExpected {
+				return
+			}
+			var res pkgResponses.MultiPendingDevicesResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Len(t, res.PendingDevices, testCase.expectedCount)
+		})
+	}
+}
+
+func TestPatchPendingDevice(t *testing.T) {
+	pendingDevice := buildTestPendingDevice()
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("PendingDeviceByName", TestDeviceName).Return(pendingDevice, nil)
+	dbClientMock.On("UpdatePendingDevice", mock.MatchedBy(func(pd pkgModels.PendingDevice) bool {
+		return pd.Device.Description == TestDescription
+	})).Return(nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewPendingDeviceController(dic)
+
+	name := TestDeviceName
+	description := TestDescription
+	valid := requests.UpdateDeviceRequest{BaseRequest: commonDTO.BaseRequest{RequestId: ExampleUUID, Versionable: commonDTO.NewVersionable()}}
+	valid.Device.Name = &name
+	valid.Device.Description = &description
+	renamed := valid
+	newName := "newName"
+	renamed.Device.Name = &newName
+
+	tests := []struct {
+		name               string
+		request            requests.UpdateDeviceRequest
+		expectedStatusCode int
+	}{
+		{"Valid", valid, http.StatusOK},
+		{"Invalid - rename", renamed, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			jsonData, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPatch, pkgCommon.ApiPendingDeviceByNameEchoRoute, strings.NewReader(string(jsonData)))
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			c.SetParamNames(common.Name)
+			c.SetParamValues(TestDeviceName)
+			err = controller.PatchPendingDevice(c)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+		})
+	}
+}
+
+func TestApprovePendingDevice(t *testing.T) {
+	pendingDevice := buildTestPendingDevice()
+	notFoundName := "notFoundName"
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("PendingDeviceByName", TestDeviceName).Return(pendingDevice, nil)
+	dbClientMock.On("PendingDeviceByName", notFoundName).Return(pkgModels.PendingDevice{},
+		edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, "pending device doesn't exist", nil))
+	dbClientMock.On("DeviceServiceNameExists", TestDeviceServiceName).Return(true, nil)
+	dbClientMock.On("DeviceProfileByName", mock.Anything).Return(models.DeviceProfile{Name: TestDeviceProfileName, DeviceResources: []models.DeviceResource{{Name: "TestResource"}}}, nil)
+	dbClientMock.On("ApprovePendingDevice", pendingDevice.Device).Return(pendingDevice.Device, nil)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	mockMessaging := &messagingMocks.MessageClient{}
+	mockMessaging.On("Publish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		wg.Done()
+	}).Return(nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+		bootstrapContainer.MessagingClientName: func(get di.Get) interface{} {
+			return mockMessaging
+		},
+	})
+	controller := NewPendingDeviceController(dic)
+
+	tests := []struct {
+		name               string
+		deviceName         string
+		expectedStatusCode int
+	}{
+		{"Valid", TestDeviceName, http.StatusCreated},
+		{"Invalid - not found", notFoundName, http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req, err := http.NewRequest(http.MethodPost, pkgCommon.ApiApprovePendingDeviceEchoRoute, http.NoBody)
+			require.NoError(t, err)
+			query := req.URL.Query()
+			query.Add(bypassValidationQueryParam, common.ValueTrue)
+			req.URL.RawQuery = query.Encode()
+
+			// Act
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			c.SetParamNames(common.Name)
+			c.SetParamValues(testCase.deviceName)
+			err = controller.ApprovePendingDevice(c)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+		})
+	}
+	wg.Wait()
+	dbClientMock.AssertNotCalled(t, "AddDevice", mock.Anything)
+	dbClientMock.AssertNotCalled(t, "DeletePendingDeviceByName", mock.Anything)
+}
+
+func TestRejectPendingDevice(t *testing.T) {
+	pendingDevice := buildTestPendingDevice()
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("PendingDeviceByName", TestDeviceName).Return(pendingDevice, nil)
+	dbClientMock.On("UpdatePendingDevice", mock.MatchedBy(func(pd pkgModels.PendingDevice) bool {
+		return pd.Status == pkgModels.PendingDeviceStatusRejected
+	})).Return(nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewPendingDeviceController(dic)
+
+	e := echo.New()
+	req, err := http.NewRequest(http.MethodPost, pkgCommon.ApiRejectPendingDeviceEchoRoute, http.NoBody)
+	require.NoError(t, err)
+
+	// Act
+	recorder := httptest.NewRecorder()
+	c := e.NewContext(req, recorder)
+	c.SetParamNames(common.Name)
+	c.SetParamValues(TestDeviceName)
+	err = controller.RejectPendingDevice(c)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, recorder.Result().StatusCode, "HTTP status code not as expected")
+	dbClientMock.AssertExpectations(t)
+}
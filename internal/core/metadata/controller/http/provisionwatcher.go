@@ -13,7 +13,10 @@ import (
 	metadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
 	"github.com/edgexfoundry/edgex-go/internal/io"
 	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	pkgRequests "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/requests"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
@@ -99,6 +102,10 @@ func (pwc *ProvisionWatcherController) ProvisionWatcherByName(c echo.Context) er
 	}
 
 	response := responseDTO.NewProvisionWatcherResponse("", "", http.StatusOK, provisionWatcher)
+	err = setETagHeader(w, common.ProvisionWatcherSystemEventType, name, pwc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
 	utils.WriteHttpHeader(w, ctx, http.StatusOK)
 	return pkg.EncodeAndWriteResponse(response, w, lc)
 }
@@ -182,6 +189,7 @@ func (pwc *ProvisionWatcherController) DeleteProvisionWatcherByName(c echo.Conte
 	// URL parameters
 	name := c.Param(common.Name)
 
+	ctx = application.NewIfMatchContext(ctx, r.Header.Get(pkgCommon.IfMatchHeader))
 	err := application.DeleteProvisionWatcherByName(ctx, name, pwc.dic)
 	if err != nil {
 		return utils.WriteErrorResponse(w, ctx, lc, err, "")
@@ -208,6 +216,10 @@ func (pwc *ProvisionWatcherController) PatchProvisionWatcher(c echo.Context) err
 	if err != nil {
 		return utils.WriteErrorResponse(w, ctx, lc, err, "")
 	}
+	ctx, err = ifMatchContext(ctx, r, len(reqDTOs))
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
 
 	var updateResponses []interface{}
 	for _, dto := range reqDTOs {
@@ -220,7 +232,7 @@ func (pwc *ProvisionWatcherController) PatchProvisionWatcher(c echo.Context) err
 			response = commonDTO.NewBaseResponse(
 				reqId,
 				err.Message(),
-				err.Code())
+				utils.StatusCode(err))
 		} else {
 			response = commonDTO.NewBaseResponse(
 				reqId,
@@ -233,3 +245,30 @@ func (pwc *ProvisionWatcherController) PatchProvisionWatcher(c echo.Context) err
 	utils.WriteHttpHeader(w, ctx, http.StatusMultiStatus)
 	return pkg.EncodeAndWriteResponse(updateResponses, w, lc)
 }
+
+func (pwc *ProvisionWatcherController) DryRunProvisionWatchers(c echo.Context) error {
+	r := c.Request()
+	w := c.Response()
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := container.LoggingClientFrom(pwc.dic.Get)
+	ctx := r.Context()
+
+	var reqDTO pkgRequests.ProvisionWatcherDryRunRequest
+	err := pwc.reader.Read(r.Body, &reqDTO)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	result, err := application.DryRunProvisionWatchers(reqDTO.DiscoveredDevice, reqDTO.ServiceName, pwc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, reqDTO.RequestId)
+	}
+
+	response := pkgResponses.NewProvisionWatcherDryRunResponse(reqDTO.RequestId, "", http.StatusOK,
+		result.Matches, result.MatchedProvisionWatcher, result.DeviceExists, result.Device)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
@@ -28,6 +28,10 @@ import (
 
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/infrastructure/interfaces/mocks"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	pkgRequests "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/requests"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
 )
 
 var testProvisionWatcherName = "TestProvisionWatcher"
@@ -316,6 +320,7 @@ func TestProvisionWatcherController_ProvisionWatcherByName(t *testing.T) {
 	dic := mockDic()
 	dbClientMock := &mocks.DBClient{}
 	dbClientMock.On("ProvisionWatcherByName", provisionWatcher.Name).Return(provisionWatcher, nil)
+	dbClientMock.On("MetadataRevision", common.ProvisionWatcherSystemEventType, provisionWatcher.Name).Return(uint64(5), nil)
 	dbClientMock.On("ProvisionWatcherByName", notFoundName).Return(models.ProvisionWatcher{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "provision watcher doesn't exist in the database", nil))
 	dic.Update(di.ServiceConstructorMap{
 		container.DBClientInterfaceName: func(get di.Get) interface{} {
@@ -369,6 +374,7 @@ func TestProvisionWatcherController_ProvisionWatcherByName(t *testing.T) {
 				assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
 				assert.Equal(t, testCase.expectedStatusCode, int(res.StatusCode), "Response status code not as expected")
 				assert.Equal(t, testCase.provisionWatcherName, res.ProvisionWatcher.Name, "Name not as expected")
+				assert.Equal(t, `"5"`, recorder.Header().Get(pkgCommon.ETagHeader), "ETag not as expected")
 				assert.Empty(t, res.Message, "Message should be empty when it is successful")
 			}
 		})
@@ -847,3 +853,120 @@ func TestProvisionWatcherController_PatchProvisionWatcher(t *testing.T) {
 		})
 	}
 }
+
+func TestProvisionWatcherController_DryRunProvisionWatchers(t *testing.T) {
+	pw := dtos.ToProvisionWatcherModel(buildTestAddProvisionWatcherRequest().ProvisionWatcher)
+	lockedPw := pw
+	lockedPw.Name = "LockedProvisionWatcher"
+	lockedPw.AdminState = models.Locked
+
+	candidate := func(name, port string) pkgDtos.CandidateDevice {
+		return pkgDtos.CandidateDevice{
+			Name:       name,
+			Protocols:  map[string]dtos.ProtocolProperties{"other": {"address": "localhost", "port": port}},
+			Properties: map[string]any{"Site": "Plant A"},
+		}
+	}
+	matchedDevice := candidate("matched-device", "301")
+	blockedDevice := candidate("blocked-device", "398")
+	unmatchedDevice := candidate("unmatched-device", "401")
+	existingDevice := candidate("existing-device", "301")
+	noProtocolsDevice := candidate("no-protocols-device", "301")
+	noProtocolsDevice.Protocols = nil
+	// a single protocol must satisfy every identifier, whatever the other protocols hold
+	oneProtocolMatchedDevice := candidate("one-protocol-matched-device", "301")
+	oneProtocolMatchedDevice.Protocols["modbus-tcp"] = dtos.ProtocolProperties{"address": "10.0.0.1", "port": "502"}
+	splitProtocolsDevice := candidate("split-protocols-device", "301")
+	splitProtocolsDevice.Protocols = map[string]dtos.ProtocolProperties{"other": {"address": "localhost"}, "modbus-tcp": {"port": "301"}}
+
+	dic := mockDic()
+	dbClientMock := &mocks.DBClient{}
+	dbClientMock.On("AllProvisionWatchers", 0, -1, []string(nil)).Return([]models.ProvisionWatcher{lockedPw, pw}, nil)
+	dbClientMock.On("ProvisionWatchersByServiceName", 0, -1, "unknown-service").Return([]models.ProvisionWatcher{}, nil)
+	dbClientMock.On("DeviceNameExists", existingDevice.Name).Return(true, nil)
+	dbClientMock.On("DeviceNameExists", matchedDevice.Name).Return(false, nil)
+	dbClientMock.On("DeviceNameExists", blockedDevice.Name).Return(false, nil)
+	dbClientMock.On("DeviceNameExists", unmatchedDevice.Name).Return(false, nil)
+	dbClientMock.On("DeviceNameExists", oneProtocolMatchedDevice.Name).Return(false, nil)
+	dbClientMock.On("DeviceNameExists", splitProtocolsDevice.Name).Return(false, nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewProvisionWatcherController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		serviceName        string
+		device             pkgDtos.CandidateDevice
+		expectedStatusCode int
+		expectedMatch      string
+		expectedBlocked    bool
+		deviceExists       bool
+	}{
+		{"Valid - matched", "", matchedDevice, http.StatusOK, pw.Name, false, false},
+		{"Valid - blocked", "", blockedDevice, http.StatusOK, "", true, false},
+		{"Valid - not matched", "", unmatchedDevice, http.StatusOK, "", false, false},
+		{"Valid - matched by one of two protocols", "", oneProtocolMatchedDevice, http.StatusOK, pw.Name, false, false},
+		{"Valid - not matched, identifiers split across protocols", "", splitProtocolsDevice, http.StatusOK, "", false, false},
+		{"Valid - device exists", "", existingDevice, http.StatusOK, pw.Name, false, true},
+		{"Valid - no provision watcher of the service", "unknown-service", matchedDevice, http.StatusOK, "", false, false},
+		{"Invalid - no protocols", "", noProtocolsDevice, http.StatusBadRequest, "", false, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			reqDTO := pkgRequests.ProvisionWatcherDryRunRequest{
+				BaseRequest:      commonDTO.BaseRequest{RequestId: ExampleUUID, Versionable: commonDTO.NewVersionable()},
+				ServiceName:      testCase.serviceName,
+				DiscoveredDevice: testCase.device,
+			}
+			jsonData, err := json.Marshal(reqDTO)
+			require.NoError(t, err)
+
+			reader := strings.NewReader(string(jsonData))
+			req, err := http.NewRequest(http.MethodPost, pkgCommon.ApiProvisionWatcherDryRunRoute, reader)
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			err = controller.DryRunProvisionWatchers(c)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode != http.StatusOK {
+				return
+			}
+			var res pkgResponses.ProvisionWatcherDryRunResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedMatch, res.MatchedProvisionWatcher)
+			assert.Equal(t, testCase.deviceExists, res.DeviceExists)
+			if testCase.serviceName != "" {
+				assert.Empty(t, res.ProvisionWatchers)
+				return
+			}
+			require.Len(t, res.ProvisionWatchers, 2)
+			assert.False(t, res.ProvisionWatchers[0].Matched, "locked provision watcher should not match")
+			assert.Contains(t, res.ProvisionWatchers[0].Reasons, "provision watcher is LOCKED")
+			assert.Equal(t, testCase.expectedBlocked, res.ProvisionWatchers[1].Blocked)
+			if testCase.expectedMatch != "" && !testCase.deviceExists {
+				require.NotNil(t, res.Device)
+				assert.Equal(t, testCase.device.Name, res.Device.Name)
+				assert.Equal(t, pw.ServiceName, res.Device.ServiceName)
+				assert.Equal(t, pw.DiscoveredDevice.ProfileName, res.Device.ProfileName)
+				assert.Equal(t, "Plant A", res.Device.Properties["Site"])
+			} else {
+				assert.Nil(t, res.Device)
+			}
+			if testCase.expectedMatch == "" {
+				assert.NotEmpty(t, res.ProvisionWatchers[1].Reasons)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/application"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+)
+
+// ifMatchContext returns a copy of the context carrying the If-Match header of the request. Since the header applies
+// to a single entity, the requests carrying multiple entities are rejected when the header is specified.
+func ifMatchContext(ctx context.Context, r *http.Request, count int) (context.Context, errors.EdgeX) {
+	ifMatch := r.Header.Get(pkgCommon.IfMatchHeader)
+	if ifMatch != "" && count != 1 {
+		return ctx, errors.NewCommonEdgeX(errors.KindContractInvalid, "the If-Match header is only supported by the requests with a single entity", nil)
+	}
+	return application.NewIfMatchContext(ctx, ifMatch), nil
+}
+
+// setETagHeader sets the ETag header to the entity tag of the metadata entity with the specified type and name
+func setETagHeader(w http.ResponseWriter, entityType string, name string, dic *di.Container) errors.EdgeX {
+	etag, err := application.MetadataEntityETag(entityType, name, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	w.Header().Set(pkgCommon.ETagHeader, etag)
+	return nil
+}
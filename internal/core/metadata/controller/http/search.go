@@ -0,0 +1,61 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/application"
+	metadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type SearchController struct {
+	dic *di.Container
+}
+
+// NewSearchController creates and initializes a SearchController
+func NewSearchController(dic *di.Container) *SearchController {
+	return &SearchController{
+		dic: dic,
+	}
+}
+
+func (sc *SearchController) Search(c echo.Context) error {
+	lc := container.LoggingClientFrom(sc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+	config := metadataContainer.ConfigurationFrom(sc.dic.Get)
+
+	// parse URL query string for offset, limit, query and entity types
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(c, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	query := c.QueryParam(pkgCommon.Query)
+	types := utils.ParseQueryStringToStrings(c, common.Type, common.CommaSeparator)
+
+	result, err := application.Search(query, types, offset, limit, sc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := pkgResponses.NewSearchResponse("", "", http.StatusOK, result.TotalCount, result.Counts,
+		result.Devices, result.DeviceProfiles, result.DeviceServices)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
@@ -0,0 +1,96 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/metadata/infrastructure/interfaces/mocks"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+)
+
+func TestSearch(t *testing.T) {
+	devices := []models.Device{
+		{Name: "device1", Protocols: map[string]models.ProtocolProperties{"modbus-tcp": {"Address": "10.0.0.1"}}},
+		{Name: "device2", Protocols: map[string]models.ProtocolProperties{"modbus-tcp": {"Address": "10.0.0.2"}}},
+		{Name: "device3", Protocols: map[string]models.ProtocolProperties{"modbus-tcp": {"Address": "192.168.0.1"}}},
+	}
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AllDevices", 0, mock.Anything, []string(nil)).Return(devices, nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewSearchController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		query              string
+		types              string
+		limit              string
+		errorExpected      bool
+		expectedCount      int
+		expectedTotalCount uint32
+		expectedStatusCode int
+	}{
+		{"Valid - search devices by protocol address", "protocols.modbus-tcp.Address=10.0.*", pkgCommon.SearchTypeDevice, "10", false, 2, 2, http.StatusOK},
+		{"Valid - search with limit", "protocols.modbus-tcp.Address=10.0.*", pkgCommon.SearchTypeDevice, "1", false, 1, 2, http.StatusOK},
+		{"Valid - no device matched", "name=pump*", pkgCommon.SearchTypeDevice, "10", false, 0, 0, http.StatusOK},
+		{"Invalid - empty query", "", pkgCommon.SearchTypeDevice, "10", true, 0, 0, http.StatusBadRequest},
+		{"Invalid - unknown type", "name=device1", "unknown", "10", true, 0, 0, http.StatusBadRequest},
+		{"Invalid - invalid limit", "name=device1", pkgCommon.SearchTypeDevice, "-2", true, 0, 0, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req, err := http.NewRequest(http.MethodGet, pkgCommon.ApiSearchRoute, http.NoBody)
+			require.NoError(t, err)
+			query := req.URL.Query()
+			query.Add(pkgCommon.Query, testCase.query)
+			query.Add(common.Type, testCase.types)
+			query.Add(common.Limit, testCase.limit)
+			req.URL.RawQuery = query.Encode()
+
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			err = controller.Search(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.errorExpected {
+				var res commonDTO.BaseResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+			} else {
+				var res pkgResponses.SearchResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedCount, len(res.Devices), "Device count not as expected")
+				assert.Equal(t, testCase.expectedTotalCount, res.TotalCount, "Total count not as expected")
+				assert.Equal(t, testCase.expectedTotalCount, res.Counts[pkgCommon.SearchTypeDevice], "Device total count not as expected")
+			}
+		})
+	}
+}
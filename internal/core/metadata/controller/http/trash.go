@@ -0,0 +1,121 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/application"
+	metadataContainer "github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TrashController struct {
+	dic *di.Container
+}
+
+// NewTrashController creates and initializes a TrashController
+func NewTrashController(dic *di.Container) *TrashController {
+	return &TrashController{
+		dic: dic,
+	}
+}
+
+func (tc *TrashController) AllTrashedEntities(c echo.Context) error {
+	lc := container.LoggingClientFrom(tc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+	config := metadataContainer.ConfigurationFrom(tc.dic.Get)
+
+	// URL parameters
+	entityType := c.Param(pkgCommon.EntityType)
+
+	// parse URL query string for offset and limit
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(c, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	entities, totalCount, err := application.TrashedEntities(entityType, offset, limit, tc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := pkgResponses.NewMultiTrashedEntitiesResponse("", "", http.StatusOK, totalCount, entities)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (tc *TrashController) TrashedEntityByName(c echo.Context) error {
+	lc := container.LoggingClientFrom(tc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+
+	// URL parameters
+	entityType := c.Param(pkgCommon.EntityType)
+	name := c.Param(common.Name)
+
+	entity, err := application.TrashedEntityByName(entityType, name, tc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := pkgResponses.NewTrashedEntityResponse("", "", http.StatusOK, entity)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (tc *TrashController) RestoreTrashedEntity(c echo.Context) error {
+	lc := container.LoggingClientFrom(tc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+
+	// URL parameters
+	entityType := c.Param(pkgCommon.EntityType)
+	name := c.Param(common.Name)
+
+	err := application.RestoreTrashedEntity(entityType, name, ctx, tc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := commonDTO.NewBaseResponse("", "", http.StatusOK)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (tc *TrashController) PurgeTrashedEntity(c echo.Context) error {
+	lc := container.LoggingClientFrom(tc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+
+	// URL parameters
+	entityType := c.Param(pkgCommon.EntityType)
+	name := c.Param(common.Name)
+
+	err := application.PurgeTrashedEntity(entityType, name, tc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := commonDTO.NewBaseResponse("", "", http.StatusOK)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
@@ -0,0 +1,236 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/config"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/metadata/infrastructure/interfaces/mocks"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+func mockTrashDic(dbClientMock *dbMock.DBClient) *di.Container {
+	dic := mockDic()
+	configuration := container.ConfigurationFrom(dic.Get)
+	configuration.Trash = config.Trash{Retention: "1h", Interval: "10m"}
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	return dic
+}
+
+func buildTestTrashedDevice(t *testing.T) pkgModels.TrashedEntity {
+	device := dtos.ToDeviceModel(buildTestDeviceRequest().Device)
+	entity, err := json.Marshal(device)
+	require.NoError(t, err)
+	return pkgModels.TrashedEntity{Type: common.DeviceSystemEventType, Name: device.Name, Deleted: 1000, Entity: entity}
+}
+
+func TestDeleteDeviceByNameToTrash(t *testing.T) {
+	device := dtos.ToDeviceModel(buildTestDeviceRequest().Device)
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("DeviceByName", device.Name).Return(device, nil)
+	dbClientMock.On("TrashDeviceByName", device.Name).Return(nil)
+	dbClientMock.On("DeviceServiceByName", device.ServiceName).Return(models.DeviceService{BaseAddress: testBaseAddress}, nil)
+	controller := NewDeviceController(mockTrashDic(dbClientMock))
+
+	e := echo.New()
+	req, err := http.NewRequest(http.MethodDelete, common.ApiDeviceByNameEchoRoute, http.NoBody)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	c := e.NewContext(req, recorder)
+	c.SetParamNames(common.Name)
+	c.SetParamValues(device.Name)
+
+	err = controller.DeleteDeviceByName(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, recorder.Result().StatusCode, "HTTP status code not as expected")
+	dbClientMock.AssertCalled(t, "TrashDeviceByName", device.Name)
+	dbClientMock.AssertNotCalled(t, "DeleteDeviceByName", device.Name)
+}
+
+func TestAllTrashedEntities(t *testing.T) {
+	trashed := buildTestTrashedDevice(t)
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("TrashedEntities", common.DeviceSystemEventType, 0, 20).Return([]pkgModels.TrashedEntity{trashed}, nil)
+	dbClientMock.On("TrashedEntityTotalCount", common.DeviceSystemEventType).Return(uint32(1), nil)
+	controller := NewTrashController(mockTrashDic(dbClientMock))
+
+	tests := []struct {
+		name               string
+		entityType         string
+		expectedCount      int
+		expectedStatusCode int
+	}{
+		{"Valid - trashed devices", common.DeviceSystemEventType, 1, http.StatusOK},
+		{"Invalid - unsupported entity type", common.DeviceServiceSystemEventType, 0, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req, err := http.NewRequest(http.MethodGet, pkgCommon.ApiAllTrashedEntitiesRoute, http.NoBody)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			c.SetParamNames(pkgCommon.EntityType)
+			c.SetParamValues(testCase.entityType)
+
+			err = controller.AllTrashedEntities(c)
+			require.NoError(t, err)
+
+			require.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode != http.StatusOK {
+				return
+			}
+			var res pkgResponses.MultiTrashedEntitiesResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, uint32(1), res.TotalCount)
+			require.Len(t, res.Entities, testCase.expectedCount)
+			assert.Equal(t, trashed.Name, res.Entities[0].Name)
+			assert.Equal(t, trashed.Deleted+3600000, res.Entities[0].Expires)
+		})
+	}
+}
+
+func TestTrashedEntityByName(t *testing.T) {
+	trashed := buildTestTrashedDevice(t)
+	notFoundName := "notFoundName"
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("TrashedEntityByName", common.DeviceSystemEventType, trashed.Name).Return(trashed, nil)
+	dbClientMock.On("TrashedEntityByName", common.DeviceSystemEventType, notFoundName).Return(pkgModels.TrashedEntity{}, edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, "not found", nil))
+	controller := NewTrashController(mockTrashDic(dbClientMock))
+
+	tests := []struct {
+		name               string
+		entityName         string
+		expectedStatusCode int
+	}{
+		{"Valid - trashed device by name", trashed.Name, http.StatusOK},
+		{"Invalid - trashed device not found", notFoundName, http.StatusNotFound},
+		{"Invalid - name parameter is empty", "", http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req, err := http.NewRequest(http.MethodGet, pkgCommon.ApiTrashedEntityByNameRoute, http.NoBody)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			c.SetParamNames(pkgCommon.EntityType, common.Name)
+			c.SetParamValues(common.DeviceSystemEventType, testCase.entityName)
+
+			err = controller.TrashedEntityByName(c)
+			require.NoError(t, err)
+
+			require.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode != http.StatusOK {
+				return
+			}
+			var res pkgResponses.TrashedEntityResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, trashed.Name, res.Entity.Name)
+			assert.NotNil(t, res.Entity.Entity)
+		})
+	}
+}
+
+func TestRestoreTrashedEntity(t *testing.T) {
+	device := dtos.ToDeviceModel(buildTestDeviceRequest().Device)
+	profileName := "missingProfile"
+	duplicateName := "duplicateName"
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("RestoreTrashedDevice", device.Name).Return(device, nil)
+	dbClientMock.On("RestoreTrashedDeviceProfile", profileName).Return(models.DeviceProfile{}, edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, "not found", nil))
+	dbClientMock.On("RestoreTrashedDevice", duplicateName).Return(models.Device{}, edgexErr.NewCommonEdgeX(edgexErr.KindDuplicateName, "device name already exists", nil))
+	dbClientMock.On("DeviceServiceByName", device.ServiceName).Return(models.DeviceService{BaseAddress: testBaseAddress}, nil)
+	controller := NewTrashController(mockTrashDic(dbClientMock))
+
+	tests := []struct {
+		name               string
+		entityType         string
+		entityName         string
+		expectedStatusCode int
+	}{
+		{"Valid - restore trashed device", common.DeviceSystemEventType, device.Name, http.StatusOK},
+		{"Invalid - trashed device profile not found", common.DeviceProfileSystemEventType, profileName, http.StatusNotFound},
+		{"Invalid - device name in use", common.DeviceSystemEventType, duplicateName, http.StatusConflict},
+		{"Invalid - unsupported entity type", common.DeviceServiceSystemEventType, device.ServiceName, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req, err := http.NewRequest(http.MethodPost, pkgCommon.ApiRestoreTrashedEntityRoute, http.NoBody)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			c.SetParamNames(pkgCommon.EntityType, common.Name)
+			c.SetParamValues(testCase.entityType, testCase.entityName)
+
+			err = controller.RestoreTrashedEntity(c)
+			require.NoError(t, err)
+
+			var res commonDTO.BaseResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, res.StatusCode, "Response status code not as expected")
+		})
+	}
+}
+
+func TestPurgeTrashedEntity(t *testing.T) {
+	name := "trashedProfile"
+	notFoundName := "notFoundName"
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("PurgeTrashedEntity", common.DeviceProfileSystemEventType, name).Return(nil)
+	dbClientMock.On("PurgeTrashedEntity", common.DeviceProfileSystemEventType, notFoundName).Return(edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, "not found", nil))
+	controller := NewTrashController(mockTrashDic(dbClientMock))
+
+	tests := []struct {
+		name               string
+		entityName         string
+		expectedStatusCode int
+	}{
+		{"Valid - purge trashed device profile", name, http.StatusOK},
+		{"Invalid - trashed device profile not found", notFoundName, http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req, err := http.NewRequest(http.MethodDelete, pkgCommon.ApiTrashedEntityByNameRoute, http.NoBody)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			c.SetParamNames(pkgCommon.EntityType, common.Name)
+			c.SetParamValues(common.DeviceProfileSystemEventType, testCase.entityName)
+
+			err = controller.PurgeTrashedEntity(c)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+		})
+	}
+}
@@ -1,32 +1,41 @@
 //
-// Copyright (C) 2022-2023 IOTech Ltd
+// Copyright (C) 2022-2024 IOTech Ltd
 //
 // SPDX-License-Identifier: Apache-2.0
 
 package http
 
 import (
+	"math"
 	"net/http"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
 
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/application"
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	"github.com/edgexfoundry/edgex-go/internal/io"
 	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	pkgRequests "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/requests"
+	pkgResponses "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/responses"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/utils"
 
 	"github.com/labstack/echo/v4"
 )
 
 type UnitOfMeasureController struct {
-	dic *di.Container
+	reader io.DtoReader
+	dic    *di.Container
 }
 
 func NewUnitOfMeasureController(dic *di.Container) *UnitOfMeasureController {
 	return &UnitOfMeasureController{
-		dic: dic,
+		reader: io.NewJsonDtoReader(),
+		dic:    dic,
 	}
 }
 
@@ -48,3 +57,137 @@ func (uc *UnitOfMeasureController) UnitsOfMeasure(c echo.Context) error {
 		return pkg.EncodeAndWriteResponse(response, w, lc)
 	}
 }
+
+func (uc *UnitOfMeasureController) AddUnitOfMeasureCategory(c echo.Context) error {
+	r := c.Request()
+	w := c.Response()
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := bootstrapContainer.LoggingClientFrom(uc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	var reqDTOs []pkgRequests.UnitOfMeasureCategoryRequest
+	err := uc.reader.Read(r.Body, &reqDTOs)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	categories := pkgRequests.UnitOfMeasureCategoryReqToUnitOfMeasureCategoryModels(reqDTOs)
+
+	var addResponses []interface{}
+	for i, category := range categories {
+		var response interface{}
+		reqId := reqDTOs[i].RequestId
+		newId, err := application.AddUnitOfMeasureCategory(category, ctx, uc.dic)
+		if err != nil {
+			lc.Error(err.Error(), common.CorrelationHeader, correlationId)
+			lc.Debug(err.DebugMessages(), common.CorrelationHeader, correlationId)
+			response = commonDTO.NewBaseResponse(reqId, err.Error(), err.Code())
+		} else {
+			response = commonDTO.NewBaseWithIdResponse(reqId, "", http.StatusCreated, newId)
+		}
+		addResponses = append(addResponses, response)
+	}
+
+	utils.WriteHttpHeader(w, ctx, http.StatusMultiStatus)
+	return pkg.EncodeAndWriteResponse(addResponses, w, lc)
+}
+
+func (uc *UnitOfMeasureController) UpdateUnitOfMeasureCategory(c echo.Context) error {
+	r := c.Request()
+	w := c.Response()
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := bootstrapContainer.LoggingClientFrom(uc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	var reqDTOs []pkgRequests.UnitOfMeasureCategoryRequest
+	err := uc.reader.Read(r.Body, &reqDTOs)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	categories := pkgRequests.UnitOfMeasureCategoryReqToUnitOfMeasureCategoryModels(reqDTOs)
+
+	var responses []interface{}
+	for i, category := range categories {
+		var response interface{}
+		reqId := reqDTOs[i].RequestId
+		err := application.UpdateUnitOfMeasureCategory(category, ctx, uc.dic)
+		if err != nil {
+			lc.Error(err.Error(), common.CorrelationHeader, correlationId)
+			lc.Debug(err.DebugMessages(), common.CorrelationHeader, correlationId)
+			response = commonDTO.NewBaseResponse(reqId, err.Error(), err.Code())
+		} else {
+			response = commonDTO.NewBaseResponse(reqId, "", http.StatusOK)
+		}
+		responses = append(responses, response)
+	}
+
+	utils.WriteHttpHeader(w, ctx, http.StatusMultiStatus)
+	return pkg.EncodeAndWriteResponse(responses, w, lc)
+}
+
+func (uc *UnitOfMeasureController) UnitOfMeasureCategoryByName(c echo.Context) error {
+	lc := bootstrapContainer.LoggingClientFrom(uc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+
+	// URL parameters
+	name := c.Param(common.Name)
+
+	category, err := application.UnitOfMeasureCategoryByName(name, uc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := pkgResponses.NewUnitOfMeasureCategoryResponse("", "", http.StatusOK, category)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (uc *UnitOfMeasureController) AllUnitOfMeasureCategories(c echo.Context) error {
+	lc := bootstrapContainer.LoggingClientFrom(uc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+	config := container.ConfigurationFrom(uc.dic.Get)
+
+	// parse URL query string for offset and limit
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(c, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+	categories, totalCount, err := application.AllUnitOfMeasureCategories(offset, limit, uc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := pkgResponses.NewMultiUnitOfMeasureCategoriesResponse("", "", http.StatusOK, totalCount, categories)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
+
+func (uc *UnitOfMeasureController) DeleteUnitOfMeasureCategoryByName(c echo.Context) error {
+	lc := bootstrapContainer.LoggingClientFrom(uc.dic.Get)
+	r := c.Request()
+	w := c.Response()
+	ctx := r.Context()
+
+	// URL parameters
+	name := c.Param(common.Name)
+
+	err := application.DeleteUnitOfMeasureCategoryByName(name, ctx, uc.dic)
+	if err != nil {
+		return utils.WriteErrorResponse(w, ctx, lc, err, "")
+	}
+
+	response := commonDTO.NewBaseResponse("", "", http.StatusOK)
+	utils.WriteHttpHeader(w, ctx, http.StatusOK)
+	return pkg.EncodeAndWriteResponse(response, w, lc)
+}
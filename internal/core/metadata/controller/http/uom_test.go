@@ -1,11 +1,12 @@
 //
-// Copyright (C) 2022-2023 IOTech Ltd
+// Copyright (C) 2022-2024 IOTech Ltd
 //
 // SPDX-License-Identifier: Apache-2.0
 
 package http
 
 import (
+	"bytes"
 	"encoding/json"
 	"gopkg.in/yaml.v3"
 	"net/http"
@@ -14,12 +15,21 @@ import (
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/responses"
+	edgexErr "github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/core/metadata/infrastructure/interfaces/mocks"
 	"github.com/edgexfoundry/edgex-go/internal/core/metadata/uom"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	pkgRequests "github.com/edgexfoundry/edgex-go/internal/pkg/dtos/requests"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
 
 	"github.com/labstack/echo/v4"
 )
@@ -88,3 +98,144 @@ func TestUnitOfMeasureController_UnitsOfMeasure(t *testing.T) {
 		})
 	}
 }
+
+func TestAddUnitOfMeasureCategory(t *testing.T) {
+	fileUoM := &uom.UnitsOfMeasureImpl{
+		Units: map[string]uom.Unit{
+			"temperature": {Values: []string{"C", "F"}},
+		},
+	}
+	category := pkgModels.UnitOfMeasureCategory{Name: "temperature", Values: []string{"mK"}}
+	duplicate := pkgModels.UnitOfMeasureCategory{Name: "pressure", Values: []string{"psi"}}
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AddUnitOfMeasureCategory", category).Return(category, nil)
+	dbClientMock.On("AddUnitOfMeasureCategory", duplicate).Return(duplicate, edgexErr.NewCommonEdgeX(edgexErr.KindDuplicateName, "unit of measure category name pressure already exists", nil))
+	dbClientMock.On("AllUnitOfMeasureCategories", 0, -1).Return([]pkgModels.UnitOfMeasureCategory{category}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+		container.UnitsOfMeasureInterfaceName: func(get di.Get) interface{} {
+			return fileUoM
+		},
+	})
+	controller := NewUnitOfMeasureController(dic)
+
+	valid := pkgRequests.UnitOfMeasureCategoryRequest{BaseRequest: commonDTO.NewBaseRequest(), Category: dtos.FromUnitOfMeasureCategoryModelToDTO(category)}
+	conflict := pkgRequests.UnitOfMeasureCategoryRequest{BaseRequest: commonDTO.NewBaseRequest(), Category: dtos.FromUnitOfMeasureCategoryModelToDTO(duplicate)}
+	noValues := valid
+	noValues.Category.Values = nil
+
+	tests := []struct {
+		name               string
+		request            []pkgRequests.UnitOfMeasureCategoryRequest
+		expectedStatusCode int
+		expectedItemCode   int
+	}{
+		{"Valid - add custom units", []pkgRequests.UnitOfMeasureCategoryRequest{valid}, http.StatusMultiStatus, http.StatusCreated},
+		{"Invalid - duplicate category name", []pkgRequests.UnitOfMeasureCategoryRequest{conflict}, http.StatusMultiStatus, http.StatusConflict},
+		{"Invalid - no unit value", []pkgRequests.UnitOfMeasureCategoryRequest{noValues}, http.StatusBadRequest, 0},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			jsonData, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+			e := echo.New()
+			req, err := http.NewRequest(http.MethodPost, pkgCommon.ApiUnitOfMeasureCategoryRoute, bytes.NewReader(jsonData))
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			err = controller.AddUnitOfMeasureCategory(c)
+			require.NoError(t, err)
+
+			require.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode != http.StatusMultiStatus {
+				return
+			}
+			var res []commonDTO.BaseResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			require.Len(t, res, 1)
+			assert.Equal(t, testCase.expectedItemCode, res[0].StatusCode, "BaseResponse status code not as expected")
+		})
+	}
+
+	// the custom unit is validated right after it's added, while the file units are kept
+	units := container.UnitsOfMeasureFrom(dic.Get)
+	assert.True(t, units.Validate("mK"))
+	assert.True(t, units.Validate("C"))
+	assert.False(t, units.Validate("psi"))
+}
+
+func TestDeleteUnitOfMeasureCategoryByName(t *testing.T) {
+	name := "pressure"
+	notFoundName := "notFoundName"
+	fileUoM := &uom.UnitsOfMeasureImpl{
+		Units: map[string]uom.Unit{
+			"temperature": {Values: []string{"C", "F"}},
+		},
+	}
+	inUseName := "flow"
+	stored := []pkgModels.UnitOfMeasureCategory{{Name: name, Values: []string{"psi"}}, {Name: inUseName, Values: []string{"gpm"}}}
+	loaded := fileUoM.WithCustomUnits(stored)
+	profile := models.DeviceProfile{
+		Name:            "test-profile",
+		DeviceResources: []models.DeviceResource{{Name: "flow-rate", Properties: models.ResourceProperties{Units: "gpm"}}},
+	}
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("DeleteUnitOfMeasureCategoryByName", name).Run(func(mock.Arguments) {
+		stored = stored[1:]
+	}).Return(nil)
+	dbClientMock.On("DeleteUnitOfMeasureCategoryByName", notFoundName).Return(edgexErr.NewCommonEdgeX(edgexErr.KindEntityDoesNotExist, "not found", nil))
+	dbClientMock.On("AllUnitOfMeasureCategories", 0, -1).Return(func(int, int) []pkgModels.UnitOfMeasureCategory {
+		return stored
+	}, nil)
+	dbClientMock.On("AllDeviceProfiles", 0, mock.Anything, mock.Anything).Return([]models.DeviceProfile{profile}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		container.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+		container.UnitsOfMeasureInterfaceName: func(get di.Get) interface{} {
+			return loaded
+		},
+	})
+	controller := NewUnitOfMeasureController(dic)
+	require.True(t, container.UnitsOfMeasureFrom(dic.Get).Validate("psi"))
+
+	tests := []struct {
+		name               string
+		categoryName       string
+		expectedStatusCode int
+	}{
+		{"Invalid - unit used by device profile", inUseName, http.StatusConflict},
+		{"Valid - delete custom units", name, http.StatusOK},
+		{"Invalid - category not found", notFoundName, http.StatusNotFound},
+		{"Invalid - name parameter is empty", "", http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			req, err := http.NewRequest(http.MethodDelete, pkgCommon.ApiUnitOfMeasureCategoryByNameRoute, http.NoBody)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			c.SetParamNames(common.Name)
+			c.SetParamValues(testCase.categoryName)
+
+			err = controller.DeleteUnitOfMeasureCategoryByName(c)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+		})
+	}
+
+	units := container.UnitsOfMeasureFrom(dic.Get)
+	assert.False(t, units.Validate("psi"))
+	assert.True(t, units.Validate("C"))
+	assert.True(t, units.Validate("gpm"))
+	dbClientMock.AssertNotCalled(t, "DeleteUnitOfMeasureCategoryByName", inUseName)
+}
@@ -8,6 +8,8 @@ package interfaces
 import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
 	model "github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
 )
 
 type DBClient interface {
@@ -51,7 +53,54 @@ type DBClient interface {
 	UpdateDevice(d model.Device) errors.EdgeX
 	DeviceCountByLabels(labels []string) (uint32, errors.EdgeX)
 	DeviceCountByProfileName(profileName string) (uint32, errors.EdgeX)
+	DevicesByGeoCircle(circle pkgModels.GeoCircle, offset int, limit int) ([]model.Device, uint32, errors.EdgeX)
+	DevicesByGeoBoundingBox(box pkgModels.GeoBoundingBox, offset int, limit int) ([]model.Device, uint32, errors.EdgeX)
 	DeviceCountByServiceName(serviceName string) (uint32, errors.EdgeX)
+	DevicesByTemplateName(offset int, limit int, templateName string) ([]model.Device, errors.EdgeX)
+	DeviceCountByTemplateName(templateName string) (uint32, errors.EdgeX)
+
+	AddPendingDevice(pd pkgModels.PendingDevice) (pkgModels.PendingDevice, errors.EdgeX)
+	PendingDeviceByName(name string) (pkgModels.PendingDevice, errors.EdgeX)
+	PendingDevices(status string, offset int, limit int) ([]pkgModels.PendingDevice, uint32, errors.EdgeX)
+	UpdatePendingDevice(pd pkgModels.PendingDevice) errors.EdgeX
+	DeletePendingDeviceByName(name string) errors.EdgeX
+	ApprovePendingDevice(d model.Device) (model.Device, errors.EdgeX)
+
+	AddDeviceLifecycleAudit(a pkgModels.DeviceLifecycleAudit) (pkgModels.DeviceLifecycleAudit, errors.EdgeX)
+	AllDeviceLifecycleAudits(offset int, limit int) ([]pkgModels.DeviceLifecycleAudit, errors.EdgeX)
+	DeviceLifecycleAuditsByDeviceName(offset int, limit int, name string) ([]pkgModels.DeviceLifecycleAudit, errors.EdgeX)
+	DeviceLifecycleAuditTotalCount() (uint32, errors.EdgeX)
+	DeviceLifecycleAuditCountByDeviceName(name string) (uint32, errors.EdgeX)
+
+	AddDeviceTemplate(t pkgModels.DeviceTemplate) (pkgModels.DeviceTemplate, errors.EdgeX)
+	UpdateDeviceTemplate(t pkgModels.DeviceTemplate) errors.EdgeX
+	DeviceTemplateByName(name string) (pkgModels.DeviceTemplate, errors.EdgeX)
+	AllDeviceTemplates(offset int, limit int, labels []string) ([]pkgModels.DeviceTemplate, errors.EdgeX)
+	DeviceTemplateCountByLabels(labels []string) (uint32, errors.EdgeX)
+	DeleteDeviceTemplateByName(name string) errors.EdgeX
+
+	MetadataChangesSince(since uint64, limit int) ([]pkgModels.MetadataChange, errors.EdgeX)
+	MetadataChangeSequenceRange() (oldest uint64, latest uint64, edgeXerr errors.EdgeX)
+	MetadataChangeTotalCount() (uint32, errors.EdgeX)
+	TrimMetadataChanges(retained uint32) errors.EdgeX
+	MetadataRevision(entityType string, name string) (uint64, errors.EdgeX)
+
+	TrashDeviceByName(name string) errors.EdgeX
+	TrashDeviceProfileByName(name string) errors.EdgeX
+	TrashedEntities(entityType string, offset int, limit int) ([]pkgModels.TrashedEntity, errors.EdgeX)
+	TrashedEntityByName(entityType string, name string) (pkgModels.TrashedEntity, errors.EdgeX)
+	TrashedEntityTotalCount(entityType string) (uint32, errors.EdgeX)
+	RestoreTrashedDevice(name string) (model.Device, errors.EdgeX)
+	RestoreTrashedDeviceProfile(name string) (model.DeviceProfile, errors.EdgeX)
+	PurgeTrashedEntity(entityType string, name string) errors.EdgeX
+	PurgeTrashedEntitiesByAge(entityType string, age int64) (uint32, errors.EdgeX)
+
+	AddUnitOfMeasureCategory(c pkgModels.UnitOfMeasureCategory) (pkgModels.UnitOfMeasureCategory, errors.EdgeX)
+	UpdateUnitOfMeasureCategory(c pkgModels.UnitOfMeasureCategory) errors.EdgeX
+	UnitOfMeasureCategoryByName(name string) (pkgModels.UnitOfMeasureCategory, errors.EdgeX)
+	AllUnitOfMeasureCategories(offset int, limit int) ([]pkgModels.UnitOfMeasureCategory, errors.EdgeX)
+	UnitOfMeasureCategoryTotalCount() (uint32, errors.EdgeX)
+	DeleteUnitOfMeasureCategoryByName(name string) errors.EdgeX
 
 	AddProvisionWatcher(pw model.ProvisionWatcher) (model.ProvisionWatcher, errors.EdgeX)
 	ProvisionWatcherById(id string) (model.ProvisionWatcher, errors.EdgeX)
@@ -64,4 +113,6 @@ type DBClient interface {
 	ProvisionWatcherCountByLabels(labels []string) (uint32, errors.EdgeX)
 	ProvisionWatcherCountByServiceName(name string) (uint32, errors.EdgeX)
 	ProvisionWatcherCountByProfileName(name string) (uint32, errors.EdgeX)
+
+	CheckMetadataIndexes(rebuild bool) (pkgModels.IndexIntegrity, errors.EdgeX)
 }
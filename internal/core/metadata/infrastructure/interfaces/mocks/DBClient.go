@@ -8,6 +8,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	models "github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
 )
 
 // DBClient is an autogenerated mock type for the DBClient type
@@ -38,6 +40,29 @@ func (_m *DBClient) AddDevice(d models.Device) (models.Device, errors.EdgeX) {
 	return r0, r1
 }
 
+// AddDeviceLifecycleAudit provides a mock function with given fields: a
+func (_m *DBClient) AddDeviceLifecycleAudit(a pkgModels.DeviceLifecycleAudit) (pkgModels.DeviceLifecycleAudit, errors.EdgeX) {
+	ret := _m.Called(a)
+
+	var r0 pkgModels.DeviceLifecycleAudit
+	if rf, ok := ret.Get(0).(func(pkgModels.DeviceLifecycleAudit) pkgModels.DeviceLifecycleAudit); ok {
+		r0 = rf(a)
+	} else {
+		r0 = ret.Get(0).(pkgModels.DeviceLifecycleAudit)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(pkgModels.DeviceLifecycleAudit) errors.EdgeX); ok {
+		r1 = rf(a)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // AddDeviceProfile provides a mock function with given fields: e
 func (_m *DBClient) AddDeviceProfile(e models.DeviceProfile) (models.DeviceProfile, errors.EdgeX) {
 	ret := _m.Called(e)
@@ -84,6 +109,52 @@ func (_m *DBClient) AddDeviceService(ds models.DeviceService) (models.DeviceServ
 	return r0, r1
 }
 
+// AddDeviceTemplate provides a mock function with given fields: t
+func (_m *DBClient) AddDeviceTemplate(t pkgModels.DeviceTemplate) (pkgModels.DeviceTemplate, errors.EdgeX) {
+	ret := _m.Called(t)
+
+	var r0 pkgModels.DeviceTemplate
+	if rf, ok := ret.Get(0).(func(pkgModels.DeviceTemplate) pkgModels.DeviceTemplate); ok {
+		r0 = rf(t)
+	} else {
+		r0 = ret.Get(0).(pkgModels.DeviceTemplate)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(pkgModels.DeviceTemplate) errors.EdgeX); ok {
+		r1 = rf(t)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// AddPendingDevice provides a mock function with given fields: pd
+func (_m *DBClient) AddPendingDevice(pd pkgModels.PendingDevice) (pkgModels.PendingDevice, errors.EdgeX) {
+	ret := _m.Called(pd)
+
+	var r0 pkgModels.PendingDevice
+	if rf, ok := ret.Get(0).(func(pkgModels.PendingDevice) pkgModels.PendingDevice); ok {
+		r0 = rf(pd)
+	} else {
+		r0 = ret.Get(0).(pkgModels.PendingDevice)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(pkgModels.PendingDevice) errors.EdgeX); ok {
+		r1 = rf(pd)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // AddProvisionWatcher provides a mock function with given fields: pw
 func (_m *DBClient) AddProvisionWatcher(pw models.ProvisionWatcher) (models.ProvisionWatcher, errors.EdgeX) {
 	ret := _m.Called(pw)
@@ -107,6 +178,54 @@ func (_m *DBClient) AddProvisionWatcher(pw models.ProvisionWatcher) (models.Prov
 	return r0, r1
 }
 
+// AddUnitOfMeasureCategory provides a mock function with given fields: c
+func (_m *DBClient) AddUnitOfMeasureCategory(c pkgModels.UnitOfMeasureCategory) (pkgModels.UnitOfMeasureCategory, errors.EdgeX) {
+	ret := _m.Called(c)
+
+	var r0 pkgModels.UnitOfMeasureCategory
+	if rf, ok := ret.Get(0).(func(pkgModels.UnitOfMeasureCategory) pkgModels.UnitOfMeasureCategory); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Get(0).(pkgModels.UnitOfMeasureCategory)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(pkgModels.UnitOfMeasureCategory) errors.EdgeX); ok {
+		r1 = rf(c)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// AllDeviceLifecycleAudits provides a mock function with given fields: offset, limit
+func (_m *DBClient) AllDeviceLifecycleAudits(offset int, limit int) ([]pkgModels.DeviceLifecycleAudit, errors.EdgeX) {
+	ret := _m.Called(offset, limit)
+
+	var r0 []pkgModels.DeviceLifecycleAudit
+	if rf, ok := ret.Get(0).(func(int, int) []pkgModels.DeviceLifecycleAudit); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pkgModels.DeviceLifecycleAudit)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int, int) errors.EdgeX); ok {
+		r1 = rf(offset, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // AllDeviceProfiles provides a mock function with given fields: offset, limit, labels
 func (_m *DBClient) AllDeviceProfiles(offset int, limit int, labels []string) ([]models.DeviceProfile, errors.EdgeX) {
 	ret := _m.Called(offset, limit, labels)
@@ -157,6 +276,31 @@ func (_m *DBClient) AllDeviceServices(offset int, limit int, labels []string) ([
 	return r0, r1
 }
 
+// AllDeviceTemplates provides a mock function with given fields: offset, limit, labels
+func (_m *DBClient) AllDeviceTemplates(offset int, limit int, labels []string) ([]pkgModels.DeviceTemplate, errors.EdgeX) {
+	ret := _m.Called(offset, limit, labels)
+
+	var r0 []pkgModels.DeviceTemplate
+	if rf, ok := ret.Get(0).(func(int, int, []string) []pkgModels.DeviceTemplate); ok {
+		r0 = rf(offset, limit, labels)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pkgModels.DeviceTemplate)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int, int, []string) errors.EdgeX); ok {
+		r1 = rf(offset, limit, labels)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // AllDevices provides a mock function with given fields: offset, limit, labels
 func (_m *DBClient) AllDevices(offset int, limit int, labels []string) ([]models.Device, errors.EdgeX) {
 	ret := _m.Called(offset, limit, labels)
@@ -207,6 +351,77 @@ func (_m *DBClient) AllProvisionWatchers(offset int, limit int, labels []string)
 	return r0, r1
 }
 
+// AllUnitOfMeasureCategories provides a mock function with given fields: offset, limit
+func (_m *DBClient) AllUnitOfMeasureCategories(offset int, limit int) ([]pkgModels.UnitOfMeasureCategory, errors.EdgeX) {
+	ret := _m.Called(offset, limit)
+
+	var r0 []pkgModels.UnitOfMeasureCategory
+	if rf, ok := ret.Get(0).(func(int, int) []pkgModels.UnitOfMeasureCategory); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pkgModels.UnitOfMeasureCategory)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int, int) errors.EdgeX); ok {
+		r1 = rf(offset, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// ApprovePendingDevice provides a mock function with given fields: d
+func (_m *DBClient) ApprovePendingDevice(d models.Device) (models.Device, errors.EdgeX) {
+	ret := _m.Called(d)
+
+	var r0 models.Device
+	if rf, ok := ret.Get(0).(func(models.Device) models.Device); ok {
+		r0 = rf(d)
+	} else {
+		r0 = ret.Get(0).(models.Device)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(models.Device) errors.EdgeX); ok {
+		r1 = rf(d)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// CheckMetadataIndexes provides a mock function with given fields: rebuild
+func (_m *DBClient) CheckMetadataIndexes(rebuild bool) (pkgModels.IndexIntegrity, errors.EdgeX) {
+	ret := _m.Called(rebuild)
+
+	var r0 pkgModels.IndexIntegrity
+	if rf, ok := ret.Get(0).(func(bool) pkgModels.IndexIntegrity); ok {
+		r0 = rf(rebuild)
+	} else {
+		r0 = ret.Get(0).(pkgModels.IndexIntegrity)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(bool) errors.EdgeX); ok {
+		r1 = rf(rebuild)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // CloseSession provides a mock function with given fields:
 func (_m *DBClient) CloseSession() {
 	_m.Called()
@@ -308,6 +523,38 @@ func (_m *DBClient) DeleteDeviceServiceByName(name string) errors.EdgeX {
 	return r0
 }
 
+// DeleteDeviceTemplateByName provides a mock function with given fields: name
+func (_m *DBClient) DeleteDeviceTemplateByName(name string) errors.EdgeX {
+	ret := _m.Called(name)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(string) errors.EdgeX); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
+// DeletePendingDeviceByName provides a mock function with given fields: name
+func (_m *DBClient) DeletePendingDeviceByName(name string) errors.EdgeX {
+	ret := _m.Called(name)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(string) errors.EdgeX); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
 // DeleteProvisionWatcherByName provides a mock function with given fields: name
 func (_m *DBClient) DeleteProvisionWatcherByName(name string) errors.EdgeX {
 	ret := _m.Called(name)
@@ -324,6 +571,22 @@ func (_m *DBClient) DeleteProvisionWatcherByName(name string) errors.EdgeX {
 	return r0
 }
 
+// DeleteUnitOfMeasureCategoryByName provides a mock function with given fields: name
+func (_m *DBClient) DeleteUnitOfMeasureCategoryByName(name string) errors.EdgeX {
+	ret := _m.Called(name)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(string) errors.EdgeX); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
 // DeviceById provides a mock function with given fields: id
 func (_m *DBClient) DeviceById(id string) (models.Device, errors.EdgeX) {
 	ret := _m.Called(id)
@@ -439,20 +702,20 @@ func (_m *DBClient) DeviceCountByServiceName(serviceName string) (uint32, errors
 	return r0, r1
 }
 
-// DeviceIdExists provides a mock function with given fields: id
-func (_m *DBClient) DeviceIdExists(id string) (bool, errors.EdgeX) {
-	ret := _m.Called(id)
+// DeviceCountByTemplateName provides a mock function with given fields: templateName
+func (_m *DBClient) DeviceCountByTemplateName(templateName string) (uint32, errors.EdgeX) {
+	ret := _m.Called(templateName)
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = rf(id)
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func(string) uint32); ok {
+		r0 = rf(templateName)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(uint32)
 	}
 
 	var r1 errors.EdgeX
 	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
-		r1 = rf(id)
+		r1 = rf(templateName)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.EdgeX)
@@ -462,8 +725,8 @@ func (_m *DBClient) DeviceIdExists(id string) (bool, errors.EdgeX) {
 	return r0, r1
 }
 
-// DeviceNameExists provides a mock function with given fields: id
-func (_m *DBClient) DeviceNameExists(id string) (bool, errors.EdgeX) {
+// DeviceIdExists provides a mock function with given fields: id
+func (_m *DBClient) DeviceIdExists(id string) (bool, errors.EdgeX) {
 	ret := _m.Called(id)
 
 	var r0 bool
@@ -485,20 +748,20 @@ func (_m *DBClient) DeviceNameExists(id string) (bool, errors.EdgeX) {
 	return r0, r1
 }
 
-// DeviceProfileById provides a mock function with given fields: id
-func (_m *DBClient) DeviceProfileById(id string) (models.DeviceProfile, errors.EdgeX) {
-	ret := _m.Called(id)
+// DeviceLifecycleAuditCountByDeviceName provides a mock function with given fields: name
+func (_m *DBClient) DeviceLifecycleAuditCountByDeviceName(name string) (uint32, errors.EdgeX) {
+	ret := _m.Called(name)
 
-	var r0 models.DeviceProfile
-	if rf, ok := ret.Get(0).(func(string) models.DeviceProfile); ok {
-		r0 = rf(id)
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func(string) uint32); ok {
+		r0 = rf(name)
 	} else {
-		r0 = ret.Get(0).(models.DeviceProfile)
+		r0 = ret.Get(0).(uint32)
 	}
 
 	var r1 errors.EdgeX
 	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
-		r1 = rf(id)
+		r1 = rf(name)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.EdgeX)
@@ -508,20 +771,20 @@ func (_m *DBClient) DeviceProfileById(id string) (models.DeviceProfile, errors.E
 	return r0, r1
 }
 
-// DeviceProfileByName provides a mock function with given fields: name
-func (_m *DBClient) DeviceProfileByName(name string) (models.DeviceProfile, errors.EdgeX) {
-	ret := _m.Called(name)
+// DeviceLifecycleAuditTotalCount provides a mock function with given fields:
+func (_m *DBClient) DeviceLifecycleAuditTotalCount() (uint32, errors.EdgeX) {
+	ret := _m.Called()
 
-	var r0 models.DeviceProfile
-	if rf, ok := ret.Get(0).(func(string) models.DeviceProfile); ok {
-		r0 = rf(name)
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(models.DeviceProfile)
+		r0 = ret.Get(0).(uint32)
 	}
 
 	var r1 errors.EdgeX
-	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func() errors.EdgeX); ok {
+		r1 = rf()
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.EdgeX)
@@ -531,20 +794,22 @@ func (_m *DBClient) DeviceProfileByName(name string) (models.DeviceProfile, erro
 	return r0, r1
 }
 
-// DeviceProfileCountByLabels provides a mock function with given fields: labels
-func (_m *DBClient) DeviceProfileCountByLabels(labels []string) (uint32, errors.EdgeX) {
-	ret := _m.Called(labels)
+// DeviceLifecycleAuditsByDeviceName provides a mock function with given fields: offset, limit, name
+func (_m *DBClient) DeviceLifecycleAuditsByDeviceName(offset int, limit int, name string) ([]pkgModels.DeviceLifecycleAudit, errors.EdgeX) {
+	ret := _m.Called(offset, limit, name)
 
-	var r0 uint32
-	if rf, ok := ret.Get(0).(func([]string) uint32); ok {
-		r0 = rf(labels)
+	var r0 []pkgModels.DeviceLifecycleAudit
+	if rf, ok := ret.Get(0).(func(int, int, string) []pkgModels.DeviceLifecycleAudit); ok {
+		r0 = rf(offset, limit, name)
 	} else {
-		r0 = ret.Get(0).(uint32)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pkgModels.DeviceLifecycleAudit)
+		}
 	}
 
 	var r1 errors.EdgeX
-	if rf, ok := ret.Get(1).(func([]string) errors.EdgeX); ok {
-		r1 = rf(labels)
+	if rf, ok := ret.Get(1).(func(int, int, string) errors.EdgeX); ok {
+		r1 = rf(offset, limit, name)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.EdgeX)
@@ -554,20 +819,112 @@ func (_m *DBClient) DeviceProfileCountByLabels(labels []string) (uint32, errors.
 	return r0, r1
 }
 
-// DeviceProfileCountByManufacturer provides a mock function with given fields: manufacturer
-func (_m *DBClient) DeviceProfileCountByManufacturer(manufacturer string) (uint32, errors.EdgeX) {
-	ret := _m.Called(manufacturer)
+// DeviceNameExists provides a mock function with given fields: id
+func (_m *DBClient) DeviceNameExists(id string) (bool, errors.EdgeX) {
+	ret := _m.Called(id)
 
-	var r0 uint32
-	if rf, ok := ret.Get(0).(func(string) uint32); ok {
-		r0 = rf(manufacturer)
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(id)
 	} else {
-		r0 = ret.Get(0).(uint32)
+		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 errors.EdgeX
 	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
-		r1 = rf(manufacturer)
+		r1 = rf(id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// DeviceProfileById provides a mock function with given fields: id
+func (_m *DBClient) DeviceProfileById(id string) (models.DeviceProfile, errors.EdgeX) {
+	ret := _m.Called(id)
+
+	var r0 models.DeviceProfile
+	if rf, ok := ret.Get(0).(func(string) models.DeviceProfile); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(models.DeviceProfile)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// DeviceProfileByName provides a mock function with given fields: name
+func (_m *DBClient) DeviceProfileByName(name string) (models.DeviceProfile, errors.EdgeX) {
+	ret := _m.Called(name)
+
+	var r0 models.DeviceProfile
+	if rf, ok := ret.Get(0).(func(string) models.DeviceProfile); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(models.DeviceProfile)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// DeviceProfileCountByLabels provides a mock function with given fields: labels
+func (_m *DBClient) DeviceProfileCountByLabels(labels []string) (uint32, errors.EdgeX) {
+	ret := _m.Called(labels)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func([]string) uint32); ok {
+		r0 = rf(labels)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func([]string) errors.EdgeX); ok {
+		r1 = rf(labels)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// DeviceProfileCountByManufacturer provides a mock function with given fields: manufacturer
+func (_m *DBClient) DeviceProfileCountByManufacturer(manufacturer string) (uint32, errors.EdgeX) {
+	ret := _m.Called(manufacturer)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func(string) uint32); ok {
+		r0 = rf(manufacturer)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(manufacturer)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.EdgeX)
@@ -797,6 +1154,116 @@ func (_m *DBClient) DeviceServiceNameExists(name string) (bool, errors.EdgeX) {
 	return r0, r1
 }
 
+// DeviceTemplateByName provides a mock function with given fields: name
+func (_m *DBClient) DeviceTemplateByName(name string) (pkgModels.DeviceTemplate, errors.EdgeX) {
+	ret := _m.Called(name)
+
+	var r0 pkgModels.DeviceTemplate
+	if rf, ok := ret.Get(0).(func(string) pkgModels.DeviceTemplate); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(pkgModels.DeviceTemplate)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// DeviceTemplateCountByLabels provides a mock function with given fields: labels
+func (_m *DBClient) DeviceTemplateCountByLabels(labels []string) (uint32, errors.EdgeX) {
+	ret := _m.Called(labels)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func([]string) uint32); ok {
+		r0 = rf(labels)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func([]string) errors.EdgeX); ok {
+		r1 = rf(labels)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// DevicesByGeoBoundingBox provides a mock function with given fields: box, offset, limit
+func (_m *DBClient) DevicesByGeoBoundingBox(box pkgModels.GeoBoundingBox, offset int, limit int) ([]models.Device, uint32, errors.EdgeX) {
+	ret := _m.Called(box, offset, limit)
+
+	var r0 []models.Device
+	if rf, ok := ret.Get(0).(func(pkgModels.GeoBoundingBox, int, int) []models.Device); ok {
+		r0 = rf(box, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
+	}
+
+	var r1 uint32
+	if rf, ok := ret.Get(1).(func(pkgModels.GeoBoundingBox, int, int) uint32); ok {
+		r1 = rf(box, offset, limit)
+	} else {
+		r1 = ret.Get(1).(uint32)
+	}
+
+	var r2 errors.EdgeX
+	if rf, ok := ret.Get(2).(func(pkgModels.GeoBoundingBox, int, int) errors.EdgeX); ok {
+		r2 = rf(box, offset, limit)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1, r2
+}
+
+// DevicesByGeoCircle provides a mock function with given fields: circle, offset, limit
+func (_m *DBClient) DevicesByGeoCircle(circle pkgModels.GeoCircle, offset int, limit int) ([]models.Device, uint32, errors.EdgeX) {
+	ret := _m.Called(circle, offset, limit)
+
+	var r0 []models.Device
+	if rf, ok := ret.Get(0).(func(pkgModels.GeoCircle, int, int) []models.Device); ok {
+		r0 = rf(circle, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
+	}
+
+	var r1 uint32
+	if rf, ok := ret.Get(1).(func(pkgModels.GeoCircle, int, int) uint32); ok {
+		r1 = rf(circle, offset, limit)
+	} else {
+		r1 = ret.Get(1).(uint32)
+	}
+
+	var r2 errors.EdgeX
+	if rf, ok := ret.Get(2).(func(pkgModels.GeoCircle, int, int) errors.EdgeX); ok {
+		r2 = rf(circle, offset, limit)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1, r2
+}
+
 // DevicesByProfileName provides a mock function with given fields: offset, limit, profileName
 func (_m *DBClient) DevicesByProfileName(offset int, limit int, profileName string) ([]models.Device, errors.EdgeX) {
 	ret := _m.Called(offset, limit, profileName)
@@ -847,20 +1314,22 @@ func (_m *DBClient) DevicesByServiceName(offset int, limit int, name string) ([]
 	return r0, r1
 }
 
-// ProvisionWatcherById provides a mock function with given fields: id
-func (_m *DBClient) ProvisionWatcherById(id string) (models.ProvisionWatcher, errors.EdgeX) {
-	ret := _m.Called(id)
+// DevicesByTemplateName provides a mock function with given fields: offset, limit, templateName
+func (_m *DBClient) DevicesByTemplateName(offset int, limit int, templateName string) ([]models.Device, errors.EdgeX) {
+	ret := _m.Called(offset, limit, templateName)
 
-	var r0 models.ProvisionWatcher
-	if rf, ok := ret.Get(0).(func(string) models.ProvisionWatcher); ok {
-		r0 = rf(id)
+	var r0 []models.Device
+	if rf, ok := ret.Get(0).(func(int, int, string) []models.Device); ok {
+		r0 = rf(offset, limit, templateName)
 	} else {
-		r0 = ret.Get(0).(models.ProvisionWatcher)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
 	}
 
 	var r1 errors.EdgeX
-	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(int, int, string) errors.EdgeX); ok {
+		r1 = rf(offset, limit, templateName)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.EdgeX)
@@ -870,43 +1339,50 @@ func (_m *DBClient) ProvisionWatcherById(id string) (models.ProvisionWatcher, er
 	return r0, r1
 }
 
-// ProvisionWatcherByName provides a mock function with given fields: name
-func (_m *DBClient) ProvisionWatcherByName(name string) (models.ProvisionWatcher, errors.EdgeX) {
-	ret := _m.Called(name)
+// MetadataChangeSequenceRange provides a mock function with given fields:
+func (_m *DBClient) MetadataChangeSequenceRange() (uint64, uint64, errors.EdgeX) {
+	ret := _m.Called()
 
-	var r0 models.ProvisionWatcher
-	if rf, ok := ret.Get(0).(func(string) models.ProvisionWatcher); ok {
-		r0 = rf(name)
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(models.ProvisionWatcher)
+		r0 = ret.Get(0).(uint64)
 	}
 
-	var r1 errors.EdgeX
-	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
-		r1 = rf(name)
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func() uint64); ok {
+		r1 = rf()
 	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(errors.EdgeX)
+		r1 = ret.Get(1).(uint64)
+	}
+
+	var r2 errors.EdgeX
+	if rf, ok := ret.Get(2).(func() errors.EdgeX); ok {
+		r2 = rf()
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(errors.EdgeX)
 		}
 	}
 
-	return r0, r1
+	return r0, r1, r2
 }
 
-// ProvisionWatcherCountByLabels provides a mock function with given fields: labels
-func (_m *DBClient) ProvisionWatcherCountByLabels(labels []string) (uint32, errors.EdgeX) {
-	ret := _m.Called(labels)
+// MetadataChangeTotalCount provides a mock function with given fields:
+func (_m *DBClient) MetadataChangeTotalCount() (uint32, errors.EdgeX) {
+	ret := _m.Called()
 
 	var r0 uint32
-	if rf, ok := ret.Get(0).(func([]string) uint32); ok {
-		r0 = rf(labels)
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
 	} else {
 		r0 = ret.Get(0).(uint32)
 	}
 
 	var r1 errors.EdgeX
-	if rf, ok := ret.Get(1).(func([]string) errors.EdgeX); ok {
-		r1 = rf(labels)
+	if rf, ok := ret.Get(1).(func() errors.EdgeX); ok {
+		r1 = rf()
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.EdgeX)
@@ -916,20 +1392,22 @@ func (_m *DBClient) ProvisionWatcherCountByLabels(labels []string) (uint32, erro
 	return r0, r1
 }
 
-// ProvisionWatcherCountByProfileName provides a mock function with given fields: name
-func (_m *DBClient) ProvisionWatcherCountByProfileName(name string) (uint32, errors.EdgeX) {
-	ret := _m.Called(name)
+// MetadataChangesSince provides a mock function with given fields: since, limit
+func (_m *DBClient) MetadataChangesSince(since uint64, limit int) ([]pkgModels.MetadataChange, errors.EdgeX) {
+	ret := _m.Called(since, limit)
 
-	var r0 uint32
-	if rf, ok := ret.Get(0).(func(string) uint32); ok {
-		r0 = rf(name)
+	var r0 []pkgModels.MetadataChange
+	if rf, ok := ret.Get(0).(func(uint64, int) []pkgModels.MetadataChange); ok {
+		r0 = rf(since, limit)
 	} else {
-		r0 = ret.Get(0).(uint32)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pkgModels.MetadataChange)
+		}
 	}
 
 	var r1 errors.EdgeX
-	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(uint64, int) errors.EdgeX); ok {
+		r1 = rf(since, limit)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.EdgeX)
@@ -939,20 +1417,20 @@ func (_m *DBClient) ProvisionWatcherCountByProfileName(name string) (uint32, err
 	return r0, r1
 }
 
-// ProvisionWatcherCountByServiceName provides a mock function with given fields: name
-func (_m *DBClient) ProvisionWatcherCountByServiceName(name string) (uint32, errors.EdgeX) {
-	ret := _m.Called(name)
+// MetadataRevision provides a mock function with given fields: entityType, name
+func (_m *DBClient) MetadataRevision(entityType string, name string) (uint64, errors.EdgeX) {
+	ret := _m.Called(entityType, name)
 
-	var r0 uint32
-	if rf, ok := ret.Get(0).(func(string) uint32); ok {
-		r0 = rf(name)
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(string, string) uint64); ok {
+		r0 = rf(entityType, name)
 	} else {
-		r0 = ret.Get(0).(uint32)
+		r0 = ret.Get(0).(uint64)
 	}
 
 	var r1 errors.EdgeX
-	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(string, string) errors.EdgeX); ok {
+		r1 = rf(entityType, name)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.EdgeX)
@@ -962,22 +1440,20 @@ func (_m *DBClient) ProvisionWatcherCountByServiceName(name string) (uint32, err
 	return r0, r1
 }
 
-// ProvisionWatchersByProfileName provides a mock function with given fields: offset, limit, name
-func (_m *DBClient) ProvisionWatchersByProfileName(offset int, limit int, name string) ([]models.ProvisionWatcher, errors.EdgeX) {
-	ret := _m.Called(offset, limit, name)
+// PendingDeviceByName provides a mock function with given fields: name
+func (_m *DBClient) PendingDeviceByName(name string) (pkgModels.PendingDevice, errors.EdgeX) {
+	ret := _m.Called(name)
 
-	var r0 []models.ProvisionWatcher
-	if rf, ok := ret.Get(0).(func(int, int, string) []models.ProvisionWatcher); ok {
-		r0 = rf(offset, limit, name)
+	var r0 pkgModels.PendingDevice
+	if rf, ok := ret.Get(0).(func(string) pkgModels.PendingDevice); ok {
+		r0 = rf(name)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.ProvisionWatcher)
-		}
+		r0 = ret.Get(0).(pkgModels.PendingDevice)
 	}
 
 	var r1 errors.EdgeX
-	if rf, ok := ret.Get(1).(func(int, int, string) errors.EdgeX); ok {
-		r1 = rf(offset, limit, name)
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(name)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.EdgeX)
@@ -987,7 +1463,179 @@ func (_m *DBClient) ProvisionWatchersByProfileName(offset int, limit int, name s
 	return r0, r1
 }
 
-// ProvisionWatchersByServiceName provides a mock function with given fields: offset, limit, name
+// PendingDevices provides a mock function with given fields: status, offset, limit
+func (_m *DBClient) PendingDevices(status string, offset int, limit int) ([]pkgModels.PendingDevice, uint32, errors.EdgeX) {
+	ret := _m.Called(status, offset, limit)
+
+	var r0 []pkgModels.PendingDevice
+	if rf, ok := ret.Get(0).(func(string, int, int) []pkgModels.PendingDevice); ok {
+		r0 = rf(status, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pkgModels.PendingDevice)
+		}
+	}
+
+	var r1 uint32
+	if rf, ok := ret.Get(1).(func(string, int, int) uint32); ok {
+		r1 = rf(status, offset, limit)
+	} else {
+		r1 = ret.Get(1).(uint32)
+	}
+
+	var r2 errors.EdgeX
+	if rf, ok := ret.Get(2).(func(string, int, int) errors.EdgeX); ok {
+		r2 = rf(status, offset, limit)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1, r2
+}
+
+// ProvisionWatcherById provides a mock function with given fields: id
+func (_m *DBClient) ProvisionWatcherById(id string) (models.ProvisionWatcher, errors.EdgeX) {
+	ret := _m.Called(id)
+
+	var r0 models.ProvisionWatcher
+	if rf, ok := ret.Get(0).(func(string) models.ProvisionWatcher); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(models.ProvisionWatcher)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// ProvisionWatcherByName provides a mock function with given fields: name
+func (_m *DBClient) ProvisionWatcherByName(name string) (models.ProvisionWatcher, errors.EdgeX) {
+	ret := _m.Called(name)
+
+	var r0 models.ProvisionWatcher
+	if rf, ok := ret.Get(0).(func(string) models.ProvisionWatcher); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(models.ProvisionWatcher)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// ProvisionWatcherCountByLabels provides a mock function with given fields: labels
+func (_m *DBClient) ProvisionWatcherCountByLabels(labels []string) (uint32, errors.EdgeX) {
+	ret := _m.Called(labels)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func([]string) uint32); ok {
+		r0 = rf(labels)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func([]string) errors.EdgeX); ok {
+		r1 = rf(labels)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// ProvisionWatcherCountByProfileName provides a mock function with given fields: name
+func (_m *DBClient) ProvisionWatcherCountByProfileName(name string) (uint32, errors.EdgeX) {
+	ret := _m.Called(name)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func(string) uint32); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// ProvisionWatcherCountByServiceName provides a mock function with given fields: name
+func (_m *DBClient) ProvisionWatcherCountByServiceName(name string) (uint32, errors.EdgeX) {
+	ret := _m.Called(name)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func(string) uint32); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// ProvisionWatchersByProfileName provides a mock function with given fields: offset, limit, name
+func (_m *DBClient) ProvisionWatchersByProfileName(offset int, limit int, name string) ([]models.ProvisionWatcher, errors.EdgeX) {
+	ret := _m.Called(offset, limit, name)
+
+	var r0 []models.ProvisionWatcher
+	if rf, ok := ret.Get(0).(func(int, int, string) []models.ProvisionWatcher); ok {
+		r0 = rf(offset, limit, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ProvisionWatcher)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(int, int, string) errors.EdgeX); ok {
+		r1 = rf(offset, limit, name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// ProvisionWatchersByServiceName provides a mock function with given fields: offset, limit, name
 func (_m *DBClient) ProvisionWatchersByServiceName(offset int, limit int, name string) ([]models.ProvisionWatcher, errors.EdgeX) {
 	ret := _m.Called(offset, limit, name)
 
@@ -1012,6 +1660,256 @@ func (_m *DBClient) ProvisionWatchersByServiceName(offset int, limit int, name s
 	return r0, r1
 }
 
+// PurgeTrashedEntitiesByAge provides a mock function with given fields: entityType, age
+func (_m *DBClient) PurgeTrashedEntitiesByAge(entityType string, age int64) (uint32, errors.EdgeX) {
+	ret := _m.Called(entityType, age)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func(string, int64) uint32); ok {
+		r0 = rf(entityType, age)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string, int64) errors.EdgeX); ok {
+		r1 = rf(entityType, age)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// PurgeTrashedEntity provides a mock function with given fields: entityType, name
+func (_m *DBClient) PurgeTrashedEntity(entityType string, name string) errors.EdgeX {
+	ret := _m.Called(entityType, name)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(string, string) errors.EdgeX); ok {
+		r0 = rf(entityType, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
+// RestoreTrashedDevice provides a mock function with given fields: name
+func (_m *DBClient) RestoreTrashedDevice(name string) (models.Device, errors.EdgeX) {
+	ret := _m.Called(name)
+
+	var r0 models.Device
+	if rf, ok := ret.Get(0).(func(string) models.Device); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(models.Device)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// RestoreTrashedDeviceProfile provides a mock function with given fields: name
+func (_m *DBClient) RestoreTrashedDeviceProfile(name string) (models.DeviceProfile, errors.EdgeX) {
+	ret := _m.Called(name)
+
+	var r0 models.DeviceProfile
+	if rf, ok := ret.Get(0).(func(string) models.DeviceProfile); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(models.DeviceProfile)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// TrashDeviceByName provides a mock function with given fields: name
+func (_m *DBClient) TrashDeviceByName(name string) errors.EdgeX {
+	ret := _m.Called(name)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(string) errors.EdgeX); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
+// TrashDeviceProfileByName provides a mock function with given fields: name
+func (_m *DBClient) TrashDeviceProfileByName(name string) errors.EdgeX {
+	ret := _m.Called(name)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(string) errors.EdgeX); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
+// TrashedEntities provides a mock function with given fields: entityType, offset, limit
+func (_m *DBClient) TrashedEntities(entityType string, offset int, limit int) ([]pkgModels.TrashedEntity, errors.EdgeX) {
+	ret := _m.Called(entityType, offset, limit)
+
+	var r0 []pkgModels.TrashedEntity
+	if rf, ok := ret.Get(0).(func(string, int, int) []pkgModels.TrashedEntity); ok {
+		r0 = rf(entityType, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pkgModels.TrashedEntity)
+		}
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string, int, int) errors.EdgeX); ok {
+		r1 = rf(entityType, offset, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// TrashedEntityByName provides a mock function with given fields: entityType, name
+func (_m *DBClient) TrashedEntityByName(entityType string, name string) (pkgModels.TrashedEntity, errors.EdgeX) {
+	ret := _m.Called(entityType, name)
+
+	var r0 pkgModels.TrashedEntity
+	if rf, ok := ret.Get(0).(func(string, string) pkgModels.TrashedEntity); ok {
+		r0 = rf(entityType, name)
+	} else {
+		r0 = ret.Get(0).(pkgModels.TrashedEntity)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string, string) errors.EdgeX); ok {
+		r1 = rf(entityType, name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// TrashedEntityTotalCount provides a mock function with given fields: entityType
+func (_m *DBClient) TrashedEntityTotalCount(entityType string) (uint32, errors.EdgeX) {
+	ret := _m.Called(entityType)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func(string) uint32); ok {
+		r0 = rf(entityType)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(entityType)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// TrimMetadataChanges provides a mock function with given fields: retained
+func (_m *DBClient) TrimMetadataChanges(retained uint32) errors.EdgeX {
+	ret := _m.Called(retained)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(uint32) errors.EdgeX); ok {
+		r0 = rf(retained)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
+// UnitOfMeasureCategoryByName provides a mock function with given fields: name
+func (_m *DBClient) UnitOfMeasureCategoryByName(name string) (pkgModels.UnitOfMeasureCategory, errors.EdgeX) {
+	ret := _m.Called(name)
+
+	var r0 pkgModels.UnitOfMeasureCategory
+	if rf, ok := ret.Get(0).(func(string) pkgModels.UnitOfMeasureCategory); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(pkgModels.UnitOfMeasureCategory)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func(string) errors.EdgeX); ok {
+		r1 = rf(name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
+// UnitOfMeasureCategoryTotalCount provides a mock function with given fields:
+func (_m *DBClient) UnitOfMeasureCategoryTotalCount() (uint32, errors.EdgeX) {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 errors.EdgeX
+	if rf, ok := ret.Get(1).(func() errors.EdgeX); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.EdgeX)
+		}
+	}
+
+	return r0, r1
+}
+
 // UpdateDevice provides a mock function with given fields: d
 func (_m *DBClient) UpdateDevice(d models.Device) errors.EdgeX {
 	ret := _m.Called(d)
@@ -1060,6 +1958,38 @@ func (_m *DBClient) UpdateDeviceService(ds models.DeviceService) errors.EdgeX {
 	return r0
 }
 
+// UpdateDeviceTemplate provides a mock function with given fields: t
+func (_m *DBClient) UpdateDeviceTemplate(t pkgModels.DeviceTemplate) errors.EdgeX {
+	ret := _m.Called(t)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(pkgModels.DeviceTemplate) errors.EdgeX); ok {
+		r0 = rf(t)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
+// UpdatePendingDevice provides a mock function with given fields: pd
+func (_m *DBClient) UpdatePendingDevice(pd pkgModels.PendingDevice) errors.EdgeX {
+	ret := _m.Called(pd)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(pkgModels.PendingDevice) errors.EdgeX); ok {
+		r0 = rf(pd)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
+
 // UpdateProvisionWatcher provides a mock function with given fields: pw
 func (_m *DBClient) UpdateProvisionWatcher(pw models.ProvisionWatcher) errors.EdgeX {
 	ret := _m.Called(pw)
@@ -1075,3 +2005,19 @@ func (_m *DBClient) UpdateProvisionWatcher(pw models.ProvisionWatcher) errors.Ed
 
 	return r0
 }
+
+// UpdateUnitOfMeasureCategory provides a mock function with given fields: c
+func (_m *DBClient) UpdateUnitOfMeasureCategory(c pkgModels.UnitOfMeasureCategory) errors.EdgeX {
+	ret := _m.Called(c)
+
+	var r0 errors.EdgeX
+	if rf, ok := ret.Get(0).(func(pkgModels.UnitOfMeasureCategory) errors.EdgeX); ok {
+		r0 = rf(c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.EdgeX)
+		}
+	}
+
+	return r0
+}
@@ -2,7 +2,12 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	interfaces "github.com/edgexfoundry/edgex-go/internal/core/metadata/infrastructure/interfaces"
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
 
 // UnitsOfMeasure is an autogenerated mock type for the UnitsOfMeasure type
 type UnitsOfMeasure struct {
@@ -22,3 +27,19 @@ func (_m *UnitsOfMeasure) Validate(_a0 string) bool {
 
 	return r0
 }
+
+// WithCustomUnits provides a mock function with given fields: _a0
+func (_m *UnitsOfMeasure) WithCustomUnits(_a0 []models.UnitOfMeasureCategory) interfaces.UnitsOfMeasure {
+	ret := _m.Called(_a0)
+
+	var r0 interfaces.UnitsOfMeasure
+	if rf, ok := ret.Get(0).(func([]models.UnitOfMeasureCategory) interfaces.UnitsOfMeasure); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.UnitsOfMeasure)
+		}
+	}
+
+	return r0
+}
@@ -1,14 +1,21 @@
 //
-// Copyright (C) 2022 IOTech Ltd
+// Copyright (C) 2022-2024 IOTech Ltd
 //
 // SPDX-License-Identifier: Apache-2.0
 
 package interfaces
 
+import (
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
 // UnitsOfMeasure defines required functionality to perform units of measure
 // validation in EdgeX
 type UnitsOfMeasure interface {
 	// Validate validates DeviceResource's unit against the list of
 	// units of measure by core metadata.
 	Validate(string) bool
+	// WithCustomUnits returns the units of measure merging the units loaded
+	// from the UoM file with the custom unit categories.
+	WithCustomUnits([]pkgModels.UnitOfMeasureCategory) UnitsOfMeasure
 }
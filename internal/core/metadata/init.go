@@ -19,10 +19,15 @@ package metadata
 import (
 	"context"
 	"sync"
+	"time"
 
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/startup"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
 
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/application"
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/container"
+
 	"github.com/labstack/echo/v4"
 )
 
@@ -44,5 +49,48 @@ func NewBootstrap(router *echo.Echo, serviceName string) *Bootstrap {
 func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
 	LoadRestRoutes(b.router, dic, b.serviceName)
 
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	config := container.ConfigurationFrom(dic.Get)
+	if config.DeviceServiceHealth.Enabled {
+		interval, err := time.ParseDuration(config.DeviceServiceHealth.Interval)
+		if err != nil {
+			lc.Errorf("Failed to parse device service health probing interval, %v", err)
+			return false
+		}
+		err = application.AsyncProbeDeviceServices(interval, config.DeviceServiceHealth.Method, config.DeviceServiceHealth.FailureThreshold, ctx, dic)
+		if err != nil {
+			lc.Errorf("Failed to start device service health probing, %v", err)
+			return false
+		}
+	}
+
+	if config.ChangeLog.MaxCap > 0 {
+		interval, err := time.ParseDuration(config.ChangeLog.Interval)
+		if err != nil {
+			lc.Errorf("Failed to parse metadata change log purging interval, %v", err)
+			return false
+		}
+		application.AsyncPurgeMetadataChanges(interval, ctx, dic)
+	}
+
+	if err := application.ReloadUnitsOfMeasure(dic); err != nil {
+		lc.Errorf("Failed to load the custom units of measure, %v", err)
+		return false
+	}
+
+	retention, err := application.ParseTrashRetention(config.Trash.Retention)
+	if err != nil {
+		lc.Errorf("Failed to parse trash retention, %v", err)
+		return false
+	}
+	if retention > 0 {
+		interval, err := time.ParseDuration(config.Trash.Interval)
+		if err != nil {
+			lc.Errorf("Failed to parse trash purging interval, %v", err)
+			return false
+		}
+		application.AsyncPurgeTrash(interval, ctx, dic)
+	}
+
 	return true
 }
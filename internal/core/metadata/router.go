@@ -16,6 +16,7 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
 
 	metadataController "github.com/edgexfoundry/edgex-go/internal/core/metadata/controller/http"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
 
 	"github.com/labstack/echo/v4"
 )
@@ -31,6 +32,11 @@ func LoadRestRoutes(r *echo.Echo, dic *di.Container, serviceName string) {
 	// Units of Measure
 	uc := metadataController.NewUnitOfMeasureController(dic)
 	r.GET(common.ApiUnitsOfMeasureRoute, uc.UnitsOfMeasure, authenticationHook)
+	r.POST(pkgCommon.ApiUnitOfMeasureCategoryRoute, uc.AddUnitOfMeasureCategory, authenticationHook)
+	r.PUT(pkgCommon.ApiUnitOfMeasureCategoryRoute, uc.UpdateUnitOfMeasureCategory, authenticationHook)
+	r.GET(pkgCommon.ApiAllUnitOfMeasureCategoryRoute, uc.AllUnitOfMeasureCategories, authenticationHook)
+	r.GET(pkgCommon.ApiUnitOfMeasureCategoryByNameRoute, uc.UnitOfMeasureCategoryByName, authenticationHook)
+	r.DELETE(pkgCommon.ApiUnitOfMeasureCategoryByNameRoute, uc.DeleteUnitOfMeasureCategoryByName, authenticationHook)
 
 	// Device Profile
 	dc := metadataController.NewDeviceProfileController(dic)
@@ -45,10 +51,24 @@ func LoadRestRoutes(r *echo.Echo, dic *di.Container, serviceName string) {
 	r.GET(common.ApiDeviceProfileByManufacturerEchoRoute, dc.DeviceProfilesByManufacturer, authenticationHook)
 	r.GET(common.ApiDeviceProfileByManufacturerAndModelEchoRoute, dc.DeviceProfilesByManufacturerAndModel, authenticationHook)
 	r.PATCH(common.ApiDeviceProfileBasicInfoRoute, dc.PatchDeviceProfileBasicInfo, authenticationHook)
+	r.POST(pkgCommon.ApiDeviceProfileValidateRoute, dc.ValidateDeviceProfile, authenticationHook)
+
+	// Device Template
+	dtc := metadataController.NewDeviceTemplateController(dic)
+	r.POST(pkgCommon.ApiDeviceTemplateRoute, dtc.AddDeviceTemplate, authenticationHook)
+	r.PUT(pkgCommon.ApiDeviceTemplateRoute, dtc.UpdateDeviceTemplate, authenticationHook)
+	r.GET(pkgCommon.ApiAllDeviceTemplateRoute, dtc.AllDeviceTemplates, authenticationHook)
+	r.GET(pkgCommon.ApiDeviceTemplateByNameEchoRoute, dtc.DeviceTemplateByName, authenticationHook)
+	r.DELETE(pkgCommon.ApiDeviceTemplateByNameEchoRoute, dtc.DeleteDeviceTemplateByName, authenticationHook)
+	r.GET(pkgCommon.ApiDeviceTemplateInstancesEchoRoute, dtc.DeviceTemplateInstances, authenticationHook)
+	r.POST(pkgCommon.ApiDeviceTemplateInstantiateEchoRoute, dtc.InstantiateDeviceTemplate, authenticationHook)
+	r.POST(pkgCommon.ApiDeviceTemplateRolloutEchoRoute, dtc.RolloutDeviceTemplate, authenticationHook)
 
 	// Device Resource
 	dr := metadataController.NewDeviceResourceController(dic)
 	r.GET(common.ApiDeviceResourceByProfileAndResourceEchoRoute, dr.DeviceResourceByProfileNameAndResourceName, authenticationHook)
+	r.GET(pkgCommon.ApiDeviceResourceByDeviceEchoRoute, dr.DeviceResourcesByDeviceName, authenticationHook)
+	r.GET(pkgCommon.ApiDeviceResourceByDeviceAndResourceEchoRoute, dr.DeviceResourceByDeviceNameAndResourceName, authenticationHook)
 	r.POST(common.ApiDeviceProfileResourceRoute, dr.AddDeviceProfileResource, authenticationHook)
 	r.PATCH(common.ApiDeviceProfileResourceRoute, dr.PatchDeviceProfileResource, authenticationHook)
 	r.DELETE(common.ApiDeviceProfileResourceByNameEchoRoute, dr.DeleteDeviceResourceByName, authenticationHook)
@@ -67,6 +87,35 @@ func LoadRestRoutes(r *echo.Echo, dic *di.Container, serviceName string) {
 	r.DELETE(common.ApiDeviceServiceByNameEchoRoute, ds.DeleteDeviceServiceByName, authenticationHook)
 	r.GET(common.ApiAllDeviceServiceRoute, ds.AllDeviceServices, authenticationHook)
 
+	// Search
+	sc := metadataController.NewSearchController(dic)
+	r.GET(pkgCommon.ApiSearchRoute, sc.Search, authenticationHook)
+
+	// Change Log
+	mc := metadataController.NewMetadataChangeController(dic)
+	r.GET(pkgCommon.ApiMetadataChangesRoute, mc.MetadataChanges, authenticationHook)
+
+	// Trash
+	tc := metadataController.NewTrashController(dic)
+	r.GET(pkgCommon.ApiAllTrashedEntitiesRoute, tc.AllTrashedEntities, authenticationHook)
+	r.GET(pkgCommon.ApiTrashedEntityByNameRoute, tc.TrashedEntityByName, authenticationHook)
+	r.DELETE(pkgCommon.ApiTrashedEntityByNameRoute, tc.PurgeTrashedEntity, authenticationHook)
+	r.POST(pkgCommon.ApiRestoreTrashedEntityRoute, tc.RestoreTrashedEntity, authenticationHook)
+
+	// Integrity
+	ic := metadataController.NewIntegrityController(dic)
+	r.GET(pkgCommon.ApiIntegrityRoute, ic.MetadataIntegrity, authenticationHook)
+	r.POST(pkgCommon.ApiIntegrityRepairRoute, ic.RepairMetadataIntegrity, authenticationHook)
+
+	// Pending Device
+	pd := metadataController.NewPendingDeviceController(dic)
+	r.GET(pkgCommon.ApiAllPendingDeviceRoute, pd.AllPendingDevices, authenticationHook)
+	r.GET(pkgCommon.ApiPendingDeviceByNameEchoRoute, pd.PendingDeviceByName, authenticationHook)
+	r.PATCH(pkgCommon.ApiPendingDeviceByNameEchoRoute, pd.PatchPendingDevice, authenticationHook)
+	r.DELETE(pkgCommon.ApiPendingDeviceByNameEchoRoute, pd.DeletePendingDeviceByName, authenticationHook)
+	r.POST(pkgCommon.ApiApprovePendingDeviceEchoRoute, pd.ApprovePendingDevice, authenticationHook)
+	r.POST(pkgCommon.ApiRejectPendingDeviceEchoRoute, pd.RejectPendingDevice, authenticationHook)
+
 	// Device
 	d := metadataController.NewDeviceController(dic)
 	r.POST(common.ApiDeviceRoute, d.AddDevice, authenticationHook)
@@ -77,6 +126,10 @@ func LoadRestRoutes(r *echo.Echo, dic *di.Container, serviceName string) {
 	r.GET(common.ApiAllDeviceRoute, d.AllDevices, authenticationHook)
 	r.GET(common.ApiDeviceByNameEchoRoute, d.DeviceByName, authenticationHook)
 	r.GET(common.ApiDeviceByProfileNameEchoRoute, d.DevicesByProfileName, authenticationHook)
+	r.GET(pkgCommon.ApiAllDeviceLifecycleEchoRoute, d.AllDeviceLifecycleAudits, authenticationHook)
+	r.GET(pkgCommon.ApiDeviceLifecycleByNameEchoRoute, d.DeviceLifecycleAuditsByDeviceName, authenticationHook)
+	r.GET(pkgCommon.ApiDeviceByLocationRadiusRoute, d.DevicesByLocationRadius, authenticationHook)
+	r.GET(pkgCommon.ApiDeviceByLocationBoundingBoxRoute, d.DevicesByLocationBoundingBox, authenticationHook)
 
 	// ProvisionWatcher
 	pwc := metadataController.NewProvisionWatcherController(dic)
@@ -87,4 +140,5 @@ func LoadRestRoutes(r *echo.Echo, dic *di.Container, serviceName string) {
 	r.GET(common.ApiAllProvisionWatcherRoute, pwc.AllProvisionWatchers, authenticationHook)
 	r.DELETE(common.ApiProvisionWatcherByNameEchoRoute, pwc.DeleteProvisionWatcherByName, authenticationHook)
 	r.PATCH(common.ApiProvisionWatcherRoute, pwc.PatchProvisionWatcher, authenticationHook)
+	r.POST(pkgCommon.ApiProvisionWatcherDryRunRoute, pwc.DryRunProvisionWatchers, authenticationHook)
 }
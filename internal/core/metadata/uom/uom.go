@@ -1,13 +1,23 @@
 //
-// Copyright (C) 2022 IOTech Ltd
+// Copyright (C) 2022-2024 IOTech Ltd
 //
 // SPDX-License-Identifier: Apache-2.0
 
 package uom
 
+import (
+	"slices"
+
+	"github.com/edgexfoundry/edgex-go/internal/core/metadata/infrastructure/interfaces"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
 type UnitsOfMeasureImpl struct {
 	Source string          `json:"source,omitempty" yaml:"Source,omitempty"`
 	Units  map[string]Unit `json:"units,omitempty" yaml:"Units,omitempty"`
+
+	// file is the catalog loaded from the UoM file, which the custom unit categories are merged with
+	file *UnitsOfMeasureImpl
 }
 
 type Unit struct {
@@ -30,3 +40,36 @@ func (u *UnitsOfMeasureImpl) Validate(unit string) bool {
 
 	return false
 }
+
+// WithCustomUnits returns new units of measure merging the catalog loaded from the UoM file with the custom unit
+// categories. The values of a custom category are added to the file category of the same name, and the source of the
+// custom category overrides the source of the file category when specified. The receiver isn't modified, so that it
+// can be replaced while other requests are validating the units against it.
+func (u *UnitsOfMeasureImpl) WithCustomUnits(categories []pkgModels.UnitOfMeasureCategory) interfaces.UnitsOfMeasure {
+	file := u.file
+	if file == nil {
+		file = u
+	}
+
+	merged := &UnitsOfMeasureImpl{
+		Source: file.Source,
+		Units:  make(map[string]Unit, len(file.Units)+len(categories)),
+		file:   file,
+	}
+	for name, unit := range file.Units {
+		merged.Units[name] = Unit{Source: unit.Source, Values: slices.Clone(unit.Values)}
+	}
+	for _, c := range categories {
+		unit := merged.Units[c.Name]
+		if c.Source != "" {
+			unit.Source = c.Source
+		}
+		for _, v := range c.Values {
+			if !slices.Contains(unit.Values, v) {
+				unit.Values = append(unit.Values, v)
+			}
+		}
+		merged.Units[c.Name] = unit
+	}
+	return merged
+}
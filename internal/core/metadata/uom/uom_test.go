@@ -0,0 +1,41 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package uom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+func TestWithCustomUnits(t *testing.T) {
+	file := &UnitsOfMeasureImpl{
+		Source: "file source",
+		Units: map[string]Unit{
+			"temperature": {Source: "www.weather.com", Values: []string{"C", "F"}},
+		},
+	}
+
+	merged, ok := file.WithCustomUnits([]pkgModels.UnitOfMeasureCategory{
+		{Name: "temperature", Source: "vendor", Values: []string{"F", "mK"}},
+		{Name: "pressure", Values: []string{"psi"}},
+	}).(*UnitsOfMeasureImpl)
+	require.True(t, ok)
+	assert.Equal(t, "file source", merged.Source)
+	assert.Equal(t, Unit{Source: "vendor", Values: []string{"C", "F", "mK"}}, merged.Units["temperature"])
+	assert.Equal(t, Unit{Values: []string{"psi"}}, merged.Units["pressure"])
+	// the file catalog is not modified by the merge
+	assert.Equal(t, []string{"C", "F"}, file.Units["temperature"].Values)
+
+	// merging again starts from the file catalog, so the removed custom units are not kept
+	reloaded, ok := merged.WithCustomUnits(nil).(*UnitsOfMeasureImpl)
+	require.True(t, ok)
+	assert.Equal(t, map[string]Unit{"temperature": {Source: "www.weather.com", Values: []string{"C", "F"}}}, reloaded.Units)
+	assert.False(t, reloaded.Validate("psi"))
+}
@@ -0,0 +1,171 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+)
+
+// Constants related to the REST routes which are not defined by go-mod-core-contracts
+const (
+	ApiDeviceLifecycleRoute           = common.ApiDeviceRoute + "/" + Lifecycle
+	ApiAllDeviceLifecycleEchoRoute    = ApiDeviceLifecycleRoute + "/" + common.All
+	ApiDeviceLifecycleByNameEchoRoute = ApiDeviceLifecycleRoute + "/" + common.Name + "/:" + common.Name
+
+	ApiSearchRoute = common.ApiBase + "/" + Search
+
+	ApiProvisionWatcherDryRunRoute = common.ApiProvisionWatcherRoute + "/" + DryRun
+
+	ApiDeviceProfileValidateRoute = common.ApiDeviceProfileRoute + "/" + Validate
+
+	ApiDeviceTemplateRoute                = common.ApiBase + "/" + DeviceTemplate
+	ApiAllDeviceTemplateRoute             = ApiDeviceTemplateRoute + "/" + common.All
+	ApiDeviceTemplateByNameEchoRoute      = ApiDeviceTemplateRoute + "/" + common.Name + "/:" + common.Name
+	ApiDeviceTemplateInstancesEchoRoute   = ApiDeviceTemplateByNameEchoRoute + "/" + Instances
+	ApiDeviceTemplateInstantiateEchoRoute = ApiDeviceTemplateByNameEchoRoute + "/" + Instantiate
+	ApiDeviceTemplateRolloutEchoRoute     = ApiDeviceTemplateByNameEchoRoute + "/" + Rollout
+
+	ApiMetadataChangesRoute = common.ApiBase + "/" + Changes
+
+	ApiTrashRoute                = common.ApiBase + "/" + Trash + "/:" + EntityType
+	ApiAllTrashedEntitiesRoute   = ApiTrashRoute + "/" + common.All
+	ApiTrashedEntityByNameRoute  = ApiTrashRoute + "/" + common.Name + "/:" + common.Name
+	ApiRestoreTrashedEntityRoute = ApiTrashedEntityByNameRoute + "/" + Restore
+
+	ApiUnitOfMeasureCategoryRoute       = common.ApiUnitsOfMeasureRoute + "/" + Category
+	ApiAllUnitOfMeasureCategoryRoute    = ApiUnitOfMeasureCategoryRoute + "/" + common.All
+	ApiUnitOfMeasureCategoryByNameRoute = ApiUnitOfMeasureCategoryRoute + "/" + common.Name + "/:" + common.Name
+
+	ApiDeviceResourceByDeviceEchoRoute            = common.ApiDeviceResourceRoute + "/" + common.Device + "/:" + common.Name
+	ApiDeviceResourceByDeviceAndResourceEchoRoute = ApiDeviceResourceByDeviceEchoRoute + "/" + common.Resource + "/:" + common.ResourceName
+
+	ApiDeviceByLocationRadiusRoute       = common.ApiDeviceRoute + "/" + Location + "/" + Radius
+	ApiDeviceByLocationBoundingBoxRoute  = common.ApiDeviceRoute + "/" + Location + "/" + BoundingBox
+	ApiReadingByLocationRadiusRoute      = common.ApiReadingRoute + "/" + Location + "/" + Radius
+	ApiReadingByLocationBoundingBoxRoute = common.ApiReadingRoute + "/" + Location + "/" + BoundingBox
+
+	ApiIntegrityRoute       = common.ApiBase + "/" + Integrity
+	ApiIntegrityRepairRoute = ApiIntegrityRoute + "/" + Repair
+
+	ApiPendingDeviceRoute            = common.ApiBase + "/" + PendingDevice
+	ApiAllPendingDeviceRoute         = ApiPendingDeviceRoute + "/" + common.All
+	ApiPendingDeviceByNameEchoRoute  = ApiPendingDeviceRoute + "/" + common.Name + "/:" + common.Name
+	ApiApprovePendingDeviceEchoRoute = ApiPendingDeviceByNameEchoRoute + "/" + Approve
+	ApiRejectPendingDeviceEchoRoute  = ApiPendingDeviceByNameEchoRoute + "/" + Reject
+
+	ApiGroupCommandRoute = common.ApiDeviceRoute + "/" + Group + "/" + common.Command
+
+	ApiScheduledCommandRoute           = common.ApiBase + "/" + ScheduledCommand
+	ApiAllScheduledCommandRoute        = ApiScheduledCommandRoute + "/" + common.All
+	ApiScheduledCommandByIdEchoRoute   = ApiScheduledCommandRoute + "/" + common.Id + "/:" + common.Id
+	ApiCancelScheduledCommandEchoRoute = ApiScheduledCommandByIdEchoRoute + "/" + Cancel
+
+	ApiCommandAuditRoute                 = common.ApiBase + "/" + CommandAudit
+	ApiAllCommandAuditRoute              = ApiCommandAuditRoute + "/" + common.All
+	ApiCommandAuditByDeviceNameEchoRoute = ApiCommandAuditRoute + "/" + common.Device + "/" + common.Name + "/:" + common.Name
+	ApiCommandAuditByTimeRangeEchoRoute  = ApiCommandAuditRoute + "/" + common.Start + "/:" + common.Start + "/" + common.End + "/:" + common.End
+
+	ApiPendingCommandRoute            = common.ApiBase + "/" + PendingCommand
+	ApiAllPendingCommandRoute         = ApiPendingCommandRoute + "/" + common.All
+	ApiPendingCommandByIdEchoRoute    = ApiPendingCommandRoute + "/" + common.Id + "/:" + common.Id
+	ApiApprovePendingCommandEchoRoute = ApiPendingCommandByIdEchoRoute + "/" + Approve
+	ApiRejectPendingCommandEchoRoute  = ApiPendingCommandByIdEchoRoute + "/" + Reject
+
+	ApiWebSocketRoute = common.ApiBase + "/" + WebSocket
+
+	ApiCommandSequenceRoute                     = common.ApiBase + "/" + CommandSequence
+	ApiAllCommandSequenceRoute                  = ApiCommandSequenceRoute + "/" + common.All
+	ApiCommandSequenceByNameEchoRoute           = ApiCommandSequenceRoute + "/" + common.Name + "/:" + common.Name
+	ApiExecuteCommandSequenceEchoRoute          = ApiCommandSequenceByNameEchoRoute + "/" + Execute
+	ApiCommandSequenceExecutionsByNameEchoRoute = ApiCommandSequenceByNameEchoRoute + "/" + Execution
+	ApiCommandSequenceExecutionRoute            = ApiCommandSequenceRoute + "/" + Execution
+	ApiAllCommandSequenceExecutionRoute         = ApiCommandSequenceExecutionRoute + "/" + common.All
+	ApiCommandSequenceExecutionByIdEchoRoute    = ApiCommandSequenceExecutionRoute + "/" + common.Id + "/:" + common.Id
+)
+
+// Constants related to the query parameters and field names which are not defined by go-mod-core-contracts
+const (
+	Lifecycle = "lifecycle"
+	Reason    = "reason" //query string to specify the reason of a device lifecycle transition
+	Search    = "search"
+	Query     = "q" //query string to specify the search query of metadata entities
+	DryRun    = "dryrun"
+	Validate  = "validate"
+
+	DeviceTemplate = "devicetemplate"
+	Instances      = "instances"
+	Instantiate    = "instantiate"
+	Rollout        = "rollout"
+
+	Changes = "changes"
+	Since   = "since" //query string to specify the cursor of the metadata change log
+	Wait    = "wait"  //query string to specify the duration to wait for new metadata changes, e.g. 30s
+
+	Trash      = "trash"
+	EntityType = "type" //path parameter to specify the entity type of the trash, either device or deviceprofile
+	Restore    = "restore"
+
+	Category              = "category"
+	UnitOfMeasureCategory = "uomcategory"
+
+	Location     = "location"
+	Radius       = "radius" //query string to specify the radius in meters of a location query
+	BoundingBox  = "bbox"
+	Latitude     = "latitude"
+	Longitude    = "longitude"
+	MinLatitude  = "minLatitude"
+	MinLongitude = "minLongitude"
+	MaxLatitude  = "maxLatitude"
+	MaxLongitude = "maxLongitude"
+
+	Integrity = "integrity"
+	Repair    = "repair"
+
+	PendingDevice = "pendingdevice"
+	Approve       = "approve"
+	Reject        = "reject"
+
+	Group = "group"
+
+	ScheduledCommand = "scheduledcommand"
+	Cancel           = "cancel"
+
+	CommandAudit = "commandaudit"
+
+	PendingCommand = "pendingcommand"
+
+	NoCache = "nocache" //query string to bypass the get command response cache of core-command
+
+	AuthToken = "authtoken" //MessageEnvelope query parameter carrying the JWT of the requester, which messages have no header for
+
+	WebSocket = "ws"
+
+	CommandSequence = "commandsequence"
+	Execute         = "execute"
+	Execution       = "execution"
+
+	SearchTypeDevice        = "device"
+	SearchTypeDeviceProfile = "deviceprofile"
+	SearchTypeDeviceService = "deviceservice"
+)
+
+// Constants related to the system event actions which are not defined by go-mod-core-contracts
+const (
+	SystemEventActionDown = "down" // the device service is unreachable and its devices are set to DOWN
+	SystemEventActionUp   = "up"   // the device service is reachable again and its devices are set back to UP
+)
+
+// Constants related to the ExternalMQTT topics which are not defined by go-mod-core-contracts
+const (
+	CommandSequenceRequestTopicKey        = "CommandSequenceRequestTopic"
+	CommandSequenceResponseTopicPrefixKey = "CommandSequenceResponseTopicPrefix"
+)
+
+// Constants related to the HTTP headers which are not defined by go-mod-core-contracts
+const (
+	ETagHeader    = "ETag"
+	IfMatchHeader = "If-Match"
+)
@@ -0,0 +1,46 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// CommandAuditEntry is the DTO of a record of a get or set command issued by core-command
+type CommandAuditEntry struct {
+	Id            string            `json:"id"`
+	Timestamp     int64             `json:"timestamp"`
+	Origin        string            `json:"origin"`
+	Actor         string            `json:"actor,omitempty"`
+	CorrelationId string            `json:"correlationId,omitempty"`
+	DeviceName    string            `json:"deviceName"`
+	CommandName   string            `json:"commandName"`
+	Method        string            `json:"method"`
+	Parameters    map[string]any    `json:"parameters,omitempty"`
+	QueryParams   map[string]string `json:"queryParams,omitempty"`
+	StatusCode    int               `json:"statusCode"`
+	Message       string            `json:"message,omitempty"`
+	Latency       int64             `json:"latency"`
+}
+
+// FromCommandAuditEntryModelToDTO transforms the CommandAuditEntry model to the CommandAuditEntry DTO
+func FromCommandAuditEntryModelToDTO(e models.CommandAuditEntry) CommandAuditEntry {
+	return CommandAuditEntry{
+		Id:            e.Id,
+		Timestamp:     e.Timestamp,
+		Origin:        e.Origin,
+		Actor:         e.Actor,
+		CorrelationId: e.CorrelationId,
+		DeviceName:    e.DeviceName,
+		CommandName:   e.CommandName,
+		Method:        e.Method,
+		Parameters:    e.Parameters,
+		QueryParams:   e.QueryParams,
+		StatusCode:    e.StatusCode,
+		Message:       e.Message,
+		Latency:       e.Latency,
+	}
+}
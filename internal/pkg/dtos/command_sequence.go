@@ -0,0 +1,171 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// CommandSequence is the DTO of a named list of steps executed in order, the RollbackSteps are executed instead of the
+// remaining steps when a step fails
+type CommandSequence struct {
+	dtos.DBTimestamp `json:",inline"`
+	Id               string                `json:"id,omitempty" validate:"omitempty,uuid"`
+	Name             string                `json:"name" validate:"required,edgex-dto-none-empty-string,edgex-dto-rfc3986-unreserved-chars"`
+	Description      string                `json:"description,omitempty"`
+	Steps            []CommandSequenceStep `json:"steps" validate:"gt=0,dive"`
+	RollbackSteps    []CommandSequenceStep `json:"rollbackSteps,omitempty" validate:"dive"`
+}
+
+// CommandSequenceStep is the DTO of a SET, GET or WAIT step. A SET step requires the settings, a GET step may assert
+// the reading values, and a WAIT step requires the duration.
+type CommandSequenceStep struct {
+	Type        string                     `json:"type" validate:"required,oneof='SET' 'GET' 'WAIT'"`
+	DeviceName  string                     `json:"deviceName,omitempty" validate:"required_unless=Type WAIT"`
+	CommandName string                     `json:"commandName,omitempty" validate:"required_unless=Type WAIT"`
+	Settings    map[string]any             `json:"settings,omitempty" validate:"required_if=Type SET"`
+	QueryParams map[string]string          `json:"queryParams,omitempty"`
+	Duration    string                     `json:"duration,omitempty" validate:"required_if=Type WAIT,omitempty,edgex-dto-duration"`
+	Assertions  []CommandSequenceAssertion `json:"assertions,omitempty" validate:"dive"`
+}
+
+// CommandSequenceAssertion is the DTO comparing the reading value of a device resource with a value
+type CommandSequenceAssertion struct {
+	ResourceName string `json:"resourceName" validate:"required,edgex-dto-none-empty-string"`
+	Operator     string `json:"operator" validate:"required,oneof='==' '!=' '<' '<=' '>' '>='"`
+	Value        string `json:"value"`
+}
+
+// CommandSequenceExecution is the DTO of an execution of a command sequence with the result of every step executed
+// so far, which is recorded by core-command
+type CommandSequenceExecution struct {
+	dtos.DBTimestamp `json:",inline"`
+	Id               string                      `json:"id"`
+	SequenceName     string                      `json:"sequenceName"`
+	Origin           string                      `json:"origin"`
+	Actor            string                      `json:"actor"`
+	Status           string                      `json:"status"`
+	StartedAt        int64                       `json:"startedAt"`
+	EndedAt          int64                       `json:"endedAt,omitempty"`
+	Steps            []CommandSequenceStep       `json:"steps"`
+	RollbackSteps    []CommandSequenceStep       `json:"rollbackSteps,omitempty"`
+	StepResults      []CommandSequenceStepResult `json:"stepResults"`
+	RollbackResults  []CommandSequenceStepResult `json:"rollbackResults,omitempty"`
+	Message          string                      `json:"message,omitempty"`
+}
+
+// CommandSequenceStepResult is the DTO of the outcome of an executed step
+type CommandSequenceStepResult struct {
+	Index       int               `json:"index"`
+	Type        string            `json:"type"`
+	DeviceName  string            `json:"deviceName,omitempty"`
+	CommandName string            `json:"commandName,omitempty"`
+	Status      string            `json:"status"`
+	StatusCode  int               `json:"statusCode,omitempty"`
+	Message     string            `json:"message,omitempty"`
+	Readings    map[string]string `json:"readings,omitempty"`
+	StartedAt   int64             `json:"startedAt"`
+	EndedAt     int64             `json:"endedAt"`
+}
+
+// ToCommandSequenceModel transforms the CommandSequence DTO to the CommandSequence model
+func ToCommandSequenceModel(dto CommandSequence) pkgModels.CommandSequence {
+	return pkgModels.CommandSequence{
+		DBTimestamp:   models.DBTimestamp(dto.DBTimestamp),
+		Id:            dto.Id,
+		Name:          dto.Name,
+		Description:   dto.Description,
+		Steps:         toCommandSequenceStepModels(dto.Steps),
+		RollbackSteps: toCommandSequenceStepModels(dto.RollbackSteps),
+	}
+}
+
+// FromCommandSequenceModelToDTO transforms the CommandSequence model to the CommandSequence DTO
+func FromCommandSequenceModelToDTO(cs pkgModels.CommandSequence) CommandSequence {
+	return CommandSequence{
+		DBTimestamp:   dtos.DBTimestamp(cs.DBTimestamp),
+		Id:            cs.Id,
+		Name:          cs.Name,
+		Description:   cs.Description,
+		Steps:         fromCommandSequenceStepModelsToDTOs(cs.Steps),
+		RollbackSteps: fromCommandSequenceStepModelsToDTOs(cs.RollbackSteps),
+	}
+}
+
+// FromCommandSequenceExecutionModelToDTO transforms the CommandSequenceExecution model to the CommandSequenceExecution DTO
+func FromCommandSequenceExecutionModelToDTO(e pkgModels.CommandSequenceExecution) CommandSequenceExecution {
+	return CommandSequenceExecution{
+		DBTimestamp:     dtos.DBTimestamp(e.DBTimestamp),
+		Id:              e.Id,
+		SequenceName:    e.SequenceName,
+		Origin:          e.Origin,
+		Actor:           e.Actor,
+		Status:          e.Status,
+		StartedAt:       e.StartedAt,
+		EndedAt:         e.EndedAt,
+		Steps:           fromCommandSequenceStepModelsToDTOs(e.Steps),
+		RollbackSteps:   fromCommandSequenceStepModelsToDTOs(e.RollbackSteps),
+		StepResults:     fromCommandSequenceStepResultModelsToDTOs(e.StepResults),
+		RollbackResults: fromCommandSequenceStepResultModelsToDTOs(e.RollbackResults),
+		Message:         e.Message,
+	}
+}
+
+func toCommandSequenceStepModels(dtos []CommandSequenceStep) []pkgModels.CommandSequenceStep {
+	if dtos == nil {
+		return nil
+	}
+	steps := make([]pkgModels.CommandSequenceStep, len(dtos))
+	for i, dto := range dtos {
+		steps[i] = pkgModels.CommandSequenceStep{
+			Type:        dto.Type,
+			DeviceName:  dto.DeviceName,
+			CommandName: dto.CommandName,
+			Settings:    dto.Settings,
+			QueryParams: dto.QueryParams,
+			Duration:    dto.Duration,
+		}
+		for _, a := range dto.Assertions {
+			steps[i].Assertions = append(steps[i].Assertions, pkgModels.CommandSequenceAssertion(a))
+		}
+	}
+	return steps
+}
+
+func fromCommandSequenceStepModelsToDTOs(models []pkgModels.CommandSequenceStep) []CommandSequenceStep {
+	if models == nil {
+		return nil
+	}
+	steps := make([]CommandSequenceStep, len(models))
+	for i, s := range models {
+		steps[i] = CommandSequenceStep{
+			Type:        s.Type,
+			DeviceName:  s.DeviceName,
+			CommandName: s.CommandName,
+			Settings:    s.Settings,
+			QueryParams: s.QueryParams,
+			Duration:    s.Duration,
+		}
+		for _, a := range s.Assertions {
+			steps[i].Assertions = append(steps[i].Assertions, CommandSequenceAssertion(a))
+		}
+	}
+	return steps
+}
+
+func fromCommandSequenceStepResultModelsToDTOs(models []pkgModels.CommandSequenceStepResult) []CommandSequenceStepResult {
+	if models == nil {
+		return nil
+	}
+	results := make([]CommandSequenceStepResult, len(models))
+	for i, r := range models {
+		results[i] = CommandSequenceStepResult(r)
+	}
+	return results
+}
@@ -0,0 +1,36 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+// DeviceCoreCommand is the DTO of the core commands of a device, it extends the DeviceCoreCommand of
+// go-mod-core-contracts with the effective resource properties of the command parameters
+type DeviceCoreCommand struct {
+	DeviceName   string        `json:"deviceName" validate:"required,edgex-dto-none-empty-string"`
+	ProfileName  string        `json:"profileName" validate:"required,edgex-dto-none-empty-string"`
+	CoreCommands []CoreCommand `json:"coreCommands,omitempty" validate:"dive"`
+}
+
+// CoreCommand is the DTO of a core command, the parameters describe the effective device resources of the device
+type CoreCommand struct {
+	Name       string                 `json:"name" validate:"required,edgex-dto-none-empty-string"`
+	Get        bool                   `json:"get,omitempty" validate:"required_without=Set"`
+	Set        bool                   `json:"set,omitempty" validate:"required_without=Get"`
+	Path       string                 `json:"path,omitempty"`
+	Url        string                 `json:"url,omitempty"`
+	Parameters []CoreCommandParameter `json:"parameters,omitempty"`
+}
+
+// CoreCommandParameter describes a parameter of the core command with the properties of the device resource after
+// the resource overrides of the device are applied
+type CoreCommandParameter struct {
+	ResourceName string   `json:"resourceName"`
+	ValueType    string   `json:"valueType"`
+	Units        string   `json:"units,omitempty"`
+	Minimum      *float64 `json:"minimum,omitempty"`
+	Maximum      *float64 `json:"maximum,omitempty"`
+	Scale        *float64 `json:"scale,omitempty"`
+	Offset       *float64 `json:"offset,omitempty"`
+}
@@ -0,0 +1,43 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// DeviceLifecycleAudit is the DTO of a device lifecycle state transition record
+type DeviceLifecycleAudit struct {
+	Id         string `json:"id,omitempty"`
+	Created    int64  `json:"created,omitempty"`
+	DeviceName string `json:"deviceName"`
+	FromState  string `json:"fromState"`
+	ToState    string `json:"toState"`
+	Actor      string `json:"actor,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// FromDeviceLifecycleAuditModelToDTO transforms the DeviceLifecycleAudit Model to the DeviceLifecycleAudit DTO
+func FromDeviceLifecycleAuditModelToDTO(a models.DeviceLifecycleAudit) DeviceLifecycleAudit {
+	return DeviceLifecycleAudit{
+		Id:         a.Id,
+		Created:    a.Created,
+		DeviceName: a.DeviceName,
+		FromState:  a.FromState,
+		ToState:    a.ToState,
+		Actor:      a.Actor,
+		Reason:     a.Reason,
+	}
+}
+
+// FromDeviceLifecycleAuditModelsToDTOs transforms the DeviceLifecycleAudit Model array to the DeviceLifecycleAudit DTO array
+func FromDeviceLifecycleAuditModelsToDTOs(audits []models.DeviceLifecycleAudit) []DeviceLifecycleAudit {
+	dtos := make([]DeviceLifecycleAudit, len(audits))
+	for i, a := range audits {
+		dtos[i] = FromDeviceLifecycleAuditModelToDTO(a)
+	}
+	return dtos
+}
@@ -0,0 +1,19 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+// DeviceProfileFinding is the DTO of an issue found by linting a device profile or by comparing it with the stored
+// version. The Devices and ProvisionWatchers are the dependents affected by the issue.
+type DeviceProfileFinding struct {
+	Severity          string   `json:"severity"`
+	Code              string   `json:"code"`
+	Message           string   `json:"message"`
+	ResourceName      string   `json:"resourceName,omitempty"`
+	CommandName       string   `json:"commandName,omitempty"`
+	Devices           []string `json:"devices,omitempty"`
+	ProvisionWatchers []string `json:"provisionWatchers,omitempty"`
+	Blocking          bool     `json:"blocking"`
+}
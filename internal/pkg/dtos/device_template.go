@@ -0,0 +1,58 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// DeviceTemplate is the DTO of a partially filled device with ${name} placeholders. The device isn't validated as a
+// device DTO until it is rendered with the parameters, and Parameters lists the placeholders used by the device.
+type DeviceTemplate struct {
+	dtos.DBTimestamp `json:",inline"`
+	Id               string      `json:"id,omitempty" validate:"omitempty,uuid"`
+	Name             string      `json:"name" validate:"required,edgex-dto-none-empty-string,edgex-dto-rfc3986-unreserved-chars"`
+	Description      string      `json:"description,omitempty"`
+	Labels           []string    `json:"labels,omitempty"`
+	Device           dtos.Device `json:"device" validate:"-"`
+	Parameters       []string    `json:"parameters,omitempty"`
+}
+
+// ToDeviceTemplateModel transforms the DeviceTemplate DTO to the DeviceTemplate model
+func ToDeviceTemplateModel(dto DeviceTemplate) pkgModels.DeviceTemplate {
+	return pkgModels.DeviceTemplate{
+		DBTimestamp: models.DBTimestamp(dto.DBTimestamp),
+		Id:          dto.Id,
+		Name:        dto.Name,
+		Description: dto.Description,
+		Labels:      dto.Labels,
+		Device:      dtos.ToDeviceModel(dto.Device),
+	}
+}
+
+// FromDeviceTemplateModelToDTO transforms the DeviceTemplate model to the DeviceTemplate DTO
+func FromDeviceTemplateModelToDTO(t pkgModels.DeviceTemplate) DeviceTemplate {
+	// the placeholders can always be collected from a stored device template
+	parameters, _ := pkgModels.DeviceTemplatePlaceholders(t.Device)
+	return DeviceTemplate{
+		DBTimestamp: dtos.DBTimestamp(t.DBTimestamp),
+		Id:          t.Id,
+		Name:        t.Name,
+		Description: t.Description,
+		Labels:      t.Labels,
+		Device:      dtos.FromDeviceModelToDTO(t.Device),
+		Parameters:  parameters,
+	}
+}
+
+// DeviceTemplateInstance is the DTO describing a device instantiated from a device template
+type DeviceTemplateInstance struct {
+	DeviceName string            `json:"deviceName"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
@@ -0,0 +1,44 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+)
+
+// DeviceSelector selects the devices a group command is issued to. The devices must match all the specified
+// criteria, i.e. have all the labels, the profile and be one of the device names.
+type DeviceSelector struct {
+	Labels      []string `json:"labels,omitempty"`
+	ProfileName string   `json:"profileName,omitempty"`
+	DeviceNames []string `json:"deviceNames,omitempty"`
+}
+
+// IsEmpty returns whether the selector doesn't specify any criteria
+func (s DeviceSelector) IsEmpty() bool {
+	return len(s.Labels) == 0 && s.ProfileName == "" && len(s.DeviceNames) == 0
+}
+
+// GroupCommandResult is the result of a group command issued to one device. The Event is the reading event returned
+// by a get command, and the PendingCommandId is the pending command awaiting approval of a critical set command.
+type GroupCommandResult struct {
+	DeviceName       string      `json:"deviceName"`
+	StatusCode       int         `json:"statusCode"`
+	Message          string      `json:"message,omitempty"`
+	Event            *dtos.Event `json:"event,omitempty"`
+	PendingCommandId string      `json:"pendingCommandId,omitempty"`
+}
+
+// GroupCommandSummary summarizes the results of a group command. PendingApproval counts the devices whose critical set
+// command awaits approval, and FailuresByStatusCode counts the failed devices by the status code of their results.
+type GroupCommandSummary struct {
+	Total                int         `json:"total"`
+	Succeeded            int         `json:"succeeded"`
+	Failed               int         `json:"failed"`
+	PendingApproval      int         `json:"pendingApproval,omitempty"`
+	FailedDevices        []string    `json:"failedDevices,omitempty"`
+	FailuresByStatusCode map[int]int `json:"failuresByStatusCode,omitempty"`
+}
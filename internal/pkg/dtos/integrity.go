@@ -0,0 +1,58 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// IndexInconsistency is the DTO of a problem found in a secondary index of a metadata collection
+type IndexInconsistency struct {
+	EntityType  string `json:"entityType"`
+	Index       string `json:"index"`
+	Member      string `json:"member"`
+	Problem     string `json:"problem"`
+	Description string `json:"description"`
+}
+
+// DanglingReference is the DTO of a reference from a metadata entity to another entity which doesn't exist
+type DanglingReference struct {
+	EntityType string `json:"entityType"`
+	Name       string `json:"name"`
+	Field      string `json:"field"`
+	Reference  string `json:"reference"`
+}
+
+// MetadataIntegrityReport reports the dangling references between the metadata entities and the inconsistencies of
+// the secondary indexes. Repaired reports whether the indexes were rebuilt, the dangling references are never repaired
+// as they need the missing entities to be added back or the referring entities to be updated.
+type MetadataIntegrityReport struct {
+	Entities             map[string]uint32    `json:"entities"`
+	DanglingReferences   []DanglingReference  `json:"danglingReferences"`
+	IndexInconsistencies []IndexInconsistency `json:"indexInconsistencies"`
+	Repaired             bool                 `json:"repaired"`
+}
+
+// FromIndexInconsistencyModelToDTO transforms the IndexInconsistency Model to the IndexInconsistency DTO
+func FromIndexInconsistencyModelToDTO(i models.IndexInconsistency) IndexInconsistency {
+	return IndexInconsistency{
+		EntityType:  i.EntityType,
+		Index:       i.Index,
+		Member:      i.Member,
+		Problem:     i.Problem,
+		Description: i.Description,
+	}
+}
+
+// FromDanglingReferenceModelToDTO transforms the DanglingReference Model to the DanglingReference DTO
+func FromDanglingReferenceModelToDTO(r models.DanglingReference) DanglingReference {
+	return DanglingReference{
+		EntityType: r.EntityType,
+		Name:       r.Name,
+		Field:      r.Field,
+		Reference:  r.Reference,
+	}
+}
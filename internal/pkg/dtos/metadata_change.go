@@ -0,0 +1,89 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	contractsModels "github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// MetadataChange is the DTO of a metadata change, the Entity is the DTO of the changed entity
+type MetadataChange struct {
+	Id       string `json:"id"`
+	Sequence uint64 `json:"sequence"`
+	Created  int64  `json:"created"`
+	Type     string `json:"type"`
+	Action   string `json:"action"`
+	Name     string `json:"name"`
+	Entity   any    `json:"entity,omitempty"`
+}
+
+// FromMetadataChangeModelToDTO transforms the MetadataChange Model to the MetadataChange DTO
+func FromMetadataChangeModelToDTO(c models.MetadataChange) (MetadataChange, error) {
+	dto := MetadataChange{
+		Id:       c.Id,
+		Sequence: c.Sequence,
+		Created:  c.Created,
+		Type:     c.Type,
+		Action:   c.Action,
+		Name:     c.Name,
+	}
+	if len(c.Entity) == 0 {
+		return dto, nil
+	}
+
+	var err error
+	dto.Entity, err = entityModelToDTO(c.Type, c.Entity)
+	if err != nil {
+		return dto, fmt.Errorf("failed to decode the %s of metadata change %d: %w", c.Type, c.Sequence, err)
+	}
+	return dto, nil
+}
+
+// entityModelToDTO decodes the stored JSON representation of a metadata entity and transforms it to the DTO
+func entityModelToDTO(entityType string, entity json.RawMessage) (any, error) {
+	switch entityType {
+	case common.DeviceSystemEventType:
+		var d contractsModels.Device
+		if err := json.Unmarshal(entity, &d); err != nil {
+			return nil, err
+		}
+		return dtos.FromDeviceModelToDTO(d), nil
+	case common.DeviceProfileSystemEventType:
+		var dp contractsModels.DeviceProfile
+		if err := json.Unmarshal(entity, &dp); err != nil {
+			return nil, err
+		}
+		return dtos.FromDeviceProfileModelToDTO(dp), nil
+	case common.DeviceServiceSystemEventType:
+		var ds contractsModels.DeviceService
+		if err := json.Unmarshal(entity, &ds); err != nil {
+			return nil, err
+		}
+		return dtos.FromDeviceServiceModelToDTO(ds), nil
+	case common.ProvisionWatcherSystemEventType:
+		var pw contractsModels.ProvisionWatcher
+		if err := json.Unmarshal(entity, &pw); err != nil {
+			return nil, err
+		}
+		return dtos.FromProvisionWatcherModelToDTO(pw), nil
+	case pkgCommon.DeviceTemplate:
+		var t models.DeviceTemplate
+		if err := json.Unmarshal(entity, &t); err != nil {
+			return nil, err
+		}
+		return FromDeviceTemplateModelToDTO(t), nil
+	default:
+		return nil, fmt.Errorf("unknown metadata entity type '%s'", entityType)
+	}
+}
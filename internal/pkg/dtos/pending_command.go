@@ -0,0 +1,51 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// PendingCommand is the DTO of a critical set command awaiting the approval of another user than the requester, which
+// is created by core-command when the set command is requested
+type PendingCommand struct {
+	dtos.DBTimestamp `json:",inline"`
+	Id               string            `json:"id"`
+	DeviceName       string            `json:"deviceName"`
+	CommandName      string            `json:"commandName"`
+	Settings         map[string]any    `json:"settings"`
+	QueryParams      map[string]string `json:"queryParams,omitempty"`
+	Origin           string            `json:"origin"`
+	RequestedBy      string            `json:"requestedBy"`
+	ExpiresAt        int64             `json:"expiresAt"`
+	Status           string            `json:"status"`
+	ReviewedBy       string            `json:"reviewedBy,omitempty"`
+	ReviewedAt       int64             `json:"reviewedAt,omitempty"`
+	StatusCode       int               `json:"statusCode,omitempty"`
+	Message          string            `json:"message,omitempty"`
+}
+
+// FromPendingCommandModelToDTO transforms the PendingCommand model to the PendingCommand DTO
+func FromPendingCommandModelToDTO(pc pkgModels.PendingCommand) PendingCommand {
+	return PendingCommand{
+		DBTimestamp: dtos.DBTimestamp(pc.DBTimestamp),
+		Id:          pc.Id,
+		DeviceName:  pc.DeviceName,
+		CommandName: pc.CommandName,
+		Settings:    pc.Settings,
+		QueryParams: pc.QueryParams,
+		Origin:      pc.Origin,
+		RequestedBy: pc.RequestedBy,
+		ExpiresAt:   pc.ExpiresAt,
+		Status:      pc.Status,
+		ReviewedBy:  pc.ReviewedBy,
+		ReviewedAt:  pc.ReviewedAt,
+		StatusCode:  pc.StatusCode,
+		Message:     pc.Message,
+	}
+}
@@ -0,0 +1,34 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// PendingDevice is the DTO of a discovered device waiting for approval
+type PendingDevice struct {
+	dtos.DBTimestamp     `json:",inline"`
+	Id                   string      `json:"id"`
+	Status               string      `json:"status"`
+	ProvisionWatcherName string      `json:"provisionWatcherName"`
+	LastDiscovered       int64       `json:"lastDiscovered"`
+	Device               dtos.Device `json:"device"`
+}
+
+// FromPendingDeviceModelToDTO transforms the PendingDevice Model to the PendingDevice DTO
+func FromPendingDeviceModelToDTO(pd models.PendingDevice) PendingDevice {
+	return PendingDevice{
+		DBTimestamp:          dtos.DBTimestamp(pd.DBTimestamp),
+		Id:                   pd.Id,
+		Status:               pd.Status,
+		ProvisionWatcherName: pd.ProvisionWatcherName,
+		LastDiscovered:       pd.LastDiscovered,
+		Device:               dtos.FromDeviceModelToDTO(pd.Device),
+	}
+}
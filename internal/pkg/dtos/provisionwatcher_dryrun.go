@@ -0,0 +1,29 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+)
+
+// CandidateDevice is the DTO of a device as reported by a device service discovery, which is matched against the
+// provision watchers without being created
+type CandidateDevice struct {
+	Name        string                             `json:"name" validate:"required,edgex-dto-none-empty-string"`
+	Description string                             `json:"description,omitempty"`
+	Labels      []string                           `json:"labels,omitempty"`
+	Protocols   map[string]dtos.ProtocolProperties `json:"protocols" validate:"required,gt=0"`
+	Properties  map[string]any                     `json:"properties,omitempty"`
+}
+
+// ProvisionWatcherMatch is the DTO describing whether a provision watcher matches or blocks a candidate device
+type ProvisionWatcherMatch struct {
+	Name        string   `json:"name"`
+	ServiceName string   `json:"serviceName"`
+	Matched     bool     `json:"matched"`
+	Blocked     bool     `json:"blocked"`
+	Reasons     []string `json:"reasons,omitempty"`
+}
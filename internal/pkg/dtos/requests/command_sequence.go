@@ -0,0 +1,47 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package requests
+
+import (
+	"encoding/json"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+)
+
+// CommandSequenceRequest defines the Request Content for POST and PUT CommandSequence DTO.
+type CommandSequenceRequest struct {
+	dtoCommon.BaseRequest `json:",inline"`
+	CommandSequence       dtos.CommandSequence `json:"commandSequence"`
+}
+
+// Validate satisfies the Validator interface
+func (r *CommandSequenceRequest) Validate() error {
+	err := common.Validate(r)
+	return err
+}
+
+// UnmarshalJSON implements the Unmarshaler interface for the CommandSequenceRequest type
+func (r *CommandSequenceRequest) UnmarshalJSON(b []byte) error {
+	var alias struct {
+		dtoCommon.BaseRequest
+		CommandSequence dtos.CommandSequence
+	}
+	if err := json.Unmarshal(b, &alias); err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "Failed to unmarshal request body as JSON.", err)
+	}
+
+	*r = CommandSequenceRequest(alias)
+
+	// validate CommandSequenceRequest DTO
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
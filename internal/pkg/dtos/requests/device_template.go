@@ -0,0 +1,88 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package requests
+
+import (
+	"encoding/json"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// DeviceTemplateRequest defines the Request Content for POST and PUT DeviceTemplate DTO.
+type DeviceTemplateRequest struct {
+	dtoCommon.BaseRequest `json:",inline"`
+	DeviceTemplate        dtos.DeviceTemplate `json:"deviceTemplate"`
+}
+
+// Validate satisfies the Validator interface
+func (r *DeviceTemplateRequest) Validate() error {
+	err := common.Validate(r)
+	return err
+}
+
+// UnmarshalJSON implements the Unmarshaler interface for the DeviceTemplateRequest type
+func (r *DeviceTemplateRequest) UnmarshalJSON(b []byte) error {
+	var alias struct {
+		dtoCommon.BaseRequest
+		DeviceTemplate dtos.DeviceTemplate
+	}
+	if err := json.Unmarshal(b, &alias); err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "Failed to unmarshal request body as JSON.", err)
+	}
+
+	*r = DeviceTemplateRequest(alias)
+
+	// validate DeviceTemplateRequest DTO
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeviceTemplateReqToDeviceTemplateModels transforms the DeviceTemplateRequest DTO array to the DeviceTemplate model array
+func DeviceTemplateReqToDeviceTemplateModels(reqs []DeviceTemplateRequest) (templates []models.DeviceTemplate) {
+	for _, req := range reqs {
+		templates = append(templates, dtos.ToDeviceTemplateModel(req.DeviceTemplate))
+	}
+	return templates
+}
+
+// InstantiateDeviceTemplateRequest defines the Request Content for instantiating devices from a device template,
+// each entry of the Parameters table creates a device
+type InstantiateDeviceTemplateRequest struct {
+	dtoCommon.BaseRequest `json:",inline"`
+	Parameters            []map[string]string `json:"parameters" validate:"gt=0"`
+}
+
+// Validate satisfies the Validator interface
+func (r *InstantiateDeviceTemplateRequest) Validate() error {
+	err := common.Validate(r)
+	return err
+}
+
+// UnmarshalJSON implements the Unmarshaler interface for the InstantiateDeviceTemplateRequest type
+func (r *InstantiateDeviceTemplateRequest) UnmarshalJSON(b []byte) error {
+	var alias struct {
+		dtoCommon.BaseRequest
+		Parameters []map[string]string
+	}
+	if err := json.Unmarshal(b, &alias); err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "Failed to unmarshal request body as JSON.", err)
+	}
+
+	*r = InstantiateDeviceTemplateRequest(alias)
+
+	// validate InstantiateDeviceTemplateRequest DTO
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
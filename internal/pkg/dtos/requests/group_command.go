@@ -0,0 +1,76 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package requests
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+)
+
+// Constants related to the methods of the group commands
+const (
+	GroupCommandMethodGet = "get"
+	GroupCommandMethodSet = "set"
+)
+
+// GroupCommandRequest defines the Request Content for issuing a get or set command to every selected device.
+// The Settings are required by a set command, and the QueryParams are passed to the device services as is.
+type GroupCommandRequest struct {
+	dtoCommon.BaseRequest `json:",inline"`
+	Selector              dtos.DeviceSelector `json:"selector"`
+	CommandName           string              `json:"commandName" validate:"required,edgex-dto-none-empty-string"`
+	Method                string              `json:"method" validate:"required,oneof='get' 'set'"`
+	Settings              map[string]any      `json:"settings,omitempty"`
+	QueryParams           map[string]string   `json:"queryParams,omitempty"`
+}
+
+// Validate satisfies the Validator interface
+func (r *GroupCommandRequest) Validate() error {
+	if err := common.Validate(r); err != nil {
+		return err
+	}
+	if r.Selector.IsEmpty() {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "the selector must specify the labels, the profileName or the deviceNames", nil)
+	}
+	if r.Method == GroupCommandMethodSet && len(r.Settings) == 0 {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "the settings of a set command must not be empty", nil)
+	}
+	for _, param := range []string{common.ReturnEvent, common.PushEvent} {
+		if value, ok := r.QueryParams[param]; ok && value != common.ValueTrue && value != common.ValueFalse {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid query parameter, %s has to be %s or %s", param, common.ValueTrue, common.ValueFalse), nil)
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON implements the Unmarshaler interface for the GroupCommandRequest type
+func (r *GroupCommandRequest) UnmarshalJSON(b []byte) error {
+	var alias struct {
+		dtoCommon.BaseRequest
+		Selector    dtos.DeviceSelector
+		CommandName string
+		Method      string
+		Settings    map[string]any
+		QueryParams map[string]string
+	}
+	if err := json.Unmarshal(b, &alias); err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "Failed to unmarshal request body as JSON.", err)
+	}
+
+	*r = GroupCommandRequest(alias)
+
+	// validate GroupCommandRequest DTO
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
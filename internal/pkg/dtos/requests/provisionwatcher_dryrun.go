@@ -0,0 +1,50 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package requests
+
+import (
+	"encoding/json"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+)
+
+// ProvisionWatcherDryRunRequest defines the Request Content for POST ProvisionWatcher dry-run DTO.
+// The ServiceName is optional and limits the simulation to the provision watchers of the device service.
+type ProvisionWatcherDryRunRequest struct {
+	dtoCommon.BaseRequest `json:",inline"`
+	ServiceName           string               `json:"serviceName,omitempty"`
+	DiscoveredDevice      dtos.CandidateDevice `json:"discoveredDevice"`
+}
+
+// Validate satisfies the Validator interface
+func (r *ProvisionWatcherDryRunRequest) Validate() error {
+	err := common.Validate(r)
+	return err
+}
+
+// UnmarshalJSON implements the Unmarshaler interface for the ProvisionWatcherDryRunRequest type
+func (r *ProvisionWatcherDryRunRequest) UnmarshalJSON(b []byte) error {
+	var alias struct {
+		dtoCommon.BaseRequest
+		ServiceName      string
+		DiscoveredDevice dtos.CandidateDevice
+	}
+	if err := json.Unmarshal(b, &alias); err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "Failed to unmarshal request body as JSON.", err)
+	}
+
+	*r = ProvisionWatcherDryRunRequest(alias)
+
+	// validate ProvisionWatcherDryRunRequest DTO
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
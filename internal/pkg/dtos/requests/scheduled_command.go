@@ -0,0 +1,53 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package requests
+
+import (
+	"encoding/json"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+)
+
+// AddScheduledCommandRequest defines the Request Content for POST ScheduledCommand DTO. The command must specify
+// either the ExecuteAt time or the Delay.
+type AddScheduledCommandRequest struct {
+	dtoCommon.BaseRequest `json:",inline"`
+	ScheduledCommand      dtos.ScheduledCommand `json:"scheduledCommand"`
+}
+
+// Validate satisfies the Validator interface
+func (r *AddScheduledCommandRequest) Validate() error {
+	if err := common.Validate(r); err != nil {
+		return err
+	}
+	if (r.ScheduledCommand.ExecuteAt == 0) == (r.ScheduledCommand.Delay == "") {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "the scheduled command must specify either the executeAt or the delay", nil)
+	}
+	return nil
+}
+
+// UnmarshalJSON implements the Unmarshaler interface for the AddScheduledCommandRequest type
+func (r *AddScheduledCommandRequest) UnmarshalJSON(b []byte) error {
+	var alias struct {
+		dtoCommon.BaseRequest
+		ScheduledCommand dtos.ScheduledCommand
+	}
+	if err := json.Unmarshal(b, &alias); err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "Failed to unmarshal request body as JSON.", err)
+	}
+
+	*r = AddScheduledCommandRequest(alias)
+
+	// validate AddScheduledCommandRequest DTO
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package requests
+
+import (
+	"encoding/json"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// UnitOfMeasureCategoryRequest defines the Request Content for POST and PUT UnitOfMeasureCategory DTO.
+type UnitOfMeasureCategoryRequest struct {
+	dtoCommon.BaseRequest `json:",inline"`
+	Category              dtos.UnitOfMeasureCategory `json:"category"`
+}
+
+// Validate satisfies the Validator interface
+func (r *UnitOfMeasureCategoryRequest) Validate() error {
+	err := common.Validate(r)
+	return err
+}
+
+// UnmarshalJSON implements the Unmarshaler interface for the UnitOfMeasureCategoryRequest type
+func (r *UnitOfMeasureCategoryRequest) UnmarshalJSON(b []byte) error {
+	var alias struct {
+		dtoCommon.BaseRequest
+		Category dtos.UnitOfMeasureCategory
+	}
+	if err := json.Unmarshal(b, &alias); err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "Failed to unmarshal request body as JSON.", err)
+	}
+
+	*r = UnitOfMeasureCategoryRequest(alias)
+
+	// validate UnitOfMeasureCategoryRequest DTO
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UnitOfMeasureCategoryReqToUnitOfMeasureCategoryModels transforms the UnitOfMeasureCategoryRequest DTO array to the
+// UnitOfMeasureCategory model array
+func UnitOfMeasureCategoryReqToUnitOfMeasureCategoryModels(reqs []UnitOfMeasureCategoryRequest) (categories []models.UnitOfMeasureCategory) {
+	for _, req := range reqs {
+		categories = append(categories, dtos.ToUnitOfMeasureCategoryModel(req.Category))
+	}
+	return categories
+}
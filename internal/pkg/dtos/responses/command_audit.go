@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+)
+
+// MultiCommandAuditEntriesResponse defines the Response Content for GET multiple command audit entries
+type MultiCommandAuditEntriesResponse struct {
+	common.BaseWithTotalCountResponse `json:",inline"`
+	Entries                           []dtos.CommandAuditEntry `json:"entries"`
+}
+
+func NewMultiCommandAuditEntriesResponse(requestId string, message string, statusCode int, totalCount uint32, entries []dtos.CommandAuditEntry) MultiCommandAuditEntriesResponse {
+	return MultiCommandAuditEntriesResponse{
+		BaseWithTotalCountResponse: common.NewBaseWithTotalCountResponse(requestId, message, statusCode, totalCount),
+		Entries:                    entries,
+	}
+}
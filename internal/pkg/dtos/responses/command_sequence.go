@@ -0,0 +1,64 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+)
+
+// CommandSequenceResponse defines the Response Content for GET a command sequence
+type CommandSequenceResponse struct {
+	common.BaseResponse `json:",inline"`
+	CommandSequence     dtos.CommandSequence `json:"commandSequence"`
+}
+
+func NewCommandSequenceResponse(requestId string, message string, statusCode int, commandSequence dtos.CommandSequence) CommandSequenceResponse {
+	return CommandSequenceResponse{
+		BaseResponse:    common.NewBaseResponse(requestId, message, statusCode),
+		CommandSequence: commandSequence,
+	}
+}
+
+// MultiCommandSequencesResponse defines the Response Content for GET multiple command sequences
+type MultiCommandSequencesResponse struct {
+	common.BaseWithTotalCountResponse `json:",inline"`
+	CommandSequences                  []dtos.CommandSequence `json:"commandSequences"`
+}
+
+func NewMultiCommandSequencesResponse(requestId string, message string, statusCode int, totalCount uint32, commandSequences []dtos.CommandSequence) MultiCommandSequencesResponse {
+	return MultiCommandSequencesResponse{
+		BaseWithTotalCountResponse: common.NewBaseWithTotalCountResponse(requestId, message, statusCode, totalCount),
+		CommandSequences:           commandSequences,
+	}
+}
+
+// CommandSequenceExecutionResponse defines the Response Content for GET a command sequence execution
+type CommandSequenceExecutionResponse struct {
+	common.BaseResponse `json:",inline"`
+	Execution           dtos.CommandSequenceExecution `json:"execution"`
+}
+
+func NewCommandSequenceExecutionResponse(requestId string, message string, statusCode int, execution dtos.CommandSequenceExecution) CommandSequenceExecutionResponse {
+	return CommandSequenceExecutionResponse{
+		BaseResponse: common.NewBaseResponse(requestId, message, statusCode),
+		Execution:    execution,
+	}
+}
+
+// MultiCommandSequenceExecutionsResponse defines the Response Content for GET multiple command sequence executions
+type MultiCommandSequenceExecutionsResponse struct {
+	common.BaseWithTotalCountResponse `json:",inline"`
+	Executions                        []dtos.CommandSequenceExecution `json:"executions"`
+}
+
+func NewMultiCommandSequenceExecutionsResponse(requestId string, message string, statusCode int, totalCount uint32, executions []dtos.CommandSequenceExecution) MultiCommandSequenceExecutionsResponse {
+	return MultiCommandSequenceExecutionsResponse{
+		BaseWithTotalCountResponse: common.NewBaseWithTotalCountResponse(requestId, message, statusCode, totalCount),
+		Executions:                 executions,
+	}
+}
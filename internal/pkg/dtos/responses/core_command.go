@@ -0,0 +1,38 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+)
+
+// DeviceCoreCommandResponse defines the Response Content for GET DeviceCoreCommand DTO.
+type DeviceCoreCommandResponse struct {
+	common.BaseResponse `json:",inline"`
+	DeviceCoreCommand   pkgDtos.DeviceCoreCommand `json:"deviceCoreCommand"`
+}
+
+func NewDeviceCoreCommandResponse(requestId string, message string, statusCode int, deviceCoreCommand pkgDtos.DeviceCoreCommand) DeviceCoreCommandResponse {
+	return DeviceCoreCommandResponse{
+		BaseResponse:      common.NewBaseResponse(requestId, message, statusCode),
+		DeviceCoreCommand: deviceCoreCommand,
+	}
+}
+
+// MultiDeviceCoreCommandsResponse defines the Response Content for GET multiple DeviceCoreCommand DTOs.
+type MultiDeviceCoreCommandsResponse struct {
+	common.BaseWithTotalCountResponse `json:",inline"`
+	DeviceCoreCommands                []pkgDtos.DeviceCoreCommand `json:"deviceCoreCommands"`
+}
+
+func NewMultiDeviceCoreCommandsResponse(requestId string, message string, statusCode int, totalCount uint32, commands []pkgDtos.DeviceCoreCommand) MultiDeviceCoreCommandsResponse {
+	return MultiDeviceCoreCommandsResponse{
+		BaseWithTotalCountResponse: common.NewBaseWithTotalCountResponse(requestId, message, statusCode, totalCount),
+		DeviceCoreCommands:         commands,
+	}
+}
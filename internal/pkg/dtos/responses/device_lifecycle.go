@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+)
+
+// MultiDeviceLifecycleAuditsResponse defines the Response Content for GET multiple DeviceLifecycleAudit DTOs.
+type MultiDeviceLifecycleAuditsResponse struct {
+	common.BaseWithTotalCountResponse `json:",inline"`
+	Audits                            []dtos.DeviceLifecycleAudit `json:"audits"`
+}
+
+func NewMultiDeviceLifecycleAuditsResponse(requestId string, message string, statusCode int, totalCount uint32, audits []dtos.DeviceLifecycleAudit) MultiDeviceLifecycleAuditsResponse {
+	return MultiDeviceLifecycleAuditsResponse{
+		BaseWithTotalCountResponse: common.NewBaseWithTotalCountResponse(requestId, message, statusCode, totalCount),
+		Audits:                     audits,
+	}
+}
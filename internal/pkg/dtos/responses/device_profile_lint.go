@@ -0,0 +1,28 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+)
+
+// DeviceProfileLintResponse defines the Response Content for the device profile validation, Blocked indicates whether
+// an update with the profile would be rejected by the current blocking policy.
+type DeviceProfileLintResponse struct {
+	common.BaseResponse `json:",inline"`
+	Findings            []pkgDtos.DeviceProfileFinding `json:"findings"`
+	Blocked             bool                           `json:"blocked"`
+}
+
+func NewDeviceProfileLintResponse(requestId string, message string, statusCode int, findings []pkgDtos.DeviceProfileFinding, blocked bool) DeviceProfileLintResponse {
+	return DeviceProfileLintResponse{
+		BaseResponse: common.NewBaseResponse(requestId, message, statusCode),
+		Findings:     findings,
+		Blocked:      blocked,
+	}
+}
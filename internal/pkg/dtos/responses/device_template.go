@@ -0,0 +1,64 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+)
+
+// DeviceTemplateResponse defines the Response Content for GET DeviceTemplate DTO.
+type DeviceTemplateResponse struct {
+	common.BaseResponse `json:",inline"`
+	DeviceTemplate      pkgDtos.DeviceTemplate `json:"deviceTemplate"`
+}
+
+func NewDeviceTemplateResponse(requestId string, message string, statusCode int, template pkgDtos.DeviceTemplate) DeviceTemplateResponse {
+	return DeviceTemplateResponse{
+		BaseResponse:   common.NewBaseResponse(requestId, message, statusCode),
+		DeviceTemplate: template,
+	}
+}
+
+// MultiDeviceTemplatesResponse defines the Response Content for GET multiple DeviceTemplate DTOs.
+type MultiDeviceTemplatesResponse struct {
+	common.BaseWithTotalCountResponse `json:",inline"`
+	DeviceTemplates                   []pkgDtos.DeviceTemplate `json:"deviceTemplates"`
+}
+
+func NewMultiDeviceTemplatesResponse(requestId string, message string, statusCode int, totalCount uint32, templates []pkgDtos.DeviceTemplate) MultiDeviceTemplatesResponse {
+	return MultiDeviceTemplatesResponse{
+		BaseWithTotalCountResponse: common.NewBaseWithTotalCountResponse(requestId, message, statusCode, totalCount),
+		DeviceTemplates:            templates,
+	}
+}
+
+// MultiDeviceTemplateInstancesResponse defines the Response Content for GET the devices instantiated from a DeviceTemplate.
+type MultiDeviceTemplateInstancesResponse struct {
+	common.BaseWithTotalCountResponse `json:",inline"`
+	Instances                         []pkgDtos.DeviceTemplateInstance `json:"instances"`
+}
+
+func NewMultiDeviceTemplateInstancesResponse(requestId string, message string, statusCode int, totalCount uint32, instances []pkgDtos.DeviceTemplateInstance) MultiDeviceTemplateInstancesResponse {
+	return MultiDeviceTemplateInstancesResponse{
+		BaseWithTotalCountResponse: common.NewBaseWithTotalCountResponse(requestId, message, statusCode, totalCount),
+		Instances:                  instances,
+	}
+}
+
+// DeviceTemplateRolloutResponse defines the Response Content for applying a DeviceTemplate change to one of its instances.
+type DeviceTemplateRolloutResponse struct {
+	common.BaseResponse `json:",inline"`
+	DeviceName          string `json:"deviceName"`
+}
+
+func NewDeviceTemplateRolloutResponse(requestId string, message string, statusCode int, deviceName string) DeviceTemplateRolloutResponse {
+	return DeviceTemplateRolloutResponse{
+		BaseResponse: common.NewBaseResponse(requestId, message, statusCode),
+		DeviceName:   deviceName,
+	}
+}
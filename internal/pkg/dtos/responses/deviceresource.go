@@ -0,0 +1,24 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+)
+
+// MultiDeviceResourcesResponse defines the Response Content for GET multiple DeviceResource DTOs.
+type MultiDeviceResourcesResponse struct {
+	common.BaseWithTotalCountResponse `json:",inline"`
+	Resources                         []dtos.DeviceResource `json:"resources"`
+}
+
+func NewMultiDeviceResourcesResponse(requestId string, message string, statusCode int, totalCount uint32, resources []dtos.DeviceResource) MultiDeviceResourcesResponse {
+	return MultiDeviceResourcesResponse{
+		BaseWithTotalCountResponse: common.NewBaseWithTotalCountResponse(requestId, message, statusCode, totalCount),
+		Resources:                  resources,
+	}
+}
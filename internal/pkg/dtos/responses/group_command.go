@@ -0,0 +1,27 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+)
+
+// GroupCommandResponse defines the Response Content for issuing a group command, with the result of every device
+type GroupCommandResponse struct {
+	common.BaseResponse `json:",inline"`
+	Summary             dtos.GroupCommandSummary  `json:"summary"`
+	Results             []dtos.GroupCommandResult `json:"results"`
+}
+
+func NewGroupCommandResponse(requestId string, message string, statusCode int, summary dtos.GroupCommandSummary, results []dtos.GroupCommandResult) GroupCommandResponse {
+	return GroupCommandResponse{
+		BaseResponse: common.NewBaseResponse(requestId, message, statusCode),
+		Summary:      summary,
+		Results:      results,
+	}
+}
@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+)
+
+// MetadataIntegrityReportResponse defines the Response Content for checking and repairing the metadata integrity
+type MetadataIntegrityReportResponse struct {
+	common.BaseResponse `json:",inline"`
+	Report              dtos.MetadataIntegrityReport `json:"report"`
+}
+
+func NewMetadataIntegrityReportResponse(requestId string, message string, statusCode int, report dtos.MetadataIntegrityReport) MetadataIntegrityReportResponse {
+	return MetadataIntegrityReportResponse{
+		BaseResponse: common.NewBaseResponse(requestId, message, statusCode),
+		Report:       report,
+	}
+}
@@ -0,0 +1,28 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+)
+
+// MetadataChangesResponse defines the Response Content for GET metadata changes. The Cursor is the value of the
+// since query parameter to request the changes following the returned ones.
+type MetadataChangesResponse struct {
+	common.BaseResponse `json:",inline"`
+	Changes             []dtos.MetadataChange `json:"changes"`
+	Cursor              uint64                `json:"cursor"`
+}
+
+func NewMetadataChangesResponse(requestId string, message string, statusCode int, changes []dtos.MetadataChange, cursor uint64) MetadataChangesResponse {
+	return MetadataChangesResponse{
+		BaseResponse: common.NewBaseResponse(requestId, message, statusCode),
+		Changes:      changes,
+		Cursor:       cursor,
+	}
+}
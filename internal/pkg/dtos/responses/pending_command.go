@@ -0,0 +1,38 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+)
+
+// PendingCommandResponse defines the Response Content for GET a pending command
+type PendingCommandResponse struct {
+	common.BaseResponse `json:",inline"`
+	PendingCommand      dtos.PendingCommand `json:"pendingCommand"`
+}
+
+func NewPendingCommandResponse(requestId string, message string, statusCode int, pendingCommand dtos.PendingCommand) PendingCommandResponse {
+	return PendingCommandResponse{
+		BaseResponse:   common.NewBaseResponse(requestId, message, statusCode),
+		PendingCommand: pendingCommand,
+	}
+}
+
+// MultiPendingCommandsResponse defines the Response Content for GET multiple pending commands
+type MultiPendingCommandsResponse struct {
+	common.BaseWithTotalCountResponse `json:",inline"`
+	PendingCommands                   []dtos.PendingCommand `json:"pendingCommands"`
+}
+
+func NewMultiPendingCommandsResponse(requestId string, message string, statusCode int, totalCount uint32, pendingCommands []dtos.PendingCommand) MultiPendingCommandsResponse {
+	return MultiPendingCommandsResponse{
+		BaseWithTotalCountResponse: common.NewBaseWithTotalCountResponse(requestId, message, statusCode, totalCount),
+		PendingCommands:            pendingCommands,
+	}
+}
@@ -0,0 +1,38 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+)
+
+// PendingDeviceResponse defines the Response Content for GET a pending device
+type PendingDeviceResponse struct {
+	common.BaseResponse `json:",inline"`
+	PendingDevice       dtos.PendingDevice `json:"pendingDevice"`
+}
+
+func NewPendingDeviceResponse(requestId string, message string, statusCode int, pendingDevice dtos.PendingDevice) PendingDeviceResponse {
+	return PendingDeviceResponse{
+		BaseResponse:  common.NewBaseResponse(requestId, message, statusCode),
+		PendingDevice: pendingDevice,
+	}
+}
+
+// MultiPendingDevicesResponse defines the Response Content for GET multiple pending devices
+type MultiPendingDevicesResponse struct {
+	common.BaseWithTotalCountResponse `json:",inline"`
+	PendingDevices                    []dtos.PendingDevice `json:"pendingDevices"`
+}
+
+func NewMultiPendingDevicesResponse(requestId string, message string, statusCode int, totalCount uint32, pendingDevices []dtos.PendingDevice) MultiPendingDevicesResponse {
+	return MultiPendingDevicesResponse{
+		BaseWithTotalCountResponse: common.NewBaseWithTotalCountResponse(requestId, message, statusCode, totalCount),
+		PendingDevices:             pendingDevices,
+	}
+}
@@ -0,0 +1,35 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+)
+
+// ProvisionWatcherDryRunResponse defines the Response Content for POST ProvisionWatcher dry-run DTO. The Device is the
+// device which would be created by the first matching provision watcher, it is omitted if no provision watcher
+// matches or if a device with the same name already exists.
+type ProvisionWatcherDryRunResponse struct {
+	common.BaseResponse     `json:",inline"`
+	ProvisionWatchers       []pkgDtos.ProvisionWatcherMatch `json:"provisionWatchers"`
+	MatchedProvisionWatcher string                          `json:"matchedProvisionWatcher,omitempty"`
+	DeviceExists            bool                            `json:"deviceExists"`
+	Device                  *dtos.Device                    `json:"device,omitempty"`
+}
+
+func NewProvisionWatcherDryRunResponse(requestId string, message string, statusCode int,
+	matches []pkgDtos.ProvisionWatcherMatch, matchedProvisionWatcher string, deviceExists bool, device *dtos.Device) ProvisionWatcherDryRunResponse {
+	return ProvisionWatcherDryRunResponse{
+		BaseResponse:            common.NewBaseResponse(requestId, message, statusCode),
+		ProvisionWatchers:       matches,
+		MatchedProvisionWatcher: matchedProvisionWatcher,
+		DeviceExists:            deviceExists,
+		Device:                  device,
+	}
+}
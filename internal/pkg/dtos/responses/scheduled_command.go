@@ -0,0 +1,38 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+)
+
+// ScheduledCommandResponse defines the Response Content for GET a scheduled command
+type ScheduledCommandResponse struct {
+	common.BaseResponse `json:",inline"`
+	ScheduledCommand    dtos.ScheduledCommand `json:"scheduledCommand"`
+}
+
+func NewScheduledCommandResponse(requestId string, message string, statusCode int, scheduledCommand dtos.ScheduledCommand) ScheduledCommandResponse {
+	return ScheduledCommandResponse{
+		BaseResponse:     common.NewBaseResponse(requestId, message, statusCode),
+		ScheduledCommand: scheduledCommand,
+	}
+}
+
+// MultiScheduledCommandsResponse defines the Response Content for GET multiple scheduled commands
+type MultiScheduledCommandsResponse struct {
+	common.BaseWithTotalCountResponse `json:",inline"`
+	ScheduledCommands                 []dtos.ScheduledCommand `json:"scheduledCommands"`
+}
+
+func NewMultiScheduledCommandsResponse(requestId string, message string, statusCode int, totalCount uint32, scheduledCommands []dtos.ScheduledCommand) MultiScheduledCommandsResponse {
+	return MultiScheduledCommandsResponse{
+		BaseWithTotalCountResponse: common.NewBaseWithTotalCountResponse(requestId, message, statusCode, totalCount),
+		ScheduledCommands:          scheduledCommands,
+	}
+}
@@ -0,0 +1,32 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+)
+
+// SearchResponse defines the Response Content for the metadata search, the TotalCount is the number of all matched
+// entities while Counts holds the number of matched entities per entity type.
+type SearchResponse struct {
+	common.BaseWithTotalCountResponse `json:",inline"`
+	Counts                            map[string]uint32    `json:"counts"`
+	Devices                           []dtos.Device        `json:"devices,omitempty"`
+	DeviceProfiles                    []dtos.DeviceProfile `json:"deviceProfiles,omitempty"`
+	DeviceServices                    []dtos.DeviceService `json:"deviceServices,omitempty"`
+}
+
+func NewSearchResponse(requestId string, message string, statusCode int, totalCount uint32, counts map[string]uint32,
+	devices []dtos.Device, profiles []dtos.DeviceProfile, services []dtos.DeviceService) SearchResponse {
+	return SearchResponse{
+		BaseWithTotalCountResponse: common.NewBaseWithTotalCountResponse(requestId, message, statusCode, totalCount),
+		Counts:                     counts,
+		Devices:                    devices,
+		DeviceProfiles:             profiles,
+		DeviceServices:             services,
+	}
+}
@@ -0,0 +1,38 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+)
+
+// TrashedEntityResponse defines the Response Content for GET a trashed metadata entity
+type TrashedEntityResponse struct {
+	common.BaseResponse `json:",inline"`
+	Entity              dtos.TrashedEntity `json:"entity"`
+}
+
+func NewTrashedEntityResponse(requestId string, message string, statusCode int, entity dtos.TrashedEntity) TrashedEntityResponse {
+	return TrashedEntityResponse{
+		BaseResponse: common.NewBaseResponse(requestId, message, statusCode),
+		Entity:       entity,
+	}
+}
+
+// MultiTrashedEntitiesResponse defines the Response Content for GET multiple trashed metadata entities
+type MultiTrashedEntitiesResponse struct {
+	common.BaseWithTotalCountResponse `json:",inline"`
+	Entities                          []dtos.TrashedEntity `json:"entities"`
+}
+
+func NewMultiTrashedEntitiesResponse(requestId string, message string, statusCode int, totalCount uint32, entities []dtos.TrashedEntity) MultiTrashedEntitiesResponse {
+	return MultiTrashedEntitiesResponse{
+		BaseWithTotalCountResponse: common.NewBaseWithTotalCountResponse(requestId, message, statusCode, totalCount),
+		Entities:                   entities,
+	}
+}
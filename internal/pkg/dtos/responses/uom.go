@@ -0,0 +1,38 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+
+	pkgDtos "github.com/edgexfoundry/edgex-go/internal/pkg/dtos"
+)
+
+// UnitOfMeasureCategoryResponse defines the Response Content for GET UnitOfMeasureCategory DTO.
+type UnitOfMeasureCategoryResponse struct {
+	common.BaseResponse `json:",inline"`
+	Category            pkgDtos.UnitOfMeasureCategory `json:"category"`
+}
+
+func NewUnitOfMeasureCategoryResponse(requestId string, message string, statusCode int, category pkgDtos.UnitOfMeasureCategory) UnitOfMeasureCategoryResponse {
+	return UnitOfMeasureCategoryResponse{
+		BaseResponse: common.NewBaseResponse(requestId, message, statusCode),
+		Category:     category,
+	}
+}
+
+// MultiUnitOfMeasureCategoriesResponse defines the Response Content for GET multiple UnitOfMeasureCategory DTOs.
+type MultiUnitOfMeasureCategoriesResponse struct {
+	common.BaseWithTotalCountResponse `json:",inline"`
+	Categories                        []pkgDtos.UnitOfMeasureCategory `json:"categories"`
+}
+
+func NewMultiUnitOfMeasureCategoriesResponse(requestId string, message string, statusCode int, totalCount uint32, categories []pkgDtos.UnitOfMeasureCategory) MultiUnitOfMeasureCategoriesResponse {
+	return MultiUnitOfMeasureCategoriesResponse{
+		BaseWithTotalCountResponse: common.NewBaseWithTotalCountResponse(requestId, message, statusCode, totalCount),
+		Categories:                 categories,
+	}
+}
@@ -0,0 +1,68 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// ScheduledCommand is the DTO of a set command issued once at the ExecuteAt time, or after the Delay since it's
+// submitted. The Actor, Status, ExecutedAt, StatusCode and Message are recorded by core-command.
+type ScheduledCommand struct {
+	dtos.DBTimestamp `json:",inline"`
+	Id               string            `json:"id,omitempty" validate:"omitempty,uuid"`
+	DeviceName       string            `json:"deviceName" validate:"required,edgex-dto-none-empty-string"`
+	CommandName      string            `json:"commandName" validate:"required,edgex-dto-none-empty-string"`
+	Settings         map[string]any    `json:"settings" validate:"gt=0"`
+	QueryParams      map[string]string `json:"queryParams,omitempty"`
+	ExecuteAt        int64             `json:"executeAt,omitempty" validate:"gte=0"`
+	Delay            string            `json:"delay,omitempty" validate:"omitempty,edgex-dto-duration"`
+	Actor            string            `json:"actor,omitempty"`
+	Status           string            `json:"status,omitempty"`
+	ExecutedAt       int64             `json:"executedAt,omitempty"`
+	StatusCode       int               `json:"statusCode,omitempty"`
+	Message          string            `json:"message,omitempty"`
+}
+
+// ToScheduledCommandModel transforms the ScheduledCommand DTO to the ScheduledCommand model, the Delay is resolved to
+// the ExecuteAt time when the command is added
+func ToScheduledCommandModel(dto ScheduledCommand) pkgModels.ScheduledCommand {
+	return pkgModels.ScheduledCommand{
+		DBTimestamp: models.DBTimestamp(dto.DBTimestamp),
+		Id:          dto.Id,
+		DeviceName:  dto.DeviceName,
+		CommandName: dto.CommandName,
+		Settings:    dto.Settings,
+		QueryParams: dto.QueryParams,
+		ExecuteAt:   dto.ExecuteAt,
+		Actor:       dto.Actor,
+		Status:      dto.Status,
+		ExecutedAt:  dto.ExecutedAt,
+		StatusCode:  dto.StatusCode,
+		Message:     dto.Message,
+	}
+}
+
+// FromScheduledCommandModelToDTO transforms the ScheduledCommand model to the ScheduledCommand DTO
+func FromScheduledCommandModelToDTO(sc pkgModels.ScheduledCommand) ScheduledCommand {
+	return ScheduledCommand{
+		DBTimestamp: dtos.DBTimestamp(sc.DBTimestamp),
+		Id:          sc.Id,
+		DeviceName:  sc.DeviceName,
+		CommandName: sc.CommandName,
+		Settings:    sc.Settings,
+		QueryParams: sc.QueryParams,
+		ExecuteAt:   sc.ExecuteAt,
+		Actor:       sc.Actor,
+		Status:      sc.Status,
+		ExecutedAt:  sc.ExecutedAt,
+		StatusCode:  sc.StatusCode,
+		Message:     sc.Message,
+	}
+}
@@ -0,0 +1,41 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+import (
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// TrashedEntity is the DTO of a soft deleted metadata entity, the Entity is the DTO of the deleted entity.
+// Expires is the time the entity is purged at, which is 0 when the trash has no retention configured.
+type TrashedEntity struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Deleted int64  `json:"deleted"`
+	Expires int64  `json:"expires,omitempty"`
+	Entity  any    `json:"entity"`
+}
+
+// FromTrashedEntityModelToDTO transforms the TrashedEntity Model to the TrashedEntity DTO, the retention is in milliseconds
+func FromTrashedEntityModelToDTO(t models.TrashedEntity, retention int64) (TrashedEntity, error) {
+	dto := TrashedEntity{
+		Type:    t.Type,
+		Name:    t.Name,
+		Deleted: t.Deleted,
+	}
+	if retention > 0 {
+		dto.Expires = t.Deleted + retention
+	}
+
+	var err error
+	dto.Entity, err = entityModelToDTO(t.Type, t.Entity)
+	if err != nil {
+		return dto, fmt.Errorf("failed to decode the trashed %s %s: %w", t.Type, t.Name, err)
+	}
+	return dto, nil
+}
@@ -0,0 +1,44 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// UnitOfMeasureCategory is the DTO of a category of custom units of measure
+type UnitOfMeasureCategory struct {
+	dtos.DBTimestamp `json:",inline"`
+	Id               string   `json:"id,omitempty" validate:"omitempty,uuid"`
+	Name             string   `json:"name" validate:"required,edgex-dto-none-empty-string,edgex-dto-rfc3986-unreserved-chars"`
+	Source           string   `json:"source,omitempty"`
+	Values           []string `json:"values" validate:"gt=0,dive,required,edgex-dto-none-empty-string"`
+}
+
+// ToUnitOfMeasureCategoryModel transforms the UnitOfMeasureCategory DTO to the UnitOfMeasureCategory model
+func ToUnitOfMeasureCategoryModel(dto UnitOfMeasureCategory) pkgModels.UnitOfMeasureCategory {
+	return pkgModels.UnitOfMeasureCategory{
+		DBTimestamp: models.DBTimestamp(dto.DBTimestamp),
+		Id:          dto.Id,
+		Name:        dto.Name,
+		Source:      dto.Source,
+		Values:      dto.Values,
+	}
+}
+
+// FromUnitOfMeasureCategoryModelToDTO transforms the UnitOfMeasureCategory model to the UnitOfMeasureCategory DTO
+func FromUnitOfMeasureCategoryModelToDTO(c pkgModels.UnitOfMeasureCategory) UnitOfMeasureCategory {
+	return UnitOfMeasureCategory{
+		DBTimestamp: dtos.DBTimestamp(c.DBTimestamp),
+		Id:          c.Id,
+		Name:        c.Name,
+		Source:      c.Source,
+		Values:      c.Values,
+	}
+}
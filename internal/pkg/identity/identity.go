@@ -0,0 +1,85 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/secret"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+)
+
+// Anonymous is the identity used when the request carries no usable token
+const Anonymous = "anonymous"
+
+type contextKey struct{}
+
+// tokenClaims holds the identity related claims of the secret store identity tokens
+type tokenClaims struct {
+	Name    string `json:"name"`
+	Subject string `json:"sub"`
+}
+
+// FromRequest returns the caller identity carried by the bearer token of the request.
+// The token signature has already been verified by the authentication hook, so only the claims are decoded here.
+func FromRequest(r *http.Request) string {
+	authHeader := r.Header.Get(internal.AuthHeaderTitle)
+	if !strings.HasPrefix(authHeader, internal.BearerLabel) {
+		return Anonymous
+	}
+	return FromToken(strings.TrimPrefix(authHeader, internal.BearerLabel))
+}
+
+// FromToken returns the identity from the JWT claims, preferring the user name over the subject
+func FromToken(token string) string {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Anonymous
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return Anonymous
+	}
+	var claims tokenClaims
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return Anonymous
+	}
+	if claims.Name != "" {
+		return claims.Name
+	}
+	if claims.Subject != "" {
+		return claims.Subject
+	}
+	return Anonymous
+}
+
+// NewContext returns a copy of the context carrying the caller identity
+func NewContext(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, contextKey{}, identity)
+}
+
+// FromContext returns the caller identity carried by the context
+func FromContext(ctx context.Context) string {
+	identity, ok := ctx.Value(contextKey{}).(string)
+	if !ok || identity == "" {
+		return Anonymous
+	}
+	return identity
+}
+
+// IsJWTValidationEnabled returns whether the JWTs of the requests are verified, which isn't the case without security or
+// while EDGEX_DISABLE_JWT_VALIDATION is set. The identities read from the tokens can be forged otherwise.
+func IsJWTValidationEnabled() bool {
+	disableJWTValidation, _ := strconv.ParseBool(os.Getenv("EDGEX_DISABLE_JWT_VALIDATION"))
+	return secret.IsSecurityEnabled() && !disableJWTValidation
+}
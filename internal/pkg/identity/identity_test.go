@@ -0,0 +1,50 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+)
+
+func testToken(payload string) string {
+	return "eyJhbGciOiJIUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".c2lnbmF0dXJl"
+}
+
+func TestFromRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{"name claim", internal.BearerLabel + testToken(`{"name":"operator","sub":"1234"}`), "operator"},
+		{"subject claim", internal.BearerLabel + testToken(`{"sub":"1234"}`), "1234"},
+		{"no claims", internal.BearerLabel + testToken(`{}`), Anonymous},
+		{"malformed token", internal.BearerLabel + "abc", Anonymous},
+		{"no token", "", Anonymous},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/", http.NoBody)
+			assert.NoError(t, err)
+			if testCase.header != "" {
+				req.Header.Set(internal.AuthHeaderTitle, testCase.header)
+			}
+			assert.Equal(t, testCase.expected, FromRequest(req))
+		})
+	}
+}
+
+func TestContext(t *testing.T) {
+	assert.Equal(t, Anonymous, FromContext(context.Background()))
+	assert.Equal(t, "operator", FromContext(NewContext(context.Background(), "operator")))
+}
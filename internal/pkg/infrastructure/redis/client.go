@@ -14,6 +14,7 @@ import (
 
 	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
 	redisClient "github.com/edgexfoundry/edgex-go/internal/pkg/db/redis"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
 
 	"github.com/google/uuid"
 )
@@ -451,12 +452,590 @@ func (c *Client) DevicesByProfileName(offset int, limit int, profileName string)
 	return devices, nil
 }
 
+// DevicesByTemplateName query devices by offset, limit and the name of the device template they were created from
+func (c *Client) DevicesByTemplateName(offset int, limit int, templateName string) (devices []model.Device, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	devices, edgeXerr = devicesByTemplateName(conn, offset, limit, templateName)
+	if edgeXerr != nil {
+		return devices, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query devices by offset %d, limit %d and template name %s", offset, limit, templateName), edgeXerr)
+	}
+	return devices, nil
+}
+
+// DevicesByGeoCircle query devices located within the circle by offset and limit, nearest first
+func (c *Client) DevicesByGeoCircle(circle pkgModels.GeoCircle, offset int, limit int) (devices []model.Device, totalCount uint32, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	devices, totalCount, edgeXerr = devicesByGeoCircle(conn, circle, offset, limit)
+	if edgeXerr != nil {
+		return devices, totalCount, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query devices by offset %d, limit %d and circle %+v", offset, limit, circle), edgeXerr)
+	}
+	return devices, totalCount, nil
+}
+
+// DevicesByGeoBoundingBox query devices located within the bounding box by offset and limit
+func (c *Client) DevicesByGeoBoundingBox(box pkgModels.GeoBoundingBox, offset int, limit int) (devices []model.Device, totalCount uint32, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	devices, totalCount, edgeXerr = devicesByGeoBoundingBox(conn, box, offset, limit)
+	if edgeXerr != nil {
+		return devices, totalCount, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query devices by offset %d, limit %d and bounding box %+v", offset, limit, box), edgeXerr)
+	}
+	return devices, totalCount, nil
+}
+
+// CheckMetadataIndexes checks the secondary indexes of the metadata collections against the stored entities, and
+// rebuilds the indexes when inconsistencies are found and the rebuild is requested
+func (c *Client) CheckMetadataIndexes(rebuild bool) (integrity pkgModels.IndexIntegrity, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	integrity, edgeXerr = checkMetadataIndexes(conn, rebuild)
+	if edgeXerr != nil {
+		return integrity, errors.NewCommonEdgeX(errors.Kind(edgeXerr), "fail to check the metadata indexes", edgeXerr)
+	}
+	return integrity, nil
+}
+
 // Update a device
 func (c *Client) UpdateDevice(d model.Device) errors.EdgeX {
 	conn := c.Pool.Get()
 	defer conn.Close()
 
-	return updateDevice(conn, d)
+	return updateDevice(conn, d)
+}
+
+// AddDeviceLifecycleAudit adds a new device lifecycle audit record
+func (c *Client) AddDeviceLifecycleAudit(a pkgModels.DeviceLifecycleAudit) (pkgModels.DeviceLifecycleAudit, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	if len(a.Id) == 0 {
+		a.Id = uuid.New().String()
+	}
+
+	return addDeviceLifecycleAudit(conn, a)
+}
+
+// AllDeviceLifecycleAudits query device lifecycle audits with offset and limit
+func (c *Client) AllDeviceLifecycleAudits(offset int, limit int) ([]pkgModels.DeviceLifecycleAudit, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	audits, edgeXerr := allDeviceLifecycleAudits(conn, offset, limit)
+	if edgeXerr != nil {
+		return audits, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query device lifecycle audits by offset %d and limit %d", offset, limit), edgeXerr)
+	}
+	return audits, nil
+}
+
+// DeviceLifecycleAuditsByDeviceName query device lifecycle audits with offset, limit and device name
+func (c *Client) DeviceLifecycleAuditsByDeviceName(offset int, limit int, name string) ([]pkgModels.DeviceLifecycleAudit, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	audits, edgeXerr := deviceLifecycleAuditsByDeviceName(conn, offset, limit, name)
+	if edgeXerr != nil {
+		return audits, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query device lifecycle audits by offset %d, limit %d and device name %s", offset, limit, name), edgeXerr)
+	}
+	return audits, nil
+}
+
+// DeviceLifecycleAuditTotalCount returns the total count of device lifecycle audits from the database
+func (c *Client) DeviceLifecycleAuditTotalCount() (uint32, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	count, edgeXerr := getMemberNumber(conn, ZCARD, DeviceLifecycleAuditCollection)
+	if edgeXerr != nil {
+		return 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return count, nil
+}
+
+// DeviceLifecycleAuditCountByDeviceName returns the count of device lifecycle audits associated with specified device from the database
+func (c *Client) DeviceLifecycleAuditCountByDeviceName(name string) (uint32, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	count, edgeXerr := getMemberNumber(conn, ZCARD, CreateKey(DeviceLifecycleAuditCollectionDeviceName, name))
+	if edgeXerr != nil {
+		return 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return count, nil
+}
+
+// AddDeviceTemplate adds a new device template
+func (c *Client) AddDeviceTemplate(t pkgModels.DeviceTemplate) (pkgModels.DeviceTemplate, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	if len(t.Id) == 0 {
+		t.Id = uuid.New().String()
+	}
+
+	return addDeviceTemplate(conn, t)
+}
+
+// UpdateDeviceTemplate updates the device template of the same name
+func (c *Client) UpdateDeviceTemplate(t pkgModels.DeviceTemplate) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	return updateDeviceTemplate(conn, t)
+}
+
+// DeviceTemplateByName gets a device template by name
+func (c *Client) DeviceTemplateByName(name string) (template pkgModels.DeviceTemplate, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	template, edgeXerr = deviceTemplateByName(conn, name)
+	if edgeXerr != nil {
+		return template, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return
+}
+
+// AllDeviceTemplates query device templates with offset, limit and labels
+func (c *Client) AllDeviceTemplates(offset int, limit int, labels []string) ([]pkgModels.DeviceTemplate, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	templates, edgeXerr := deviceTemplatesByLabels(conn, offset, limit, labels)
+	if edgeXerr != nil {
+		return templates, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query device templates by offset %d, limit %d and labels %v", offset, limit, labels), edgeXerr)
+	}
+	return templates, nil
+}
+
+// DeviceTemplateCountByLabels returns the total count of device templates with labels specified. If no label is specified, the total count of all device templates will be returned.
+func (c *Client) DeviceTemplateCountByLabels(labels []string) (uint32, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	count, edgeXerr := getMemberCountByLabels(conn, ZREVRANGE, DeviceTemplateCollection, labels)
+	if edgeXerr != nil {
+		return 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return count, nil
+}
+
+// DeleteDeviceTemplateByName deletes a device template by name
+func (c *Client) DeleteDeviceTemplateByName(name string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := deleteDeviceTemplateByName(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to delete the device template with name %s", name), edgeXerr)
+	}
+
+	return nil
+}
+
+// AddPendingDevice stages a discovered device waiting for approval
+func (c *Client) AddPendingDevice(pd pkgModels.PendingDevice) (pkgModels.PendingDevice, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	if len(pd.Id) == 0 {
+		pd.Id = uuid.New().String()
+	}
+
+	return addPendingDevice(conn, pd)
+}
+
+// PendingDeviceByName gets a pending device by the name of the device
+func (c *Client) PendingDeviceByName(name string) (pd pkgModels.PendingDevice, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	pd, edgeXerr = pendingDeviceByName(conn, name)
+	if edgeXerr != nil {
+		return pd, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return
+}
+
+// PendingDevices query pending devices with status, offset and limit, and returns the total count of the pending
+// devices with the status. All the pending devices are queried when the status is empty.
+func (c *Client) PendingDevices(status string, offset int, limit int) ([]pkgModels.PendingDevice, uint32, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	pendingDevices, totalCount, edgeXerr := pendingDevicesByStatus(conn, status, offset, limit)
+	if edgeXerr != nil {
+		return pendingDevices, totalCount, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query pending devices by status %s, offset %d and limit %d", status, offset, limit), edgeXerr)
+	}
+	return pendingDevices, totalCount, nil
+}
+
+// UpdatePendingDevice updates the pending device of the same device name
+func (c *Client) UpdatePendingDevice(pd pkgModels.PendingDevice) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	return updatePendingDevice(conn, pd)
+}
+
+// ApprovePendingDevice adds the device staged by the pending device of the same name and deletes the pending device
+func (c *Client) ApprovePendingDevice(d model.Device) (model.Device, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	if len(d.Id) == 0 {
+		d.Id = uuid.New().String()
+	}
+	return approvePendingDevice(conn, d)
+}
+
+// DeletePendingDeviceByName deletes a pending device by the name of the device
+func (c *Client) DeletePendingDeviceByName(name string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := deletePendingDeviceByName(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to delete the pending device with name %s", name), edgeXerr)
+	}
+
+	return nil
+}
+
+// AddScheduledCommand adds a new scheduled command
+func (c *Client) AddScheduledCommand(sc pkgModels.ScheduledCommand) (pkgModels.ScheduledCommand, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	if len(sc.Id) == 0 {
+		sc.Id = uuid.New().String()
+	}
+
+	return addScheduledCommand(conn, sc)
+}
+
+// ScheduledCommandById gets a scheduled command by id
+func (c *Client) ScheduledCommandById(id string) (sc pkgModels.ScheduledCommand, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	sc, edgeXerr = scheduledCommandById(conn, id)
+	if edgeXerr != nil {
+		return sc, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return
+}
+
+// ScheduledCommands query scheduled commands with status, offset and limit, and returns the total count of the
+// scheduled commands with the status. All the scheduled commands are queried when the status is empty.
+func (c *Client) ScheduledCommands(status string, offset int, limit int) ([]pkgModels.ScheduledCommand, uint32, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	scheduledCommands, totalCount, edgeXerr := scheduledCommandsByStatus(conn, status, offset, limit)
+	if edgeXerr != nil {
+		return scheduledCommands, totalCount, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query scheduled commands by status %s, offset %d and limit %d", status, offset, limit), edgeXerr)
+	}
+	return scheduledCommands, totalCount, nil
+}
+
+// DueScheduledCommands query at most limit scheduled commands waiting for execution whose execute-at time is not
+// after the specified time
+func (c *Client) DueScheduledCommands(executeAt int64, limit int) ([]pkgModels.ScheduledCommand, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	scheduledCommands, edgeXerr := dueScheduledCommands(conn, executeAt, limit)
+	if edgeXerr != nil {
+		return nil, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to query scheduled commands due at %d", executeAt), edgeXerr)
+	}
+	return scheduledCommands, nil
+}
+
+// UpdateScheduledCommand updates the scheduled command of the same id
+func (c *Client) UpdateScheduledCommand(sc pkgModels.ScheduledCommand) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	return updateScheduledCommand(conn, sc)
+}
+
+// AddPendingCommand adds a new pending command
+func (c *Client) AddPendingCommand(pc pkgModels.PendingCommand) (pkgModels.PendingCommand, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	if len(pc.Id) == 0 {
+		pc.Id = uuid.New().String()
+	}
+
+	return addPendingCommand(conn, pc)
+}
+
+// PendingCommandById gets a pending command by id
+func (c *Client) PendingCommandById(id string) (pc pkgModels.PendingCommand, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	pc, edgeXerr = pendingCommandById(conn, id)
+	if edgeXerr != nil {
+		return pc, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return
+}
+
+// PendingCommands query pending commands with status, offset and limit, and returns the total count of the pending
+// commands with the status. All the pending commands are queried when the status is empty.
+func (c *Client) PendingCommands(status string, offset int, limit int) ([]pkgModels.PendingCommand, uint32, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	pendingCommands, totalCount, edgeXerr := pendingCommandsByStatus(conn, status, offset, limit)
+	if edgeXerr != nil {
+		return pendingCommands, totalCount, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query pending commands by status %s, offset %d and limit %d", status, offset, limit), edgeXerr)
+	}
+	return pendingCommands, totalCount, nil
+}
+
+// ExpiredPendingCommands query at most limit pending commands awaiting approval whose expires-at time is not after
+// the specified time
+func (c *Client) ExpiredPendingCommands(expiresAt int64, limit int) ([]pkgModels.PendingCommand, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	pendingCommands, edgeXerr := expiredPendingCommands(conn, expiresAt, limit)
+	if edgeXerr != nil {
+		return nil, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to query pending commands expired at %d", expiresAt), edgeXerr)
+	}
+	return pendingCommands, nil
+}
+
+// UpdatePendingCommand updates the pending command of the same id
+func (c *Client) UpdatePendingCommand(pc pkgModels.PendingCommand) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	return updatePendingCommand(conn, pc)
+}
+
+// AddCommandAuditEntry appends a new command audit entry
+func (c *Client) AddCommandAuditEntry(e pkgModels.CommandAuditEntry) (pkgModels.CommandAuditEntry, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	if len(e.Id) == 0 {
+		e.Id = uuid.New().String()
+	}
+
+	return addCommandAuditEntry(conn, e)
+}
+
+// CommandAuditEntries query the command audit entries of the device within the timestamp range with offset and limit,
+// and returns the total count of the entries within the range. The entries of all the devices are queried when the
+// device name is empty.
+func (c *Client) CommandAuditEntries(deviceName string, start int64, end int64, offset int, limit int) ([]pkgModels.CommandAuditEntry, uint32, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	entries, totalCount, edgeXerr := commandAuditEntries(conn, deviceName, start, end, offset, limit)
+	if edgeXerr != nil {
+		return entries, totalCount, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query command audit entries by device %s from %d to %d, offset %d and limit %d", deviceName, start, end, offset, limit), edgeXerr)
+	}
+	return entries, totalCount, nil
+}
+
+// AddCommandSequence adds a new command sequence
+func (c *Client) AddCommandSequence(cs pkgModels.CommandSequence) (pkgModels.CommandSequence, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	if len(cs.Id) == 0 {
+		cs.Id = uuid.New().String()
+	}
+
+	return addCommandSequence(conn, cs)
+}
+
+// UpdateCommandSequence updates the command sequence of the same name
+func (c *Client) UpdateCommandSequence(cs pkgModels.CommandSequence) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	return updateCommandSequence(conn, cs)
+}
+
+// CommandSequenceByName gets a command sequence by name
+func (c *Client) CommandSequenceByName(name string) (cs pkgModels.CommandSequence, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	cs, edgeXerr = commandSequenceByName(conn, name)
+	if edgeXerr != nil {
+		return cs, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return
+}
+
+// AllCommandSequences query command sequences with offset and limit, and returns the total count of the command sequences
+func (c *Client) AllCommandSequences(offset int, limit int) ([]pkgModels.CommandSequence, uint32, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	sequences, totalCount, edgeXerr := allCommandSequences(conn, offset, limit)
+	if edgeXerr != nil {
+		return sequences, totalCount, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query command sequences by offset %d and limit %d", offset, limit), edgeXerr)
+	}
+	return sequences, totalCount, nil
+}
+
+// DeleteCommandSequenceByName deletes a command sequence by name
+func (c *Client) DeleteCommandSequenceByName(name string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := deleteCommandSequenceByName(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to delete the command sequence with name %s", name), edgeXerr)
+	}
+
+	return nil
+}
+
+// AddCommandSequenceExecution adds a new command sequence execution
+func (c *Client) AddCommandSequenceExecution(e pkgModels.CommandSequenceExecution) (pkgModels.CommandSequenceExecution, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	if len(e.Id) == 0 {
+		e.Id = uuid.New().String()
+	}
+
+	return addCommandSequenceExecution(conn, e)
+}
+
+// CommandSequenceExecutionById gets a command sequence execution by id
+func (c *Client) CommandSequenceExecutionById(id string) (e pkgModels.CommandSequenceExecution, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	e, edgeXerr = commandSequenceExecutionById(conn, id)
+	if edgeXerr != nil {
+		return e, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return
+}
+
+// CommandSequenceExecutions query command sequence executions with status, offset and limit, and returns the total
+// count of the executions with the status. All the executions are queried when the status is empty.
+func (c *Client) CommandSequenceExecutions(status string, offset int, limit int) ([]pkgModels.CommandSequenceExecution, uint32, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	executions, totalCount, edgeXerr := commandSequenceExecutionsByStatus(conn, status, offset, limit)
+	if edgeXerr != nil {
+		return executions, totalCount, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query command sequence executions by status %s, offset %d and limit %d", status, offset, limit), edgeXerr)
+	}
+	return executions, totalCount, nil
+}
+
+// CommandSequenceExecutionsBySequenceName query the executions of the command sequence with offset and limit, and
+// returns the total count of the executions of the command sequence
+func (c *Client) CommandSequenceExecutionsBySequenceName(name string, offset int, limit int) ([]pkgModels.CommandSequenceExecution, uint32, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	executions, totalCount, edgeXerr := commandSequenceExecutionsBySequenceName(conn, name, offset, limit)
+	if edgeXerr != nil {
+		return executions, totalCount, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query command sequence executions by sequence name %s, offset %d and limit %d", name, offset, limit), edgeXerr)
+	}
+	return executions, totalCount, nil
+}
+
+// UpdateCommandSequenceExecution updates the command sequence execution of the same id
+func (c *Client) UpdateCommandSequenceExecution(e pkgModels.CommandSequenceExecution) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	return updateCommandSequenceExecution(conn, e)
+}
+
+// MetadataChangesSince queries the metadata changes whose sequence is greater than since, in the ascending order of the sequence
+func (c *Client) MetadataChangesSince(since uint64, limit int) ([]pkgModels.MetadataChange, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	changes, edgeXerr := metadataChangesSince(conn, since, limit)
+	if edgeXerr != nil {
+		return changes, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to query metadata changes since %d", since), edgeXerr)
+	}
+
+	return changes, nil
+}
+
+// MetadataChangeSequenceRange returns the sequence of the oldest retained metadata change and the sequence of the latest metadata change
+func (c *Client) MetadataChangeSequenceRange() (uint64, uint64, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	oldest, latest, edgeXerr := metadataChangeSequenceRange(conn)
+	if edgeXerr != nil {
+		return 0, 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return oldest, latest, nil
+}
+
+// MetadataChangeTotalCount returns the total count of the retained metadata changes from the database
+func (c *Client) MetadataChangeTotalCount() (uint32, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	count, edgeXerr := getMemberNumber(conn, ZCARD, MetadataChangeCollection)
+	if edgeXerr != nil {
+		return 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return count, nil
+}
+
+// TrimMetadataChanges deletes the oldest metadata changes and keeps the latest retained count of metadata changes
+func (c *Client) TrimMetadataChanges(retained uint32) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := trimMetadataChanges(conn, retained)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to trim metadata changes to %d", retained), edgeXerr)
+	}
+
+	return nil
 }
 
 // AllEvents query events by offset and limit
@@ -705,6 +1284,19 @@ func (c *Client) ReadingsByDeviceNameAndResourceNamesAndTimeRange(deviceName str
 	return readings, totalCount, nil
 }
 
+func (c *Client) ReadingsByDeviceNamesAndTimeRange(deviceNames []string, start, end, offset, limit int) (readings []model.Reading, totalCount uint32, err errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	readings, totalCount, err = readingsByDeviceNamesAndTimeRange(conn, deviceNames, start, end, offset, limit)
+	if err != nil {
+		return readings, totalCount, errors.NewCommonEdgeX(errors.Kind(err),
+			fmt.Sprintf("fail to query readings by deviceNames %v and time range %v ~ %v", deviceNames, start, end), err)
+	}
+
+	return readings, totalCount, nil
+}
+
 func (c *Client) ReadingsByDeviceNameAndTimeRange(deviceName string, start int, end int, offset int, limit int) (readings []model.Reading, err errors.EdgeX) {
 	conn := c.Pool.Get()
 	defer conn.Close()
@@ -921,6 +1513,19 @@ func (c *Client) DeviceCountByServiceName(serviceName string) (uint32, errors.Ed
 	return count, nil
 }
 
+// DeviceCountByTemplateName returns the count of Devices created from the specified device template
+func (c *Client) DeviceCountByTemplateName(templateName string) (uint32, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	count, edgeXerr := getMemberNumber(conn, ZCARD, CreateKey(DeviceCollectionTemplateName, templateName))
+	if edgeXerr != nil {
+		return 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return count, nil
+}
+
 // ProvisionWatcherCountByLabels returns the total count of Provision Watchers with labels specified.  If no label is specified, the total count of all provision watchers will be returned.
 func (c *Client) ProvisionWatcherCountByLabels(labels []string) (uint32, errors.EdgeX) {
 	conn := c.Pool.Get()
@@ -1682,3 +2287,207 @@ func (c *Client) LatestReadingByOffset(offset uint32) (model.Reading, errors.Edg
 
 	return reading, nil
 }
+
+// MetadataRevision returns the revision of the metadata entity with the specified type and name
+func (c *Client) MetadataRevision(entityType string, name string) (uint64, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	revision, edgeXerr := metadataRevision(conn, entityType, name)
+	if edgeXerr != nil {
+		return 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return revision, nil
+}
+
+// TrashDeviceByName deletes a device by name and keeps it in the trash
+func (c *Client) TrashDeviceByName(name string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := trashDeviceByName(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to delete the device with name %s", name), edgeXerr)
+	}
+
+	return nil
+}
+
+// TrashDeviceProfileByName deletes a device profile by name and keeps it in the trash
+func (c *Client) TrashDeviceProfileByName(name string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := trashDeviceProfileByName(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to delete the device profile with name %s", name), edgeXerr)
+	}
+
+	return nil
+}
+
+// TrashedEntities query the trashed entities of the type with offset and limit
+func (c *Client) TrashedEntities(entityType string, offset int, limit int) ([]pkgModels.TrashedEntity, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	entities, edgeXerr := trashedEntities(conn, entityType, offset, limit)
+	if edgeXerr != nil {
+		return entities, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query trashed %s by offset %d and limit %d", entityType, offset, limit), edgeXerr)
+	}
+
+	return entities, nil
+}
+
+// TrashedEntityByName gets a trashed entity by type and name
+func (c *Client) TrashedEntityByName(entityType string, name string) (pkgModels.TrashedEntity, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	entity, edgeXerr := trashedEntityByName(conn, entityType, name)
+	if edgeXerr != nil {
+		return entity, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return entity, nil
+}
+
+// TrashedEntityTotalCount returns the total count of the trashed entities of the type
+func (c *Client) TrashedEntityTotalCount(entityType string) (uint32, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	count, edgeXerr := getMemberNumber(conn, ZCARD, trashCollectionByType(entityType))
+	if edgeXerr != nil {
+		return 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return count, nil
+}
+
+// RestoreTrashedDevice moves the trashed device with the name back to the devices
+func (c *Client) RestoreTrashedDevice(name string) (model.Device, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	device, edgeXerr := restoreTrashedDevice(conn, name)
+	if edgeXerr != nil {
+		return device, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to restore the device with name %s", name), edgeXerr)
+	}
+
+	return device, nil
+}
+
+// RestoreTrashedDeviceProfile moves the trashed device profile with the name back to the device profiles
+func (c *Client) RestoreTrashedDeviceProfile(name string) (model.DeviceProfile, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	dp, edgeXerr := restoreTrashedDeviceProfile(conn, name)
+	if edgeXerr != nil {
+		return dp, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to restore the device profile with name %s", name), edgeXerr)
+	}
+
+	return dp, nil
+}
+
+// PurgeTrashedEntity permanently deletes a trashed entity by type and name
+func (c *Client) PurgeTrashedEntity(entityType string, name string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := purgeTrashedEntity(conn, entityType, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return nil
+}
+
+// PurgeTrashedEntitiesByAge permanently deletes the entities of the type which were trashed before the age, and
+// returns the number of the purged entities
+func (c *Client) PurgeTrashedEntitiesByAge(entityType string, age int64) (uint32, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	count, edgeXerr := purgeTrashedEntitiesByAge(conn, entityType, age)
+	if edgeXerr != nil {
+		return 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return count, nil
+}
+
+// AddUnitOfMeasureCategory adds a new unit of measure category
+func (c *Client) AddUnitOfMeasureCategory(category pkgModels.UnitOfMeasureCategory) (pkgModels.UnitOfMeasureCategory, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	if len(category.Id) == 0 {
+		category.Id = uuid.New().String()
+	}
+
+	return addUnitOfMeasureCategory(conn, category)
+}
+
+// UpdateUnitOfMeasureCategory updates the unit of measure category of the same name
+func (c *Client) UpdateUnitOfMeasureCategory(category pkgModels.UnitOfMeasureCategory) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	return updateUnitOfMeasureCategory(conn, category)
+}
+
+// UnitOfMeasureCategoryByName gets a unit of measure category by name
+func (c *Client) UnitOfMeasureCategoryByName(name string) (category pkgModels.UnitOfMeasureCategory, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	category, edgeXerr = unitOfMeasureCategoryByName(conn, name)
+	if edgeXerr != nil {
+		return category, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return
+}
+
+// AllUnitOfMeasureCategories query unit of measure categories with offset and limit
+func (c *Client) AllUnitOfMeasureCategories(offset int, limit int) ([]pkgModels.UnitOfMeasureCategory, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	categories, edgeXerr := unitOfMeasureCategories(conn, offset, limit)
+	if edgeXerr != nil {
+		return categories, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query unit of measure categories by offset %d and limit %d", offset, limit), edgeXerr)
+	}
+	return categories, nil
+}
+
+// UnitOfMeasureCategoryTotalCount returns the total count of unit of measure categories
+func (c *Client) UnitOfMeasureCategoryTotalCount() (uint32, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	count, edgeXerr := getMemberNumber(conn, ZCARD, UnitOfMeasureCategoryCollection)
+	if edgeXerr != nil {
+		return 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return count, nil
+}
+
+// DeleteUnitOfMeasureCategoryByName deletes a unit of measure category by name
+func (c *Client) DeleteUnitOfMeasureCategoryByName(name string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := deleteUnitOfMeasureCategoryByName(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to delete the unit of measure category with name %s", name), edgeXerr)
+	}
+
+	return nil
+}
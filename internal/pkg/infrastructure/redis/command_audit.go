@@ -0,0 +1,79 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	CommandAuditCollection           = "cc|audit"
+	CommandAuditCollectionDeviceName = CommandAuditCollection + DBKeySeparator + common.Device + DBKeySeparator + common.Name
+)
+
+// commandAuditStoredKey return the command audit entry's stored key which combines the collection name and object id
+func commandAuditStoredKey(id string) string {
+	return CreateKey(CommandAuditCollection, id)
+}
+
+// addCommandAuditEntry appends a new command audit entry into DB, the entries are sorted by timestamp
+func addCommandAuditEntry(conn redis.Conn, e pkgModels.CommandAuditEntry) (pkgModels.CommandAuditEntry, errors.EdgeX) {
+	storedKey := commandAuditStoredKey(e.Id)
+	exists, edgeXerr := objectIdExists(conn, storedKey)
+	if edgeXerr != nil {
+		return e, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return e, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("command audit entry id %s already exists", e.Id), edgeXerr)
+	}
+
+	m, err := json.Marshal(e)
+	if err != nil {
+		return e, errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal command audit entry for Redis persistence", err)
+	}
+	_ = conn.Send(MULTI)
+	_ = conn.Send(SET, storedKey, m)
+	_ = conn.Send(ZADD, CommandAuditCollection, e.Timestamp, storedKey)
+	_ = conn.Send(ZADD, CreateKey(CommandAuditCollectionDeviceName, e.DeviceName), e.Timestamp, storedKey)
+	_, err = conn.Do(EXEC)
+	if err != nil {
+		return e, errors.NewCommonEdgeX(errors.KindDatabaseError, "command audit entry creation failed", err)
+	}
+	return e, nil
+}
+
+// commandAuditEntries query the command audit entries of the device within the timestamp range from DB, sorted by
+// timestamp descending. The entries of all the devices are queried when the device name is empty.
+func commandAuditEntries(conn redis.Conn, deviceName string, start int64, end int64, offset int, limit int) ([]pkgModels.CommandAuditEntry, uint32, errors.EdgeX) {
+	key := CommandAuditCollection
+	if deviceName != "" {
+		key = CreateKey(CommandAuditCollectionDeviceName, deviceName)
+	}
+	totalCount, edgeXerr := getMemberCountByScoreRange(conn, key, int(start), int(end))
+	if edgeXerr != nil {
+		return nil, 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	objects, edgeXerr := getObjectsByScoreRange(conn, key, int(start), int(end), offset, limit)
+	if edgeXerr != nil {
+		return nil, 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	entries := make([]pkgModels.CommandAuditEntry, len(objects))
+	for i, o := range objects {
+		err := json.Unmarshal(o, &entries[i])
+		if err != nil {
+			return nil, 0, errors.NewCommonEdgeX(errors.KindDatabaseError, "command audit entry format parsing failed from the database", err)
+		}
+	}
+	return entries, totalCount, nil
+}
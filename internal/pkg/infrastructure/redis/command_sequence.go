@@ -0,0 +1,278 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	CommandSequenceCollection                = "cc|cs"
+	CommandSequenceCollectionName            = CommandSequenceCollection + DBKeySeparator + common.Name
+	CommandSequenceExecutionCollection       = "cc|cse"
+	CommandSequenceExecutionCollectionName   = CommandSequenceExecutionCollection + DBKeySeparator + common.Name
+	CommandSequenceExecutionCollectionStatus = CommandSequenceExecutionCollection + DBKeySeparator + common.Status
+)
+
+// commandSequenceStoredKey return the command sequence's stored key which combines the collection name and object id
+func commandSequenceStoredKey(id string) string {
+	return CreateKey(CommandSequenceCollection, id)
+}
+
+// commandSequenceExecutionStoredKey return the command sequence execution's stored key which combines the collection
+// name and object id
+func commandSequenceExecutionStoredKey(id string) string {
+	return CreateKey(CommandSequenceExecutionCollection, id)
+}
+
+// sendAddCommandSequenceCmd send redis command for adding command sequence
+func sendAddCommandSequenceCmd(conn redis.Conn, storedKey string, cs pkgModels.CommandSequence) errors.EdgeX {
+	m, err := json.Marshal(cs)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal command sequence for Redis persistence", err)
+	}
+	_ = conn.Send(SET, storedKey, m)
+	_ = conn.Send(ZADD, CommandSequenceCollection, cs.Modified, storedKey)
+	_ = conn.Send(HSET, CommandSequenceCollectionName, cs.Name, storedKey)
+	return nil
+}
+
+// sendDeleteCommandSequenceCmd send redis command for deleting command sequence
+func sendDeleteCommandSequenceCmd(conn redis.Conn, storedKey string, cs pkgModels.CommandSequence) {
+	_ = conn.Send(DEL, storedKey)
+	_ = conn.Send(ZREM, CommandSequenceCollection, storedKey)
+	_ = conn.Send(HDEL, CommandSequenceCollectionName, cs.Name)
+}
+
+// addCommandSequence adds a new command sequence into DB
+func addCommandSequence(conn redis.Conn, cs pkgModels.CommandSequence) (pkgModels.CommandSequence, errors.EdgeX) {
+	exists, edgeXerr := objectIdExists(conn, commandSequenceStoredKey(cs.Id))
+	if edgeXerr != nil {
+		return cs, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return cs, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("command sequence id %s already exists", cs.Id), edgeXerr)
+	}
+
+	exists, edgeXerr = objectNameExists(conn, CommandSequenceCollectionName, cs.Name)
+	if edgeXerr != nil {
+		return cs, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return cs, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("command sequence name %s already exists", cs.Name), edgeXerr)
+	}
+
+	if cs.Created == 0 {
+		cs.Created = pkgCommon.MakeTimestamp()
+	}
+	cs.Modified = cs.Created
+
+	_ = conn.Send(MULTI)
+	edgeXerr = sendAddCommandSequenceCmd(conn, commandSequenceStoredKey(cs.Id), cs)
+	if edgeXerr != nil {
+		return cs, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return cs, errors.NewCommonEdgeX(errors.KindDatabaseError, "command sequence creation failed", err)
+	}
+	return cs, nil
+}
+
+// commandSequenceByName query command sequence by name from DB
+func commandSequenceByName(conn redis.Conn, name string) (cs pkgModels.CommandSequence, edgeXerr errors.EdgeX) {
+	edgeXerr = getObjectByHash(conn, CommandSequenceCollectionName, name, &cs)
+	if edgeXerr != nil {
+		return cs, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to query command sequence by name %s", name), edgeXerr)
+	}
+	return
+}
+
+// allCommandSequences query command sequences from DB sorted by the modified timestamp descending
+func allCommandSequences(conn redis.Conn, offset int, limit int) ([]pkgModels.CommandSequence, uint32, errors.EdgeX) {
+	totalCount, edgeXerr := getMemberNumber(conn, ZCARD, CommandSequenceCollection)
+	if edgeXerr != nil {
+		return nil, 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	objects, edgeXerr := getObjectsByRevRange(conn, CommandSequenceCollection, offset, limit)
+	if edgeXerr != nil {
+		return nil, 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	sequences := make([]pkgModels.CommandSequence, len(objects))
+	for i, o := range objects {
+		err := json.Unmarshal(o, &sequences[i])
+		if err != nil {
+			return nil, 0, errors.NewCommonEdgeX(errors.KindDatabaseError, "command sequence format parsing failed from the database", err)
+		}
+	}
+	return sequences, totalCount, nil
+}
+
+// updateCommandSequence replaces the command sequence of the same name, the id and created timestamp are kept
+func updateCommandSequence(conn redis.Conn, cs pkgModels.CommandSequence) errors.EdgeX {
+	old, edgeXerr := commandSequenceByName(conn, cs.Name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	cs.Id = old.Id
+	cs.Created = old.Created
+	cs.Modified = pkgCommon.MakeTimestamp()
+	storedKey := commandSequenceStoredKey(cs.Id)
+	_ = conn.Send(MULTI)
+	sendDeleteCommandSequenceCmd(conn, storedKey, old)
+	edgeXerr = sendAddCommandSequenceCmd(conn, storedKey, cs)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "command sequence update failed", err)
+	}
+	return nil
+}
+
+// deleteCommandSequenceByName deletes the command sequence by name, the executions of the sequence are kept
+func deleteCommandSequenceByName(conn redis.Conn, name string) errors.EdgeX {
+	cs, edgeXerr := commandSequenceByName(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	_ = conn.Send(MULTI)
+	sendDeleteCommandSequenceCmd(conn, commandSequenceStoredKey(cs.Id), cs)
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "command sequence deletion failed", err)
+	}
+	return nil
+}
+
+// sendAddCommandSequenceExecutionCmd send redis command for adding command sequence execution, the executions are
+// sorted by the started-at time
+func sendAddCommandSequenceExecutionCmd(conn redis.Conn, storedKey string, e pkgModels.CommandSequenceExecution) errors.EdgeX {
+	m, err := json.Marshal(e)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal command sequence execution for Redis persistence", err)
+	}
+	_ = conn.Send(SET, storedKey, m)
+	_ = conn.Send(ZADD, CommandSequenceExecutionCollection, e.StartedAt, storedKey)
+	_ = conn.Send(ZADD, CreateKey(CommandSequenceExecutionCollectionName, e.SequenceName), e.StartedAt, storedKey)
+	_ = conn.Send(ZADD, CreateKey(CommandSequenceExecutionCollectionStatus, e.Status), e.StartedAt, storedKey)
+	return nil
+}
+
+// sendDeleteCommandSequenceExecutionCmd send redis command for deleting command sequence execution
+func sendDeleteCommandSequenceExecutionCmd(conn redis.Conn, storedKey string, e pkgModels.CommandSequenceExecution) {
+	_ = conn.Send(DEL, storedKey)
+	_ = conn.Send(ZREM, CommandSequenceExecutionCollection, storedKey)
+	_ = conn.Send(ZREM, CreateKey(CommandSequenceExecutionCollectionName, e.SequenceName), storedKey)
+	_ = conn.Send(ZREM, CreateKey(CommandSequenceExecutionCollectionStatus, e.Status), storedKey)
+}
+
+// addCommandSequenceExecution adds a new command sequence execution into DB
+func addCommandSequenceExecution(conn redis.Conn, e pkgModels.CommandSequenceExecution) (pkgModels.CommandSequenceExecution, errors.EdgeX) {
+	exists, edgeXerr := objectIdExists(conn, commandSequenceExecutionStoredKey(e.Id))
+	if edgeXerr != nil {
+		return e, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return e, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("command sequence execution id %s already exists", e.Id), edgeXerr)
+	}
+
+	ts := pkgCommon.MakeTimestamp()
+	if e.Created == 0 {
+		e.Created = ts
+	}
+	e.Modified = ts
+
+	_ = conn.Send(MULTI)
+	edgeXerr = sendAddCommandSequenceExecutionCmd(conn, commandSequenceExecutionStoredKey(e.Id), e)
+	if edgeXerr != nil {
+		return e, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return e, errors.NewCommonEdgeX(errors.KindDatabaseError, "command sequence execution creation failed", err)
+	}
+	return e, nil
+}
+
+// commandSequenceExecutionById query command sequence execution by id from DB
+func commandSequenceExecutionById(conn redis.Conn, id string) (e pkgModels.CommandSequenceExecution, edgeXerr errors.EdgeX) {
+	edgeXerr = getObjectById(conn, commandSequenceExecutionStoredKey(id), &e)
+	if edgeXerr != nil {
+		return e, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to query command sequence execution by id %s", id), edgeXerr)
+	}
+	return
+}
+
+// commandSequenceExecutionsByKey query command sequence executions of the sorted set from DB sorted by the started-at
+// time descending
+func commandSequenceExecutionsByKey(conn redis.Conn, key string, offset int, limit int) ([]pkgModels.CommandSequenceExecution, uint32, errors.EdgeX) {
+	totalCount, edgeXerr := getMemberNumber(conn, ZCARD, key)
+	if edgeXerr != nil {
+		return nil, 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	objects, edgeXerr := getObjectsByRevRange(conn, key, offset, limit)
+	if edgeXerr != nil {
+		return nil, 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	executions := make([]pkgModels.CommandSequenceExecution, len(objects))
+	for i, o := range objects {
+		err := json.Unmarshal(o, &executions[i])
+		if err != nil {
+			return nil, 0, errors.NewCommonEdgeX(errors.KindDatabaseError, "command sequence execution format parsing failed from the database", err)
+		}
+	}
+	return executions, totalCount, nil
+}
+
+// commandSequenceExecutionsByStatus query command sequence executions by status, all the executions are queried when
+// the status is empty
+func commandSequenceExecutionsByStatus(conn redis.Conn, status string, offset int, limit int) ([]pkgModels.CommandSequenceExecution, uint32, errors.EdgeX) {
+	key := CommandSequenceExecutionCollection
+	if status != "" {
+		key = CreateKey(CommandSequenceExecutionCollectionStatus, status)
+	}
+	return commandSequenceExecutionsByKey(conn, key, offset, limit)
+}
+
+// commandSequenceExecutionsBySequenceName query command sequence executions of the command sequence
+func commandSequenceExecutionsBySequenceName(conn redis.Conn, name string, offset int, limit int) ([]pkgModels.CommandSequenceExecution, uint32, errors.EdgeX) {
+	return commandSequenceExecutionsByKey(conn, CreateKey(CommandSequenceExecutionCollectionName, name), offset, limit)
+}
+
+// updateCommandSequenceExecution replaces the command sequence execution of the same id, the created timestamp is kept
+func updateCommandSequenceExecution(conn redis.Conn, e pkgModels.CommandSequenceExecution) errors.EdgeX {
+	old, edgeXerr := commandSequenceExecutionById(conn, e.Id)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	e.Created = old.Created
+	e.Modified = pkgCommon.MakeTimestamp()
+	storedKey := commandSequenceExecutionStoredKey(e.Id)
+	_ = conn.Send(MULTI)
+	sendDeleteCommandSequenceExecutionCmd(conn, storedKey, old)
+	edgeXerr = sendAddCommandSequenceExecutionCmd(conn, storedKey, e)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "command sequence execution update failed", err)
+	}
+	return nil
+}
@@ -33,6 +33,12 @@ const (
 	LIMIT            = "LIMIT"
 	ZUNIONSTORE      = "ZUNIONSTORE"
 	ZINTERSTORE      = "ZINTERSTORE"
+	GEOADD           = "GEOADD"
+	GEOSEARCH        = "GEOSEARCH"
+	SCAN             = "SCAN"
+	HGETALL          = "HGETALL"
+	WATCH            = "WATCH"
+	UNWATCH          = "UNWATCH"
 )
 
 const (
@@ -40,4 +46,11 @@ const (
 	InfiniteMax     = "+inf"
 	GreaterThanZero = "(0"
 	DBKeySeparator  = ":"
+	FromLonLat      = "FROMLONLAT"
+	ByRadius        = "BYRADIUS"
+	Meters          = "m"
+	Asc             = "ASC"
+	WithCoord       = "WITHCOORD"
+	Match           = "MATCH"
+	Count           = "COUNT"
 )
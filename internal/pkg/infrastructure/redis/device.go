@@ -10,6 +10,7 @@ import (
 	"fmt"
 
 	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
@@ -19,11 +20,13 @@ import (
 )
 
 const (
-	DeviceCollection            = "md|dv"
-	DeviceCollectionName        = DeviceCollection + DBKeySeparator + common.Name
-	DeviceCollectionLabel       = DeviceCollection + DBKeySeparator + common.Label
-	DeviceCollectionServiceName = DeviceCollection + DBKeySeparator + common.Service + DBKeySeparator + common.Name
-	DeviceCollectionProfileName = DeviceCollection + DBKeySeparator + common.Profile + DBKeySeparator + common.Name
+	DeviceCollection             = "md|dv"
+	DeviceCollectionName         = DeviceCollection + DBKeySeparator + common.Name
+	DeviceCollectionLabel        = DeviceCollection + DBKeySeparator + common.Label
+	DeviceCollectionServiceName  = DeviceCollection + DBKeySeparator + common.Service + DBKeySeparator + common.Name
+	DeviceCollectionProfileName  = DeviceCollection + DBKeySeparator + common.Profile + DBKeySeparator + common.Name
+	DeviceCollectionLocation     = DeviceCollection + DBKeySeparator + "location"
+	DeviceCollectionTemplateName = DeviceCollection + DBKeySeparator + "template" + DBKeySeparator + common.Name
 )
 
 // deviceStoredKey return the device's stored key which combines the collection name and object id
@@ -60,14 +63,18 @@ func sendAddDeviceCmd(conn redis.Conn, storedKey string, d models.Device) errors
 	_ = conn.Send(HSET, DeviceCollectionName, d.Name, storedKey)
 	_ = conn.Send(ZADD, CreateKey(DeviceCollectionServiceName, d.ServiceName), d.Modified, storedKey)
 	_ = conn.Send(ZADD, CreateKey(DeviceCollectionProfileName, d.ProfileName), d.Modified, storedKey)
+	if templateName, _ := pkgModels.DeviceTemplateParameters(d); templateName != "" {
+		_ = conn.Send(ZADD, CreateKey(DeviceCollectionTemplateName, templateName), d.Modified, storedKey)
+	}
 	for _, label := range d.Labels {
 		_ = conn.Send(ZADD, CreateKey(DeviceCollectionLabel, label), d.Modified, storedKey)
 	}
+	sendAddDeviceLocationCmd(conn, storedKey, d)
 	return nil
 }
 
-// addDevice adds a new device into DB
-func addDevice(conn redis.Conn, d models.Device) (models.Device, errors.EdgeX) {
+// checkNewDevice checks that the device can be added, and fills its timestamps
+func checkNewDevice(conn redis.Conn, d models.Device) (models.Device, errors.EdgeX) {
 	exists, edgeXerr := deviceProfileNameExists(conn, d.ProfileName)
 	if edgeXerr != nil {
 		return d, errors.NewCommonEdgeXWrapper(edgeXerr)
@@ -89,11 +96,27 @@ func addDevice(conn redis.Conn, d models.Device) (models.Device, errors.EdgeX) {
 		return d, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("device name %s already exists", d.Name), edgeXerr)
 	}
 
+	exists, edgeXerr = trashedEntityExists(conn, common.DeviceSystemEventType, d.Name)
+	if edgeXerr != nil {
+		return d, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return d, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("device name %s is held by a deleted device in the trash", d.Name), nil)
+	}
+
 	ts := pkgCommon.MakeTimestamp()
 	if d.Created == 0 {
 		d.Created = ts
 	}
 	d.Modified = ts
+	return d, nil
+}
+
+// addDevice adds a new device into DB
+func addDevice(conn redis.Conn, d models.Device) (models.Device, errors.EdgeX) {
+	d, edgeXerr := checkNewDevice(conn, d)
+	if edgeXerr != nil {
+		return d, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
 
 	storedKey := deviceStoredKey(d.Id)
 	_ = conn.Send(MULTI)
@@ -101,6 +124,9 @@ func addDevice(conn redis.Conn, d models.Device) (models.Device, errors.EdgeX) {
 	if edgeXerr != nil {
 		return d, errors.NewCommonEdgeXWrapper(edgeXerr)
 	}
+	if edgeXerr := sendAddMetadataChangeCmd(conn, common.DeviceSystemEventType, common.SystemEventActionAdd, d.Name, d); edgeXerr != nil {
+		return d, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
 	_, err := conn.Do(EXEC)
 	if err != nil {
 		edgeXerr = errors.NewCommonEdgeX(errors.KindDatabaseError, "device creation failed", err)
@@ -160,9 +186,13 @@ func sendDeleteDeviceCmd(conn redis.Conn, storedKey string, device models.Device
 	_ = conn.Send(HDEL, DeviceCollectionName, device.Name)
 	_ = conn.Send(ZREM, CreateKey(DeviceCollectionServiceName, device.ServiceName), storedKey)
 	_ = conn.Send(ZREM, CreateKey(DeviceCollectionProfileName, device.ProfileName), storedKey)
+	if templateName, _ := pkgModels.DeviceTemplateParameters(device); templateName != "" {
+		_ = conn.Send(ZREM, CreateKey(DeviceCollectionTemplateName, templateName), storedKey)
+	}
 	for _, label := range device.Labels {
 		_ = conn.Send(ZREM, CreateKey(DeviceCollectionLabel, label), storedKey)
 	}
+	_ = conn.Send(ZREM, DeviceCollectionLocation, storedKey)
 }
 
 // deleteDevice deletes a device
@@ -170,6 +200,9 @@ func deleteDevice(conn redis.Conn, device models.Device) errors.EdgeX {
 	storedKey := deviceStoredKey(device.Id)
 	_ = conn.Send(MULTI)
 	sendDeleteDeviceCmd(conn, storedKey, device)
+	if edgeXerr := sendAddMetadataChangeCmd(conn, common.DeviceSystemEventType, common.SystemEventActionDelete, device.Name, device); edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
 	_, err := conn.Do(EXEC)
 	if err != nil {
 		return errors.NewCommonEdgeX(errors.KindDatabaseError, "device deletion failed", err)
@@ -234,6 +267,25 @@ func devicesByProfileName(conn redis.Conn, offset int, limit int, profileName st
 	return devices, nil
 }
 
+// devicesByTemplateName query devices by offset, limit and the name of the device template they were created from
+func devicesByTemplateName(conn redis.Conn, offset int, limit int, templateName string) (devices []models.Device, edgeXerr errors.EdgeX) {
+	objects, err := getObjectsByRevRange(conn, CreateKey(DeviceCollectionTemplateName, templateName), offset, limit)
+	if err != nil {
+		return devices, errors.NewCommonEdgeXWrapper(err)
+	}
+
+	devices = make([]models.Device, len(objects))
+	for i, in := range objects {
+		s := models.Device{}
+		err := json.Unmarshal(in, &s)
+		if err != nil {
+			return []models.Device{}, errors.NewCommonEdgeX(errors.KindDatabaseError, "device format parsing failed from the database", err)
+		}
+		devices[i] = s
+	}
+	return devices, nil
+}
+
 func updateDevice(conn redis.Conn, d models.Device) errors.EdgeX {
 	exists, edgeXerr := deviceProfileNameExists(conn, d.ProfileName)
 	if edgeXerr != nil {
@@ -257,6 +309,9 @@ func updateDevice(conn redis.Conn, d models.Device) errors.EdgeX {
 	if edgexErr != nil {
 		return errors.NewCommonEdgeXWrapper(edgexErr)
 	}
+	if edgeXerr := sendAddMetadataChangeCmd(conn, common.DeviceSystemEventType, common.SystemEventActionUpdate, d.Name, d); edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
 	_, err := conn.Do(EXEC)
 	if err != nil {
 		return errors.NewCommonEdgeX(errors.KindDatabaseError, "device update failed", err)
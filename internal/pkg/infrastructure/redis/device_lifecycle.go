@@ -0,0 +1,81 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	DeviceLifecycleAuditCollection           = "md|dla"
+	DeviceLifecycleAuditCollectionDeviceName = DeviceLifecycleAuditCollection + DBKeySeparator + common.Device + DBKeySeparator + common.Name
+)
+
+// deviceLifecycleAuditStoredKey return the device lifecycle audit's stored key which combines the collection name and object id
+func deviceLifecycleAuditStoredKey(id string) string {
+	return CreateKey(DeviceLifecycleAuditCollection, id)
+}
+
+// addDeviceLifecycleAudit appends a new device lifecycle audit record into DB
+func addDeviceLifecycleAudit(conn redis.Conn, audit pkgModels.DeviceLifecycleAudit) (pkgModels.DeviceLifecycleAudit, errors.EdgeX) {
+	if audit.Created == 0 {
+		audit.Created = pkgCommon.MakeTimestamp()
+	}
+
+	m, err := json.Marshal(audit)
+	if err != nil {
+		return audit, errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal device lifecycle audit for Redis persistence", err)
+	}
+
+	storedKey := deviceLifecycleAuditStoredKey(audit.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(SET, storedKey, m)
+	_ = conn.Send(ZADD, DeviceLifecycleAuditCollection, audit.Created, storedKey)
+	_ = conn.Send(ZADD, CreateKey(DeviceLifecycleAuditCollectionDeviceName, audit.DeviceName), audit.Created, storedKey)
+	_, err = conn.Do(EXEC)
+	if err != nil {
+		return audit, errors.NewCommonEdgeX(errors.KindDatabaseError, "device lifecycle audit creation failed", err)
+	}
+
+	return audit, nil
+}
+
+// allDeviceLifecycleAudits queries device lifecycle audits by offset and limit
+func allDeviceLifecycleAudits(conn redis.Conn, offset int, limit int) ([]pkgModels.DeviceLifecycleAudit, errors.EdgeX) {
+	objects, edgeXerr := getObjectsByRevRange(conn, DeviceLifecycleAuditCollection, offset, limit)
+	if edgeXerr != nil {
+		return nil, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return objectsToDeviceLifecycleAudits(objects)
+}
+
+// deviceLifecycleAuditsByDeviceName queries device lifecycle audits by offset, limit and device name
+func deviceLifecycleAuditsByDeviceName(conn redis.Conn, offset int, limit int, name string) ([]pkgModels.DeviceLifecycleAudit, errors.EdgeX) {
+	objects, edgeXerr := getObjectsByRevRange(conn, CreateKey(DeviceLifecycleAuditCollectionDeviceName, name), offset, limit)
+	if edgeXerr != nil {
+		return nil, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return objectsToDeviceLifecycleAudits(objects)
+}
+
+func objectsToDeviceLifecycleAudits(objects [][]byte) ([]pkgModels.DeviceLifecycleAudit, errors.EdgeX) {
+	audits := make([]pkgModels.DeviceLifecycleAudit, len(objects))
+	for i, o := range objects {
+		err := json.Unmarshal(o, &audits[i])
+		if err != nil {
+			return []pkgModels.DeviceLifecycleAudit{}, errors.NewCommonEdgeX(errors.KindDatabaseError, "device lifecycle audit format parsing failed from the database", err)
+		}
+	}
+	return audits, nil
+}
@@ -0,0 +1,114 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/gomodule/redigo/redis"
+
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// geoMember is a member of the device location index with its indexed coordinates
+type geoMember struct {
+	storedKey string
+	latitude  float64
+	longitude float64
+}
+
+// sendAddDeviceLocationCmd indexes the device by its coordinates, the devices without structured location or
+// coordinates are not indexed
+func sendAddDeviceLocationCmd(conn redis.Conn, storedKey string, d models.Device) {
+	l, structured, err := pkgModels.ParseDeviceLocation(d.Location)
+	if err != nil || !structured || !l.HasCoordinates() {
+		return
+	}
+	_ = conn.Send(GEOADD, DeviceCollectionLocation, *l.Longitude, *l.Latitude, storedKey)
+}
+
+// searchDeviceLocations returns the indexed devices within the circle, ordered from the nearest to the farthest
+func searchDeviceLocations(conn redis.Conn, c pkgModels.GeoCircle) ([]geoMember, errors.EdgeX) {
+	// GEOSEARCH key FROMLONLAT longitude latitude BYRADIUS radius m ASC WITHCOORD
+	values, err := redis.Values(conn.Do(GEOSEARCH, DeviceCollectionLocation, FromLonLat, c.Longitude, c.Latitude, ByRadius, c.Radius, Meters, Asc, WithCoord))
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "device location search failed", err)
+	}
+	members := make([]geoMember, len(values))
+	for i, v := range values {
+		fields, err := redis.Values(v, nil)
+		if err != nil || len(fields) != 2 {
+			return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "device location format parsing failed from the database", err)
+		}
+		coordinates, err := redis.Float64s(fields[1], nil)
+		if err != nil || len(coordinates) != 2 {
+			return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "device location format parsing failed from the database", err)
+		}
+		members[i].storedKey, err = redis.String(fields[0], nil)
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "device location format parsing failed from the database", err)
+		}
+		members[i].longitude, members[i].latitude = coordinates[0], coordinates[1]
+	}
+	return members, nil
+}
+
+// devicesByGeoMembers returns the devices of the members within offset and limit, and the total count of the members
+func devicesByGeoMembers(conn redis.Conn, members []geoMember, offset int, limit int) (devices []models.Device, totalCount uint32, edgeXerr errors.EdgeX) {
+	totalCount = uint32(len(members))
+	if offset > len(members) {
+		return devices, totalCount, errors.NewCommonEdgeX(errors.KindRangeNotSatisfiable, fmt.Sprintf("query objects bounds out of range. length:%v", len(members)), nil)
+	}
+	members = members[offset:]
+	if limit >= 0 && limit < len(members) {
+		members = members[:limit]
+	}
+	storedKeys := make([]interface{}, len(members))
+	for i, m := range members {
+		storedKeys[i] = m.storedKey
+	}
+	objects, edgeXerr := getObjectsByIds(conn, storedKeys)
+	if edgeXerr != nil {
+		return devices, totalCount, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	devices = make([]models.Device, len(objects))
+	for i, in := range objects {
+		err := json.Unmarshal(in, &devices[i])
+		if err != nil {
+			return []models.Device{}, totalCount, errors.NewCommonEdgeX(errors.KindDatabaseError, "device format parsing failed from the database", err)
+		}
+	}
+	return devices, totalCount, nil
+}
+
+// devicesByGeoCircle query the devices located within the circle by offset and limit, nearest first
+func devicesByGeoCircle(conn redis.Conn, c pkgModels.GeoCircle, offset int, limit int) ([]models.Device, uint32, errors.EdgeX) {
+	members, edgeXerr := searchDeviceLocations(conn, c)
+	if edgeXerr != nil {
+		return nil, 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return devicesByGeoMembers(conn, members, offset, limit)
+}
+
+// devicesByGeoBoundingBox query the devices located within the bounding box by offset and limit, the index is
+// searched by the circumcircle of the box and the results are filtered by the box
+func devicesByGeoBoundingBox(conn redis.Conn, b pkgModels.GeoBoundingBox, offset int, limit int) ([]models.Device, uint32, errors.EdgeX) {
+	members, edgeXerr := searchDeviceLocations(conn, b.Circumcircle())
+	if edgeXerr != nil {
+		return nil, 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	inBox := make([]geoMember, 0, len(members))
+	for _, m := range members {
+		if b.Contains(m.latitude, m.longitude) {
+			inBox = append(inBox, m)
+		}
+	}
+	return devicesByGeoMembers(conn, inBox, offset, limit)
+}
@@ -83,6 +83,13 @@ func addDeviceProfile(conn redis.Conn, dp models.DeviceProfile) (models.DevicePr
 		return dp, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("device profile name %s exists", dp.Name), edgeXerr)
 	}
 
+	exists, edgeXerr = trashedEntityExists(conn, common.DeviceProfileSystemEventType, dp.Name)
+	if edgeXerr != nil {
+		return dp, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return dp, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("device profile name %s is held by a deleted device profile in the trash", dp.Name), nil)
+	}
+
 	ts := pkgCommon.MakeTimestamp()
 	// For Redis DB, the PUT or PATCH operation will removes the old object and add the modified one,
 	// so the Created is not zero value and we shouldn't set the timestamp again.
@@ -97,6 +104,9 @@ func addDeviceProfile(conn redis.Conn, dp models.DeviceProfile) (models.DevicePr
 	if edgeXerr != nil {
 		return dp, errors.NewCommonEdgeXWrapper(edgeXerr)
 	}
+	if edgeXerr := sendAddMetadataChangeCmd(conn, common.DeviceProfileSystemEventType, common.SystemEventActionAdd, dp.Name, dp); edgeXerr != nil {
+		return dp, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
 	_, err := conn.Do(EXEC)
 	if err != nil {
 		edgeXerr = errors.NewCommonEdgeX(errors.KindDatabaseError, "device profile creation failed", err)
@@ -139,6 +149,9 @@ func deleteDeviceProfile(conn redis.Conn, dp models.DeviceProfile) errors.EdgeX
 	storedKey := deviceProfileStoredKey(dp.Id)
 	_ = conn.Send(MULTI)
 	sendDeleteDeviceProfileCmd(conn, storedKey, dp)
+	if edgeXerr := sendAddMetadataChangeCmd(conn, common.DeviceProfileSystemEventType, common.SystemEventActionDelete, dp.Name, dp); edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
 	_, err := conn.Do(EXEC)
 	if err != nil {
 		return errors.NewCommonEdgeX(errors.KindDatabaseError, "device profile deletion failed", err)
@@ -172,6 +185,9 @@ func updateDeviceProfile(conn redis.Conn, dp models.DeviceProfile) (edgeXerr err
 	if edgeXerr != nil {
 		return errors.NewCommonEdgeXWrapper(edgeXerr)
 	}
+	if edgeXerr := sendAddMetadataChangeCmd(conn, common.DeviceProfileSystemEventType, common.SystemEventActionUpdate, dp.Name, dp); edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
 	_, err := conn.Do(EXEC)
 	if err != nil {
 		return errors.NewCommonEdgeX(errors.KindDatabaseError, "device profile update failed", err)
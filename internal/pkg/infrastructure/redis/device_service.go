@@ -91,6 +91,9 @@ func addDeviceService(conn redis.Conn, ds models.DeviceService) (addedDeviceServ
 	if edgeXerr != nil {
 		return ds, errors.NewCommonEdgeXWrapper(edgeXerr)
 	}
+	if edgeXerr := sendAddMetadataChangeCmd(conn, common.DeviceServiceSystemEventType, common.SystemEventActionAdd, ds.Name, ds); edgeXerr != nil {
+		return ds, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
 	_, err := conn.Do(EXEC)
 	if err != nil {
 		edgeXerr = errors.NewCommonEdgeX(errors.KindDatabaseError, "device service creation failed", err)
@@ -131,6 +134,9 @@ func deleteDeviceService(conn redis.Conn, ds models.DeviceService) errors.EdgeX
 	storedKey := deviceServiceStoredKey(ds.Id)
 	_ = conn.Send(MULTI)
 	sendDeleteDeviceServiceCmd(conn, storedKey, ds)
+	if edgeXerr := sendAddMetadataChangeCmd(conn, common.DeviceServiceSystemEventType, common.SystemEventActionDelete, ds.Name, ds); edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
 	_, err := conn.Do(EXEC)
 	if err != nil {
 		return errors.NewCommonEdgeX(errors.KindDatabaseError, "device service deletion failed", err)
@@ -214,6 +220,9 @@ func updateDeviceService(conn redis.Conn, ds models.DeviceService) errors.EdgeX
 	if edgeXerr != nil {
 		return errors.NewCommonEdgeXWrapper(edgeXerr)
 	}
+	if edgeXerr := sendAddMetadataChangeCmd(conn, common.DeviceServiceSystemEventType, common.SystemEventActionUpdate, ds.Name, ds); edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
 	_, err := conn.Do(EXEC)
 	if err != nil {
 		return errors.NewCommonEdgeX(errors.KindDatabaseError, "device service update failed", err)
@@ -0,0 +1,164 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	DeviceTemplateCollection      = "md|dt"
+	DeviceTemplateCollectionName  = DeviceTemplateCollection + DBKeySeparator + common.Name
+	DeviceTemplateCollectionLabel = DeviceTemplateCollection + DBKeySeparator + common.Label
+)
+
+// deviceTemplateStoredKey return the device template's stored key which combines the collection name and object id
+func deviceTemplateStoredKey(id string) string {
+	return CreateKey(DeviceTemplateCollection, id)
+}
+
+// sendAddDeviceTemplateCmd send redis command for adding device template
+func sendAddDeviceTemplateCmd(conn redis.Conn, storedKey string, t pkgModels.DeviceTemplate) errors.EdgeX {
+	m, err := json.Marshal(t)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal device template for Redis persistence", err)
+	}
+	_ = conn.Send(SET, storedKey, m)
+	_ = conn.Send(ZADD, DeviceTemplateCollection, t.Modified, storedKey)
+	_ = conn.Send(HSET, DeviceTemplateCollectionName, t.Name, storedKey)
+	for _, label := range t.Labels {
+		_ = conn.Send(ZADD, CreateKey(DeviceTemplateCollectionLabel, label), t.Modified, storedKey)
+	}
+	return nil
+}
+
+// sendDeleteDeviceTemplateCmd send redis command for deleting device template
+func sendDeleteDeviceTemplateCmd(conn redis.Conn, storedKey string, t pkgModels.DeviceTemplate) {
+	_ = conn.Send(DEL, storedKey)
+	_ = conn.Send(ZREM, DeviceTemplateCollection, storedKey)
+	_ = conn.Send(HDEL, DeviceTemplateCollectionName, t.Name)
+	for _, label := range t.Labels {
+		_ = conn.Send(ZREM, CreateKey(DeviceTemplateCollectionLabel, label), storedKey)
+	}
+}
+
+// addDeviceTemplate adds a new device template into DB
+func addDeviceTemplate(conn redis.Conn, t pkgModels.DeviceTemplate) (pkgModels.DeviceTemplate, errors.EdgeX) {
+	exists, edgeXerr := objectIdExists(conn, deviceTemplateStoredKey(t.Id))
+	if edgeXerr != nil {
+		return t, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return t, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("device template id %s already exists", t.Id), edgeXerr)
+	}
+
+	exists, edgeXerr = objectNameExists(conn, DeviceTemplateCollectionName, t.Name)
+	if edgeXerr != nil {
+		return t, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return t, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("device template name %s already exists", t.Name), edgeXerr)
+	}
+
+	if t.Created == 0 {
+		t.Created = pkgCommon.MakeTimestamp()
+	}
+	t.Modified = t.Created
+
+	storedKey := deviceTemplateStoredKey(t.Id)
+	_ = conn.Send(MULTI)
+	edgeXerr = sendAddDeviceTemplateCmd(conn, storedKey, t)
+	if edgeXerr != nil {
+		return t, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	if edgeXerr := sendAddMetadataChangeCmd(conn, pkgCommon.DeviceTemplate, common.SystemEventActionAdd, t.Name, t); edgeXerr != nil {
+		return t, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return t, errors.NewCommonEdgeX(errors.KindDatabaseError, "device template creation failed", err)
+	}
+	return t, nil
+}
+
+// deviceTemplateByName query device template by name from DB
+func deviceTemplateByName(conn redis.Conn, name string) (template pkgModels.DeviceTemplate, edgeXerr errors.EdgeX) {
+	edgeXerr = getObjectByHash(conn, DeviceTemplateCollectionName, name, &template)
+	if edgeXerr != nil {
+		return template, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to query device template by name %s", name), edgeXerr)
+	}
+	return
+}
+
+// deviceTemplatesByLabels query multiple device templates from DB per labels
+func deviceTemplatesByLabels(conn redis.Conn, offset int, limit int, labels []string) ([]pkgModels.DeviceTemplate, errors.EdgeX) {
+	objects, edgeXerr := getObjectsByLabelsAndSomeRange(conn, ZREVRANGE, DeviceTemplateCollection, labels, offset, limit)
+	if edgeXerr != nil {
+		return nil, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	templates := make([]pkgModels.DeviceTemplate, len(objects))
+	for i, o := range objects {
+		err := json.Unmarshal(o, &templates[i])
+		if err != nil {
+			return []pkgModels.DeviceTemplate{}, errors.NewCommonEdgeX(errors.KindDatabaseError, "device template format parsing failed from the database", err)
+		}
+	}
+	return templates, nil
+}
+
+// updateDeviceTemplate replaces the device template of the same name, the id and created timestamp are kept
+func updateDeviceTemplate(conn redis.Conn, t pkgModels.DeviceTemplate) errors.EdgeX {
+	oldTemplate, edgeXerr := deviceTemplateByName(conn, t.Name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	t.Id = oldTemplate.Id
+	t.Created = oldTemplate.Created
+	t.Modified = pkgCommon.MakeTimestamp()
+	storedKey := deviceTemplateStoredKey(t.Id)
+	_ = conn.Send(MULTI)
+	sendDeleteDeviceTemplateCmd(conn, storedKey, oldTemplate)
+	edgeXerr = sendAddDeviceTemplateCmd(conn, storedKey, t)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	if edgeXerr := sendAddMetadataChangeCmd(conn, pkgCommon.DeviceTemplate, common.SystemEventActionUpdate, t.Name, t); edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "device template update failed", err)
+	}
+	return nil
+}
+
+// deleteDeviceTemplateByName deletes the device template by name
+func deleteDeviceTemplateByName(conn redis.Conn, name string) errors.EdgeX {
+	template, edgeXerr := deviceTemplateByName(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	_ = conn.Send(MULTI)
+	sendDeleteDeviceTemplateCmd(conn, deviceTemplateStoredKey(template.Id), template)
+	if edgeXerr := sendAddMetadataChangeCmd(conn, pkgCommon.DeviceTemplate, common.SystemEventActionDelete, template.Name, template); edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "device template deletion failed", err)
+	}
+	return nil
+}
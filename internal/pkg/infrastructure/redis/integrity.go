@@ -0,0 +1,409 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/gomodule/redigo/redis"
+	"github.com/google/uuid"
+
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+// scanCount is the number of keys hinted to the SCAN command per iteration
+const scanCount = 1000
+
+// metadataIndexSpec describes how the entities of a metadata collection are stored and indexed
+type metadataIndexSpec struct {
+	entityType string
+	collection string
+	nameHash   string
+	// indexPrefixes are the prefixes of the secondary sorted sets keyed by a property value, e.g. the labels
+	indexPrefixes []string
+	// indexKeys are the secondary sorted sets which are not keyed by a property value, e.g. the device locations
+	indexKeys []string
+	// sendAdd decodes the stored entity and sends the commands adding it with its indexes
+	sendAdd func(conn redis.Conn, storedKey string, data []byte) errors.EdgeX
+}
+
+// metadataIndexSpecs are the metadata collections checked and rebuilt by the index integrity check
+var metadataIndexSpecs = []metadataIndexSpec{
+	{
+		entityType:    common.DeviceServiceSystemEventType,
+		collection:    DeviceServiceCollection,
+		nameHash:      DeviceServiceCollectionName,
+		indexPrefixes: []string{DeviceServiceCollectionLabel},
+		sendAdd:       decodeAndSendAddCmd(sendAddDeviceServiceCmd),
+	},
+	{
+		entityType:    common.DeviceProfileSystemEventType,
+		collection:    DeviceProfileCollection,
+		nameHash:      DeviceProfileCollectionName,
+		indexPrefixes: []string{DeviceProfileCollectionLabel, DeviceProfileCollectionModel, DeviceProfileCollectionManufacturer},
+		sendAdd:       decodeAndSendAddCmd(sendAddDeviceProfileCmd),
+	},
+	{
+		entityType:    common.DeviceSystemEventType,
+		collection:    DeviceCollection,
+		nameHash:      DeviceCollectionName,
+		indexPrefixes: []string{DeviceCollectionLabel, DeviceCollectionServiceName, DeviceCollectionProfileName, DeviceCollectionTemplateName},
+		indexKeys:     []string{DeviceCollectionLocation},
+		sendAdd:       decodeAndSendAddCmd(sendAddDeviceCmd),
+	},
+	{
+		entityType:    common.ProvisionWatcherSystemEventType,
+		collection:    ProvisionWatcherCollection,
+		nameHash:      ProvisionWatcherCollectionName,
+		indexPrefixes: []string{ProvisionWatcherCollectionLabel, ProvisionWatcherCollectionServiceName, ProvisionWatcherCollectionProfileName},
+		sendAdd:       decodeAndSendAddCmd(sendAddProvisionWatcherCmd),
+	},
+	{
+		entityType:    pkgCommon.DeviceTemplate,
+		collection:    DeviceTemplateCollection,
+		nameHash:      DeviceTemplateCollectionName,
+		indexPrefixes: []string{DeviceTemplateCollectionLabel},
+		sendAdd:       decodeAndSendAddCmd(sendAddDeviceTemplateCmd),
+	},
+	{
+		entityType:    pkgCommon.PendingDevice,
+		collection:    PendingDeviceCollection,
+		nameHash:      PendingDeviceCollectionName,
+		indexPrefixes: []string{PendingDeviceCollectionStatus},
+		sendAdd:       decodeAndSendAddCmd(sendAddPendingDeviceCmd),
+	},
+	{
+		entityType: pkgCommon.UnitOfMeasureCategory,
+		collection: UnitOfMeasureCategoryCollection,
+		nameHash:   UnitOfMeasureCategoryCollectionName,
+		sendAdd:    decodeAndSendAddCmd(sendAddUnitOfMeasureCategoryCmd),
+	},
+}
+
+// decodeAndSendAddCmd adapts the function sending the commands to add an entity to the stored JSON of the entity
+func decodeAndSendAddCmd[T any](sendAdd func(conn redis.Conn, storedKey string, entity T) errors.EdgeX) func(redis.Conn, string, []byte) errors.EdgeX {
+	return func(conn redis.Conn, storedKey string, data []byte) errors.EdgeX {
+		var entity T
+		if err := json.Unmarshal(data, &entity); err != nil {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON unmarshal the stored entity", err)
+		}
+		return sendAdd(conn, storedKey, entity)
+	}
+}
+
+// recordedCmd is a command recorded by the indexRecorder
+type recordedCmd struct {
+	name string
+	args []any
+}
+
+// indexRecorder is a redis.Conn which records the sent commands instead of executing them, so that the index entries
+// expected for a stored entity are derived from the same commands which maintain the indexes when the entity is added
+type indexRecorder struct {
+	cmds []recordedCmd
+}
+
+func (r *indexRecorder) Close() error { return nil }
+
+func (r *indexRecorder) Err() error { return nil }
+
+func (r *indexRecorder) Do(commandName string, _ ...any) (any, error) {
+	return nil, fmt.Errorf("command %s can't be executed while recording the index commands", commandName)
+}
+
+func (r *indexRecorder) Send(commandName string, args ...any) error {
+	r.cmds = append(r.cmds, recordedCmd{name: commandName, args: args})
+	return nil
+}
+
+func (r *indexRecorder) Flush() error { return nil }
+
+func (r *indexRecorder) Receive() (any, error) {
+	return nil, fmt.Errorf("no reply is available while recording the index commands")
+}
+
+// expectedIndexes are the index entries derived from the stored entities of a collection
+type expectedIndexes struct {
+	// sortedSets maps the key of each sorted set to its expected members
+	sortedSets map[string]map[string]bool
+	// names maps the name of each entity to its stored key
+	names map[string]string
+	// cmds are the commands adding the index entries, excluding the commands storing the entities
+	cmds            []recordedCmd
+	inconsistencies []pkgModels.IndexInconsistency
+}
+
+// buildExpectedIndexes derives the expected index entries of the stored entities keyed by their stored keys
+func buildExpectedIndexes(spec metadataIndexSpec, entities map[string][]byte) expectedIndexes {
+	expected := expectedIndexes{
+		sortedSets: map[string]map[string]bool{spec.collection: {}},
+		names:      make(map[string]string),
+	}
+	for _, storedKey := range sortedKeys(entities) {
+		recorder := &indexRecorder{}
+		if edgeXerr := spec.sendAdd(recorder, storedKey, entities[storedKey]); edgeXerr != nil {
+			expected.inconsistencies = append(expected.inconsistencies, pkgModels.IndexInconsistency{
+				EntityType:  spec.entityType,
+				Index:       spec.collection,
+				Member:      storedKey,
+				Problem:     pkgModels.IndexInvalidEntity,
+				Description: fmt.Sprintf("%s %s can't be indexed: %v", spec.entityType, storedKey, edgeXerr),
+			})
+			continue
+		}
+		for _, cmd := range recorder.cmds {
+			switch cmd.name {
+			case ZADD, GEOADD:
+				// ZADD key score member, GEOADD key longitude latitude member
+				key, member := fmt.Sprint(cmd.args[0]), fmt.Sprint(cmd.args[len(cmd.args)-1])
+				if expected.sortedSets[key] == nil {
+					expected.sortedSets[key] = make(map[string]bool)
+				}
+				expected.sortedSets[key][member] = true
+			case HSET:
+				// HSET hash name storedKey
+				name := fmt.Sprint(cmd.args[1])
+				if other, exists := expected.names[name]; exists {
+					expected.inconsistencies = append(expected.inconsistencies, pkgModels.IndexInconsistency{
+						EntityType:  spec.entityType,
+						Index:       spec.nameHash,
+						Member:      name,
+						Problem:     pkgModels.IndexDuplicateName,
+						Description: fmt.Sprintf("%s name %s is used by both %s and %s", spec.entityType, name, other, storedKey),
+					})
+				}
+				expected.names[name] = storedKey
+			case SET:
+				continue
+			}
+			expected.cmds = append(expected.cmds, cmd)
+		}
+	}
+	return expected
+}
+
+// diffIndexes compares the expected index entries with the actual sorted set members and name index
+func diffIndexes(spec metadataIndexSpec, expected expectedIndexes, sortedSets map[string][]string, names map[string]string) []pkgModels.IndexInconsistency {
+	inconsistencies := slices.Clone(expected.inconsistencies)
+	add := func(index, member, problem, description string) {
+		inconsistencies = append(inconsistencies, pkgModels.IndexInconsistency{
+			EntityType:  spec.entityType,
+			Index:       index,
+			Member:      member,
+			Problem:     problem,
+			Description: description,
+		})
+	}
+
+	keys := sortedKeys(sortedSets)
+	for key := range expected.sortedSets {
+		if _, ok := sortedSets[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		actual := make(map[string]bool, len(sortedSets[key]))
+		for _, member := range sortedSets[key] {
+			actual[member] = true
+		}
+		for _, member := range sortedKeys(expected.sortedSets[key]) {
+			if !actual[member] {
+				add(key, member, pkgModels.IndexEntryMissing, fmt.Sprintf("%s %s is missing from the index", spec.entityType, member))
+			}
+		}
+		for _, member := range sortedKeys(actual) {
+			if !expected.sortedSets[key][member] {
+				add(key, member, pkgModels.IndexEntryStale, fmt.Sprintf("the index refers to %s which doesn't exist or doesn't belong to the index", member))
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(expected.names) {
+		storedKey, ok := names[name]
+		if !ok {
+			add(spec.nameHash, name, pkgModels.IndexEntryMissing, fmt.Sprintf("%s name %s of %s is missing from the name index", spec.entityType, name, expected.names[name]))
+		} else if storedKey != expected.names[name] {
+			add(spec.nameHash, name, pkgModels.IndexEntryMismatch, fmt.Sprintf("%s name %s refers to %s instead of %s", spec.entityType, name, storedKey, expected.names[name]))
+		}
+	}
+	for _, name := range sortedKeys(names) {
+		if _, ok := expected.names[name]; !ok {
+			add(spec.nameHash, name, pkgModels.IndexEntryStale, fmt.Sprintf("%s name %s refers to %s which doesn't exist", spec.entityType, name, names[name]))
+		}
+	}
+	return inconsistencies
+}
+
+// collectionIndexes is the state of the indexes of a metadata collection
+type collectionIndexes struct {
+	entities uint32
+	expected expectedIndexes
+	// indexKeys are the keys of all the existing and expected indexes of the collection
+	indexKeys []string
+}
+
+// checkCollectionIndexes loads the stored entities and the indexes of the collection and compares them
+func checkCollectionIndexes(conn redis.Conn, spec metadataIndexSpec) (collectionIndexes, []pkgModels.IndexInconsistency, errors.EdgeX) {
+	var state collectionIndexes
+
+	// the entities are stored under the collection name followed by their ids, which distinguishes them from the indexes
+	keys, edgeXerr := scanKeys(conn, CreateKey(spec.collection, "*"))
+	if edgeXerr != nil {
+		return state, nil, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	var storedKeys []any
+	for _, key := range keys {
+		if _, err := uuid.Parse(strings.TrimPrefix(key, spec.collection+DBKeySeparator)); err == nil {
+			storedKeys = append(storedKeys, key)
+		}
+	}
+	entities := make(map[string][]byte, len(storedKeys))
+	if len(storedKeys) > 0 {
+		values, err := redis.ByteSlices(conn.Do(MGET, storedKeys...))
+		if err != nil {
+			return state, nil, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("query %s entities from database failed", spec.entityType), err)
+		}
+		for i, value := range values {
+			// the entity may have been deleted since the scan
+			if value != nil {
+				entities[storedKeys[i].(string)] = value
+			}
+		}
+	}
+	state.entities = uint32(len(entities))
+	state.expected = buildExpectedIndexes(spec, entities)
+
+	indexKeys := append([]string{spec.collection}, spec.indexKeys...)
+	for _, prefix := range spec.indexPrefixes {
+		keys, edgeXerr := scanKeys(conn, CreateKey(prefix, "*"))
+		if edgeXerr != nil {
+			return state, nil, errors.NewCommonEdgeXWrapper(edgeXerr)
+		}
+		indexKeys = append(indexKeys, keys...)
+	}
+	sortedSets := make(map[string][]string, len(indexKeys))
+	for _, key := range indexKeys {
+		members, err := redis.Strings(conn.Do(ZRANGE, key, 0, -1))
+		if err != nil {
+			return state, nil, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("query index %s from database failed", key), err)
+		}
+		if len(members) > 0 {
+			sortedSets[key] = members
+		}
+	}
+	names, err := redis.StringMap(conn.Do(HGETALL, spec.nameHash))
+	if err != nil {
+		return state, nil, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("query index %s from database failed", spec.nameHash), err)
+	}
+
+	state.indexKeys = append(indexKeys, spec.nameHash)
+	return state, diffIndexes(spec, state.expected, sortedSets, names), nil
+}
+
+// sendRebuildIndexesCmd sends the commands to drop the indexes of the collection and add the entries of the stored
+// entities again, it must be called in a MULTI transaction
+func (state collectionIndexes) sendRebuildIndexesCmd(conn redis.Conn) {
+	for _, key := range state.indexKeys {
+		_ = conn.Send(DEL, key)
+	}
+	for _, cmd := range state.expected.cmds {
+		_ = conn.Send(cmd.name, cmd.args...)
+	}
+}
+
+// checkMetadataIndexes checks the secondary indexes of the metadata collections against the stored entities, and
+// rebuilds the indexes from the stored entities when inconsistencies are found and the rebuild is requested
+func checkMetadataIndexes(conn redis.Conn, rebuild bool) (pkgModels.IndexIntegrity, errors.EdgeX) {
+	result := pkgModels.IndexIntegrity{Entities: make(map[string]uint32, len(metadataIndexSpecs))}
+	if rebuild {
+		// every addition, update and deletion of a metadata entity updates the name index of its collection, so watching
+		// the name indexes aborts the rebuild when the metadata is modified after being checked
+		nameHashes := make([]any, len(metadataIndexSpecs))
+		for i, spec := range metadataIndexSpecs {
+			nameHashes[i] = spec.nameHash
+		}
+		if _, err := conn.Do(WATCH, nameHashes...); err != nil {
+			return result, errors.NewCommonEdgeX(errors.KindDatabaseError, "watch metadata name indexes failed", err)
+		}
+		defer func() {
+			_, _ = conn.Do(UNWATCH)
+		}()
+	}
+
+	states := make([]collectionIndexes, len(metadataIndexSpecs))
+	for i, spec := range metadataIndexSpecs {
+		state, inconsistencies, edgeXerr := checkCollectionIndexes(conn, spec)
+		if edgeXerr != nil {
+			return result, errors.NewCommonEdgeXWrapper(edgeXerr)
+		}
+		states[i] = state
+		result.Entities[spec.entityType] = state.entities
+		result.Inconsistencies = append(result.Inconsistencies, inconsistencies...)
+	}
+	if !rebuild || len(result.Inconsistencies) == 0 {
+		return result, nil
+	}
+
+	_ = conn.Send(MULTI)
+	for _, state := range states {
+		state.sendRebuildIndexesCmd(conn)
+	}
+	reply, err := conn.Do(EXEC)
+	if err != nil {
+		return result, errors.NewCommonEdgeX(errors.KindDatabaseError, "metadata indexes rebuild failed", err)
+	} else if reply == nil {
+		return result, errors.NewCommonEdgeX(errors.KindStatusConflict, "metadata was modified while rebuilding the indexes, please retry", nil)
+	}
+	result.Rebuilt = true
+	return result, nil
+}
+
+// scanKeys returns the keys matching the pattern
+func scanKeys(conn redis.Conn, pattern string) ([]string, errors.EdgeX) {
+	found := make(map[string]bool)
+	cursor := 0
+	for {
+		values, err := redis.Values(conn.Do(SCAN, cursor, Match, pattern, Count, scanCount))
+		if err == nil && len(values) != 2 {
+			err = fmt.Errorf("unexpected SCAN reply of %d elements", len(values))
+		}
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("scan keys matching %s failed", pattern), err)
+		}
+		cursor, err = redis.Int(values[0], nil)
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "scan cursor parsing failed", err)
+		}
+		keys, err := redis.Strings(values[1], nil)
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "scanned keys parsing failed", err)
+		}
+		// SCAN may return a key more than once
+		for _, key := range keys {
+			found[key] = true
+		}
+		if cursor == 0 {
+			return sortedKeys(found), nil
+		}
+	}
+}
+
+// sortedKeys returns the sorted keys of the map
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,104 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+)
+
+func deviceIndexSpec(t *testing.T) metadataIndexSpec {
+	for _, spec := range metadataIndexSpecs {
+		if spec.entityType == common.DeviceSystemEventType {
+			return spec
+		}
+	}
+	require.Fail(t, "device index spec not found")
+	return metadataIndexSpec{}
+}
+
+func TestBuildExpectedIndexes(t *testing.T) {
+	spec := deviceIndexSpec(t)
+	device := models.Device{
+		Id:          exampleUUID,
+		Name:        testDeviceName,
+		ServiceName: "testServiceName",
+		ProfileName: testProfileName,
+		Labels:      []string{"label"},
+		Location:    map[string]any{"latitude": 25.03, "longitude": 121.56},
+		Properties:  map[string]any{pkgModels.DeviceTemplateNameProperty: "testTemplateName"},
+	}
+	data, err := json.Marshal(device)
+	require.NoError(t, err)
+	storedKey := deviceStoredKey(device.Id)
+	otherKey := deviceStoredKey("7aa1a7ba-6ca1-4c85-9ab3-f1e0e4d5f3c2")
+
+	expected := buildExpectedIndexes(spec, map[string][]byte{storedKey: data, otherKey: []byte("invalid")})
+	assert.Equal(t, map[string]string{testDeviceName: storedKey}, expected.names)
+	for _, key := range []string{
+		DeviceCollection,
+		CreateKey(DeviceCollectionLabel, "label"),
+		CreateKey(DeviceCollectionServiceName, device.ServiceName),
+		CreateKey(DeviceCollectionProfileName, device.ProfileName),
+		CreateKey(DeviceCollectionTemplateName, "testTemplateName"),
+		DeviceCollectionLocation,
+	} {
+		assert.True(t, expected.sortedSets[key][storedKey], "device is expected in index %s", key)
+	}
+	for _, cmd := range expected.cmds {
+		assert.NotEqual(t, SET, cmd.name, "the entities must not be stored again when rebuilding the indexes")
+	}
+	require.Len(t, expected.inconsistencies, 1)
+	assert.Equal(t, pkgModels.IndexInvalidEntity, expected.inconsistencies[0].Problem)
+	assert.Equal(t, otherKey, expected.inconsistencies[0].Member)
+}
+
+func TestDiffIndexes(t *testing.T) {
+	spec := deviceIndexSpec(t)
+	storedKey := deviceStoredKey(exampleUUID)
+	staleKey := deviceStoredKey("7aa1a7ba-6ca1-4c85-9ab3-f1e0e4d5f3c2")
+	labelKey := CreateKey(DeviceCollectionLabel, "label")
+	expected := expectedIndexes{
+		sortedSets: map[string]map[string]bool{
+			DeviceCollection:         {storedKey: true},
+			labelKey:                 {storedKey: true},
+			DeviceCollectionLocation: {storedKey: true},
+		},
+		names: map[string]string{testDeviceName: storedKey, "renamed": storedKey},
+	}
+
+	inconsistencies := diffIndexes(spec, expected,
+		map[string][]string{
+			DeviceCollection: {storedKey, staleKey},
+			labelKey:         {storedKey},
+		},
+		map[string]string{testDeviceName: staleKey, "deleted": staleKey},
+	)
+
+	problems := make(map[string]string)
+	for _, i := range inconsistencies {
+		problems[i.Index+"|"+i.Member] = i.Problem
+	}
+	assert.Equal(t, map[string]string{
+		DeviceCollection + "|" + staleKey:           pkgModels.IndexEntryStale,
+		DeviceCollectionLocation + "|" + storedKey:  pkgModels.IndexEntryMissing,
+		DeviceCollectionName + "|" + testDeviceName: pkgModels.IndexEntryMismatch,
+		DeviceCollectionName + "|renamed":           pkgModels.IndexEntryMissing,
+		DeviceCollectionName + "|deleted":           pkgModels.IndexEntryStale,
+	}, problems)
+
+	assert.Empty(t, diffIndexes(spec, expected,
+		map[string][]string{DeviceCollection: {storedKey}, labelKey: {storedKey}, DeviceCollectionLocation: {storedKey}},
+		map[string]string{testDeviceName: storedKey, "renamed": storedKey},
+	))
+}
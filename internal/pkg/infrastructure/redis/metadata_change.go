@@ -0,0 +1,148 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/google/uuid"
+)
+
+const (
+	MetadataChangeCollection         = "md|chg"
+	MetadataChangeCollectionSequence = MetadataChangeCollection + DBKeySeparator + "seq"
+)
+
+// addMetadataChangeScript assigns the next sequence to the change and stores it under a key built from the sequence.
+// The sequence is also recorded as the revision of the entity, or the revision is removed when the entity is deleted.
+// The script is sent as a part of the MULTI transaction writing the entity, so a change is persisted if and only if
+// the entity is, and the sequences follow the order the transactions are executed in.
+var addMetadataChangeScript = redis.NewScript(3, `
+local seq = redis.call('INCR', KEYS[2])
+local key = KEYS[1] .. ':' .. seq
+redis.call('SET', key, ARGV[1])
+redis.call('ZADD', KEYS[1], seq, key)
+if ARGV[3] == '1' then
+  redis.call('HDEL', KEYS[3], ARGV[2])
+else
+  redis.call('HSET', KEYS[3], ARGV[2], seq)
+end
+return seq
+`)
+
+// sendAddMetadataChangeCmd sends the command to record the change of a metadata entity and to update the revision of
+// the entity, it must be called in a MULTI transaction
+func sendAddMetadataChangeCmd(conn redis.Conn, changeType string, action string, name string, entity any) errors.EdgeX {
+	e, err := json.Marshal(entity)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("unable to JSON marshal %s for the change log", changeType), err)
+	}
+	change := pkgModels.MetadataChange{
+		Id:      uuid.NewString(),
+		Created: pkgCommon.MakeTimestamp(),
+		Type:    changeType,
+		Action:  action,
+		Name:    name,
+		Entity:  e,
+	}
+	m, err := json.Marshal(change)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal metadata change for Redis persistence", err)
+	}
+	deleted := "0"
+	if action == common.SystemEventActionDelete {
+		deleted = "1"
+	}
+	_ = addMetadataChangeScript.Send(conn, MetadataChangeCollection, MetadataChangeCollectionSequence, MetadataRevisionCollection,
+		m, metadataRevisionField(changeType, name), deleted)
+	return nil
+}
+
+// metadataChangesSince queries the changes whose sequence is greater than since in the ascending order of the sequence
+func metadataChangesSince(conn redis.Conn, since uint64, limit int) ([]pkgModels.MetadataChange, errors.EdgeX) {
+	if limit == 0 {
+		return []pkgModels.MetadataChange{}, nil
+	}
+	storedKeys, err := redis.Strings(conn.Do(ZRANGEBYSCORE, MetadataChangeCollection, fmt.Sprintf("(%d", since), InfiniteMax, LIMIT, 0, limit))
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "query metadata change keys from database failed", err)
+	}
+	if len(storedKeys) == 0 {
+		return []pkgModels.MetadataChange{}, nil
+	}
+	// query the objects with MGET instead of getObjectsByIds to keep the objects aligned with the keys
+	objects, err := redis.ByteSlices(conn.Do(MGET, pkgCommon.ConvertStringsToInterfaces(storedKeys)...))
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "query metadata changes from database failed", err)
+	}
+
+	changes := make([]pkgModels.MetadataChange, 0, len(objects))
+	for i, o := range objects {
+		if o == nil {
+			// the change has been purged after its key was queried
+			continue
+		}
+		var change pkgModels.MetadataChange
+		err = json.Unmarshal(o, &change)
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "metadata change format parsing failed from the database", err)
+		}
+		change.Sequence, err = strconv.ParseUint(idFromStoredKey(storedKeys[i]), 10, 64)
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("invalid metadata change key %s", storedKeys[i]), err)
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// metadataChangeSequenceRange returns the sequence of the oldest retained change and the sequence of the latest change,
+// the oldest sequence is 0 when no change is retained
+func metadataChangeSequenceRange(conn redis.Conn) (oldest uint64, latest uint64, edgeXerr errors.EdgeX) {
+	latest, err := redis.Uint64(conn.Do(GET, MetadataChangeCollectionSequence))
+	if err != nil && err != redis.ErrNil {
+		return 0, 0, errors.NewCommonEdgeX(errors.KindDatabaseError, "query the latest metadata change sequence failed", err)
+	}
+	values, err := redis.Strings(conn.Do(ZRANGE, MetadataChangeCollection, 0, 0))
+	if err != nil {
+		return 0, 0, errors.NewCommonEdgeX(errors.KindDatabaseError, "query the oldest metadata change failed", err)
+	}
+	if len(values) > 0 {
+		oldest, err = strconv.ParseUint(idFromStoredKey(values[0]), 10, 64)
+		if err != nil {
+			return 0, 0, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("invalid metadata change key %s", values[0]), err)
+		}
+	}
+	return oldest, latest, nil
+}
+
+// trimMetadataChanges deletes the oldest changes so that only the latest retained count of changes are kept
+func trimMetadataChanges(conn redis.Conn, retained uint32) errors.EdgeX {
+	storedKeys, err := redis.Values(conn.Do(ZRANGE, MetadataChangeCollection, 0, -int(retained)-1))
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "query the metadata changes to trim failed", err)
+	}
+	if len(storedKeys) == 0 {
+		return nil
+	}
+	_ = conn.Send(MULTI)
+	_ = conn.Send(UNLINK, storedKeys...)
+	_ = conn.Send(ZREM, append([]interface{}{MetadataChangeCollection}, storedKeys...)...)
+	_, err = conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "metadata changes deletion failed", err)
+	}
+	return nil
+}
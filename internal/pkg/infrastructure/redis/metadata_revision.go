@@ -0,0 +1,34 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"fmt"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// MetadataRevisionCollection is the hash holding the revisions of the metadata entities. The field is built from the
+// entity type and name, and the value is the sequence of the latest change of the entity, see addMetadataChangeScript.
+const MetadataRevisionCollection = "md|rev"
+
+func metadataRevisionField(entityType string, name string) string {
+	return CreateKey(entityType, name)
+}
+
+// metadataRevision queries the revision of the entity, the revision is 0 for the entities which have not been
+// changed since the revisions were introduced
+func metadataRevision(conn redis.Conn, entityType string, name string) (uint64, errors.EdgeX) {
+	revision, err := redis.Uint64(conn.Do(HGET, MetadataRevisionCollection, metadataRevisionField(entityType, name)))
+	if err == redis.ErrNil {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("query the revision of %s %s failed", entityType, name), err)
+	}
+	return revision, nil
+}
@@ -0,0 +1,159 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	PendingCommandCollection       = "cc|pc"
+	PendingCommandCollectionStatus = PendingCommandCollection + DBKeySeparator + common.Status
+)
+
+// pendingCommandStoredKey return the pending command's stored key which combines the collection name and object id
+func pendingCommandStoredKey(id string) string {
+	return CreateKey(PendingCommandCollection, id)
+}
+
+// sendAddPendingCommandCmd send redis command for adding pending command, the pending commands are sorted by
+// the expires-at time
+func sendAddPendingCommandCmd(conn redis.Conn, storedKey string, pc pkgModels.PendingCommand) errors.EdgeX {
+	m, err := json.Marshal(pc)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal pending command for Redis persistence", err)
+	}
+	_ = conn.Send(SET, storedKey, m)
+	_ = conn.Send(ZADD, PendingCommandCollection, pc.ExpiresAt, storedKey)
+	_ = conn.Send(ZADD, CreateKey(PendingCommandCollectionStatus, pc.Status), pc.ExpiresAt, storedKey)
+	return nil
+}
+
+// sendDeletePendingCommandCmd send redis command for deleting pending command
+func sendDeletePendingCommandCmd(conn redis.Conn, storedKey string, pc pkgModels.PendingCommand) {
+	_ = conn.Send(DEL, storedKey)
+	_ = conn.Send(ZREM, PendingCommandCollection, storedKey)
+	_ = conn.Send(ZREM, CreateKey(PendingCommandCollectionStatus, pc.Status), storedKey)
+}
+
+// addPendingCommand adds a new pending command into DB
+func addPendingCommand(conn redis.Conn, pc pkgModels.PendingCommand) (pkgModels.PendingCommand, errors.EdgeX) {
+	exists, edgeXerr := objectIdExists(conn, pendingCommandStoredKey(pc.Id))
+	if edgeXerr != nil {
+		return pc, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return pc, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("pending command id %s already exists", pc.Id), edgeXerr)
+	}
+
+	ts := pkgCommon.MakeTimestamp()
+	if pc.Created == 0 {
+		pc.Created = ts
+	}
+	pc.Modified = ts
+
+	_ = conn.Send(MULTI)
+	edgeXerr = sendAddPendingCommandCmd(conn, pendingCommandStoredKey(pc.Id), pc)
+	if edgeXerr != nil {
+		return pc, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return pc, errors.NewCommonEdgeX(errors.KindDatabaseError, "pending command creation failed", err)
+	}
+	return pc, nil
+}
+
+// pendingCommandById query pending command by id from DB
+func pendingCommandById(conn redis.Conn, id string) (pc pkgModels.PendingCommand, edgeXerr errors.EdgeX) {
+	edgeXerr = getObjectById(conn, pendingCommandStoredKey(id), &pc)
+	if edgeXerr != nil {
+		return pc, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to query pending command by id %s", id), edgeXerr)
+	}
+	return
+}
+
+// pendingCommandsByStatus query pending commands from DB by status sorted by the expires-at time descending, all
+// the pending commands are queried when the status is empty
+func pendingCommandsByStatus(conn redis.Conn, status string, offset int, limit int) ([]pkgModels.PendingCommand, uint32, errors.EdgeX) {
+	key := PendingCommandCollection
+	if status != "" {
+		key = CreateKey(PendingCommandCollectionStatus, status)
+	}
+	totalCount, edgeXerr := getMemberNumber(conn, ZCARD, key)
+	if edgeXerr != nil {
+		return nil, 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	objects, edgeXerr := getObjectsByRevRange(conn, key, offset, limit)
+	if edgeXerr != nil {
+		return nil, 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	pendingCommands, edgeXerr := convertObjectsToPendingCommands(objects)
+	if edgeXerr != nil {
+		return nil, 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return pendingCommands, totalCount, nil
+}
+
+// expiredPendingCommands query at most limit pending commands in the PENDING status whose expires-at time is not
+// after the specified time, sorted by the expires-at time ascending
+func expiredPendingCommands(conn redis.Conn, expiresAt int64, limit int) ([]pkgModels.PendingCommand, errors.EdgeX) {
+	storedKeys, err := redis.Strings(conn.Do(ZRANGEBYSCORE, CreateKey(PendingCommandCollectionStatus, pkgModels.PendingCommandStatusPending),
+		0, expiresAt, LIMIT, 0, limit))
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "query expired pending command ids from database failed", err)
+	}
+	if len(storedKeys) == 0 {
+		return nil, nil
+	}
+	objects, edgeXerr := getObjectsByIds(conn, pkgCommon.ConvertStringsToInterfaces(storedKeys))
+	if edgeXerr != nil {
+		return nil, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return convertObjectsToPendingCommands(objects)
+}
+
+// updatePendingCommand replaces the pending command of the same id, the created timestamp is kept
+func updatePendingCommand(conn redis.Conn, pc pkgModels.PendingCommand) errors.EdgeX {
+	old, edgeXerr := pendingCommandById(conn, pc.Id)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	pc.Created = old.Created
+	pc.Modified = pkgCommon.MakeTimestamp()
+	storedKey := pendingCommandStoredKey(pc.Id)
+	_ = conn.Send(MULTI)
+	sendDeletePendingCommandCmd(conn, storedKey, old)
+	edgeXerr = sendAddPendingCommandCmd(conn, storedKey, pc)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "pending command update failed", err)
+	}
+	return nil
+}
+
+func convertObjectsToPendingCommands(objects [][]byte) ([]pkgModels.PendingCommand, errors.EdgeX) {
+	pendingCommands := make([]pkgModels.PendingCommand, len(objects))
+	for i, o := range objects {
+		err := json.Unmarshal(o, &pendingCommands[i])
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "pending command format parsing failed from the database", err)
+		}
+	}
+	return pendingCommands, nil
+}
@@ -0,0 +1,210 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	PendingDeviceCollection       = "md|pdv"
+	PendingDeviceCollectionName   = PendingDeviceCollection + DBKeySeparator + common.Name
+	PendingDeviceCollectionStatus = PendingDeviceCollection + DBKeySeparator + common.Status
+)
+
+// pendingDeviceStoredKey return the pending device's stored key which combines the collection name and object id
+func pendingDeviceStoredKey(id string) string {
+	return CreateKey(PendingDeviceCollection, id)
+}
+
+// sendAddPendingDeviceCmd send redis command for adding pending device
+func sendAddPendingDeviceCmd(conn redis.Conn, storedKey string, pd pkgModels.PendingDevice) errors.EdgeX {
+	m, err := json.Marshal(pd)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal pending device for Redis persistence", err)
+	}
+	_ = conn.Send(SET, storedKey, m)
+	_ = conn.Send(ZADD, PendingDeviceCollection, pd.Modified, storedKey)
+	_ = conn.Send(HSET, PendingDeviceCollectionName, pd.Device.Name, storedKey)
+	_ = conn.Send(ZADD, CreateKey(PendingDeviceCollectionStatus, pd.Status), pd.Modified, storedKey)
+	return nil
+}
+
+// sendDeletePendingDeviceCmd send redis command for deleting pending device
+func sendDeletePendingDeviceCmd(conn redis.Conn, storedKey string, pd pkgModels.PendingDevice) {
+	_ = conn.Send(DEL, storedKey)
+	_ = conn.Send(ZREM, PendingDeviceCollection, storedKey)
+	_ = conn.Send(HDEL, PendingDeviceCollectionName, pd.Device.Name)
+	_ = conn.Send(ZREM, CreateKey(PendingDeviceCollectionStatus, pd.Status), storedKey)
+}
+
+// addPendingDevice adds a new pending device into DB
+func addPendingDevice(conn redis.Conn, pd pkgModels.PendingDevice) (pkgModels.PendingDevice, errors.EdgeX) {
+	exists, edgeXerr := objectIdExists(conn, pendingDeviceStoredKey(pd.Id))
+	if edgeXerr != nil {
+		return pd, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return pd, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("pending device id %s already exists", pd.Id), edgeXerr)
+	}
+
+	exists, edgeXerr = objectNameExists(conn, PendingDeviceCollectionName, pd.Device.Name)
+	if edgeXerr != nil {
+		return pd, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return pd, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("pending device name %s already exists", pd.Device.Name), edgeXerr)
+	}
+
+	ts := pkgCommon.MakeTimestamp()
+	if pd.Created == 0 {
+		pd.Created = ts
+	}
+	pd.Modified = ts
+
+	_ = conn.Send(MULTI)
+	edgeXerr = sendAddPendingDeviceCmd(conn, pendingDeviceStoredKey(pd.Id), pd)
+	if edgeXerr != nil {
+		return pd, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	if edgeXerr := sendAddMetadataChangeCmd(conn, pkgCommon.PendingDevice, common.SystemEventActionAdd, pd.Device.Name, pd); edgeXerr != nil {
+		return pd, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return pd, errors.NewCommonEdgeX(errors.KindDatabaseError, "pending device creation failed", err)
+	}
+	return pd, nil
+}
+
+// pendingDeviceByName query pending device by name from DB
+func pendingDeviceByName(conn redis.Conn, name string) (pd pkgModels.PendingDevice, edgeXerr errors.EdgeX) {
+	edgeXerr = getObjectByHash(conn, PendingDeviceCollectionName, name, &pd)
+	if edgeXerr != nil {
+		return pd, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to query pending device by name %s", name), edgeXerr)
+	}
+	return
+}
+
+// pendingDevicesByStatus query pending devices from DB by status sorted by modified descending, all the pending
+// devices are queried when the status is empty
+func pendingDevicesByStatus(conn redis.Conn, status string, offset int, limit int) ([]pkgModels.PendingDevice, uint32, errors.EdgeX) {
+	key := PendingDeviceCollection
+	if status != "" {
+		key = CreateKey(PendingDeviceCollectionStatus, status)
+	}
+	totalCount, edgeXerr := getMemberNumber(conn, ZCARD, key)
+	if edgeXerr != nil {
+		return nil, 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	objects, edgeXerr := getObjectsByRevRange(conn, key, offset, limit)
+	if edgeXerr != nil {
+		return nil, 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	pendingDevices := make([]pkgModels.PendingDevice, len(objects))
+	for i, o := range objects {
+		err := json.Unmarshal(o, &pendingDevices[i])
+		if err != nil {
+			return nil, 0, errors.NewCommonEdgeX(errors.KindDatabaseError, "pending device format parsing failed from the database", err)
+		}
+	}
+	return pendingDevices, totalCount, nil
+}
+
+// updatePendingDevice replaces the pending device of the same device name, the id and created timestamp are kept
+func updatePendingDevice(conn redis.Conn, pd pkgModels.PendingDevice) errors.EdgeX {
+	old, edgeXerr := pendingDeviceByName(conn, pd.Device.Name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	pd.Id = old.Id
+	pd.Created = old.Created
+	pd.Modified = pkgCommon.MakeTimestamp()
+	storedKey := pendingDeviceStoredKey(pd.Id)
+	_ = conn.Send(MULTI)
+	sendDeletePendingDeviceCmd(conn, storedKey, old)
+	edgeXerr = sendAddPendingDeviceCmd(conn, storedKey, pd)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	if edgeXerr := sendAddMetadataChangeCmd(conn, pkgCommon.PendingDevice, common.SystemEventActionUpdate, pd.Device.Name, pd); edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "pending device update failed", err)
+	}
+	return nil
+}
+
+// deletePendingDeviceByName deletes the pending device by name
+func deletePendingDeviceByName(conn redis.Conn, name string) errors.EdgeX {
+	pd, edgeXerr := pendingDeviceByName(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	_ = conn.Send(MULTI)
+	sendDeletePendingDeviceCmd(conn, pendingDeviceStoredKey(pd.Id), pd)
+	if edgeXerr := sendAddMetadataChangeCmd(conn, pkgCommon.PendingDevice, common.SystemEventActionDelete, pd.Device.Name, pd); edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "pending device deletion failed", err)
+	}
+	return nil
+}
+
+// approvePendingDevice adds the device staged by the pending device of the same name and deletes the pending device in
+// a single transaction, which is aborted when a pending device is modified meanwhile
+func approvePendingDevice(conn redis.Conn, d models.Device) (models.Device, errors.EdgeX) {
+	if _, err := conn.Do(WATCH, PendingDeviceCollectionName); err != nil {
+		return d, errors.NewCommonEdgeX(errors.KindDatabaseError, "watch pending device name index failed", err)
+	}
+	defer func() {
+		_, _ = conn.Do(UNWATCH)
+	}()
+
+	pd, edgeXerr := pendingDeviceByName(conn, d.Name)
+	if edgeXerr != nil {
+		return d, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	d, edgeXerr = checkNewDevice(conn, d)
+	if edgeXerr != nil {
+		return d, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	_ = conn.Send(MULTI)
+	edgeXerr = sendAddDeviceCmd(conn, deviceStoredKey(d.Id), d)
+	if edgeXerr != nil {
+		return d, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	if edgeXerr = sendAddMetadataChangeCmd(conn, common.DeviceSystemEventType, common.SystemEventActionAdd, d.Name, d); edgeXerr != nil {
+		return d, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	sendDeletePendingDeviceCmd(conn, pendingDeviceStoredKey(pd.Id), pd)
+	if edgeXerr := sendAddMetadataChangeCmd(conn, pkgCommon.PendingDevice, common.SystemEventActionDelete, pd.Device.Name, pd); edgeXerr != nil {
+		return d, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	reply, err := conn.Do(EXEC)
+	if err != nil {
+		return d, errors.NewCommonEdgeX(errors.KindDatabaseError, "pending device approval failed", err)
+	} else if reply == nil {
+		return d, errors.NewCommonEdgeX(errors.KindStatusConflict, fmt.Sprintf("pending device %s was modified while being approved, please retry", d.Name), nil)
+	}
+	return d, nil
+}
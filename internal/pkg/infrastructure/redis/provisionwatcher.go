@@ -84,6 +84,9 @@ func addProvisionWatcher(conn redis.Conn, pw models.ProvisionWatcher) (addedProv
 	storedKey := provisionWatcherStoredKey(pw.Id)
 	_ = conn.Send(MULTI)
 	edgexErr = sendAddProvisionWatcherCmd(conn, storedKey, pw)
+	if edgeXerr := sendAddMetadataChangeCmd(conn, common.ProvisionWatcherSystemEventType, common.SystemEventActionAdd, pw.Name, pw); edgeXerr != nil {
+		return pw, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
 	_, err := conn.Do(EXEC)
 	if err != nil {
 		edgexErr = errors.NewCommonEdgeX(errors.KindDatabaseError, "provision watcher creation failed", err)
@@ -203,6 +206,9 @@ func deleteProvisionWatcher(conn redis.Conn, pw models.ProvisionWatcher) errors.
 	storedKey := provisionWatcherStoredKey(pw.Id)
 	_ = conn.Send(MULTI)
 	sendDeleteProvisionWatcherCmd(conn, storedKey, pw)
+	if edgeXerr := sendAddMetadataChangeCmd(conn, common.ProvisionWatcherSystemEventType, common.SystemEventActionDelete, pw.Name, pw); edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
 	_, err := conn.Do(EXEC)
 	if err != nil {
 		return errors.NewCommonEdgeX(errors.KindDatabaseError, "provision watcher deletion failed", err)
@@ -234,6 +240,9 @@ func updateProvisionWatcher(conn redis.Conn, pw models.ProvisionWatcher) errors.
 	if edgexErr != nil {
 		return errors.NewCommonEdgeXWrapper(edgexErr)
 	}
+	if edgeXerr := sendAddMetadataChangeCmd(conn, common.ProvisionWatcherSystemEventType, common.SystemEventActionUpdate, pw.Name, pw); edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
 	_, err := conn.Do(EXEC)
 	if err != nil {
 		return errors.NewCommonEdgeX(errors.KindDatabaseError, "provision watcher update failed", err)
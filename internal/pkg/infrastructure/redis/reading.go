@@ -249,6 +249,21 @@ func readingsByDeviceNameAndResourceNamesAndTimeRange(conn redis.Conn, deviceNam
 	return readings, totalCount, err
 }
 
+// readingsByDeviceNamesAndTimeRange query the readings of the devices by time range, offset, and limit
+func readingsByDeviceNamesAndTimeRange(conn redis.Conn, deviceNames []string, startTime int, endTime int, offset int, limit int) (readings []models.Reading, totalCount uint32, err errors.EdgeX) {
+	redisKeys := make([]string, len(deviceNames))
+	for i, deviceName := range deviceNames {
+		redisKeys[i] = CreateKey(ReadingsCollectionDeviceName, deviceName)
+	}
+
+	objects, totalCount, err := unionObjectsByKeysAndScoreRange(conn, startTime, endTime, offset, limit, redisKeys...)
+	if err != nil {
+		return readings, totalCount, err
+	}
+	readings, err = convertObjectsToReadings(objects)
+	return readings, totalCount, err
+}
+
 // readingsByTimeRange query readings by time range, offset, and limit
 func readingsByTimeRange(conn redis.Conn, startTime int, endTime int, offset int, limit int) (readings []models.Reading, edgeXerr errors.EdgeX) {
 	objects, edgeXerr := getObjectsByScoreRange(conn, ReadingsCollectionOrigin, startTime, endTime, offset, limit)
@@ -0,0 +1,159 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	ScheduledCommandCollection       = "cc|sc"
+	ScheduledCommandCollectionStatus = ScheduledCommandCollection + DBKeySeparator + common.Status
+)
+
+// scheduledCommandStoredKey return the scheduled command's stored key which combines the collection name and object id
+func scheduledCommandStoredKey(id string) string {
+	return CreateKey(ScheduledCommandCollection, id)
+}
+
+// sendAddScheduledCommandCmd send redis command for adding scheduled command, the scheduled commands are sorted by
+// the execute-at time
+func sendAddScheduledCommandCmd(conn redis.Conn, storedKey string, sc pkgModels.ScheduledCommand) errors.EdgeX {
+	m, err := json.Marshal(sc)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal scheduled command for Redis persistence", err)
+	}
+	_ = conn.Send(SET, storedKey, m)
+	_ = conn.Send(ZADD, ScheduledCommandCollection, sc.ExecuteAt, storedKey)
+	_ = conn.Send(ZADD, CreateKey(ScheduledCommandCollectionStatus, sc.Status), sc.ExecuteAt, storedKey)
+	return nil
+}
+
+// sendDeleteScheduledCommandCmd send redis command for deleting scheduled command
+func sendDeleteScheduledCommandCmd(conn redis.Conn, storedKey string, sc pkgModels.ScheduledCommand) {
+	_ = conn.Send(DEL, storedKey)
+	_ = conn.Send(ZREM, ScheduledCommandCollection, storedKey)
+	_ = conn.Send(ZREM, CreateKey(ScheduledCommandCollectionStatus, sc.Status), storedKey)
+}
+
+// addScheduledCommand adds a new scheduled command into DB
+func addScheduledCommand(conn redis.Conn, sc pkgModels.ScheduledCommand) (pkgModels.ScheduledCommand, errors.EdgeX) {
+	exists, edgeXerr := objectIdExists(conn, scheduledCommandStoredKey(sc.Id))
+	if edgeXerr != nil {
+		return sc, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return sc, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("scheduled command id %s already exists", sc.Id), edgeXerr)
+	}
+
+	ts := pkgCommon.MakeTimestamp()
+	if sc.Created == 0 {
+		sc.Created = ts
+	}
+	sc.Modified = ts
+
+	_ = conn.Send(MULTI)
+	edgeXerr = sendAddScheduledCommandCmd(conn, scheduledCommandStoredKey(sc.Id), sc)
+	if edgeXerr != nil {
+		return sc, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return sc, errors.NewCommonEdgeX(errors.KindDatabaseError, "scheduled command creation failed", err)
+	}
+	return sc, nil
+}
+
+// scheduledCommandById query scheduled command by id from DB
+func scheduledCommandById(conn redis.Conn, id string) (sc pkgModels.ScheduledCommand, edgeXerr errors.EdgeX) {
+	edgeXerr = getObjectById(conn, scheduledCommandStoredKey(id), &sc)
+	if edgeXerr != nil {
+		return sc, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to query scheduled command by id %s", id), edgeXerr)
+	}
+	return
+}
+
+// scheduledCommandsByStatus query scheduled commands from DB by status sorted by the execute-at time descending, all
+// the scheduled commands are queried when the status is empty
+func scheduledCommandsByStatus(conn redis.Conn, status string, offset int, limit int) ([]pkgModels.ScheduledCommand, uint32, errors.EdgeX) {
+	key := ScheduledCommandCollection
+	if status != "" {
+		key = CreateKey(ScheduledCommandCollectionStatus, status)
+	}
+	totalCount, edgeXerr := getMemberNumber(conn, ZCARD, key)
+	if edgeXerr != nil {
+		return nil, 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	objects, edgeXerr := getObjectsByRevRange(conn, key, offset, limit)
+	if edgeXerr != nil {
+		return nil, 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	scheduledCommands, edgeXerr := convertObjectsToScheduledCommands(objects)
+	if edgeXerr != nil {
+		return nil, 0, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return scheduledCommands, totalCount, nil
+}
+
+// dueScheduledCommands query at most limit scheduled commands in the SCHEDULED status whose execute-at time is not
+// after the specified time, sorted by the execute-at time ascending
+func dueScheduledCommands(conn redis.Conn, executeAt int64, limit int) ([]pkgModels.ScheduledCommand, errors.EdgeX) {
+	storedKeys, err := redis.Strings(conn.Do(ZRANGEBYSCORE, CreateKey(ScheduledCommandCollectionStatus, pkgModels.ScheduledCommandStatusScheduled),
+		0, executeAt, LIMIT, 0, limit))
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "query due scheduled command ids from database failed", err)
+	}
+	if len(storedKeys) == 0 {
+		return nil, nil
+	}
+	objects, edgeXerr := getObjectsByIds(conn, pkgCommon.ConvertStringsToInterfaces(storedKeys))
+	if edgeXerr != nil {
+		return nil, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return convertObjectsToScheduledCommands(objects)
+}
+
+// updateScheduledCommand replaces the scheduled command of the same id, the created timestamp is kept
+func updateScheduledCommand(conn redis.Conn, sc pkgModels.ScheduledCommand) errors.EdgeX {
+	old, edgeXerr := scheduledCommandById(conn, sc.Id)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	sc.Created = old.Created
+	sc.Modified = pkgCommon.MakeTimestamp()
+	storedKey := scheduledCommandStoredKey(sc.Id)
+	_ = conn.Send(MULTI)
+	sendDeleteScheduledCommandCmd(conn, storedKey, old)
+	edgeXerr = sendAddScheduledCommandCmd(conn, storedKey, sc)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "scheduled command update failed", err)
+	}
+	return nil
+}
+
+func convertObjectsToScheduledCommands(objects [][]byte) ([]pkgModels.ScheduledCommand, errors.EdgeX) {
+	scheduledCommands := make([]pkgModels.ScheduledCommand, len(objects))
+	for i, o := range objects {
+		err := json.Unmarshal(o, &scheduledCommands[i])
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "scheduled command format parsing failed from the database", err)
+		}
+	}
+	return scheduledCommands, nil
+}
@@ -0,0 +1,290 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// TrashCollection holds the soft deleted metadata entities. The entities of a type are enumerated by the sorted set
+// md|trash:<type> scored by the deletion time, and each entity is stored under the key md|trash:<type>:<name>.
+const TrashCollection = "md|trash"
+
+// trashCollectionByType returns the key of the sorted set enumerating the trashed entities of the type
+func trashCollectionByType(entityType string) string {
+	return CreateKey(TrashCollection, entityType)
+}
+
+// trashedEntityStoredKey returns the key the trashed entity is stored under
+func trashedEntityStoredKey(entityType string, name string) string {
+	return CreateKey(TrashCollection, entityType, name)
+}
+
+// trashedEntityExists checks whether the entity with the type and name is in the trash
+func trashedEntityExists(conn redis.Conn, entityType string, name string) (bool, errors.EdgeX) {
+	exists, err := objectIdExists(conn, trashedEntityStoredKey(entityType, name))
+	if err != nil {
+		return false, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("trashed %s existence check by name failed", entityType), err)
+	}
+	return exists, nil
+}
+
+// sendTrashEntityCmd sends the commands to store the deleted entity in the trash, it must be called in a MULTI transaction
+func sendTrashEntityCmd(conn redis.Conn, entityType string, name string, entity any) errors.EdgeX {
+	e, err := json.Marshal(entity)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("unable to JSON marshal %s for the trash", entityType), err)
+	}
+	trashed := pkgModels.TrashedEntity{
+		Type:    entityType,
+		Name:    name,
+		Deleted: pkgCommon.MakeTimestamp(),
+		Entity:  e,
+	}
+	m, err := json.Marshal(trashed)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal trashed entity for Redis persistence", err)
+	}
+	storedKey := trashedEntityStoredKey(entityType, name)
+	_ = conn.Send(SET, storedKey, m)
+	_ = conn.Send(ZADD, trashCollectionByType(entityType), trashed.Deleted, storedKey)
+	return nil
+}
+
+// sendDeleteTrashedEntityCmd sends the commands to remove the entity from the trash, it must be called in a MULTI transaction
+func sendDeleteTrashedEntityCmd(conn redis.Conn, entityType string, name string) {
+	storedKey := trashedEntityStoredKey(entityType, name)
+	_ = conn.Send(DEL, storedKey)
+	_ = conn.Send(ZREM, trashCollectionByType(entityType), storedKey)
+}
+
+// trashDeviceByName deletes the device by name and keeps it in the trash
+func trashDeviceByName(conn redis.Conn, name string) errors.EdgeX {
+	device, edgeXerr := deviceByName(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	_ = conn.Send(MULTI)
+	sendDeleteDeviceCmd(conn, deviceStoredKey(device.Id), device)
+	if edgeXerr := sendTrashEntityCmd(conn, common.DeviceSystemEventType, device.Name, device); edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	if edgeXerr := sendAddMetadataChangeCmd(conn, common.DeviceSystemEventType, common.SystemEventActionDelete, device.Name, device); edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "device deletion failed", err)
+	}
+	return nil
+}
+
+// trashDeviceProfileByName deletes the device profile by name and keeps it in the trash
+func trashDeviceProfileByName(conn redis.Conn, name string) errors.EdgeX {
+	dp, edgeXerr := deviceProfileByName(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	// Check the associated Device and ProvisionWatcher existence
+	devices, edgeXerr := devicesByProfileName(conn, 0, 1, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	if len(devices) > 0 {
+		return errors.NewCommonEdgeX(errors.KindStatusConflict, "fail to delete the device profile when associated device exists", nil)
+	}
+	provisionWatchers, edgeXerr := provisionWatchersByProfileName(conn, 0, 1, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	if len(provisionWatchers) > 0 {
+		return errors.NewCommonEdgeX(errors.KindStatusConflict, "fail to delete the device profile when associated provisionWatcher exists", nil)
+	}
+
+	_ = conn.Send(MULTI)
+	sendDeleteDeviceProfileCmd(conn, deviceProfileStoredKey(dp.Id), dp)
+	if edgeXerr := sendTrashEntityCmd(conn, common.DeviceProfileSystemEventType, dp.Name, dp); edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	if edgeXerr := sendAddMetadataChangeCmd(conn, common.DeviceProfileSystemEventType, common.SystemEventActionDelete, dp.Name, dp); edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "device profile deletion failed", err)
+	}
+	return nil
+}
+
+// trashedEntityByName queries the trashed entity by type and name
+func trashedEntityByName(conn redis.Conn, entityType string, name string) (trashed pkgModels.TrashedEntity, edgeXerr errors.EdgeX) {
+	edgeXerr = getObjectById(conn, trashedEntityStoredKey(entityType, name), &trashed)
+	if edgeXerr != nil {
+		return trashed, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("%s %s doesn't exist in the trash", entityType, name), edgeXerr)
+	}
+	return trashed, nil
+}
+
+// trashedEntities queries the trashed entities of the type with offset and limit, the latest deleted entity comes first
+func trashedEntities(conn redis.Conn, entityType string, offset int, limit int) ([]pkgModels.TrashedEntity, errors.EdgeX) {
+	objects, edgeXerr := getObjectsByRevRange(conn, trashCollectionByType(entityType), offset, limit)
+	if edgeXerr != nil {
+		return nil, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	entities := make([]pkgModels.TrashedEntity, len(objects))
+	for i, o := range objects {
+		err := json.Unmarshal(o, &entities[i])
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, "trashed entity format parsing failed from the database", err)
+		}
+	}
+	return entities, nil
+}
+
+// restoreTrashedDevice moves the device from the trash back to the devices. The device profile and device service of
+// the device must exist, and the device name must not be used again.
+func restoreTrashedDevice(conn redis.Conn, name string) (d models.Device, edgeXerr errors.EdgeX) {
+	trashed, edgeXerr := trashedEntityByName(conn, common.DeviceSystemEventType, name)
+	if edgeXerr != nil {
+		return d, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	if err := json.Unmarshal(trashed.Entity, &d); err != nil {
+		return d, errors.NewCommonEdgeX(errors.KindDatabaseError, "trashed device format parsing failed from the database", err)
+	}
+
+	exists, edgeXerr := deviceProfileNameExists(conn, d.ProfileName)
+	if edgeXerr != nil {
+		return d, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if !exists {
+		return d, errors.NewCommonEdgeX(errors.KindStatusConflict, fmt.Sprintf("device profile '%s' of device %s does not exist, it must be restored first", d.ProfileName, name), nil)
+	}
+	exists, edgeXerr = deviceServiceNameExist(conn, d.ServiceName)
+	if edgeXerr != nil {
+		return d, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if !exists {
+		return d, errors.NewCommonEdgeX(errors.KindStatusConflict, fmt.Sprintf("device service '%s' of device %s does not exist", d.ServiceName, name), nil)
+	}
+	exists, edgeXerr = deviceIdExists(conn, d.Id)
+	if edgeXerr != nil {
+		return d, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return d, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("device id %s already exists", d.Id), nil)
+	}
+	exists, edgeXerr = deviceNameExists(conn, d.Name)
+	if edgeXerr != nil {
+		return d, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return d, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("device name %s already exists", d.Name), nil)
+	}
+
+	d.Modified = pkgCommon.MakeTimestamp()
+	_ = conn.Send(MULTI)
+	edgeXerr = sendAddDeviceCmd(conn, deviceStoredKey(d.Id), d)
+	if edgeXerr != nil {
+		return d, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	sendDeleteTrashedEntityCmd(conn, common.DeviceSystemEventType, name)
+	if edgeXerr := sendAddMetadataChangeCmd(conn, common.DeviceSystemEventType, common.SystemEventActionAdd, d.Name, d); edgeXerr != nil {
+		return d, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return d, errors.NewCommonEdgeX(errors.KindDatabaseError, "device restoration failed", err)
+	}
+	return d, nil
+}
+
+// restoreTrashedDeviceProfile moves the device profile from the trash back to the device profiles
+func restoreTrashedDeviceProfile(conn redis.Conn, name string) (dp models.DeviceProfile, edgeXerr errors.EdgeX) {
+	trashed, edgeXerr := trashedEntityByName(conn, common.DeviceProfileSystemEventType, name)
+	if edgeXerr != nil {
+		return dp, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	if err := json.Unmarshal(trashed.Entity, &dp); err != nil {
+		return dp, errors.NewCommonEdgeX(errors.KindDatabaseError, "trashed device profile format parsing failed from the database", err)
+	}
+
+	exists, edgeXerr := deviceProfileIdExists(conn, dp.Id)
+	if edgeXerr != nil {
+		return dp, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return dp, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("device profile id %s exists", dp.Id), nil)
+	}
+	exists, edgeXerr = deviceProfileNameExists(conn, dp.Name)
+	if edgeXerr != nil {
+		return dp, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return dp, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("device profile name %s exists", dp.Name), nil)
+	}
+
+	dp.Modified = pkgCommon.MakeTimestamp()
+	_ = conn.Send(MULTI)
+	edgeXerr = sendAddDeviceProfileCmd(conn, deviceProfileStoredKey(dp.Id), dp)
+	if edgeXerr != nil {
+		return dp, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	sendDeleteTrashedEntityCmd(conn, common.DeviceProfileSystemEventType, name)
+	if edgeXerr := sendAddMetadataChangeCmd(conn, common.DeviceProfileSystemEventType, common.SystemEventActionAdd, dp.Name, dp); edgeXerr != nil {
+		return dp, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return dp, errors.NewCommonEdgeX(errors.KindDatabaseError, "device profile restoration failed", err)
+	}
+	return dp, nil
+}
+
+// purgeTrashedEntity permanently deletes the trashed entity
+func purgeTrashedEntity(conn redis.Conn, entityType string, name string) errors.EdgeX {
+	exists, edgeXerr := trashedEntityExists(conn, entityType, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if !exists {
+		return errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("%s %s doesn't exist in the trash", entityType, name), nil)
+	}
+
+	_ = conn.Send(MULTI)
+	sendDeleteTrashedEntityCmd(conn, entityType, name)
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("trashed %s deletion failed", entityType), err)
+	}
+	return nil
+}
+
+// purgeTrashedEntitiesByAge permanently deletes the entities of the type which were trashed before the age
+func purgeTrashedEntitiesByAge(conn redis.Conn, entityType string, age int64) (uint32, errors.EdgeX) {
+	collection := trashCollectionByType(entityType)
+	storedKeys, err := redis.Values(conn.Do(ZRANGEBYSCORE, collection, InfiniteMin, fmt.Sprintf("(%d", age)))
+	if err != nil {
+		return 0, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("query the trashed %s to purge failed", entityType), err)
+	}
+	if len(storedKeys) == 0 {
+		return 0, nil
+	}
+
+	_ = conn.Send(MULTI)
+	_ = conn.Send(UNLINK, storedKeys...)
+	_ = conn.Send(ZREM, append([]interface{}{collection}, storedKeys...)...)
+	_, err = conn.Do(EXEC)
+	if err != nil {
+		return 0, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("trashed %s deletion failed", entityType), err)
+	}
+	return uint32(len(storedKeys)), nil
+}
@@ -0,0 +1,157 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	UnitOfMeasureCategoryCollection     = "md|uom"
+	UnitOfMeasureCategoryCollectionName = UnitOfMeasureCategoryCollection + DBKeySeparator + common.Name
+)
+
+// unitOfMeasureCategoryStoredKey return the unit of measure category's stored key which combines the collection name and object id
+func unitOfMeasureCategoryStoredKey(id string) string {
+	return CreateKey(UnitOfMeasureCategoryCollection, id)
+}
+
+// sendAddUnitOfMeasureCategoryCmd send redis command for adding unit of measure category
+func sendAddUnitOfMeasureCategoryCmd(conn redis.Conn, storedKey string, c pkgModels.UnitOfMeasureCategory) errors.EdgeX {
+	m, err := json.Marshal(c)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal unit of measure category for Redis persistence", err)
+	}
+	_ = conn.Send(SET, storedKey, m)
+	_ = conn.Send(ZADD, UnitOfMeasureCategoryCollection, c.Modified, storedKey)
+	_ = conn.Send(HSET, UnitOfMeasureCategoryCollectionName, c.Name, storedKey)
+	return nil
+}
+
+// sendDeleteUnitOfMeasureCategoryCmd send redis command for deleting unit of measure category
+func sendDeleteUnitOfMeasureCategoryCmd(conn redis.Conn, storedKey string, c pkgModels.UnitOfMeasureCategory) {
+	_ = conn.Send(DEL, storedKey)
+	_ = conn.Send(ZREM, UnitOfMeasureCategoryCollection, storedKey)
+	_ = conn.Send(HDEL, UnitOfMeasureCategoryCollectionName, c.Name)
+}
+
+// addUnitOfMeasureCategory adds a new unit of measure category into DB
+func addUnitOfMeasureCategory(conn redis.Conn, c pkgModels.UnitOfMeasureCategory) (pkgModels.UnitOfMeasureCategory, errors.EdgeX) {
+	exists, edgeXerr := objectIdExists(conn, unitOfMeasureCategoryStoredKey(c.Id))
+	if edgeXerr != nil {
+		return c, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return c, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("unit of measure category id %s already exists", c.Id), edgeXerr)
+	}
+
+	exists, edgeXerr = objectNameExists(conn, UnitOfMeasureCategoryCollectionName, c.Name)
+	if edgeXerr != nil {
+		return c, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return c, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("unit of measure category name %s already exists", c.Name), edgeXerr)
+	}
+
+	if c.Created == 0 {
+		c.Created = pkgCommon.MakeTimestamp()
+	}
+	c.Modified = c.Created
+
+	storedKey := unitOfMeasureCategoryStoredKey(c.Id)
+	_ = conn.Send(MULTI)
+	edgeXerr = sendAddUnitOfMeasureCategoryCmd(conn, storedKey, c)
+	if edgeXerr != nil {
+		return c, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	if edgeXerr := sendAddMetadataChangeCmd(conn, pkgCommon.UnitOfMeasureCategory, common.SystemEventActionAdd, c.Name, c); edgeXerr != nil {
+		return c, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return c, errors.NewCommonEdgeX(errors.KindDatabaseError, "unit of measure category creation failed", err)
+	}
+	return c, nil
+}
+
+// unitOfMeasureCategoryByName query unit of measure category by name from DB
+func unitOfMeasureCategoryByName(conn redis.Conn, name string) (category pkgModels.UnitOfMeasureCategory, edgeXerr errors.EdgeX) {
+	edgeXerr = getObjectByHash(conn, UnitOfMeasureCategoryCollectionName, name, &category)
+	if edgeXerr != nil {
+		return category, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to query unit of measure category by name %s", name), edgeXerr)
+	}
+	return
+}
+
+// unitOfMeasureCategories query unit of measure categories with offset and limit from DB
+func unitOfMeasureCategories(conn redis.Conn, offset int, limit int) ([]pkgModels.UnitOfMeasureCategory, errors.EdgeX) {
+	objects, edgeXerr := getObjectsByRevRange(conn, UnitOfMeasureCategoryCollection, offset, limit)
+	if edgeXerr != nil {
+		return nil, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	categories := make([]pkgModels.UnitOfMeasureCategory, len(objects))
+	for i, o := range objects {
+		err := json.Unmarshal(o, &categories[i])
+		if err != nil {
+			return []pkgModels.UnitOfMeasureCategory{}, errors.NewCommonEdgeX(errors.KindDatabaseError, "unit of measure category format parsing failed from the database", err)
+		}
+	}
+	return categories, nil
+}
+
+// updateUnitOfMeasureCategory replaces the unit of measure category of the same name, the id and created timestamp are kept
+func updateUnitOfMeasureCategory(conn redis.Conn, c pkgModels.UnitOfMeasureCategory) errors.EdgeX {
+	oldCategory, edgeXerr := unitOfMeasureCategoryByName(conn, c.Name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	c.Id = oldCategory.Id
+	c.Created = oldCategory.Created
+	c.Modified = pkgCommon.MakeTimestamp()
+	storedKey := unitOfMeasureCategoryStoredKey(c.Id)
+	_ = conn.Send(MULTI)
+	sendDeleteUnitOfMeasureCategoryCmd(conn, storedKey, oldCategory)
+	edgeXerr = sendAddUnitOfMeasureCategoryCmd(conn, storedKey, c)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	if edgeXerr := sendAddMetadataChangeCmd(conn, pkgCommon.UnitOfMeasureCategory, common.SystemEventActionUpdate, c.Name, c); edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "unit of measure category update failed", err)
+	}
+	return nil
+}
+
+// deleteUnitOfMeasureCategoryByName deletes the unit of measure category by name
+func deleteUnitOfMeasureCategoryByName(conn redis.Conn, name string) errors.EdgeX {
+	category, edgeXerr := unitOfMeasureCategoryByName(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	_ = conn.Send(MULTI)
+	sendDeleteUnitOfMeasureCategoryCmd(conn, unitOfMeasureCategoryStoredKey(category.Id), category)
+	if edgeXerr := sendAddMetadataChangeCmd(conn, pkgCommon.UnitOfMeasureCategory, common.SystemEventActionDelete, category.Name, category); edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "unit of measure category deletion failed", err)
+	}
+	return nil
+}
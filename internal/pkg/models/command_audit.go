@@ -0,0 +1,43 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// Constants related to where the audited commands are received from
+const (
+	CommandAuditOriginHTTP       = "HTTP"
+	CommandAuditOriginMessageBus = "MESSAGEBUS"
+	CommandAuditOriginMQTT       = "MQTT"
+	CommandAuditOriginScheduled  = "SCHEDULED"
+	CommandAuditOriginWebSocket  = "WEBSOCKET"
+	CommandAuditOriginSequence   = "SEQUENCE"
+
+	CommandMethodGet = "get"
+	CommandMethodSet = "set"
+)
+
+// CommandAuditEntry records a get or set command issued by core-command. The entries are append-only, i.e. they are
+// never updated or deleted through core-command.
+type CommandAuditEntry struct {
+	Id string
+	// Timestamp is the time in milliseconds the command was received
+	Timestamp int64
+	Origin    string
+	// Actor identifies who issued the command, i.e. the subject of the verified JWT of the request, or else the client
+	// ID in the request topic of a MessageBus or MQTT request prefixed with 'topic:' since it isn't verified.
+	Actor         string
+	CorrelationId string
+	DeviceName    string
+	CommandName   string
+	Method        string
+	// Parameters are the settings of a set command
+	Parameters  map[string]any
+	QueryParams map[string]string
+	// StatusCode is the HTTP status code of the outcome, the MessageBus and MQTT requests use the same codes
+	StatusCode int
+	Message    string
+	// Latency is the time in milliseconds the command took
+	Latency int64
+}
@@ -0,0 +1,96 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+)
+
+// Constants related to the types of the command sequence steps
+const (
+	CommandSequenceStepSet  = "SET"
+	CommandSequenceStepGet  = "GET"
+	CommandSequenceStepWait = "WAIT"
+)
+
+// Constants related to the status of the command sequence executions and their steps
+const (
+	CommandSequenceStatusRunning        = "RUNNING"
+	CommandSequenceStatusSucceeded      = "SUCCEEDED"
+	CommandSequenceStatusFailed         = "FAILED"
+	CommandSequenceStatusRolledBack     = "ROLLED_BACK"
+	CommandSequenceStatusRollbackFailed = "ROLLBACK_FAILED"
+)
+
+// CommandSequence is a named list of steps which core-command executes in order, e.g. set A, wait 2s, get B and assert
+// B==ready, then set C. When a step fails, the remaining steps are skipped and the RollbackSteps are executed instead.
+type CommandSequence struct {
+	models.DBTimestamp
+	Id            string
+	Name          string
+	Description   string
+	Steps         []CommandSequenceStep
+	RollbackSteps []CommandSequenceStep
+}
+
+// CommandSequenceStep is either a set command, a get command whose readings are asserted, or a wait
+type CommandSequenceStep struct {
+	Type        string
+	DeviceName  string
+	CommandName string
+	// Settings are the settings of a SET step
+	Settings    map[string]any
+	QueryParams map[string]string
+	// Duration is how long a WAIT step waits, e.g. 2s
+	Duration string
+	// Assertions are checked against the readings of a GET step, the step fails unless they all hold
+	Assertions []CommandSequenceAssertion
+}
+
+// CommandSequenceAssertion compares the reading value of a device resource with a value
+type CommandSequenceAssertion struct {
+	ResourceName string
+	// Operator is one of ==, !=, <, <=, > and >=, the ordering operators only apply to numeric values
+	Operator string
+	Value    string
+}
+
+// CommandSequenceExecution records an execution of a command sequence and the result of every step executed so far.
+// The steps of the sequence are copied when the execution starts, so that updating the sequence doesn't affect it.
+type CommandSequenceExecution struct {
+	models.DBTimestamp
+	Id           string
+	SequenceName string
+	// Origin is how the execution was requested, and Actor who requested it. The commands of the steps are audited
+	// with both.
+	Origin string
+	Actor  string
+	Status string
+	// StartedAt and EndedAt are the times in milliseconds the execution started and ended
+	StartedAt       int64
+	EndedAt         int64
+	Steps           []CommandSequenceStep
+	RollbackSteps   []CommandSequenceStep
+	StepResults     []CommandSequenceStepResult
+	RollbackResults []CommandSequenceStepResult
+	Message         string
+}
+
+// CommandSequenceStepResult is the outcome of an executed step
+type CommandSequenceStepResult struct {
+	// Index is the index of the step in the Steps or RollbackSteps
+	Index       int
+	Type        string
+	DeviceName  string
+	CommandName string
+	Status      string
+	StatusCode  int
+	Message     string
+	// Readings are the reading values of a GET step keyed by resource name
+	Readings  map[string]string
+	StartedAt int64
+	EndedAt   int64
+}
@@ -0,0 +1,85 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+)
+
+// DeviceLifecycleStateProperty is the key of the device Properties entry which holds the lifecycle state
+const DeviceLifecycleStateProperty = "LifecycleState"
+
+// Constants related to the device lifecycle states
+const (
+	Provisioned    = "PROVISIONED"
+	Commissioned   = "COMMISSIONED"
+	Active         = "ACTIVE"
+	Maintenance    = "MAINTENANCE"
+	Decommissioned = "DECOMMISSIONED"
+)
+
+// deviceLifecycleTransitions defines the allowed target states for each lifecycle state
+var deviceLifecycleTransitions = map[string][]string{
+	Provisioned:    {Commissioned, Decommissioned},
+	Commissioned:   {Active, Provisioned, Decommissioned},
+	Active:         {Maintenance, Decommissioned},
+	Maintenance:    {Active, Decommissioned},
+	Decommissioned: {},
+}
+
+// DeviceLifecycleAudit records a single lifecycle state transition of a device
+type DeviceLifecycleAudit struct {
+	Id         string
+	Created    int64
+	DeviceName string
+	FromState  string
+	ToState    string
+	Actor      string
+	Reason     string
+}
+
+// IsValidDeviceLifecycleState checks whether the specified state is a known lifecycle state
+func IsValidDeviceLifecycleState(state string) bool {
+	_, ok := deviceLifecycleTransitions[state]
+	return ok
+}
+
+// DeviceLifecycleState returns the lifecycle state stored in the device properties.
+// Devices created before the lifecycle was introduced carry no state and are regarded as ACTIVE.
+func DeviceLifecycleState(properties map[string]any) string {
+	if properties == nil {
+		return Active
+	}
+	state, ok := properties[DeviceLifecycleStateProperty].(string)
+	if !ok || state == "" {
+		return Active
+	}
+	return strings.ToUpper(state)
+}
+
+// IsDeviceDecommissioned checks whether the device has reached the terminal DECOMMISSIONED state
+func IsDeviceDecommissioned(d models.Device) bool {
+	return DeviceLifecycleState(d.Properties) == Decommissioned
+}
+
+// ValidateDeviceLifecycleTransition checks whether the device can move from one lifecycle state to another
+func ValidateDeviceLifecycleTransition(from, to string) error {
+	if !IsValidDeviceLifecycleState(to) {
+		return fmt.Errorf("unknown lifecycle state '%s'", to)
+	}
+	if from == to {
+		return nil
+	}
+	for _, s := range deviceLifecycleTransitions[from] {
+		if s == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("lifecycle transition from '%s' to '%s' is not allowed", from, to)
+}
@@ -0,0 +1,50 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceLifecycleState(t *testing.T) {
+	assert.Equal(t, Active, DeviceLifecycleState(nil))
+	assert.Equal(t, Active, DeviceLifecycleState(map[string]any{"foo": "bar"}))
+	assert.Equal(t, Active, DeviceLifecycleState(map[string]any{DeviceLifecycleStateProperty: 1}))
+	assert.Equal(t, Maintenance, DeviceLifecycleState(map[string]any{DeviceLifecycleStateProperty: "maintenance"}))
+}
+
+func TestValidateDeviceLifecycleTransition(t *testing.T) {
+	tests := []struct {
+		name          string
+		from          string
+		to            string
+		errorExpected bool
+	}{
+		{"provisioned to commissioned", Provisioned, Commissioned, false},
+		{"commissioned to active", Commissioned, Active, false},
+		{"commissioned back to provisioned", Commissioned, Provisioned, false},
+		{"active to maintenance", Active, Maintenance, false},
+		{"maintenance to active", Maintenance, Active, false},
+		{"active to decommissioned", Active, Decommissioned, false},
+		{"unchanged", Active, Active, false},
+		{"provisioned to active", Provisioned, Active, true},
+		{"active to provisioned", Active, Provisioned, true},
+		{"decommissioned is terminal", Decommissioned, Active, true},
+		{"unknown state", Active, "RETIRED", true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := ValidateDeviceLifecycleTransition(testCase.from, testCase.to)
+			if testCase.errorExpected {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	// MaxLatitude is the maximum absolute latitude of a device location, which is limited by the Web Mercator
+	// projection used by the spatial index of the devices
+	MaxLatitude  = 85.05112878
+	MaxLongitude = 180
+
+	earthRadiusMeters = 6372797.560856
+)
+
+// DeviceLocation is the structured location of a device, which is either the geographic coordinates or the reference
+// to a site, building and floor, or both. The structured location is stored as an object in the Location of the device.
+type DeviceLocation struct {
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+	Altitude  *float64 `json:"altitude,omitempty"`
+	Site      string   `json:"site,omitempty"`
+	Building  string   `json:"building,omitempty"`
+	Floor     string   `json:"floor,omitempty"`
+}
+
+// HasCoordinates returns whether the location specifies the geographic coordinates
+func (l DeviceLocation) HasCoordinates() bool {
+	return l.Latitude != nil && l.Longitude != nil
+}
+
+// ParseDeviceLocation parses the Location of a device. Only the object locations are structured, other locations,
+// e.g. the free text locations of the existing devices, are returned as not structured without error.
+func ParseDeviceLocation(location any) (l DeviceLocation, structured bool, err error) {
+	if _, ok := location.(map[string]any); !ok {
+		return l, false, nil
+	}
+
+	data, err := json.Marshal(location)
+	if err != nil {
+		return l, true, fmt.Errorf("failed to encode the device location: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&l); err != nil {
+		return l, true, fmt.Errorf("the device location must specify the latitude, longitude, altitude, site, building or floor: %w", err)
+	}
+	return l, true, l.Validate()
+}
+
+// Validate checks whether the location specifies the coordinates in range or the site reference
+func (l DeviceLocation) Validate() error {
+	if (l.Latitude == nil) != (l.Longitude == nil) {
+		return errors.New("the latitude and longitude of the device location must be specified together")
+	}
+	if l.HasCoordinates() {
+		if err := validateCoordinates(*l.Latitude, *l.Longitude); err != nil {
+			return err
+		}
+	} else if l.Altitude != nil {
+		return errors.New("the altitude of the device location requires the latitude and longitude")
+	}
+	if !l.HasCoordinates() && l.Site == "" {
+		return errors.New("the device location must specify either the latitude and longitude or the site")
+	}
+	if l.Site == "" && (l.Building != "" || l.Floor != "") {
+		return errors.New("the building and floor of the device location require the site")
+	}
+	return nil
+}
+
+func validateCoordinates(latitude, longitude float64) error {
+	if math.IsNaN(latitude) || math.Abs(latitude) > MaxLatitude {
+		return fmt.Errorf("latitude %v is out of range [-%v, %v]", latitude, MaxLatitude, MaxLatitude)
+	}
+	if math.IsNaN(longitude) || math.Abs(longitude) > MaxLongitude {
+		return fmt.Errorf("longitude %v is out of range [-%v, %v]", longitude, MaxLongitude, MaxLongitude)
+	}
+	return nil
+}
+
+// GeoCircle is the area within the radius in meters around the center coordinates
+type GeoCircle struct {
+	Latitude  float64
+	Longitude float64
+	Radius    float64
+}
+
+// Validate checks whether the center is in range and the radius is positive
+func (c GeoCircle) Validate() error {
+	if err := validateCoordinates(c.Latitude, c.Longitude); err != nil {
+		return err
+	}
+	if math.IsNaN(c.Radius) || c.Radius <= 0 {
+		return fmt.Errorf("radius %v must be greater than 0", c.Radius)
+	}
+	return nil
+}
+
+// Contains returns whether the coordinates are within the circle
+func (c GeoCircle) Contains(latitude, longitude float64) bool {
+	return DistanceMeters(c.Latitude, c.Longitude, latitude, longitude) <= c.Radius
+}
+
+// GeoBoundingBox is the area between the south-west and north-east corners. The box crosses the antimeridian when the
+// minimum longitude is greater than the maximum longitude.
+type GeoBoundingBox struct {
+	MinLatitude  float64
+	MinLongitude float64
+	MaxLatitude  float64
+	MaxLongitude float64
+}
+
+// Validate checks whether the corners are in range and the minimum latitude doesn't exceed the maximum latitude
+func (b GeoBoundingBox) Validate() error {
+	if err := validateCoordinates(b.MinLatitude, b.MinLongitude); err != nil {
+		return err
+	}
+	if err := validateCoordinates(b.MaxLatitude, b.MaxLongitude); err != nil {
+		return err
+	}
+	if b.MinLatitude > b.MaxLatitude {
+		return fmt.Errorf("minimum latitude %v is greater than maximum latitude %v", b.MinLatitude, b.MaxLatitude)
+	}
+	return nil
+}
+
+// Contains returns whether the coordinates are within the bounding box
+func (b GeoBoundingBox) Contains(latitude, longitude float64) bool {
+	if latitude < b.MinLatitude || latitude > b.MaxLatitude {
+		return false
+	}
+	if b.MinLongitude <= b.MaxLongitude {
+		return longitude >= b.MinLongitude && longitude <= b.MaxLongitude
+	}
+	return longitude >= b.MinLongitude || longitude <= b.MaxLongitude
+}
+
+// Circumcircle returns the smallest circle around the center of the bounding box which covers the whole bounding box,
+// so that the spatial index can be searched by radius and the results filtered by the bounding box
+func (b GeoBoundingBox) Circumcircle() GeoCircle {
+	width := b.MaxLongitude - b.MinLongitude
+	if width < 0 {
+		width += 360
+	}
+	center := GeoCircle{Latitude: (b.MinLatitude + b.MaxLatitude) / 2, Longitude: b.MinLongitude + width/2}
+	if center.Longitude > MaxLongitude {
+		center.Longitude -= 360
+	}
+	// the farthest point of the box is either a corner or the middle of the longer parallel
+	for _, lat := range []float64{b.MinLatitude, b.MaxLatitude} {
+		for _, lon := range []float64{b.MinLongitude, b.MaxLongitude, center.Longitude} {
+			center.Radius = max(center.Radius, DistanceMeters(center.Latitude, center.Longitude, lat, lon))
+		}
+	}
+	// avoid the radius search to miss the points on the edges due to the precision of the spatial index
+	center.Radius = center.Radius*1.001 + 1
+	return center
+}
+
+// DistanceMeters returns the great-circle distance in meters between two coordinates by the haversine formula, using
+// the same earth radius as the Redis GEO commands
+func DistanceMeters(latitude1, longitude1, latitude2, longitude2 float64) float64 {
+	lat1 := latitude1 * math.Pi / 180
+	lat2 := latitude2 * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (longitude2 - longitude1) * math.Pi / 180
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
@@ -0,0 +1,91 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDeviceLocation(t *testing.T) {
+	tests := []struct {
+		name               string
+		location           any
+		expectedStructured bool
+		errorExpected      bool
+	}{
+		{"valid - no location", nil, false, false},
+		{"valid - free text location", "{40lat;45long}", false, false},
+		{"valid - coordinates", map[string]any{"latitude": 25.03, "longitude": 121.56, "altitude": 10}, true, false},
+		{"valid - site reference", map[string]any{"site": "HQ", "building": "B1", "floor": "3F"}, true, false},
+		{"invalid - latitude without longitude", map[string]any{"latitude": 25.03}, true, true},
+		{"invalid - latitude out of range", map[string]any{"latitude": 89.5, "longitude": 121.56}, true, true},
+		{"invalid - longitude out of range", map[string]any{"latitude": 25.03, "longitude": 181}, true, true},
+		{"invalid - altitude without coordinates", map[string]any{"site": "HQ", "altitude": 10}, true, true},
+		{"invalid - floor without site", map[string]any{"latitude": 25.03, "longitude": 121.56, "floor": "3F"}, true, true},
+		{"invalid - empty location", map[string]any{}, true, true},
+		{"invalid - unknown field", map[string]any{"lat": 25.03, "long": 121.56}, true, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, structured, err := ParseDeviceLocation(testCase.location)
+			assert.Equal(t, testCase.expectedStructured, structured)
+			if testCase.errorExpected {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	l, _, err := ParseDeviceLocation(map[string]any{"latitude": 25.03, "longitude": 121.56})
+	require.NoError(t, err)
+	assert.True(t, l.HasCoordinates())
+	assert.Equal(t, 25.03, *l.Latitude)
+}
+
+func TestDistanceMeters(t *testing.T) {
+	assert.Equal(t, float64(0), DistanceMeters(25.03, 121.56, 25.03, 121.56))
+	// one degree of latitude is about 111 km
+	assert.InDelta(t, 111226, DistanceMeters(0, 0, 1, 0), 10)
+	assert.InDelta(t, DistanceMeters(0, 179.5, 0, -179.5), DistanceMeters(0, 0, 0, 1), 1, "distance across the antimeridian")
+}
+
+func TestGeoCircle(t *testing.T) {
+	c := GeoCircle{Latitude: 25.03, Longitude: 121.56, Radius: 5000}
+	require.NoError(t, c.Validate())
+	assert.True(t, c.Contains(25.04, 121.57))
+	assert.False(t, c.Contains(25.1, 121.56))
+
+	assert.Error(t, GeoCircle{Latitude: 25.03, Longitude: 121.56}.Validate(), "radius is required")
+	assert.Error(t, GeoCircle{Latitude: 90, Longitude: 121.56, Radius: 1}.Validate())
+}
+
+func TestGeoBoundingBox(t *testing.T) {
+	b := GeoBoundingBox{MinLatitude: 25, MinLongitude: 121, MaxLatitude: 26, MaxLongitude: 122}
+	require.NoError(t, b.Validate())
+	assert.True(t, b.Contains(25.5, 121.5))
+	assert.False(t, b.Contains(25.5, 122.5))
+	assert.Error(t, GeoBoundingBox{MinLatitude: 26, MinLongitude: 121, MaxLatitude: 25, MaxLongitude: 122}.Validate())
+
+	antimeridian := GeoBoundingBox{MinLatitude: -20, MinLongitude: 179, MaxLatitude: -10, MaxLongitude: -179}
+	require.NoError(t, antimeridian.Validate())
+	assert.True(t, antimeridian.Contains(-15, 179.5))
+	assert.True(t, antimeridian.Contains(-15, -179.5))
+	assert.False(t, antimeridian.Contains(-15, 0))
+
+	for _, box := range []GeoBoundingBox{b, antimeridian} {
+		c := box.Circumcircle()
+		for _, lat := range []float64{box.MinLatitude, box.MaxLatitude} {
+			for _, lon := range []float64{box.MinLongitude, box.MaxLongitude} {
+				assert.True(t, c.Contains(lat, lon), "the circumcircle must cover the corners of the box")
+			}
+		}
+	}
+	assert.InDelta(t, 180, antimeridian.Circumcircle().Longitude, 0.001)
+}
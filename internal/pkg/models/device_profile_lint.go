@@ -0,0 +1,53 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import "strings"
+
+// Constants related to the severity of the device profile lint findings, ordered from the least to the most severe
+const (
+	ProfileFindingSeverityInfo    = "INFO"
+	ProfileFindingSeverityWarning = "WARNING"
+	ProfileFindingSeverityError   = "ERROR"
+	// ProfileFindingSeverityNone is only used as blocking policy, no finding is blocked
+	ProfileFindingSeverityNone = "NONE"
+)
+
+// Constants related to the codes of the device profile lint findings
+const (
+	ProfileFindingResourceRemoved      = "RESOURCE_REMOVED"
+	ProfileFindingCommandRemoved       = "COMMAND_REMOVED"
+	ProfileFindingAutoEventSource      = "AUTOEVENT_SOURCE_MISSING"
+	ProfileFindingValueTypeChanged     = "VALUE_TYPE_CHANGED"
+	ProfileFindingReadWriteReduced     = "READ_WRITE_REDUCED"
+	ProfileFindingUnitsChanged         = "UNITS_CHANGED"
+	ProfileFindingInvalidRange         = "INVALID_RANGE"
+	ProfileFindingUnknownUnits         = "UNKNOWN_UNITS"
+	ProfileFindingUnreferencedResource = "UNREFERENCED_RESOURCE"
+)
+
+var profileFindingSeverityRanks = map[string]int{
+	ProfileFindingSeverityInfo:    1,
+	ProfileFindingSeverityWarning: 2,
+	ProfileFindingSeverityError:   3,
+}
+
+// IsValidProfileFindingBlockingSeverity checks whether the value can be used as the blocking policy of device profile changes
+func IsValidProfileFindingBlockingSeverity(severity string) bool {
+	severity = strings.ToUpper(severity)
+	_, ok := profileFindingSeverityRanks[severity]
+	return ok || severity == ProfileFindingSeverityNone || severity == ""
+}
+
+// IsProfileFindingBlocked checks whether a finding of the severity is blocked by the blocking severity, i.e. the
+// finding is at least as severe as the blocking severity. Nothing is blocked when the blocking severity is NONE or empty.
+func IsProfileFindingBlocked(severity string, blockingSeverity string) bool {
+	threshold, ok := profileFindingSeverityRanks[strings.ToUpper(blockingSeverity)]
+	if !ok {
+		return false
+	}
+	return profileFindingSeverityRanks[severity] >= threshold
+}
@@ -0,0 +1,41 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsProfileFindingBlocked(t *testing.T) {
+	tests := []struct {
+		name             string
+		severity         string
+		blockingSeverity string
+		blocked          bool
+	}{
+		{"error blocked by error", ProfileFindingSeverityError, ProfileFindingSeverityError, true},
+		{"warning not blocked by error", ProfileFindingSeverityWarning, ProfileFindingSeverityError, false},
+		{"error blocked by warning", ProfileFindingSeverityError, "warning", true},
+		{"warning blocked by warning", ProfileFindingSeverityWarning, ProfileFindingSeverityWarning, true},
+		{"info not blocked by warning", ProfileFindingSeverityInfo, ProfileFindingSeverityWarning, false},
+		{"error not blocked by none", ProfileFindingSeverityError, ProfileFindingSeverityNone, false},
+		{"error not blocked by empty policy", ProfileFindingSeverityError, "", false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.blocked, IsProfileFindingBlocked(testCase.severity, testCase.blockingSeverity))
+		})
+	}
+}
+
+func TestIsValidProfileFindingBlockingSeverity(t *testing.T) {
+	assert.True(t, IsValidProfileFindingBlockingSeverity(ProfileFindingSeverityError))
+	assert.True(t, IsValidProfileFindingBlockingSeverity("none"))
+	assert.True(t, IsValidProfileFindingBlockingSeverity(""))
+	assert.False(t, IsValidProfileFindingBlockingSeverity("CRITICAL"))
+}
@@ -0,0 +1,105 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+)
+
+// DeviceResourceOverridesProperty is the key of the device Properties entry which holds the resource overrides of the
+// device, keyed by the name of the overridden device resource
+const DeviceResourceOverridesProperty = "ResourceOverrides"
+
+// numericValueTypes are the value types of the device resources whose range and transformation can be overridden
+var numericValueTypes = []string{
+	common.ValueTypeUint8, common.ValueTypeUint16, common.ValueTypeUint32, common.ValueTypeUint64,
+	common.ValueTypeInt8, common.ValueTypeInt16, common.ValueTypeInt32, common.ValueTypeInt64,
+	common.ValueTypeFloat32, common.ValueTypeFloat64,
+}
+
+// ResourceOverride overrides selected properties of a device resource defined by the device profile for a single
+// device, e.g. the calibration of a sensor. The properties which are not specified are inherited from the profile.
+type ResourceOverride struct {
+	Units   *string  `json:"units,omitempty"`
+	Minimum *float64 `json:"minimum,omitempty"`
+	Maximum *float64 `json:"maximum,omitempty"`
+	Scale   *float64 `json:"scale,omitempty"`
+	Offset  *float64 `json:"offset,omitempty"`
+}
+
+// DeviceResourceOverrides returns the resource overrides stored in the device properties, devices without overrides
+// return an empty map
+func DeviceResourceOverrides(properties map[string]any) (map[string]ResourceOverride, error) {
+	overrides := make(map[string]ResourceOverride)
+	raw, ok := properties[DeviceResourceOverridesProperty]
+	if !ok || raw == nil {
+		return overrides, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the %s property: %w", DeviceResourceOverridesProperty, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&overrides); err != nil {
+		return nil, fmt.Errorf("the %s property must map the device resource names to the overridden units, minimum, maximum, scale or offset: %w", DeviceResourceOverridesProperty, err)
+	}
+	return overrides, nil
+}
+
+// Apply returns the resource properties with the overridden properties replaced
+func (o ResourceOverride) Apply(p models.ResourceProperties) models.ResourceProperties {
+	if o.Units != nil {
+		p.Units = *o.Units
+	}
+	if o.Minimum != nil {
+		p.Minimum = o.Minimum
+	}
+	if o.Maximum != nil {
+		p.Maximum = o.Maximum
+	}
+	if o.Scale != nil {
+		p.Scale = o.Scale
+	}
+	if o.Offset != nil {
+		p.Offset = o.Offset
+	}
+	return p
+}
+
+// ValidateResourceOverride checks whether the override can be applied to the device resource. The range and
+// transformation are only applicable to numeric resources, and the effective minimum can't exceed the effective maximum.
+func ValidateResourceOverride(r models.DeviceResource, o ResourceOverride) error {
+	numeric := slices.Contains(numericValueTypes, r.Properties.ValueType)
+	if !numeric && (o.Minimum != nil || o.Maximum != nil || o.Scale != nil || o.Offset != nil) {
+		return fmt.Errorf("device resource %s of value type %s only allows overriding the units", r.Name, r.Properties.ValueType)
+	}
+	effective := o.Apply(r.Properties)
+	if effective.Minimum != nil && effective.Maximum != nil && *effective.Minimum > *effective.Maximum {
+		return fmt.Errorf("device resource %s effective minimum %v is greater than maximum %v", r.Name, *effective.Minimum, *effective.Maximum)
+	}
+	return nil
+}
+
+// EffectiveDeviceResources returns the device resources of the profile with the resource overrides of the device
+// applied. The overrides of the resources which no longer exist in the profile are ignored.
+func EffectiveDeviceResources(resources []models.DeviceResource, overrides map[string]ResourceOverride) []models.DeviceResource {
+	effective := make([]models.DeviceResource, len(resources))
+	for i, r := range resources {
+		if o, ok := overrides[r.Name]; ok {
+			r.Properties = o.Apply(r.Properties)
+		}
+		effective[i] = r
+	}
+	return effective
+}
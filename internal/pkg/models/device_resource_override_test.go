@@ -0,0 +1,93 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceResourceOverrides(t *testing.T) {
+	overrides, err := DeviceResourceOverrides(nil)
+	require.NoError(t, err)
+	assert.Empty(t, overrides)
+
+	overrides, err = DeviceResourceOverrides(map[string]any{
+		DeviceResourceOverridesProperty: map[string]any{
+			"Temperature": map[string]any{"units": "degreeFahrenheit", "scale": 1.8, "offset": 32},
+		},
+	})
+	require.NoError(t, err)
+	require.Contains(t, overrides, "Temperature")
+	assert.Equal(t, "degreeFahrenheit", *overrides["Temperature"].Units)
+	assert.Equal(t, 1.8, *overrides["Temperature"].Scale)
+	assert.Equal(t, float64(32), *overrides["Temperature"].Offset)
+	assert.Nil(t, overrides["Temperature"].Minimum)
+
+	_, err = DeviceResourceOverrides(map[string]any{DeviceResourceOverridesProperty: "invalid"})
+	assert.Error(t, err)
+	_, err = DeviceResourceOverrides(map[string]any{
+		DeviceResourceOverridesProperty: map[string]any{"Temperature": map[string]any{"valueType": common.ValueTypeString}},
+	})
+	assert.Error(t, err, "only the overridable properties are allowed")
+}
+
+func TestValidateResourceOverride(t *testing.T) {
+	min, max, scale := float64(0), float64(100), float64(2)
+	numeric := models.DeviceResource{Name: "Temperature", Properties: models.ResourceProperties{ValueType: common.ValueTypeFloat32, Minimum: &min, Maximum: &max}}
+	text := models.DeviceResource{Name: "Model", Properties: models.ResourceProperties{ValueType: common.ValueTypeString}}
+	units := "degreeFahrenheit"
+	belowMin := float64(-1)
+	aboveMax := float64(200)
+
+	tests := []struct {
+		name          string
+		resource      models.DeviceResource
+		override      ResourceOverride
+		errorExpected bool
+	}{
+		{"valid - numeric transformation", numeric, ResourceOverride{Units: &units, Scale: &scale}, false},
+		{"valid - numeric range", numeric, ResourceOverride{Minimum: &belowMin, Maximum: &aboveMax}, false},
+		{"valid - non-numeric units", text, ResourceOverride{Units: &units}, false},
+		{"invalid - non-numeric scale", text, ResourceOverride{Scale: &scale}, true},
+		{"invalid - minimum greater than profile maximum", numeric, ResourceOverride{Minimum: &aboveMax}, true},
+		{"invalid - maximum less than profile minimum", numeric, ResourceOverride{Maximum: &belowMin}, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := ValidateResourceOverride(testCase.resource, testCase.override)
+			if testCase.errorExpected {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEffectiveDeviceResources(t *testing.T) {
+	scale, offset, override := float64(1), float64(0), float64(1.8)
+	units := "degreeFahrenheit"
+	resources := []models.DeviceResource{
+		{Name: "Temperature", Properties: models.ResourceProperties{ValueType: common.ValueTypeFloat32, Units: "degreeCelsius", Scale: &scale, Offset: &offset}},
+		{Name: "Humidity", Properties: models.ResourceProperties{ValueType: common.ValueTypeFloat32, Units: "percent"}},
+	}
+
+	effective := EffectiveDeviceResources(resources, map[string]ResourceOverride{
+		"Temperature": {Units: &units, Scale: &override},
+		"Removed":     {Units: &units},
+	})
+	require.Len(t, effective, 2)
+	assert.Equal(t, units, effective[0].Properties.Units)
+	assert.Equal(t, override, *effective[0].Properties.Scale)
+	assert.Equal(t, offset, *effective[0].Properties.Offset)
+	assert.Equal(t, resources[1], effective[1])
+	assert.Equal(t, "degreeCelsius", resources[0].Properties.Units, "the profile resources must not be modified")
+}
@@ -0,0 +1,11 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// DeviceServiceDownProperty is the key of the device Properties entry which marks the devices set to DOWN by the device
+// service health probing of core-metadata, so that only these devices are set back to UP once their device service
+// recovers, even when core-metadata is restarted meanwhile
+const DeviceServiceDownProperty = "DeviceServiceDown"
@@ -0,0 +1,178 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+)
+
+// Constants related to the device properties linking a device to the device template it was instantiated from
+const (
+	DeviceTemplateNameProperty       = "DeviceTemplateName"
+	DeviceTemplateParametersProperty = "DeviceTemplateParameters"
+)
+
+// deviceTemplatePlaceholder matches the ${name} placeholders of a device template
+var deviceTemplatePlaceholder = regexp.MustCompile(`\$\{([A-Za-z0-9_.-]+)\}`)
+
+// DeviceTemplate is a partially filled device whose string values, including the map keys, may contain ${name}
+// placeholders which are replaced by the parameters when devices are instantiated from the template
+type DeviceTemplate struct {
+	models.DBTimestamp
+	Id          string
+	Name        string
+	Description string
+	Labels      []string
+	Device      models.Device
+}
+
+// DeviceTemplatePlaceholders returns the sorted names of the placeholders used by the device of the template
+func DeviceTemplatePlaceholders(device models.Device) ([]string, error) {
+	document, err := deviceToDocument(device)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool)
+	walkTemplateStrings(document, func(s string) string {
+		for _, match := range deviceTemplatePlaceholder.FindAllStringSubmatch(s, -1) {
+			names[match[1]] = true
+		}
+		return s
+	})
+	placeholders := make([]string, 0, len(names))
+	for name := range names {
+		placeholders = append(placeholders, name)
+	}
+	slices.Sort(placeholders)
+	return placeholders, nil
+}
+
+// RenderDeviceTemplate replaces the placeholders of the device with the parameters, every placeholder must have a parameter
+func RenderDeviceTemplate(device models.Device, parameters map[string]string) (models.Device, error) {
+	document, err := deviceToDocument(device)
+	if err != nil {
+		return models.Device{}, err
+	}
+
+	missing := make(map[string]bool)
+	document = walkTemplateStrings(document, func(s string) string {
+		return deviceTemplatePlaceholder.ReplaceAllStringFunc(s, func(placeholder string) string {
+			name := deviceTemplatePlaceholder.FindStringSubmatch(placeholder)[1]
+			value, ok := parameters[name]
+			if !ok {
+				missing[name] = true
+				return placeholder
+			}
+			return value
+		})
+	})
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		return models.Device{}, fmt.Errorf("missing device template parameters: %s", strings.Join(names, ", "))
+	}
+
+	bytes, err := json.Marshal(document)
+	if err != nil {
+		return models.Device{}, fmt.Errorf("failed to encode the rendered device: %w", err)
+	}
+	var rendered models.Device
+	if err = json.Unmarshal(bytes, &rendered); err != nil {
+		return models.Device{}, fmt.Errorf("failed to decode the rendered device: %w", err)
+	}
+	return rendered, nil
+}
+
+// DeviceTemplateParameters returns the name of the device template and the parameters the device was instantiated
+// with, the name is empty if the device is not linked to a device template
+func DeviceTemplateParameters(device models.Device) (string, map[string]string) {
+	name, _ := device.Properties[DeviceTemplateNameProperty].(string)
+	if name == "" {
+		return "", nil
+	}
+	parameters := make(map[string]string)
+	switch p := device.Properties[DeviceTemplateParametersProperty].(type) {
+	case map[string]string:
+		for k, v := range p {
+			parameters[k] = v
+		}
+	case map[string]any:
+		for k, v := range p {
+			parameters[k] = fmt.Sprint(v)
+		}
+	}
+	return name, parameters
+}
+
+// DeviceTemplateSecretPlaceholders returns the sorted names of the placeholders used by the values of the secret
+// protocol properties of the device which aren't secret references, whose parameters are plain text secrets
+func DeviceTemplateSecretPlaceholders(device models.Device, secretProperties []string) []string {
+	names := make(map[string]bool)
+	for _, properties := range device.Protocols {
+		for name, value := range properties {
+			if !IsSecretProtocolProperty(name, secretProperties) {
+				continue
+			}
+			if _, isReference, _ := ParseSecretReference(value); isReference {
+				continue
+			}
+			walkTemplateStrings(value, func(s string) string {
+				for _, match := range deviceTemplatePlaceholder.FindAllStringSubmatch(s, -1) {
+					names[match[1]] = true
+				}
+				return s
+			})
+		}
+	}
+	placeholders := make([]string, 0, len(names))
+	for name := range names {
+		placeholders = append(placeholders, name)
+	}
+	slices.Sort(placeholders)
+	return placeholders
+}
+
+func deviceToDocument(device models.Device) (any, error) {
+	bytes, err := json.Marshal(device)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the device template: %w", err)
+	}
+	var document any
+	if err = json.Unmarshal(bytes, &document); err != nil {
+		return nil, fmt.Errorf("failed to decode the device template: %w", err)
+	}
+	return document, nil
+}
+
+// walkTemplateStrings applies the function to every string value and map key of the JSON document
+func walkTemplateStrings(node any, f func(string) string) any {
+	switch v := node.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for key, child := range v {
+			result[f(key)] = walkTemplateStrings(child, f)
+		}
+		return result
+	case []any:
+		for i, child := range v {
+			v[i] = walkTemplateStrings(child, f)
+		}
+		return v
+	case string:
+		return f(v)
+	default:
+		return v
+	}
+}
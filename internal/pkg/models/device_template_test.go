@@ -0,0 +1,71 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testTemplateDevice = models.Device{
+	Name:        "pump-${line}-${slaveId}",
+	Description: "Pump ${slaveId} of line ${line}",
+	ServiceName: "device-modbus",
+	ProfileName: "pump-profile",
+	Protocols: map[string]models.ProtocolProperties{
+		"modbus-tcp": {"Address": "${ip}", "Port": "502", "UnitID": "${slaveId}"},
+	},
+	AutoEvents: []models.AutoEvent{{SourceName: "Flow", Interval: "10s"}},
+	Properties: map[string]any{"Line": "${line}"},
+}
+
+func TestDeviceTemplatePlaceholders(t *testing.T) {
+	placeholders, err := DeviceTemplatePlaceholders(testTemplateDevice)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ip", "line", "slaveId"}, placeholders)
+}
+
+func TestRenderDeviceTemplate(t *testing.T) {
+	device, err := RenderDeviceTemplate(testTemplateDevice, map[string]string{"line": "A", "slaveId": "7", "ip": "10.0.0.7"})
+	require.NoError(t, err)
+	assert.Equal(t, "pump-A-7", device.Name)
+	assert.Equal(t, "Pump 7 of line A", device.Description)
+	assert.Equal(t, "10.0.0.7", device.Protocols["modbus-tcp"]["Address"])
+	assert.Equal(t, "7", device.Protocols["modbus-tcp"]["UnitID"])
+	assert.Equal(t, "A", device.Properties["Line"])
+	assert.Equal(t, testTemplateDevice.AutoEvents, device.AutoEvents)
+	// the template itself is left untouched
+	assert.Equal(t, "${ip}", testTemplateDevice.Protocols["modbus-tcp"]["Address"])
+
+	_, err = RenderDeviceTemplate(testTemplateDevice, map[string]string{"line": "A"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ip, slaveId")
+}
+
+func TestDeviceTemplateParameters(t *testing.T) {
+	name, parameters := DeviceTemplateParameters(models.Device{Properties: map[string]any{
+		DeviceTemplateNameProperty:       "pump",
+		DeviceTemplateParametersProperty: map[string]any{"slaveId": "7"},
+	}})
+	assert.Equal(t, "pump", name)
+	assert.Equal(t, map[string]string{"slaveId": "7"}, parameters)
+
+	name, parameters = DeviceTemplateParameters(models.Device{})
+	assert.Empty(t, name)
+	assert.Nil(t, parameters)
+}
+
+func TestDeviceTemplateSecretPlaceholders(t *testing.T) {
+	device := models.Device{Protocols: map[string]models.ProtocolProperties{
+		"snmp": {"Address": "${ip}", "Community": "${community}"},
+		"mqtt": {"Password": map[string]any{"secretName": "broker-${line}", "secretKey": "password"}},
+	}}
+	assert.Equal(t, []string{"community"}, DeviceTemplateSecretPlaceholders(device, []string{"community", "password"}))
+	assert.Empty(t, DeviceTemplateSecretPlaceholders(device, nil))
+}
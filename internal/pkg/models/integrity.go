@@ -0,0 +1,48 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// Constants related to the problems of the metadata secondary indexes
+const (
+	// IndexEntryMissing means the stored entity is not a member of an index it belongs to
+	IndexEntryMissing = "missing"
+	// IndexEntryStale means the index refers to an entity which doesn't exist or no longer belongs to the index
+	IndexEntryStale = "stale"
+	// IndexEntryMismatch means the name index maps the entity name to another stored entity
+	IndexEntryMismatch = "mismatch"
+	// IndexDuplicateName means more than one stored entity has the same name
+	IndexDuplicateName = "duplicateName"
+	// IndexInvalidEntity means the stored entity can't be decoded and therefore can't be indexed
+	IndexInvalidEntity = "invalidEntity"
+)
+
+// IndexInconsistency is a problem found in a secondary index of a metadata collection. The Index is the key of the
+// index, e.g. the sorted set of a label, and the Member is the stored key or the name the problem is about.
+type IndexInconsistency struct {
+	EntityType  string
+	Index       string
+	Member      string
+	Problem     string
+	Description string
+}
+
+// IndexIntegrity is the result of checking the secondary indexes of the metadata collections against the stored
+// entities. Entities counts the stored entities by type, and Rebuilt reports whether the indexes were rebuilt to
+// repair the inconsistencies.
+type IndexIntegrity struct {
+	Entities        map[string]uint32
+	Inconsistencies []IndexInconsistency
+	Rebuilt         bool
+}
+
+// DanglingReference is a reference from a metadata entity to another entity which doesn't exist, e.g. a device
+// referring to a missing device profile. Field is the name of the referring field.
+type DanglingReference struct {
+	EntityType string
+	Name       string
+	Field      string
+	Reference  string
+}
@@ -0,0 +1,21 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import "encoding/json"
+
+// MetadataChange records a single add, update or delete of a metadata entity. The Sequence is assigned by the
+// database when the change is persisted along with the entity, so the changes are totally ordered by the Sequence.
+type MetadataChange struct {
+	Id       string
+	Sequence uint64
+	Created  int64
+	Type     string
+	Action   string
+	Name     string
+	// Entity is the stored JSON representation of the entity after the change, or before the deletion
+	Entity json.RawMessage
+}
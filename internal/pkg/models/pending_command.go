@@ -0,0 +1,43 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+)
+
+// Constants related to the status of the pending set commands awaiting approval
+const (
+	PendingCommandStatusPending   = "PENDING"
+	PendingCommandStatusApproved  = "APPROVED"
+	PendingCommandStatusSucceeded = "SUCCEEDED"
+	PendingCommandStatusFailed    = "FAILED"
+	PendingCommandStatusRejected  = "REJECTED"
+	PendingCommandStatusExpired   = "EXPIRED"
+)
+
+// PendingCommand is a critical set command which core-command only issues once another user than the requester
+// approves it before the ExpiresAt time. The outcome of the command is recorded in the StatusCode and Message once it's
+// issued.
+type PendingCommand struct {
+	models.DBTimestamp
+	Id          string
+	DeviceName  string
+	CommandName string
+	Settings    map[string]any
+	QueryParams map[string]string
+	// Origin is where the set command was received from, and RequestedBy is who requested it
+	Origin      string
+	RequestedBy string
+	// ExpiresAt is the time in milliseconds the command can no longer be approved after
+	ExpiresAt int64
+	Status    string
+	// ReviewedBy is who approved or rejected the command at the ReviewedAt time in milliseconds
+	ReviewedBy string
+	ReviewedAt int64
+	StatusCode int
+	Message    string
+}
@@ -0,0 +1,33 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+)
+
+// ProvisionWatcherNameProperty is the key of the device Properties entry which the device service sets to the name of
+// the provision watcher when it adds a device found by a discovery, which marks the device as discovered
+const ProvisionWatcherNameProperty = "ProvisionWatcherName"
+
+// Constants related to the status of the discovered devices waiting for approval
+const (
+	PendingDeviceStatusPending  = "PENDING"
+	PendingDeviceStatusRejected = "REJECTED"
+)
+
+// PendingDevice is a device discovered by a device service which is staged until an operator approves it. The Device
+// becomes a real device when approved, and a rejected device is kept so that it isn't staged again when rediscovered.
+type PendingDevice struct {
+	models.DBTimestamp
+	Id     string
+	Status string
+	// ProvisionWatcherName is the name of the provision watcher which matched the device
+	ProvisionWatcherName string
+	// LastDiscovered is the time the device was last reported by the device service
+	LastDiscovered int64
+	Device         models.Device
+}
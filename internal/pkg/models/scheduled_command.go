@@ -0,0 +1,40 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+)
+
+// Constants related to the status of the scheduled set commands
+const (
+	ScheduledCommandStatusScheduled = "SCHEDULED"
+	ScheduledCommandStatusRunning   = "RUNNING"
+	ScheduledCommandStatusSucceeded = "SUCCEEDED"
+	ScheduledCommandStatusFailed    = "FAILED"
+	ScheduledCommandStatusCancelled = "CANCELLED"
+	ScheduledCommandStatusExpired   = "EXPIRED"
+)
+
+// ScheduledCommand is a set command which core-command issues once at the ExecuteAt time. The outcome of the command
+// is recorded in the StatusCode and Message once it's executed.
+type ScheduledCommand struct {
+	models.DBTimestamp
+	Id          string
+	DeviceName  string
+	CommandName string
+	Settings    map[string]any
+	QueryParams map[string]string
+	// ExecuteAt is the time in milliseconds the command is due
+	ExecuteAt int64
+	// Actor is who submitted the command, the command is audited with the actor when it's executed
+	Actor  string
+	Status string
+	// ExecutedAt is the time in milliseconds the command was actually issued
+	ExecutedAt int64
+	StatusCode int
+	Message    string
+}
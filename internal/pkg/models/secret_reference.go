@@ -0,0 +1,88 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// RedactedValue replaces the plain text value of a secret protocol property in the responses
+	RedactedValue = "<redacted>"
+
+	secretNameField = "secretName"
+)
+
+// SecretReference is a protocol property value which refers to a secret in the secret store of the device service
+// instead of holding the credential in plain text. The device service resolves the reference through its
+// SecretProvider, i.e. the value is the SecretKey of the secret stored at the SecretName.
+type SecretReference struct {
+	SecretName string `json:"secretName"`
+	SecretKey  string `json:"secretKey"`
+}
+
+// ParseSecretReference parses a protocol property value. Only the objects specifying the secretName are secret
+// references, other values are returned as not a reference without error.
+func ParseSecretReference(value any) (ref SecretReference, isReference bool, err error) {
+	m, ok := value.(map[string]any)
+	if !ok {
+		return ref, false, nil
+	}
+	if _, ok = m[secretNameField]; !ok {
+		return ref, false, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ref, true, fmt.Errorf("failed to encode the secret reference: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&ref); err != nil {
+		return ref, true, fmt.Errorf("the secret reference must only specify the secretName and secretKey: %w", err)
+	}
+	if strings.TrimSpace(ref.SecretName) == "" || strings.TrimSpace(ref.SecretKey) == "" {
+		return ref, true, errors.New("the secretName and secretKey of the secret reference must not be empty")
+	}
+	return ref, true, nil
+}
+
+// RedactProtocolSecrets returns a copy of the protocols where the values of the secret properties which aren't secret
+// references are replaced by RedactedValue. The secret properties are matched case-insensitively by name, and the
+// secret references are kept as the device services resolve them.
+func RedactProtocolSecrets[P ~map[string]any](protocols map[string]P, secretProperties []string) map[string]P {
+	if len(protocols) == 0 || len(secretProperties) == 0 {
+		return protocols
+	}
+	redacted := make(map[string]P, len(protocols))
+	for protocol, properties := range protocols {
+		redactedProperties := make(P, len(properties))
+		for name, value := range properties {
+			if IsSecretProtocolProperty(name, secretProperties) {
+				if _, isReference, err := ParseSecretReference(value); !isReference || err != nil {
+					value = RedactedValue
+				}
+			}
+			redactedProperties[name] = value
+		}
+		redacted[protocol] = redactedProperties
+	}
+	return redacted
+}
+
+// IsSecretProtocolProperty returns whether the protocol property is one of the secret properties, case-insensitively
+func IsSecretProtocolProperty(name string, secretProperties []string) bool {
+	for _, p := range secretProperties {
+		if strings.EqualFold(name, p) {
+			return true
+		}
+	}
+	return false
+}
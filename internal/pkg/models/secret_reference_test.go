@@ -0,0 +1,57 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSecretReference(t *testing.T) {
+	tests := []struct {
+		name                string
+		value               any
+		expectedIsReference bool
+		errorExpected       bool
+	}{
+		{"valid - plain text", "public", false, false},
+		{"valid - object without secretName", map[string]any{"key": "value"}, false, false},
+		{"valid - secret reference", map[string]any{"secretName": "snmp-agent", "secretKey": "community"}, true, false},
+		{"invalid - no secretKey", map[string]any{"secretName": "snmp-agent"}, true, true},
+		{"invalid - empty secretName", map[string]any{"secretName": " ", "secretKey": "community"}, true, true},
+		{"invalid - unknown field", map[string]any{"secretName": "snmp-agent", "secretKey": "community", "value": "public"}, true, true},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, isReference, err := ParseSecretReference(testCase.value)
+			assert.Equal(t, testCase.expectedIsReference, isReference)
+			if testCase.errorExpected {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRedactProtocolSecrets(t *testing.T) {
+	reference := map[string]any{"secretName": "opcua-server", "secretKey": "password"}
+	protocols := map[string]models.ProtocolProperties{
+		"snmp":  {"Address": "10.0.0.1", "Community": "public"},
+		"opcua": {"Endpoint": "opc.tcp://10.0.0.2:4840", "password": reference},
+	}
+
+	redacted := RedactProtocolSecrets(protocols, []string{"Community", "Password"})
+
+	require.Len(t, redacted, 2)
+	assert.Equal(t, "10.0.0.1", redacted["snmp"]["Address"])
+	assert.Equal(t, RedactedValue, redacted["snmp"]["Community"])
+	assert.Equal(t, reference, redacted["opcua"]["password"], "the secret reference should be kept")
+	assert.Equal(t, "public", protocols["snmp"]["Community"], "the protocols should not be modified")
+}
@@ -0,0 +1,17 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import "encoding/json"
+
+// TrashedEntity is a soft deleted metadata entity kept in the trash until it's restored or purged
+type TrashedEntity struct {
+	Type    string
+	Name    string
+	Deleted int64
+	// Entity is the stored JSON representation of the entity when it was deleted
+	Entity json.RawMessage
+}
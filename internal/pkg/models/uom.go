@@ -0,0 +1,20 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+)
+
+// UnitOfMeasureCategory is a category of custom units of measure, the values are merged with the units of the category
+// of the same name in the UoM file
+type UnitOfMeasureCategory struct {
+	models.DBTimestamp
+	Id     string
+	Name   string
+	Source string
+	Values []string
+}
@@ -8,6 +8,7 @@ package utils
 import (
 	"context"
 	"encoding/json"
+	stdErrors "errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -15,7 +16,9 @@ import (
 	"strings"
 
 	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	pkgCommon "github.com/edgexfoundry/edgex-go/internal/pkg/common"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	pkgModels "github.com/edgexfoundry/edgex-go/internal/pkg/models"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
@@ -40,6 +43,34 @@ func WriteHttpHeader(w http.ResponseWriter, ctx context.Context, statusCode int)
 	}
 }
 
+// ErrPreconditionFailed is wrapped by the errors of the conditional requests whose precondition is not met. Since the
+// error kinds have no mapping to 412 Precondition Failed, StatusCode is used to derive the status code of these errors.
+var ErrPreconditionFailed = stdErrors.New("precondition failed")
+
+// ErrTooManyRequests is wrapped by the errors of the requests rejected by a rate limit, which StatusCode derives
+// 429 Too Many Requests from
+var ErrTooManyRequests = stdErrors.New("too many requests")
+
+// ErrForbidden is wrapped by the errors of the requests the caller isn't authorized to make, which StatusCode derives
+// 403 Forbidden from
+var ErrForbidden = stdErrors.New("forbidden")
+
+// StatusCode returns the HTTP status code of the error, which is 412 for the errors wrapping ErrPreconditionFailed,
+// 429 for the errors wrapping ErrTooManyRequests, 403 for the errors wrapping ErrForbidden and the code of the error
+// kind otherwise
+func StatusCode(err errors.EdgeX) int {
+	if stdErrors.Is(err, ErrPreconditionFailed) {
+		return http.StatusPreconditionFailed
+	}
+	if stdErrors.Is(err, ErrForbidden) {
+		return http.StatusForbidden
+	}
+	if stdErrors.Is(err, ErrTooManyRequests) {
+		return http.StatusTooManyRequests
+	}
+	return err.Code()
+}
+
 // WriteErrorResponse writes Http header, encode error response with JSON format and writes to the HTTP response.
 func WriteErrorResponse(w *echo.Response, ctx context.Context, lc logger.LoggingClient, err errors.EdgeX, requestId string) error {
 	correlationId := correlation.FromContext(ctx)
@@ -47,8 +78,9 @@ func WriteErrorResponse(w *echo.Response, ctx context.Context, lc logger.Logging
 		lc.Error(err.Error(), common.CorrelationHeader, correlationId)
 	}
 	lc.Debug(err.DebugMessages(), common.CorrelationHeader, correlationId)
-	errResponses := commonDTO.NewBaseResponse(requestId, err.Message(), err.Code())
-	WriteHttpHeader(w, ctx, err.Code())
+	statusCode := StatusCode(err)
+	errResponses := commonDTO.NewBaseResponse(requestId, err.Message(), statusCode)
+	WriteHttpHeader(w, ctx, statusCode)
 	return pkg.EncodeAndWriteResponse(errResponses, w, lc)
 }
 
@@ -101,6 +133,57 @@ func ParseQueryStringToInt(c echo.Context, queryStringKey string, defaultValue i
 	return result, nil
 }
 
+// Parse the specified query string key to a float.  EdgeX error will be returned if any parsing error occurs or the
+// specified query string key could not be found in the http request.
+func ParseQueryStringToFloat(c echo.Context, queryStringKey string) (float64, errors.EdgeX) {
+	value := c.QueryParam(queryStringKey)
+	if value == "" {
+		return 0, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("querystring %s is required", queryStringKey), nil)
+	}
+	result, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("failed to parse querystring %s's value %s into float. Error:%s", queryStringKey, value, err.Error()), nil)
+	}
+	return result, nil
+}
+
+// ParseGeoCircleQueryString parses the latitude, longitude and radius in meters query strings to a GeoCircle
+func ParseGeoCircleQueryString(c echo.Context) (circle pkgModels.GeoCircle, edgexErr errors.EdgeX) {
+	if circle.Latitude, edgexErr = ParseQueryStringToFloat(c, pkgCommon.Latitude); edgexErr != nil {
+		return circle, edgexErr
+	}
+	if circle.Longitude, edgexErr = ParseQueryStringToFloat(c, pkgCommon.Longitude); edgexErr != nil {
+		return circle, edgexErr
+	}
+	if circle.Radius, edgexErr = ParseQueryStringToFloat(c, pkgCommon.Radius); edgexErr != nil {
+		return circle, edgexErr
+	}
+	if err := circle.Validate(); err != nil {
+		return circle, errors.NewCommonEdgeX(errors.KindContractInvalid, "invalid location query", err)
+	}
+	return circle, nil
+}
+
+// ParseGeoBoundingBoxQueryString parses the minimum and maximum latitude and longitude query strings to a GeoBoundingBox
+func ParseGeoBoundingBoxQueryString(c echo.Context) (box pkgModels.GeoBoundingBox, edgexErr errors.EdgeX) {
+	if box.MinLatitude, edgexErr = ParseQueryStringToFloat(c, pkgCommon.MinLatitude); edgexErr != nil {
+		return box, edgexErr
+	}
+	if box.MinLongitude, edgexErr = ParseQueryStringToFloat(c, pkgCommon.MinLongitude); edgexErr != nil {
+		return box, edgexErr
+	}
+	if box.MaxLatitude, edgexErr = ParseQueryStringToFloat(c, pkgCommon.MaxLatitude); edgexErr != nil {
+		return box, edgexErr
+	}
+	if box.MaxLongitude, edgexErr = ParseQueryStringToFloat(c, pkgCommon.MaxLongitude); edgexErr != nil {
+		return box, edgexErr
+	}
+	if err := box.Validate(); err != nil {
+		return box, errors.NewCommonEdgeX(errors.KindContractInvalid, "invalid location query", err)
+	}
+	return box, nil
+}
+
 // Parse the specified query string key to an array of string.  If specified query string key is found more than once in
 // the http request, only the first specified query string will be parsed and converted to an array of string.  The
 // value of query string will be split into an array of string by the passing separator.  If separator is passed in as